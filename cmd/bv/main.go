@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notify"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
@@ -51,6 +53,7 @@ func main() {
 	rollbackFlag := flag.Bool("rollback", false, "Rollback to the previous version (from backup)")
 	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	exportMdSchedule := flag.Bool("export-md-schedule", false, "Include a Mermaid forecast schedule (Gantt) in --export-md")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
@@ -63,24 +66,41 @@ func main() {
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
+	robotFlow := flag.Bool("robot-flow", false, "Output cumulative flow, lead/cycle time, throughput and WIP aging as JSON")
+	flowDays := flag.Int("flow-days", 30, "Window in days for --robot-flow")
+	robotEpics := flag.Bool("robot-epics", false, "Output epic progress rollups (completion, blockers, critical path, forecast) as JSON")
+	robotClusters := flag.Bool("robot-clusters", false, "Output dependency-graph communities with names, coupling and epic/label suggestions as JSON")
+	clusterResolution := flag.Float64("cluster-resolution", 1.0, "Modularity resolution for --robot-clusters (higher = smaller clusters)")
+	clusterCorrelation := flag.Bool("cluster-correlation", false, "Weight --robot-clusters with shared-commit and shared-file edges from git history")
+	robotWorkspaceHealth := flag.Bool("robot-workspace-health", false, "Output per-repo health, repo-to-repo blocking flow, cross-repo blockers and cycles as JSON (requires --workspace)")
+	robotGates := flag.String("robot-gates", "", "Output dominator-tree gates (gating chain, dominated set, epic chains) for issue ID as JSON, or 'all' for the largest gates")
+	robotAssigneeHealth := flag.Bool("robot-assignee-health", false, "Output per-assignee workload, blockers, stale claims, throughput trend and attention as JSON")
+	assigneeWindow := flag.Int("assignee-window", 14, "Trend window in days for --robot-assignee-health")
+	staleClaimDays := flag.Int("stale-claim-days", 7, "Days without updates before an in-progress claim counts as stale")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
 	robotMetrics := flag.Bool("robot-metrics", false, "Output performance metrics (timing, cache, memory) as JSON")
 	// Smart suggestions (bv-180)
 	robotSuggest := flag.Bool("robot-suggest", false, "Output smart suggestions (duplicates, dependencies, labels, cycles) as JSON")
-	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
+	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle, redundant")
 	suggestConfidence := flag.Float64("suggest-confidence", 0.0, "Minimum confidence for suggestions (0.0-1.0)")
 	suggestBead := flag.String("suggest-bead", "", "Filter suggestions for specific bead ID")
+	semanticDuplicates := flag.Bool("semantic-duplicates", false, "Blend embedding nearest-neighbour similarity (BV_SEMANTIC_EMBEDDER) into duplicate detection for --robot-suggest and --robot-alerts")
+	duplicateCosine := flag.Float64("duplicate-cosine", 0.85, "Minimum embedding cosine similarity for a semantic duplicate (with --semantic-duplicates)")
 	// Graph export (bv-136)
 	robotGraph := flag.Bool("robot-graph", false, "Output dependency graph as JSON/DOT/Mermaid for AI agents")
 	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid")
 	graphRoot := flag.String("graph-root", "", "Subgraph from specific root issue ID")
 	graphDepth := flag.Int("graph-depth", 0, "Max depth for subgraph (0 = unlimited)")
+	graphReduce := flag.Bool("graph-reduce", false, "Drop blocking edges implied by longer chains (transitive reduction) in graph exports")
 	// Graph snapshot export (bv-94)
 	exportGraph := flag.String("export-graph", "", "Export graph: .html for interactive, .png/.svg for static (auto-names if empty)")
 	graphPreset := flag.String("graph-preset", "compact", "Graph layout preset: compact (default) or roomy")
 	graphTitle := flag.String("graph-title", "", "Title for graph export (default: project name)")
+	// Gantt schedule export
+	exportGantt := flag.String("export-gantt", "", "Export forecast schedule as Gantt chart: .mmd/.md for Mermaid, .svg or .png")
+	ganttGroup := flag.String("gantt-group", "epic", "Gantt section grouping: epic, label, or track")
 	// Robot output filters (bv-84)
 	robotMinConf := flag.Float64("robot-min-confidence", 0.0, "Filter robot outputs by minimum confidence (0.0-1.0)")
 	robotMaxResults := flag.Int("robot-max-results", 0, "Limit robot output count (0 = use defaults)")
@@ -99,6 +119,7 @@ func main() {
 	searchMode := flag.String("search-mode", "", "Search ranking mode: text or hybrid (default: BV_SEARCH_MODE or text)")
 	searchPreset := flag.String("search-preset", "", "Hybrid preset name (default: BV_SEARCH_PRESET or default)")
 	searchWeights := flag.String("search-weights", "", "Hybrid weights JSON (overrides preset; keys: text,pagerank,status,impact,priority,recency)")
+	searchSources := flag.String("search-sources", "", "Keyword search sources: comma list of issue,comment,commit,file or all (default: issue,comment)")
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
@@ -110,7 +131,11 @@ func main() {
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
 	checkDrift := flag.Bool("check-drift", false, "Check for drift from baseline (exit codes: 0=OK, 1=critical, 2=warning)")
+	baselineName := flag.String("baseline-name", "", "Named baseline for --save-baseline/--baseline-info, stored in .bv/baselines/<name>.json")
+	driftFrom := flag.String("from", "", "With --check-drift: compare from this baseline name or git revision (default: .bv/baseline.json)")
+	driftTo := flag.String("to", "", "With --check-drift: compare to this baseline name or git revision (default: current working tree)")
 	robotDriftCheck := flag.Bool("robot-drift", false, "Output drift check as JSON (use with --check-drift)")
+	notifySinks := flag.Bool("notify", false, "With --check-drift, --robot-alerts or the TUI: send new alerts to the sinks in .bv/notify.yaml")
 	robotHistory := flag.Bool("robot-history", false, "Output bead-to-commit correlations as JSON")
 	beadHistory := flag.String("bead-history", "", "Show history for specific bead ID")
 	historySince := flag.String("history-since", "", "Limit history to commits after this date/ref (e.g., '30 days ago', '2024-01-01')")
@@ -142,6 +167,7 @@ func main() {
 	relatedMinRelevance := flag.Int("related-min-relevance", 20, "Minimum relevance score (0-100) for related work")
 	relatedMaxResults := flag.Int("related-max-results", 10, "Max results per category for related work")
 	relatedIncludeClosed := flag.Bool("related-include-closed", false, "Include closed beads in related work results")
+	robotSimilar := flag.String("robot-similar", "", "Output issues similar to a bead ID (embeddings, shared labels, graph proximity, co-changed files) as JSON")
 	// Blocker chain analysis flag (bv-nlo0)
 	robotBlockerChain := flag.String("robot-blocker-chain", "", "Output full blocker chain analysis for issue ID as JSON")
 	// Impact network graph flag (bv-48kr)
@@ -152,6 +178,12 @@ func main() {
 	// Sprint flags (bv-156)
 	robotSprintList := flag.Bool("robot-sprint-list", false, "Output sprints as JSON")
 	robotSprintShow := flag.String("robot-sprint-show", "", "Output specific sprint details as JSON")
+	robotSprintPlan := flag.Bool("robot-sprint-plan", false, "Output a capacity-filled plan for the next sprint as JSON")
+	planCapacity := flag.Float64("plan-capacity", 0, "Capacity in estimated hours for --robot-sprint-plan (default: latest sprint velocity target, else 40)")
+	planDays := flag.Int("plan-days", 14, "Sprint length in days for --robot-sprint-plan --plan-save")
+	planSave := flag.String("plan-save", "", "With --robot-sprint-plan: save the plan as a new sprint with this ID")
+	robotSprintRetro := flag.String("robot-sprint-retro", "", "Output a retrospective for sprint ID ('current' for the active sprint, 'last' for the latest ended) as JSON")
+	retroMD := flag.String("retro-md", "", "With --robot-sprint-retro: write the retrospective as Markdown to this file ('-' for stdout)")
 	// Forecast flags (bv-158)
 	robotForecast := flag.String("robot-forecast", "", "Output ETA forecast for bead ID, or 'all' for all open issues")
 	forecastLabel := flag.String("forecast-label", "", "Filter forecast by label")
@@ -163,6 +195,7 @@ func main() {
 	capacityLabel := flag.String("capacity-label", "", "Filter capacity simulation by label")
 	// Burndown flags (bv-159)
 	robotBurndown := flag.String("robot-burndown", "", "Output burndown data for sprint ID, or 'current' for active sprint")
+	burndownCSV := flag.String("burndown-csv", "", "With --robot-burndown: write the git-replayed daily burndown as CSV to this file ('-' for stdout)")
 	// Action script emission flags (bv-89)
 	emitScript := flag.Bool("emit-script", false, "Emit shell script for top-N recommendations (agent workflows)")
 	scriptLimit := flag.Int("script-limit", 5, "Limit number of items in emitted script (use with --emit-script)")
@@ -228,6 +261,12 @@ func main() {
 		*robotRecipes ||
 		*robotLabelHealth ||
 		*robotLabelFlow ||
+		*robotFlow ||
+		*robotEpics ||
+		*robotClusters ||
+		*robotGates != "" ||
+		*robotWorkspaceHealth ||
+		*robotAssigneeHealth ||
 		*robotLabelAttention ||
 		*robotAlerts ||
 		*robotMetrics ||
@@ -241,11 +280,14 @@ func main() {
 		*robotImpact != "" ||
 		*robotFileRelations != "" ||
 		*robotRelatedWork != "" ||
+		*robotSimilar != "" ||
 		*robotBlockerChain != "" ||
 		*robotImpactNetwork != "" ||
 		*robotCausality != "" ||
 		*robotSprintList ||
 		*robotSprintShow != "" ||
+		*robotSprintPlan ||
+		*robotSprintRetro != "" ||
 		*robotForecast != "" ||
 		*robotBurndown != "" ||
 		*robotByLabel != "" ||
@@ -332,6 +374,9 @@ func main() {
 		fmt.Println("      - --search-mode=text|hybrid (default: BV_SEARCH_MODE or text)")
 		fmt.Println("      - --search-preset=default|bug-hunting|sprint-planning|impact-first|text-only")
 		fmt.Println("      - --search-weights='{\"text\":0.4,\"pagerank\":0.2,\"status\":0.15,\"impact\":0.1,\"priority\":0.1,\"recency\":0.05}'")
+		fmt.Println("      Keyword sources (results show where each issue matched):")
+		fmt.Println("      - --search-sources=issue,comment,commit,file|all (default: issue,comment)")
+		fmt.Println("        commit/file search correlated git history (--history-limit commits)")
 		fmt.Println("")
 		fmt.Println("  --emit-script [--script-limit=N]")
 		fmt.Println("      Emits a shell script for top-N recommendations (default: 5).")
@@ -424,6 +469,17 @@ func main() {
 		fmt.Println("      Example: bv --robot-related bv-abc1")
 		fmt.Println("      Example: bv --robot-related bv-abc1 --related-include-closed")
 		fmt.Println("")
+		fmt.Println("  --robot-similar <bead-id>")
+		fmt.Println("      Outputs \"more like this\" suggestions for a bead as JSON, combining:")
+		fmt.Println("      - embedding: text similarity with the configured embedder (BV_SEMANTIC_EMBEDDER)")
+		fmt.Println("      - labels: shared labels (Jaccard)")
+		fmt.Println("      - graph: 2-3 hops away in the dependency graph (direct links are left out)")
+		fmt.Println("      - cochange: files touched by both beads' correlated commits")
+		fmt.Println("      Key fields: similar[{issue_id,score,components,reasons,shared_labels,shared_files,")
+		fmt.Println("                  dependency_path,add_command}]")
+		fmt.Println("      Options: --related-max-results <n>, --related-include-closed, --history-limit <n>")
+		fmt.Println("      Example: bv --robot-similar bv-abc1 | jq '.similar[] | {issue_id, reasons}'")
+		fmt.Println("")
 		fmt.Println("  --robot-sprint-list")
 		fmt.Println("      Outputs all sprints as JSON for planning and forecasting.")
 		fmt.Println("      Key fields:")
@@ -437,6 +493,38 @@ func main() {
 		fmt.Println("      Returns the full sprint object with all fields.")
 		fmt.Println("      Example: bv --robot-sprint-show sprint-1")
 		fmt.Println("")
+		fmt.Println("  --robot-sprint-plan")
+		fmt.Println("      Fills the next sprint to capacity with the open issues of highest triage impact.")
+		fmt.Println("      Issues are priced with their open blockers, which are pulled in and ordered first;")
+		fmt.Println("      planning all of an epic's children gets a small bonus to keep epics together.")
+		fmt.Println("      Key fields:")
+		fmt.Println("      - capacity_minutes, planned_minutes, total_score")
+		fmt.Println("      - items: Chosen issues in execution order (pulled_in_for lists dependents)")
+		fmt.Println("      - excluded: Open issues left out, with reason (capacity, too_large, in_sprint,")
+		fmt.Println("        blocked, cycle, blocker_unavailable) and detail")
+		fmt.Println("      - epics: Planned vs open children per epic touched by the plan")
+		fmt.Println("      - saved_sprint: The sprint written by --plan-save")
+		fmt.Println("      Options:")
+		fmt.Println("        --plan-capacity=H   Capacity in estimated hours (default: latest sprint capacity_minutes, else 40)")
+		fmt.Println("        --plan-days=N       Sprint length for --plan-save (default: 14)")
+		fmt.Println("        --plan-save=ID      Append the plan to .beads/sprints.jsonl as sprint ID")
+		fmt.Println("      Example: bv --robot-sprint-plan --plan-capacity=60")
+		fmt.Println("      Example: bv --robot-sprint-plan --plan-save=sprint-7")
+		fmt.Println("")
+		fmt.Println("  --robot-sprint-retro <id|current|last>")
+		fmt.Println("      Outputs a sprint retrospective as JSON. 'last' picks the most recently ended sprint.")
+		fmt.Println("      Key fields:")
+		fmt.Println("      - planned, added, removed, delivered, delivered_planned, completion_ratio")
+		fmt.Println("      - carryover: Items still open when the sprint ended")
+		fmt.Println("      - cycle_time, cycle_outliers: Delivered items over 2x the sprint median")
+		fmt.Println("      - most_blocked: Blocked hours inside the sprint, from git status history")
+		fmt.Println("      - churned_files: Files changed by the most sprint beads during the sprint")
+		fmt.Println("      - velocity, velocity_trend: Delivery vs up to 5 previous sprints")
+		fmt.Println("      Options:")
+		fmt.Println("        --retro-md=FILE     Also write the report as Markdown ('-' for stdout instead of JSON)")
+		fmt.Println("      Example: bv --robot-sprint-retro last")
+		fmt.Println("      Example: bv --robot-sprint-retro sprint-3 --retro-md retro.md")
+		fmt.Println("")
 		fmt.Println("  --robot-burndown <id|current>")
 		fmt.Println("      Outputs burndown data for a sprint as JSON.")
 		fmt.Println("      Use 'current' to get the active sprint, or specify sprint ID.")
@@ -448,8 +536,11 @@ func main() {
 		fmt.Println("      - on_track: Whether sprint will complete on time")
 		fmt.Println("      - daily_points: Actual burndown data points")
 		fmt.Println("      - ideal_line: Expected burndown line")
+		fmt.Println("      - history: Day-by-day state replayed from git (count and estimated minutes,")
+		fmt.Println("        ideal line, scope changes with commit SHAs, end-of-sprint carryover)")
 		fmt.Println("      Example: bv --robot-burndown current")
 		fmt.Println("      Example: bv --robot-burndown sprint-1")
+		fmt.Println("      Example: bv --robot-burndown sprint-1 --burndown-csv burndown.csv")
 		fmt.Println("")
 		fmt.Println("  --robot-forecast <id|all>")
 		fmt.Println("      Outputs ETA forecast for a specific bead or all open issues.")
@@ -495,6 +586,7 @@ func main() {
 		fmt.Println("  --export-md <file>")
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
+		fmt.Println("      --export-md-schedule adds a Mermaid forecast schedule (see --export-gantt).")
 		fmt.Println("")
 		fmt.Println("  --no-hooks")
 		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
@@ -545,6 +637,57 @@ func main() {
 		fmt.Println("                  bottleneck_labels (highest outgoing), total_cross_label_deps.")
 		fmt.Println("      Use when you need to see which labels are blocking others at a glance.")
 		fmt.Println("")
+		fmt.Println("  --robot-flow [--flow-days=N]")
+		fmt.Println("      Outputs flow metrics for the project and each label as JSON (default window: 30 days).")
+		fmt.Println("      Status transitions are reconstructed from git history of the beads file;")
+		fmt.Println("      issues without history fall back to created/updated/closed timestamps (see .flow.source).")
+		fmt.Println("      Key fields: cfd[{date,open,in_progress,blocked,closed}], lead_time, cycle_time")
+		fmt.Println("                  (count, median_days, p85_days, p95_days), throughput{total,per_week,daily},")
+		fmt.Println("                  wip_aging[{issue_id,age_days,over_p85}].")
+		fmt.Println("      Example: bv --robot-flow | jq '.flow.project.cycle_time'")
+		fmt.Println("")
+		fmt.Println("  --robot-epics")
+		fmt.Println("      Outputs a progress rollup for every epic as JSON, recursing through child epics.")
+		fmt.Println("      Work items are leaf descendants via parent-child deps; missing estimates use the median.")
+		fmt.Println("      Key fields: percent_by_count, percent_by_minutes, remaining_minutes,")
+		fmt.Println("                  external_blockers[{issue_id,blocks}], critical_path{path,minutes},")
+		fmt.Println("                  velocity{issues_per_week,minutes_per_week}, forecast{status,eta_date}.")
+		fmt.Println("      Example: bv --robot-epics | jq '.epics[] | {epic_id, percent_by_count, eta: .forecast.eta_date}'")
+		fmt.Println("")
+		fmt.Println("  --robot-clusters [--cluster-resolution=1.0] [--cluster-correlation]")
+		fmt.Println("      Outputs communities of open issues found by Louvain modularity optimization over")
+		fmt.Println("      all dependency types; communities are split so each one is connected.")
+		fmt.Println("      --cluster-correlation adds shared-commit/shared-file edges from git history.")
+		fmt.Println("      Key fields: clusters[{id,name,issue_ids,top_labels,cohesion}], coupling[{from,to,weight}],")
+		fmt.Println("                  suggestions[{type: unlabeled_epic|label, message, issue_ids}], modularity.")
+		fmt.Println("      Example: bv --robot-clusters | jq '.clusters.suggestions[] | .message'")
+		fmt.Println("")
+		fmt.Println("  --robot-workspace-health --workspace CONFIG")
+		fmt.Println("      Outputs cross-repo structure for a multi-repo workspace as JSON.")
+		fmt.Println("      Issues belong to the repo whose ID prefix they carry; only open work counts in the flow.")
+		fmt.Println("      Key fields: repos[{repo,open_count,blocked_count,blocked_by_other_repos,blocking_other_repos,")
+		fmt.Println("                  health,health_level}], flow{repos,flow_matrix[from][to],dependencies},")
+		fmt.Println("                  cross_repo_blockers[{issue_id,repo,blocked_repos,downstream_impact}],")
+		fmt.Println("                  cycles[{kind: issue|repo, repos, issue_ids}].")
+		fmt.Println("      Example: bv --workspace .bv/workspace.yaml --robot-workspace-health | jq '.workspace_health.cross_repo_blockers[:5]'")
+		fmt.Println("")
+		fmt.Println("  --robot-gates <id|all>")
+		fmt.Println("      Outputs dominator-tree gates over open blocking and parent-child dependencies.")
+		fmt.Println("      Issue Y dominates X when every path to X goes through Y, so X cannot start before Y.")
+		fmt.Println("      Each epic also gets a post-dominator tree showing which issues funnel its sub-project.")
+		fmt.Println("      Key fields: report{gating_chain[{issue_id,dominates}], dominates, dominated,")
+		fmt.Println("                  epics[{epic_id,chain}], subproject_gates}; 'all' returns top_gates[].")
+		fmt.Println("      Example: bv --robot-gates=all | jq '.top_gates[:5] | map({issue_id, dominates})'")
+		fmt.Println("")
+		fmt.Println("  --robot-assignee-health [--assignee-window=14] [--stale-claim-days=7]")
+		fmt.Println("      Outputs workload and flow health for every assignee (people and agents) as JSON.")
+		fmt.Println("      Throughput and median cycle time compare the last window with the one before it;")
+		fmt.Println("      cycle times use git status history when available.")
+		fmt.Println("      Key fields: wip, open_count, blocked[{issue_id,blockers[{issue_id,assignee,external}]}],")
+		fmt.Println("                  blocked_by_others, stale_claims, throughput{recent,prior,trend},")
+		fmt.Println("                  cycle_time{trend}, load_ratio, overloaded, attention_score, reasons.")
+		fmt.Println("      Example: bv --robot-assignee-health | jq '.assignee_health.assignees[] | select(.overloaded)'")
+		fmt.Println("")
 		fmt.Println("  --robot-label-attention [--attention-limit=N]")
 		fmt.Println("      Outputs attention-ranked labels as JSON (default limit: 5).")
 		fmt.Println("      Labels ranked by attention score = (pagerank * staleness * block_impact) / velocity.")
@@ -552,11 +695,16 @@ func main() {
 		fmt.Println("      Use to identify which labels need the most focus based on centrality and health factors.")
 		fmt.Println("")
 		fmt.Println("  --robot-alerts")
-		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles).")
+		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles, due dates).")
+		fmt.Println("      Due-date alerts compare each dated issue's ETA, including its open blockers, to its due date:")
+		fmt.Println("      due_date_late (critical) and due_date_at_risk (warning).")
+		fmt.Println("      potential_duplicate flags open issue pairs that look alike, with the issue to keep in details.")
+		fmt.Println("      Add --semantic-duplicates [--duplicate-cosine=0.85] to blend embedding similarity into")
+		fmt.Println("      duplicate detection (also applies to --robot-suggest).")
 		fmt.Println("      Filters: --severity=<info|warning|critical>, --alert-type=<type>, --alert-label=<label>")
 		fmt.Println("      Fields: type, severity, message, issue_id, label, detected_at, details[].")
 		fmt.Println("")
-		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N]")
+		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N] [--graph-reduce]")
 		fmt.Println("      Outputs dependency graph in specified format (default: JSON adjacency).")
 		fmt.Println("      Formats:")
 		fmt.Println("        - json: Adjacency list with nodes[], edges[], metadata")
@@ -566,6 +714,7 @@ func main() {
 		fmt.Println("        --label LABEL: Filter to issues with specific label")
 		fmt.Println("        --graph-root ID: Extract subgraph starting from root issue")
 		fmt.Println("        --graph-depth N: Limit subgraph depth (0 = unlimited)")
+		fmt.Println("        --graph-reduce: Drop blocking edges already implied by a longer chain")
 		fmt.Println("      Fields: format, graph (string for dot/mermaid), nodes, edges, filters_applied, explanation")
 		fmt.Println("      Example: bv --robot-graph --graph-format=dot --label=api > api-deps.dot")
 		fmt.Println("")
@@ -589,10 +738,22 @@ func main() {
 		fmt.Println("        --label LABEL: Filter to issues with specific label")
 		fmt.Println("        --graph-preset: Layout spacing - 'compact' (default) or 'roomy'")
 		fmt.Println("        --graph-title: Custom title for the graph header")
+		fmt.Println("        --graph-reduce: Render the transitive reduction (no redundant blocking edges)")
 		fmt.Println("")
 		fmt.Println("      Example: bv --export-graph deps.svg --label=api --graph-title='API Dependencies'")
 		fmt.Println("      Example: bv --export-graph full.png --graph-style=force --graph-preset=roomy")
 		fmt.Println("")
+		fmt.Println("  --export-gantt <path.mmd|path.md|path.svg|path.png> [--gantt-group=epic|label|track]")
+		fmt.Println("      Export a forecast schedule as a Gantt chart.")
+		fmt.Println("      Bars come from ETA forecasts; execution plan tracks run in parallel,")
+		fmt.Println("      and no task starts before its open blockers finish.")
+		fmt.Println("      Format is inferred from file extension (.mmd/.mermaid/.md = Mermaid gantt).")
+		fmt.Println("      Options:")
+		fmt.Println("        --gantt-group: Section grouping - 'epic' (default), 'label', or 'track'")
+		fmt.Println("        --label LABEL: Filter to issues with specific label")
+		fmt.Println("        --graph-title: Custom chart title")
+		fmt.Println("      Example: bv --export-gantt roadmap.svg --gantt-group=label")
+		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Graph metrics JSON for agents.")
 		fmt.Println("      Top lists: Bottlenecks (betweenness), Keystones (critical path), Influencers (eigenvector),")
@@ -669,9 +830,14 @@ func main() {
 		fmt.Println("      Stores graph stats, top metrics, and cycle info in .bv/baseline.json.")
 		fmt.Println("      Use for drift detection: compare current state to saved baseline.")
 		fmt.Println("      Example: bv --save-baseline \"Before major refactor\"")
+		fmt.Println("      Example: bv --save-baseline \"Release 1.2\" --baseline-name v1.2")
+		fmt.Println("")
+		fmt.Println("  --baseline-name <name>")
+		fmt.Println("      Save or show a named baseline in .bv/baselines/<name>.json,")
+		fmt.Println("      e.g. one per release or sprint start, kept side by side.")
 		fmt.Println("")
 		fmt.Println("  --baseline-info")
-		fmt.Println("      Show information about the saved baseline.")
+		fmt.Println("      Show information about the saved baseline and list named baselines.")
 		fmt.Println("      Displays: creation date, git commit, graph stats, top metrics.")
 		fmt.Println("")
 		fmt.Println("  --check-drift")
@@ -681,11 +847,24 @@ func main() {
 		fmt.Println("        1 = Critical alerts (new cycles detected)")
 		fmt.Println("        2 = Warning alerts (blocked increase, density growth)")
 		fmt.Println("      Human-readable output by default, use --robot-drift for JSON.")
+		fmt.Println("      --from=<name|ref> --to=<name|ref> compare any two points: a named")
+		fmt.Println("      baseline, or a git revision snapshotted on the fly. --to defaults to")
+		fmt.Println("      the working tree. Ranges add a timeline of when each alert first")
+		fmt.Println("      appeared, replaying the commits that touched the beads file.")
+		fmt.Println("      Example: bv --check-drift --from=v1.1 --to=v1.2")
+		fmt.Println("      Example: bv --check-drift --from=HEAD~20 --robot-drift")
 		fmt.Println("")
 		fmt.Println("  --robot-drift")
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
 		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
 		fmt.Println("")
+		fmt.Println("  --notify")
+		fmt.Println("      With --check-drift, --robot-alerts or the TUI: send new alerts to the sinks in .bv/notify.yaml")
+		fmt.Println("      (webhook, slack, command, file, desktop). Alerts already sent are suppressed until")
+		fmt.Println("      their cooldown expires (state in .bv/notify-state.json). A report goes to stderr;")
+		fmt.Println("      delivery failures never change the exit code.")
+		fmt.Println("      Example: bv --check-drift --notify")
+		fmt.Println("")
 		fmt.Println("  Static Site Export & GitHub Pages (bv-7pu):")
 		fmt.Println("      --pages")
 		fmt.Println("          Launch interactive Pages deployment wizard.")
@@ -713,7 +892,16 @@ func main() {
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
 		fmt.Println("      - blocked_increase_threshold: 5   # Warn if 5+ more blocked")
+		fmt.Println("      - due_date_at_risk_likelihood: 0.8   # Warn below 80% on-time likelihood")
 		fmt.Println("      Run 'bv --baseline-info' to see current baseline state.")
+		fmt.Println("")
+		fmt.Println("  Triage Scoring Configuration (.bv/triage.yaml)")
+		fmt.Println("      Replace the built-in triage score with a safe expression, e.g.:")
+		fmt.Println("      - formula: \"0.5*pagerank + 0.3*min(unblocks, 5)/5 + 0.4*due_risk\"")
+		fmt.Println("      - label_multipliers: {security: 1.5}")
+		fmt.Println("      - rules: [{name: security first, label: security, top: 3}]")
+		fmt.Println("      Variables: " + triageFormulaVariableNames())
+		fmt.Println("      Functions: min, max, abs, sqrt, log, clamp, has_label('x'), label_value('x', default)")
 		os.Exit(0)
 	}
 
@@ -919,19 +1107,52 @@ func main() {
 	projectDir, _ := os.Getwd()
 	baselinePath := baseline.DefaultPath(projectDir)
 
+	if *baselineName != "" {
+		if err := baseline.ValidateName(*baselineName); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Handle --baseline-info
 	if *baselineInfo {
-		if !baseline.Exists(baselinePath) {
-			fmt.Println("No baseline found.")
-			fmt.Println("Create one with: bv --save-baseline \"description\"")
+		if *baselineName != "" {
+			bl, err := baseline.Load(baseline.NamedPath(projectDir, *baselineName))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(bl.Summary())
 			os.Exit(0)
 		}
-		bl, err := baseline.Load(baselinePath)
+		named, err := baseline.List(projectDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error listing baselines: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Print(bl.Summary())
+		if !baseline.Exists(baselinePath) && len(named) == 0 {
+			fmt.Println("No baseline found.")
+			fmt.Println("Create one with: bv --save-baseline \"description\"")
+			os.Exit(0)
+		}
+		if baseline.Exists(baselinePath) {
+			bl, err := baseline.Load(baselinePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(bl.Summary())
+		}
+		if len(named) > 0 {
+			fmt.Println("\nNamed baselines:")
+			for _, e := range named {
+				sha := e.CommitSHA
+				if len(sha) > 8 {
+					sha = sha[:8]
+				}
+				fmt.Printf("  %-20s %s  %-8s %s\n", e.Name, e.CreatedAt.Format("2006-01-02"), sha, e.Description)
+			}
+		}
 		os.Exit(0)
 	}
 
@@ -955,6 +1176,7 @@ func main() {
 	var issues []model.Issue
 	var beadsPath string
 	var workspaceInfo *workspace.LoadSummary
+	var workspaceRepos []analysis.WorkspaceRepo
 	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
 
 	if *asOf != "" {
@@ -995,6 +1217,13 @@ func main() {
 		issues = loadedIssues
 		summary := workspace.Summarize(results)
 		workspaceInfo = &summary
+		for _, result := range results {
+			repo := analysis.WorkspaceRepo{Name: result.RepoName, Prefix: result.Prefix}
+			if result.Error != nil {
+				repo.Error = result.Error.Error()
+			}
+			workspaceRepos = append(workspaceRepos, repo)
+		}
 
 		// Print workspace loading summary
 		if summary.FailedRepos > 0 {
@@ -1091,8 +1320,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-
-		embedder, err := search.NewEmbedderFromConfig(embedCfg)
+		sources, err := search.ParseSearchSources(*searchSources)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -1103,12 +1331,21 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		embedCfg.CacheDir = search.DefaultEmbeddingCacheDir(projectDir)
+
+		embedder, err := search.NewEmbedderFromConfig(embedCfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		embedCfg.Dim = embedder.Dim()
 		indexPath := search.DefaultIndexPath(projectDir, embedCfg)
 		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		idx.SetHNSWConfig(search.HNSWConfigFromEnv())
 
 		docs := search.DocumentsFromIssues(issuesForSearch)
 		if !*robotSearch && !loaded {
@@ -1143,19 +1380,51 @@ func main() {
 		if limit <= 0 {
 			limit = 10
 		}
-		fetchLimit := limit
-		if searchCfg.Mode == search.SearchModeHybrid {
-			fetchLimit = search.HybridCandidateLimit(limit, len(issuesForSearch), *semanticQuery)
-		}
-		results, err := idx.SearchTopK(qvecs[0], fetchLimit)
+		// Both rankings are over-fetched so rank fusion sees issues that only one of them finds.
+		fetchLimit := search.HybridCandidateLimit(limit, len(issuesForSearch), *semanticQuery)
+		semanticResults, err := idx.SearchTopK(qvecs[0], fetchLimit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
 			os.Exit(1)
 		}
-		results = search.ApplyShortQueryLexicalBoost(results, *semanticQuery, docs)
+		if !slices.Contains(sources, search.SourceIssue) {
+			// Embeddings cover issue fields, so they only rank when issues are a source.
+			semanticResults = nil
+		}
+
+		sourceDocs := search.SourceDocumentsFromIssues(issuesForSearch)
+		var searchWarnings []string
+		if search.NeedsHistory(sources) {
+			historyDocs, err := searchHistoryDocuments(issuesForSearch, *historyLimit)
+			if err != nil {
+				warning := fmt.Sprintf("commit and file sources were not searched: could not correlate git history: %v", err)
+				if *robotSearch {
+					searchWarnings = append(searchWarnings, warning)
+				} else {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+				}
+			}
+			sourceDocs = append(sourceDocs, historyDocs...)
+		}
+		lexicalPath := search.SourceIndexPath(projectDir, sources)
+		lexicalIdx, lexicalLoaded, err := search.LoadOrBuildSourceIndex(lexicalPath, search.FilterSourceDocuments(sourceDocs, sources))
+		if err != nil && !*robotSearch {
+			fmt.Fprintf(os.Stderr, "Warning: could not save lexical index: %v\n", err)
+		}
+		sourceResults := lexicalIdx.Search(*semanticQuery, fetchLimit)
+		lexicalResults := search.SourceSearchResults(sourceResults)
+		matchesByID := make(map[string][]search.SourceMatch, len(sourceResults))
+		for _, r := range sourceResults {
+			matchesByID[r.IssueID] = r.Matches
+		}
+
+		results := search.FuseTextResults(lexicalResults, semanticResults)
 		if isLikelyIssueID(*semanticQuery) {
 			results = promoteExactSearchResult(*semanticQuery, results)
 		}
+		if len(results) > limit {
+			results = results[:limit]
+		}
 
 		titleByID := make(map[string]string, len(issuesForSearch))
 		for _, iss := range issuesForSearch {
@@ -1172,7 +1441,6 @@ func main() {
 				os.Exit(1)
 			}
 			weights = weights.Normalize()
-			weights = search.AdjustWeightsForQuery(weights, *semanticQuery)
 			resolvedPreset = presetName
 			resolvedWeights = &weights
 
@@ -1183,7 +1451,7 @@ func main() {
 			}
 
 			scorer := search.NewHybridScorer(weights, cache)
-			hybridResults, err = buildHybridScores(results, scorer)
+			hybridResults, err = search.FuseHybridResults(lexicalResults, semanticResults, scorer)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error scoring hybrid results: %v\n", err)
 				os.Exit(1)
@@ -1198,17 +1466,22 @@ func main() {
 
 		if *robotSearch {
 			out := robotSearchOutput{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-				DataHash:    dataHash,
-				Query:       *semanticQuery,
-				Provider:    embedCfg.Provider,
-				Model:       embedCfg.Model,
-				Dim:         embedder.Dim(),
-				IndexPath:   indexPath,
-				Index:       syncStats,
-				Loaded:      loaded,
-				Limit:       limit,
-				Mode:        searchCfg.Mode,
+				GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+				DataHash:      dataHash,
+				Query:         *semanticQuery,
+				Provider:      embedCfg.Provider,
+				Model:         embedCfg.Model,
+				Dim:           embedder.Dim(),
+				IndexPath:     indexPath,
+				Index:         syncStats,
+				Loaded:        loaded,
+				Approximate:   idx.Approximate(),
+				LexicalPath:   lexicalPath,
+				LexicalLoaded: lexicalLoaded,
+				Sources:       sources,
+				Limit:         limit,
+				Mode:          searchCfg.Mode,
+				Warnings:      searchWarnings,
 			}
 			if searchCfg.Mode == search.SearchModeHybrid {
 				out.Preset = resolvedPreset
@@ -1223,6 +1496,7 @@ func main() {
 						TextScore:       r.TextScore,
 						Title:           titleByID[r.IssueID],
 						ComponentScores: r.ComponentScores,
+						MatchedIn:       matchesByID[r.IssueID],
 					})
 				}
 				out.UsageHints = []string{
@@ -1233,13 +1507,15 @@ func main() {
 			} else {
 				for _, r := range results {
 					out.Results = append(out.Results, robotSearchResult{
-						IssueID: r.IssueID,
-						Score:   r.Score,
-						Title:   titleByID[r.IssueID],
+						IssueID:   r.IssueID,
+						Score:     r.Score,
+						Title:     titleByID[r.IssueID],
+						MatchedIn: matchesByID[r.IssueID],
 					})
 				}
 				out.UsageHints = []string{
 					"jq '.results[] | {id: .issue_id, score: .score, title: .title}' - Extract results",
+					"jq '.results[] | {id: .issue_id, matched_in: [.matched_in[]?.source]}' - Where keywords matched (issue/comment/commit/file)",
 					"jq '.index' - Index update stats (added/updated/removed/embedded)",
 				}
 			}
@@ -1258,10 +1534,12 @@ func main() {
 		if searchCfg.Mode == search.SearchModeHybrid {
 			for _, r := range hybridResults {
 				fmt.Printf("%.4f\t%s\t%s\n", r.FinalScore, r.IssueID, titleByID[r.IssueID])
+				printSearchMatch(os.Stdout, matchesByID[r.IssueID])
 			}
 		} else {
 			for _, r := range results {
 				fmt.Printf("%.4f\t%s\t%s\n", r.Score, r.IssueID, titleByID[r.IssueID])
+				printSearchMatch(os.Stdout, matchesByID[r.IssueID])
 			}
 		}
 		os.Exit(0)
@@ -1338,7 +1616,7 @@ func main() {
 
 		// Compute triage
 		fmt.Println("  → Generating triage data...")
-		triage := analysis.ComputeTriage(exportIssues)
+		triage := analysis.ComputeTriageWithOptions(exportIssues, triageOptionsWithFormula(projectDir, analysis.TriageOptions{}))
 
 		// Extract dependencies
 		var deps []*model.Dependency
@@ -1365,6 +1643,9 @@ func main() {
 		if *pagesTitle != "" {
 			exporter.Config.Title = *pagesTitle
 		}
+		if transitions, err := loadStatusTransitions(*historyLimit); err == nil {
+			exporter.Transitions = transitions
+		}
 
 		// Export SQLite database
 		fmt.Println("  → Writing database and JSON files...")
@@ -1489,6 +1770,246 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-flow: cumulative flow, lead/cycle time, throughput, WIP aging
+	if *robotFlow {
+		transitions, err := loadStatusTransitions(*historyLimit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read git history, using snapshot timestamps: %v\n", err)
+		}
+
+		cfg := analysis.DefaultFlowConfig()
+		cfg.Days = *flowDays
+		report := analysis.ComputeFlowReport(issues, transitions, cfg)
+		output := struct {
+			GeneratedAt string              `json:"generated_at"`
+			DataHash    string              `json:"data_hash"`
+			Flow        analysis.FlowReport `json:"flow"`
+			UsageHints  []string            `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Flow:        report,
+			UsageHints: []string{
+				"jq '.flow.project.cfd[-1]' - status counts today",
+				"jq '.flow.project.cycle_time' - cycle time distribution (days)",
+				"jq '.flow.project.wip_aging[] | select(.over_p85)' - in-progress work older than usual",
+				"jq '.flow.labels[] | {label, throughput: .throughput.per_week}' - weekly throughput per label",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding flow metrics: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-epics: per-epic progress, blockers, critical path and forecast
+	if *robotEpics {
+		rollups := analysis.ComputeEpicRollups(issues, analysis.DefaultEpicRollupConfig())
+		complete := 0
+		for _, r := range rollups {
+			if r.Forecast.Status == analysis.EpicForecastComplete {
+				complete++
+			}
+		}
+		output := struct {
+			GeneratedAt   string                `json:"generated_at"`
+			DataHash      string                `json:"data_hash"`
+			EpicCount     int                   `json:"epic_count"`
+			CompleteCount int                   `json:"complete_count"`
+			Epics         []analysis.EpicRollup `json:"epics"`
+			UsageHints    []string              `json:"usage_hints"`
+		}{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			DataHash:      dataHash,
+			EpicCount:     len(rollups),
+			CompleteCount: complete,
+			Epics:         rollups,
+			UsageHints: []string{
+				"jq '.epics[] | {epic_id, percent_by_count, percent_by_minutes}' - completion per epic",
+				"jq '.epics[] | select(.external_blockers) | {epic_id, blockers: [.external_blockers[].issue_id]}' - epics waiting on outside work",
+				"jq '.epics[] | {epic_id, path: .critical_path.path}' - longest open chain inside each epic",
+				"jq '.epics[] | select(.forecast.status == \"no_velocity\")' - epics with no recent progress",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding epic rollups: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-clusters: community detection over the dependency graph
+	if *robotClusters {
+		cfg := analysis.DefaultClusterConfig()
+		cfg.Resolution = *clusterResolution
+		if *clusterCorrelation {
+			report, err := loadHistoryReport(issues, *historyLimit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not read git history, clustering on dependencies only: %v\n", err)
+			}
+			cfg.ExtraEdges = analysis.ClusterEdgesFromHistory(report, 10)
+		}
+		clusters := analysis.ComputeClusters(issues, cfg)
+		output := struct {
+			GeneratedAt string                 `json:"generated_at"`
+			DataHash    string                 `json:"data_hash"`
+			Clusters    analysis.ClusterReport `json:"clusters"`
+			UsageHints  []string               `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Clusters:    clusters,
+			UsageHints: []string{
+				"jq '.clusters.clusters[] | {id, name, size}' - detected groups",
+				"jq '.clusters.suggestions[] | select(.type == \"unlabeled_epic\")' - groups that look like missing epics",
+				"jq '.clusters.coupling[:5]' - most tightly coupled cluster pairs",
+				"--cluster-correlation - also weight by shared commits and files",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding clusters: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-gates: dominator-tree analysis of what gates what
+	if *robotGates != "" {
+		gates := analysis.ComputeGates(issues)
+		output := struct {
+			GeneratedAt string               `json:"generated_at"`
+			DataHash    string               `json:"data_hash"`
+			Report      *analysis.GateReport `json:"report,omitempty"`
+			TopGates    []analysis.GateIssue `json:"top_gates,omitempty"`
+			UsageHints  []string             `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+		}
+		if *robotGates == "all" {
+			limit := 20
+			if *robotMaxResults > 0 {
+				limit = *robotMaxResults
+			}
+			output.TopGates = gates.TopGates(limit)
+			if output.TopGates == nil {
+				output.TopGates = []analysis.GateIssue{}
+			}
+			output.UsageHints = []string{
+				"jq '.top_gates[:5] | map({issue_id, dominates})' - issues gating the most work",
+				"--robot-gates=<id> - gating chain and dominated set for one issue",
+			}
+		} else {
+			output.Report = gates.Report(*robotGates)
+			if output.Report == nil {
+				fmt.Fprintf(os.Stderr, "Issue not found or not open: %s\n", *robotGates)
+				os.Exit(1)
+			}
+			output.UsageHints = []string{
+				"jq '.report.gating_chain | map(.issue_id)' - issues every path to this one goes through",
+				"jq '.report.dominated' - issues that cannot start until this one is done",
+				"jq '.report.epics[] | {epic_id, chain: (.chain | map(.issue_id))}' - gates between this issue and its epics",
+				"jq '.report.subproject_gates[:5]' - for epics: issues funnelling the most sub-project work",
+			}
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding gates: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-similar: "more like this" suggestions with explanations
+	if *robotSimilar != "" {
+		output, err := buildRobotSimilar(issues, *robotSimilar, search.SimilarOptions{
+			Limit:         *relatedMaxResults,
+			IncludeClosed: *relatedIncludeClosed,
+			Weights:       search.DefaultSimilarWeights(),
+		}, *historyLimit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		output.DataHash = dataHash
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding similar issues: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-workspace-health: cross-repo structure in workspace mode
+	if *robotWorkspaceHealth {
+		if workspaceInfo == nil {
+			fmt.Fprintln(os.Stderr, "Error: --robot-workspace-health requires --workspace")
+			os.Exit(1)
+		}
+		report := analysis.ComputeWorkspaceHealth(issues, workspaceRepos, analysis.DefaultWorkspaceHealthConfig())
+		output := struct {
+			GeneratedAt     string                         `json:"generated_at"`
+			DataHash        string                         `json:"data_hash"`
+			WorkspaceHealth analysis.WorkspaceHealthReport `json:"workspace_health"`
+			UsageHints      []string                       `json:"usage_hints"`
+		}{
+			GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+			DataHash:        dataHash,
+			WorkspaceHealth: report,
+			UsageHints: []string{
+				"jq '.workspace_health.repos[] | {repo, health, health_level}' - per-repo health",
+				"jq '.workspace_health.flow.dependencies[] | {from_repo, to_repo, issue_count}' - repo-to-repo blocking flow",
+				"jq '.workspace_health.cross_repo_blockers[:5]' - cross-repo blockers with the most downstream impact",
+				"jq '.workspace_health.cycles[] | select(.kind == \"issue\")' - blocking cycles spanning repos",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding workspace health: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-assignee-health: per-assignee workload and flow health
+	if *robotAssigneeHealth {
+		transitions, err := loadStatusTransitions(*historyLimit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read git history, using snapshot timestamps: %v\n", err)
+		}
+
+		cfg := analysis.DefaultAssigneeHealthConfig()
+		cfg.WindowDays = *assigneeWindow
+		cfg.StaleClaimDays = *staleClaimDays
+		report := analysis.ComputeAssigneeHealth(issues, transitions, cfg)
+		output := struct {
+			GeneratedAt    string                        `json:"generated_at"`
+			DataHash       string                        `json:"data_hash"`
+			AssigneeHealth analysis.AssigneeHealthReport `json:"assignee_health"`
+			UsageHints     []string                      `json:"usage_hints"`
+		}{
+			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+			DataHash:       dataHash,
+			AssigneeHealth: report,
+			UsageHints: []string{
+				"jq '.assignee_health.assignees[0]' - assignee needing the most attention",
+				"jq '.assignee_health.assignees[] | select(.overloaded) | .assignee' - overloaded assignees",
+				"jq '.assignee_health.assignees[] | {assignee, stale_claims}' - claims to follow up on",
+				"jq '.assignee_health.assignees[].blocked[] | select(.blockers[].external)' - work waiting on others",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding assignee health: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-label-attention (bv-121)
 	if *robotLabelAttention {
 		cfg := analysis.DefaultLabelHealthConfig()
@@ -1594,6 +2115,7 @@ func main() {
 			Label:    *labelScope,
 			Root:     *graphRoot,
 			Depth:    *graphDepth,
+			Reduced:  *graphReduce,
 			DataHash: dataHash,
 		}
 
@@ -1630,6 +2152,9 @@ func main() {
 			}
 			exportIssues = filtered
 		}
+		if *graphReduce {
+			exportIssues = analysis.ApplyTransitiveReduction(exportIssues, analysis.ComputeTransitiveReduction(exportIssues))
+		}
 
 		if len(exportIssues) == 0 {
 			fmt.Fprintf(os.Stderr, "No issues to export (check filters)\n")
@@ -1648,7 +2173,7 @@ func main() {
 			}
 
 			// Compute triage for the graph export
-			triageOpts := analysis.TriageOptions{WaitForPhase2: true}
+			triageOpts := triageOptionsWithFormula(projectDir, analysis.TriageOptions{WaitForPhase2: true})
 			triage := analysis.ComputeTriageWithOptions(exportIssues, triageOpts)
 
 			opts := export.InteractiveGraphOptions{
@@ -1693,6 +2218,46 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --export-gantt - Mermaid/SVG/PNG forecast schedule
+	if *exportGantt != "" {
+		groupBy, err := export.ParseGanttGroupBy(*ganttGroup)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+
+		schedule := export.BuildGanttSchedule(issues, export.GanttOptions{
+			GroupBy: groupBy,
+			Stats:   &stats,
+		})
+		if schedule.TaskCount == 0 {
+			fmt.Fprintf(os.Stderr, "No open issues to schedule (check filters)\n")
+			os.Exit(1)
+		}
+
+		title := *graphTitle
+		if title == "" {
+			cwd, _ := os.Getwd()
+			title = filepath.Base(cwd) + " forecast"
+		}
+
+		if err := export.SaveGanttChart(export.GanttExportOptions{
+			Path:     *exportGantt,
+			Title:    title,
+			Schedule: schedule,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting gantt chart: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓ Gantt chart exported to %s (%d tasks, %d sections, ends %s)\n",
+			*exportGantt, schedule.TaskCount, len(schedule.Sections), schedule.End.Format("2006-01-02"))
+		os.Exit(0)
+	}
+
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
 		driftConfig, err := drift.LoadConfig(projectDir)
@@ -1757,6 +2322,23 @@ func main() {
 
 		calc := drift.NewCalculator(bl, cur, driftConfig)
 		calc.SetIssues(issues)
+		if !driftConfig.IsAlertDisabled(string(drift.AlertAbandonedClaim)) {
+			claims, err := loadClaimActivity(issues, *historyLimit)
+			if err != nil && !envRobot {
+				fmt.Fprintf(os.Stderr, "Warning: could not read git activity for claims: %v\n", err)
+			}
+			calc.SetClaimActivity(claims)
+		}
+		if *semanticDuplicates {
+			dupConfig := analysis.DefaultDuplicateConfig()
+			dupConfig.CosineThreshold = *duplicateCosine
+			dupConfig.Semantic, err = semanticDuplicatePairs(issues, *duplicateCosine)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error computing semantic duplicates: %v\n", err)
+				os.Exit(1)
+			}
+			calc.SetDuplicates(analysis.FindDuplicateMatches(issues, dupConfig))
+		}
 		driftResult := calc.Calculate()
 
 		// Apply optional filters
@@ -1783,6 +2365,9 @@ func main() {
 			filtered = append(filtered, a)
 		}
 		driftResult.Alerts = filtered
+		if *notifySinks {
+			sendAlertNotifications(projectDir, "robot-alerts", driftResult.Alerts)
+		}
 
 		output := struct {
 			GeneratedAt string        `json:"generated_at"`
@@ -1802,6 +2387,9 @@ func main() {
 			UsageHints: []string{
 				"--severity=warning --alert-type=stale_issue   # stale warnings only",
 				"--alert-type=blocking_cascade                 # high-unblock opportunities",
+				"--alert-type=due_date_at_risk                 # dated issues whose ETA may slip",
+				"--alert-type=potential_duplicate --semantic-duplicates # look-alike issues",
+				"--alert-type=abandoned_claim                  # in_progress work with no recent commits",
 				"jq '.alerts | map(.issue_id)'                # list impacted issues",
 			},
 		}
@@ -1830,6 +2418,15 @@ func main() {
 		config := analysis.DefaultSuggestAllConfig()
 		config.MinConfidence = *suggestConfidence
 		config.FilterBead = *suggestBead
+		if *semanticDuplicates {
+			pairs, err := semanticDuplicatePairs(issues, *duplicateCosine)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error computing semantic duplicates: %v\n", err)
+				os.Exit(1)
+			}
+			config.Duplicates.Semantic = pairs
+			config.Duplicates.CosineThreshold = *duplicateCosine
+		}
 
 		// Parse filter type
 		switch *suggestType {
@@ -1841,13 +2438,23 @@ func main() {
 			config.FilterType = analysis.SuggestionLabelSuggestion
 		case "cycle", "cycles":
 			config.FilterType = analysis.SuggestionCycleWarning
+		case "redundant", "redundancy":
+			config.FilterType = analysis.SuggestionRedundantDependency
 		case "":
 			// All types
 		default:
-			fmt.Fprintf(os.Stderr, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle)\n", *suggestType)
+			fmt.Fprintf(os.Stderr, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle, redundant)\n", *suggestType)
 			os.Exit(1)
 		}
 
+		if config.FilterType == "" || config.FilterType == analysis.SuggestionLabelSuggestion {
+			classifier, err := analysis.LoadOrTrainLabelClassifier(projectDir, issues, dataHash)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: label model not saved: %v\n", err)
+			}
+			config.Labels.Classifier = classifier
+		}
+
 		output := analysis.GenerateRobotSuggestOutput(issues, config, dataHash)
 
 		encoder := newRobotEncoder(os.Stdout)
@@ -1866,56 +2473,17 @@ func main() {
 
 	// Handle --save-baseline
 	if *saveBaseline != "" {
-		analyzer := analysis.NewAnalyzer(issues)
-		if *forceFullAnalysis {
-			cfg := analysis.FullAnalysisConfig()
-			analyzer.SetConfig(&cfg)
-		}
-		stats := analyzer.Analyze()
-
-		// Compute status counts from issues
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusOpen, model.StatusInProgress:
-				openCount++
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			}
-		}
-
-		// Get actionable count from analyzer
-		actionableCount := len(analyzer.GetActionableIssues())
-
-		// Get cycles (method returns a copy)
-		cycles := stats.Cycles()
-
-		// Build GraphStats from analysis
-		graphStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(cycles),
-			ActionableCount: actionableCount,
+		bl := snapshotBaseline(issues, *forceFullAnalysis, *saveBaseline)
+		if driftConfig, err := drift.LoadConfig(projectDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading drift config, rule metrics not saved: %v\n", err)
+		} else {
+			bl.RuleMetrics = driftConfig.EvaluateRules(issues, time.Now())
 		}
-
-		// Build TopMetrics from analysis (top 10 for each)
-		// Methods return copies of the maps
-		topMetrics := baseline.TopMetrics{
-			PageRank:     buildMetricItems(stats.PageRank(), 10),
-			Betweenness:  buildMetricItems(stats.Betweenness(), 10),
-			CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
-			Hubs:         buildMetricItems(stats.Hubs(), 10),
-			Authorities:  buildMetricItems(stats.Authorities(), 10),
+		if *baselineName != "" {
+			bl.Name = *baselineName
+			baselinePath = baseline.NamedPath(projectDir, *baselineName)
 		}
 
-		bl := baseline.New(graphStats, topMetrics, cycles, *saveBaseline)
-
 		if err := bl.Save(baselinePath); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving baseline: %v\n", err)
 			os.Exit(1)
@@ -1923,67 +2491,18 @@ func main() {
 
 		fmt.Printf("Baseline saved to %s\n", baselinePath)
 		fmt.Print(bl.Summary())
-		os.Exit(0)
-	}
-
-	// Handle --check-drift
-	if *checkDrift {
-		if !baseline.Exists(baselinePath) {
-			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
-			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
-			os.Exit(1)
-		}
-
-		bl, err := baseline.Load(baselinePath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Run analysis on current issues
-		analyzer := analysis.NewAnalyzer(issues)
-		if *forceFullAnalysis {
-			cfg := analysis.FullAnalysisConfig()
-			analyzer.SetConfig(&cfg)
-		}
-		stats := analyzer.Analyze()
-
-		// Compute status counts from issues
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusOpen, model.StatusInProgress:
-				openCount++
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			}
-		}
-		actionableCount := len(analyzer.GetActionableIssues())
-		cycles := stats.Cycles()
-
-		// Build current snapshot as baseline for comparison
-		currentStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(cycles),
-			ActionableCount: actionableCount,
-		}
-		currentMetrics := baseline.TopMetrics{
-			PageRank:     buildMetricItems(stats.PageRank(), 10),
-			Betweenness:  buildMetricItems(stats.Betweenness(), 10),
-			CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
-			Hubs:         buildMetricItems(stats.Hubs(), 10),
-			Authorities:  buildMetricItems(stats.Authorities(), 10),
+		os.Exit(0)
+	}
+
+	// Handle --check-drift
+	if *checkDrift {
+		if *driftFrom == "" && !baseline.Exists(baselinePath) {
+			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
+			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
+			os.Exit(1)
 		}
-		current := baseline.New(currentStats, currentMetrics, cycles, "current")
 
-		// Load drift config and run calculator
+		// Load drift config first: snapshots record custom rule metrics
 		driftConfig, err := drift.LoadConfig(projectDir)
 		if err != nil {
 			if !envRobot {
@@ -1992,9 +2511,30 @@ func main() {
 			driftConfig = drift.DefaultConfig()
 		}
 
+		from, err := resolveDriftPoint(projectDir, *driftFrom, true, issues, *forceFullAnalysis, driftConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+			os.Exit(1)
+		}
+		to, err := resolveDriftPoint(projectDir, *driftTo, false, issues, *forceFullAnalysis, driftConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving --to: %v\n", err)
+			os.Exit(1)
+		}
+		bl, current := from.baseline, to.baseline
+		isRange := *driftFrom != "" || *driftTo != ""
+
 		calc := drift.NewCalculator(bl, current, driftConfig)
 		result := calc.Calculate()
 
+		var timeline []drift.TimelineEntry
+		timelineSampled := false
+		if isRange {
+			var points []drift.TimelinePoint
+			points, timelineSampled = driftTimelinePoints(projectDir, from, to, *forceFullAnalysis, driftConfig, driftTimelineLimit)
+			timeline = drift.BuildTimeline(bl, points, driftConfig)
+		}
+
 		if *robotDriftCheck {
 			// JSON output
 			output := struct {
@@ -2011,6 +2551,10 @@ func main() {
 					CreatedAt string `json:"created_at"`
 					CommitSHA string `json:"commit_sha,omitempty"`
 				} `json:"baseline"`
+				From            *driftPointInfo       `json:"from,omitempty"`
+				To              *driftPointInfo       `json:"to,omitempty"`
+				Timeline        []drift.TimelineEntry `json:"timeline,omitempty"`
+				TimelineSampled bool                  `json:"timeline_sampled,omitempty"`
 			}{
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 				HasDrift:    result.HasDrift,
@@ -2022,6 +2566,14 @@ func main() {
 			output.Summary.Info = result.InfoCount
 			output.Baseline.CreatedAt = bl.CreatedAt.Format(time.RFC3339)
 			output.Baseline.CommitSHA = bl.CommitSHA
+			if isRange {
+				output.From, output.To = from.info(), to.info()
+				output.Timeline = timeline
+				if output.Timeline == nil {
+					output.Timeline = []drift.TimelineEntry{}
+				}
+				output.TimelineSampled = timelineSampled
+			}
 
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
@@ -2031,7 +2583,20 @@ func main() {
 			}
 		} else {
 			// Human-readable output
+			if isRange {
+				fmt.Printf("Comparing %s → %s\n\n", from.label, to.label)
+			}
 			fmt.Print(result.Summary())
+			if isRange {
+				fmt.Print(drift.FormatTimeline(timeline))
+				if timelineSampled {
+					fmt.Printf("(timeline sampled to %d commits)\n", driftTimelineLimit)
+				}
+			}
+		}
+
+		if *notifySinks {
+			sendAlertNotifications(projectDir, "check-drift", result.Alerts)
 		}
 
 		os.Exit(result.ExitCode())
@@ -2409,7 +2974,7 @@ func main() {
 			WaitForPhase2: true,  // Triage needs full graph metrics
 			UseFastConfig: true,  // Use minimal Phase 2 config for robot mode (bv-t1js)
 		}
-		triage := analysis.ComputeTriageWithOptions(issues, opts)
+		triage := analysis.ComputeTriageWithOptions(issues, triageOptionsWithFormula(projectDir, opts))
 
 		// bv-90: Load feedback data for output
 		var feedbackInfo *analysis.FeedbackJSON
@@ -2523,7 +3088,7 @@ func main() {
 	// Handle --priority-brief flag (bv-96)
 	if *priorityBrief != "" {
 		fmt.Printf("Generating priority brief to %s...\n", *priorityBrief)
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, triageOptionsWithFormula(projectDir, analysis.TriageOptions{}))
 
 		// Marshal triage to JSON for the export function
 		triageJSON, err := json.Marshal(triage)
@@ -2562,7 +3127,7 @@ func main() {
 		}
 
 		// Generate triage data
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, triageOptionsWithFormula(projectDir, analysis.TriageOptions{}))
 		triageJSON, err := json.MarshalIndent(triage, "", "  ")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling triage: %v\n", err)
@@ -2638,7 +3203,7 @@ func main() {
 
 	// Handle --emit-script flag (bv-89)
 	if *emitScript {
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, triageOptionsWithFormula(projectDir, analysis.TriageOptions{}))
 
 		// Determine script limit
 		limit := *scriptLimit
@@ -3792,12 +4357,47 @@ func main() {
 		// Build burndown data
 		now := time.Now()
 		burndown := calculateBurndownAt(targetSprint, issues, now)
-		issueMap := make(map[string]model.Issue, len(issues))
-		for _, iss := range issues {
-			issueMap[iss.ID] = iss
+		history, historyErr := analysis.LoadSprintBurndown(cwd, *targetSprint, now)
+		if history != nil {
+			burndown.applyHistory(history)
+		} else {
+			issueMap := make(map[string]model.Issue, len(issues))
+			for _, iss := range issues {
+				issueMap[iss.ID] = iss
+			}
+			if scopeChanges, err := computeSprintScopeChanges(cwd, targetSprint, issueMap, now); err == nil && len(scopeChanges) > 0 {
+				burndown.ScopeChanges = scopeChanges
+			}
 		}
-		if scopeChanges, err := computeSprintScopeChanges(cwd, targetSprint, issueMap, now); err == nil && len(scopeChanges) > 0 {
-			burndown.ScopeChanges = scopeChanges
+
+		if *burndownCSV != "" {
+			if history == nil {
+				if historyErr == nil {
+					historyErr = fmt.Errorf("no git history for the beads files")
+				}
+				fmt.Fprintf(os.Stderr, "Error: burndown CSV needs git history: %v\n", historyErr)
+				os.Exit(1)
+			}
+			if *burndownCSV == "-" {
+				if err := history.WriteCSV(os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing burndown CSV: %v\n", err)
+					os.Exit(1)
+				}
+				os.Exit(0)
+			}
+			f, err := os.Create(*burndownCSV)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating burndown CSV: %v\n", err)
+				os.Exit(1)
+			}
+			err = history.WriteCSV(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing burndown CSV: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		encoder := newRobotEncoder(os.Stdout)
@@ -3808,6 +4408,159 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-sprint-plan flag
+	if *robotSprintPlan {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
+			os.Exit(1)
+		}
+
+		capacity := analysis.SprintCapacityMinutes(sprints)
+		if *planCapacity > 0 {
+			capacity = int(*planCapacity * 60)
+		}
+		now := time.Now()
+		plan := analysis.PlanSprint(issues, analysis.SprintPlanOptions{
+			CapacityMinutes: capacity,
+			Sprints:         sprints,
+			Triage:          triageOptionsWithFormula(cwd, analysis.TriageOptions{}),
+			Now:             now,
+		})
+
+		output := struct {
+			GeneratedAt time.Time `json:"generated_at"`
+			DataHash    string    `json:"data_hash"`
+			analysis.SprintPlan
+			SavedSprint *model.Sprint `json:"saved_sprint,omitempty"`
+		}{
+			GeneratedAt: now.UTC(),
+			DataHash:    dataHash,
+			SprintPlan:  plan,
+		}
+
+		if *planSave != "" {
+			for _, s := range sprints {
+				if s.ID == *planSave {
+					fmt.Fprintf(os.Stderr, "Error: sprint %s already exists\n", *planSave)
+					os.Exit(1)
+				}
+			}
+			if len(plan.Items) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: nothing to plan within %dm capacity\n", capacity)
+				os.Exit(1)
+			}
+			sprint := plan.NewSprint(*planSave, *planSave, *planDays, sprints, now)
+			if err := loader.SaveSprints(cwd, append(sprints, sprint)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving sprint: %v\n", err)
+				os.Exit(1)
+			}
+			output.SavedSprint = &sprint
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding sprint plan: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-sprint-retro flag
+	if *robotSprintRetro != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		var targetSprint *model.Sprint
+		for i := range sprints {
+			switch *robotSprintRetro {
+			case "current":
+				if sprints[i].IsActive() && targetSprint == nil {
+					targetSprint = &sprints[i]
+				}
+			case "last":
+				if !sprints[i].EndDate.IsZero() && sprints[i].EndDate.Before(now) &&
+					(targetSprint == nil || sprints[i].EndDate.After(targetSprint.EndDate)) {
+					targetSprint = &sprints[i]
+				}
+			default:
+				if sprints[i].ID == *robotSprintRetro {
+					targetSprint = &sprints[i]
+				}
+			}
+		}
+		if targetSprint == nil {
+			fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotSprintRetro)
+			os.Exit(1)
+		}
+
+		opts := analysis.SprintRetroOptions{Sprints: sprints, Now: now}
+		burndown, err := analysis.LoadSprintBurndown(cwd, *targetSprint, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not replay sprint history, using current state: %v\n", err)
+		}
+		opts.Burndown = burndown
+		report, err := loadHistoryReport(issues, *historyLimit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not correlate git history, skipping blocked time and churn: %v\n", err)
+		}
+		opts.History = report
+		retro := analysis.BuildSprintRetro(*targetSprint, issues, opts)
+
+		if *retroMD != "" {
+			if *retroMD == "-" {
+				if err := retro.WriteMarkdown(os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing retrospective: %v\n", err)
+					os.Exit(1)
+				}
+				os.Exit(0)
+			}
+			f, err := os.Create(*retroMD)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating retrospective: %v\n", err)
+				os.Exit(1)
+			}
+			err = retro.WriteMarkdown(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing retrospective: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		output := struct {
+			GeneratedAt time.Time `json:"generated_at"`
+			DataHash    string    `json:"data_hash"`
+			analysis.SprintRetro
+		}{
+			GeneratedAt: now.UTC(),
+			DataHash:    dataHash,
+			SprintRetro: retro,
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding sprint retrospective: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-forecast flag (bv-158)
 	if *robotForecast != "" {
 		cwd, err := os.Getwd()
@@ -4293,7 +5046,12 @@ func main() {
 		}
 
 		// Perform the export
-		if err := export.SaveMarkdownToFile(issues, *exportFile); err != nil {
+		var mdOpts export.MarkdownOptions
+		if *exportMdSchedule {
+			schedule := export.BuildGanttSchedule(issues, export.GanttOptions{})
+			mdOpts.Schedule = &schedule
+		}
+		if err := export.SaveMarkdownToFileWithOptions(issues, *exportFile, mdOpts); err != nil {
 			fmt.Printf("Error exporting: %v\n", err)
 			os.Exit(1)
 		}
@@ -4351,6 +5109,12 @@ func main() {
 	m := ui.NewModel(issues, activeRecipe, beadsPath)
 	defer m.Stop() // Clean up file watcher
 
+	// Notification sinks come from the repository and can run commands, so
+	// the TUI only delivers when asked to.
+	if *notifySinks {
+		m.EnableAlertNotifications()
+	}
+
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
 		m.EnableWorkspaceMode(ui.WorkspaceInfo{
@@ -4359,6 +5123,7 @@ func main() {
 			FailedCount:  workspaceInfo.FailedRepos,
 			TotalIssues:  workspaceInfo.TotalIssues,
 			RepoPrefixes: workspaceInfo.RepoPrefixes,
+			Repos:        workspaceRepos,
 		})
 	}
 
@@ -4617,6 +5382,27 @@ func formatCycle(cycle []string) string {
 	return result
 }
 
+// triageFormulaVariableNames lists the variables .bv/triage.yaml formulas may use.
+func triageFormulaVariableNames() string {
+	names := make([]string, 0, len(analysis.TriageFormulaVariables))
+	for _, v := range analysis.TriageFormulaVariables {
+		names = append(names, v.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// triageOptionsWithFormula adds the project's .bv/triage.yaml formula to opts so
+// every output ranks issues the same way. An invalid file is fatal.
+func triageOptionsWithFormula(projectDir string, opts analysis.TriageOptions) analysis.TriageOptions {
+	formula, err := analysis.LoadTriageFormula(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading triage formula: %v\n", err)
+		os.Exit(1)
+	}
+	opts.Formula = formula
+	return opts
+}
+
 // naturalLess compares two strings using natural sort order (numeric parts sorted numerically)
 func naturalLess(s1, s2 string) bool {
 	// Simple heuristic: if both strings end with numbers, compare the prefix then the number
@@ -5168,6 +5954,190 @@ func buildMetricItems(metrics map[string]float64, limit int) []baseline.MetricIt
 	return items
 }
 
+// snapshotBaseline computes graph stats, top metrics and cycles for issues
+// as a baseline stamped with the current git commit.
+func snapshotBaseline(issues []model.Issue, full bool, description string) *baseline.Baseline {
+	analyzer := analysis.NewAnalyzer(issues)
+	if full {
+		cfg := analysis.FullAnalysisConfig()
+		analyzer.SetConfig(&cfg)
+	}
+	stats := analyzer.Analyze()
+
+	// Compute status counts from issues
+	openCount, closedCount, blockedCount := 0, 0, 0
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusOpen, model.StatusInProgress:
+			openCount++
+		case model.StatusClosed:
+			closedCount++
+		case model.StatusBlocked:
+			blockedCount++
+		}
+	}
+	actionableCount := len(analyzer.GetActionableIssues())
+	cycles := stats.Cycles()
+
+	graphStats := baseline.GraphStats{
+		NodeCount:       stats.NodeCount,
+		EdgeCount:       stats.EdgeCount,
+		Density:         stats.Density,
+		OpenCount:       openCount,
+		ClosedCount:     closedCount,
+		BlockedCount:    blockedCount,
+		CycleCount:      len(cycles),
+		ActionableCount: actionableCount,
+	}
+	// Top 10 for each metric; the methods return copies of the maps
+	topMetrics := baseline.TopMetrics{
+		PageRank:     buildMetricItems(stats.PageRank(), 10),
+		Betweenness:  buildMetricItems(stats.Betweenness(), 10),
+		CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
+		Hubs:         buildMetricItems(stats.Hubs(), 10),
+		Authorities:  buildMetricItems(stats.Authorities(), 10),
+	}
+	return baseline.New(graphStats, topMetrics, cycles, description)
+}
+
+// sendAlertNotifications delivers alerts to the sinks in .bv/notify.yaml
+// and reports on stderr. Delivery problems never change the exit code.
+func sendAlertNotifications(projectDir, source string, alerts []drift.Alert) {
+	cfg, err := notify.LoadConfig(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if !cfg.HasSinks() {
+		fmt.Fprintf(os.Stderr, "Warning: --notify: no sinks configured in %s\n", notify.ConfigPath(projectDir))
+		return
+	}
+	report, err := notify.NewDispatcher(cfg, projectDir).Notify(source, alerts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	fmt.Fprintln(os.Stderr, report.String())
+}
+
+// driftTimelineLimit caps the commits replayed for a --check-drift timeline
+const driftTimelineLimit = 50
+
+// driftPoint is one end of a --check-drift comparison
+type driftPoint struct {
+	label    string
+	kind     string // "baseline", "revision" or "working_tree"
+	baseline *baseline.Baseline
+	commit   string // Commit the snapshot reflects; empty when unknown
+}
+
+// driftPointInfo describes a drift point in --robot-drift output
+type driftPointInfo struct {
+	Label     string `json:"label"`
+	Kind      string `json:"kind"` // "baseline", "revision" or "working_tree"
+	CreatedAt string `json:"created_at"`
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+func (p driftPoint) info() *driftPointInfo {
+	return &driftPointInfo{
+		Label:     p.label,
+		Kind:      p.kind,
+		CreatedAt: p.baseline.CreatedAt.Format(time.RFC3339),
+		CommitSHA: p.commit,
+	}
+}
+
+// resolveDriftPoint resolves a --from/--to value. Empty means the default
+// baseline for from and the working tree for to; "current" is the working
+// tree. A saved baseline name wins over a git revision of the same name, and
+// revisions are snapshotted from the beads file at that commit. Snapshots
+// record the custom rule metrics from rules.
+func resolveDriftPoint(projectDir, spec string, isFrom bool, issues []model.Issue, full bool, rules *drift.Config) (driftPoint, error) {
+	if spec == "" && isFrom {
+		bl, err := baseline.Load(baseline.DefaultPath(projectDir))
+		if err != nil {
+			return driftPoint{}, err
+		}
+		return driftPoint{label: "baseline", kind: "baseline", baseline: bl, commit: bl.CommitSHA}, nil
+	}
+	if spec == "" || spec == "current" {
+		bl := snapshotBaseline(issues, full, "current")
+		bl.RuleMetrics = rules.EvaluateRules(issues, time.Now())
+		return driftPoint{label: "working tree", kind: "working_tree", baseline: bl, commit: bl.CommitSHA}, nil
+	}
+	if baseline.ValidateName(spec) == nil {
+		if path := baseline.NamedPath(projectDir, spec); baseline.Exists(path) {
+			bl, err := baseline.Load(path)
+			if err != nil {
+				return driftPoint{}, fmt.Errorf("loading baseline %s: %w", spec, err)
+			}
+			return driftPoint{label: spec, kind: "baseline", baseline: bl, commit: bl.CommitSHA}, nil
+		}
+	}
+
+	sha, message, at, err := baseline.GetCommitInfo(projectDir, spec)
+	if err != nil {
+		return driftPoint{}, fmt.Errorf("%q is neither a saved baseline nor a git revision", spec)
+	}
+	revIssues, err := loader.NewGitLoader(projectDir).LoadAt(sha)
+	if err != nil {
+		return driftPoint{}, fmt.Errorf("loading beads at %s: %w", spec, err)
+	}
+	bl := snapshotBaseline(revIssues, full, spec)
+	bl.CreatedAt, bl.CommitSHA, bl.CommitMessage, bl.Branch = at, sha, message, ""
+	bl.RuleMetrics = rules.EvaluateRules(revIssues, at)
+	return driftPoint{label: spec, kind: "revision", baseline: bl, commit: sha}, nil
+}
+
+// driftTimelinePoints snapshots the commits that touched the beads file
+// between two drift points, oldest first and sampled evenly down to limit,
+// then the to point itself. Without commits for both ends the timeline is
+// just the to point. The second result reports whether commits were skipped.
+func driftTimelinePoints(projectDir string, from, to driftPoint, full bool, rules *drift.Config, limit int) ([]drift.TimelinePoint, bool) {
+	final := drift.TimelinePoint{Label: to.label, CommitSHA: to.commit, Timestamp: to.baseline.CreatedAt, Baseline: to.baseline}
+	if from.commit == "" || to.commit == "" {
+		return []drift.TimelinePoint{final}, false
+	}
+	gitLoader := loader.NewGitLoader(projectDir)
+	revisions, err := gitLoader.GetCommitsBetween(from.commit, to.commit)
+	if err != nil {
+		return []drift.TimelinePoint{final}, false
+	}
+
+	// Oldest first, leaving out the to commit itself
+	var commits []loader.RevisionInfo
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].SHA != to.commit {
+			commits = append(commits, revisions[i])
+		}
+	}
+	sampled := false
+	if limit > 0 && len(commits) > limit {
+		picked := make([]loader.RevisionInfo, 0, limit)
+		for i := 0; i < limit; i++ {
+			picked = append(picked, commits[(i+1)*len(commits)/limit-1])
+		}
+		commits, sampled = picked, true
+	}
+
+	points := make([]drift.TimelinePoint, 0, len(commits)+1)
+	for _, rev := range commits {
+		revIssues, err := gitLoader.LoadAt(rev.SHA)
+		if err != nil {
+			continue
+		}
+		bl := snapshotBaseline(revIssues, full, "")
+		bl.CreatedAt, bl.CommitSHA = rev.Timestamp, rev.SHA
+		bl.RuleMetrics = rules.EvaluateRules(revIssues, rev.Timestamp)
+		label := rev.SHA
+		if len(label) > 7 {
+			label = label[:7]
+		}
+		points = append(points, drift.TimelinePoint{Label: label, CommitSHA: rev.SHA, Timestamp: rev.Timestamp, Baseline: bl})
+	}
+	return append(points, final), sampled
+}
+
 // buildAttentionReason creates a human-readable reason for attention score
 func buildAttentionReason(score analysis.LabelAttentionScore) string {
 	var parts []string
@@ -5721,7 +6691,8 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 
 	// Compute triage
 	fmt.Println("  -> Generating triage data...")
-	triage := analysis.ComputeTriage(exportIssues)
+	projectDir, _ := os.Getwd()
+	triage := analysis.ComputeTriageWithOptions(exportIssues, triageOptionsWithFormula(projectDir, analysis.TriageOptions{}))
 
 	// Extract dependencies
 	var deps []*model.Dependency
@@ -5748,6 +6719,9 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 	if config.Title != "" {
 		exporter.Config.Title = config.Title
 	}
+	if transitions, err := loadStatusTransitions(500); err == nil {
+		exporter.Transitions = transitions
+	}
 
 	// Export SQLite database
 	fmt.Println("  -> Writing database and JSON files...")
@@ -5869,6 +6843,32 @@ type BurndownOutput struct {
 	DailyPoints       []model.BurndownPoint `json:"daily_points"`
 	IdealLine         []model.BurndownPoint `json:"ideal_line"`
 	ScopeChanges      []ScopeChangeEvent    `json:"scope_changes,omitempty"`
+
+	// Source is "git" when daily points and scope changes were replayed from
+	// the history of the beads files, else "snapshot".
+	Source  string                   `json:"source"`
+	History *analysis.SprintBurndown `json:"history,omitempty"`
+}
+
+// applyHistory replaces snapshot-derived daily points and scope changes with
+// the git-replayed burndown, so retroactive edits do not rewrite past days.
+func (b *BurndownOutput) applyHistory(h *analysis.SprintBurndown) {
+	b.Source = "git"
+	b.History = h
+	b.DailyPoints = make([]model.BurndownPoint, 0, len(h.Days))
+	for _, d := range h.Days {
+		b.DailyPoints = append(b.DailyPoints, model.BurndownPoint{Date: d.Date, Remaining: d.Remaining, Completed: d.Completed})
+	}
+	b.ScopeChanges = nil
+	for _, c := range h.ScopeChanges {
+		b.ScopeChanges = append(b.ScopeChanges, ScopeChangeEvent{
+			Date:       c.Date,
+			IssueID:    c.IssueID,
+			IssueTitle: c.Title,
+			Action:     c.Action,
+			CommitSHA:  c.CommitSHA,
+		})
+	}
 }
 
 // ScopeChangeEvent represents when issues were added/removed from sprint
@@ -5877,6 +6877,7 @@ type ScopeChangeEvent struct {
 	IssueID    string    `json:"issue_id"`
 	IssueTitle string    `json:"issue_title"`
 	Action     string    `json:"action"` // "added" or "removed"
+	CommitSHA  string    `json:"commit_sha,omitempty"`
 }
 
 type sprintSnapshot struct {
@@ -6195,6 +7196,7 @@ func calculateBurndownAt(sprint *model.Sprint, issues []model.Issue, now time.Ti
 		DailyPoints:       dailyPoints,
 		IdealLine:         idealLine,
 		ScopeChanges:      nil,
+		Source:            "snapshot",
 	}
 }
 
@@ -6450,6 +7452,85 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	}, nil
 }
 
+// loadStatusTransitions reconstructs issue status transitions from the git
+// history of the beads file in the current repository. Outside a git
+// repository it returns no transitions and no error, so callers fall back to
+// snapshot timestamps.
+func loadStatusTransitions(limit int) ([]analysis.StatusTransition, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := correlation.ValidateRepository(cwd); err != nil {
+		return nil, nil
+	}
+
+	beadsPath := ""
+	if beadsDir, err := loader.GetBeadsDir(""); err == nil {
+		beadsPath, _ = loader.FindJSONLPath(beadsDir)
+	}
+	events, err := correlation.NewExtractor(cwd, beadsPath).Extract(correlation.ExtractOptions{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return analysis.TransitionsFromEvents(events), nil
+}
+
+// loadHistoryReport correlates beads with git commits for the current
+// repository. It returns nil without error when not inside a git repository.
+func loadHistoryReport(issues []model.Issue, limit int) (*correlation.HistoryReport, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := correlation.ValidateRepository(cwd); err != nil {
+		return nil, nil
+	}
+
+	beadsPath := ""
+	if beadsDir, err := loader.GetBeadsDir(""); err == nil {
+		beadsPath, _ = loader.FindJSONLPath(beadsDir)
+	}
+	beadInfos := make([]correlation.BeadInfo, len(issues))
+	for i, issue := range issues {
+		beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+	}
+	return correlation.NewCorrelator(cwd, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{Limit: limit})
+}
+
+// loadClaimActivity gathers git evidence (correlated commits, the assignee's
+// file changes, unmerged branches when git can list them) for in_progress
+// issues. It returns nil without error when nothing is claimed or outside a
+// git repository.
+func loadClaimActivity(issues []model.Issue, limit int) (map[string]correlation.ClaimActivity, error) {
+	claimed := false
+	for _, issue := range issues {
+		if issue.Status == model.StatusInProgress {
+			claimed = true
+			break
+		}
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	report, err := loadHistoryReport(issues, limit)
+	if err != nil || report == nil {
+		return nil, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	// Branch evidence is optional (shallow clones, detached worktrees); keep the
+	// commit and file evidence without it.
+	branches, err := correlation.ListUnmergedBranches(cwd)
+	if err != nil {
+		branches = nil
+	}
+	return correlation.ClaimActivities(report, issues, branches), nil
+}
+
 // newRobotEncoder creates a JSON encoder for robot mode output.
 // By default, output is compact (no indentation) for performance.
 // Set BV_PRETTY_JSON=1 to enable pretty-printing for human readability.
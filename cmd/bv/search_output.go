@@ -1,40 +1,50 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
-	"sort"
 	"strings"
+	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
 type robotSearchResult struct {
-	IssueID         string             `json:"issue_id"`
-	Score           float64            `json:"score"`
-	TextScore       float64            `json:"text_score,omitempty"`
-	Title           string             `json:"title,omitempty"`
-	ComponentScores map[string]float64 `json:"component_scores,omitempty"`
+	IssueID         string               `json:"issue_id"`
+	Score           float64              `json:"score"`
+	TextScore       float64              `json:"text_score,omitempty"`
+	Title           string               `json:"title,omitempty"`
+	ComponentScores map[string]float64   `json:"component_scores,omitempty"`
+	MatchedIn       []search.SourceMatch `json:"matched_in,omitempty"` // keyword matches, best first
 }
 
 type robotSearchOutput struct {
-	GeneratedAt string                `json:"generated_at"`
-	DataHash    string                `json:"data_hash"`
-	Query       string                `json:"query"`
-	Provider    search.Provider       `json:"provider"`
-	Model       string                `json:"model,omitempty"`
-	Dim         int                   `json:"dim"`
-	IndexPath   string                `json:"index_path"`
-	Index       search.IndexSyncStats `json:"index"`
-	Loaded      bool                  `json:"loaded"`
-	Limit       int                   `json:"limit"`
-	Mode        search.SearchMode     `json:"mode"`
-	Preset      search.PresetName     `json:"preset,omitempty"`
-	Weights     *search.Weights       `json:"weights,omitempty"`
-	Results     []robotSearchResult   `json:"results"`
-	UsageHints  []string              `json:"usage_hints,omitempty"`
+	GeneratedAt   string                `json:"generated_at"`
+	DataHash      string                `json:"data_hash"`
+	Query         string                `json:"query"`
+	Provider      search.Provider       `json:"provider"`
+	Model         string                `json:"model,omitempty"`
+	Dim           int                   `json:"dim"`
+	IndexPath     string                `json:"index_path"`
+	Index         search.IndexSyncStats `json:"index"`
+	Loaded        bool                  `json:"loaded"`
+	Approximate   bool                  `json:"approximate,omitempty"` // HNSW rather than an exact scan
+	LexicalPath   string                `json:"lexical_index_path"`
+	LexicalLoaded bool                  `json:"lexical_loaded"` // BM25 index reused from disk
+	Sources       []search.SearchSource `json:"sources"`
+	Limit         int                   `json:"limit"`
+	Mode          search.SearchMode     `json:"mode"`
+	Preset        search.PresetName     `json:"preset,omitempty"`
+	Weights       *search.Weights       `json:"weights,omitempty"`
+	Results       []robotSearchResult   `json:"results"`
+	Warnings      []string              `json:"warnings,omitempty"` // e.g. history sources that could not be searched
+	UsageHints    []string              `json:"usage_hints,omitempty"`
 }
 
 func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
@@ -43,6 +53,147 @@ func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
 	return enc.Encode(out)
 }
 
+// searchHistoryDocuments correlates git history with issues so commit messages and
+// touched paths can be searched.
+func searchHistoryDocuments(issues []model.Issue, limit int) ([]search.SourceDocument, error) {
+	report, err := loadHistoryReport(issues, limit)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, fmt.Errorf("not inside a git repository")
+	}
+	return search.SourceDocumentsFromHistory(report), nil
+}
+
+type robotSimilarIssue struct {
+	search.SimilarIssue
+	AddCommand string `json:"add_command"` // links the two beads with a related dependency
+}
+
+type robotSimilarOutput struct {
+	GeneratedAt   string                `json:"generated_at"`
+	DataHash      string                `json:"data_hash"`
+	IssueID       string                `json:"issue_id"`
+	Title         string                `json:"title"`
+	Provider      search.Provider       `json:"provider"`
+	Model         string                `json:"model,omitempty"`
+	HistoryLoaded bool                  `json:"history_loaded"` // co-change signal available
+	Weights       search.SimilarWeights `json:"weights"`
+	Similar       []robotSimilarIssue   `json:"similar"`
+	UsageHints    []string              `json:"usage_hints"`
+}
+
+// buildRobotSimilar embeds issues with the configured embedder and, inside a git repo,
+// correlates history for co-changed files, then ranks issues similar to id.
+func buildRobotSimilar(issues []model.Issue, id string, opts search.SimilarOptions, historyLimit int) (robotSimilarOutput, error) {
+	out := robotSimilarOutput{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		IssueID:     id,
+		Weights:     opts.Weights,
+		Similar:     []robotSimilarIssue{},
+		UsageHints: []string{
+			"jq '.similar[] | {issue_id, score, reasons}' - suggestions with explanations",
+			"jq -r '.similar[0].add_command' - link the best suggestion as related",
+			"jq '.similar[] | {issue_id, components}' - per-signal scores",
+		},
+	}
+	found := false
+	for _, issue := range issues {
+		if issue.ID == id {
+			out.Title, found = issue.Title, true
+			break
+		}
+	}
+	if !found {
+		return out, fmt.Errorf("issue not found: %s", id)
+	}
+
+	cfg := search.EmbeddingConfigFromEnv()
+	if projectDir, err := os.Getwd(); err == nil {
+		cfg.CacheDir = search.DefaultEmbeddingCacheDir(projectDir)
+	}
+	embedder, err := search.NewEmbedderFromConfig(cfg)
+	if err != nil {
+		return out, err
+	}
+	out.Provider, out.Model = cfg.Provider, cfg.Model
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if opts.Vectors, err = search.NewSimilarityIndex(ctx, embedder, issues); err != nil {
+		return out, fmt.Errorf("embedding issues: %w", err)
+	}
+	// History is optional: outside a git repo the co-change signal is simply absent.
+	if report, err := loadHistoryReport(issues, historyLimit); err == nil && report != nil {
+		opts.History = report
+		out.HistoryLoaded = true
+	}
+
+	similar, err := search.FindSimilarIssues(id, issues, opts)
+	if err != nil {
+		return out, err
+	}
+	for _, s := range similar {
+		out.Similar = append(out.Similar, robotSimilarIssue{
+			SimilarIssue: s,
+			AddCommand:   fmt.Sprintf("bd dep add %s %s --type related", id, s.IssueID),
+		})
+	}
+	return out, nil
+}
+
+// printSearchMatch prints where the best keyword match for a result was found.
+func printSearchMatch(w io.Writer, matches []search.SourceMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	best := matches[0]
+	if best.Snippet == "" {
+		fmt.Fprintf(w, "\t\t%s\n", best.Describe())
+		return
+	}
+	fmt.Fprintf(w, "\t\t%s: %s\n", best.Describe(), best.Snippet)
+}
+
+// semanticDuplicatePairs embeds issues with the configured embedder into the
+// persisted duplicate index and returns nearest-neighbour cosine scores for
+// duplicate detection. Only new or edited issues are re-embedded.
+func semanticDuplicatePairs(issues []model.Issue, cosine float64) ([]analysis.SemanticPair, error) {
+	if cosine < 0 || cosine > 1 {
+		return nil, fmt.Errorf("invalid --duplicate-cosine %v (expected 0..1)", cosine)
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	cfg := search.EmbeddingConfigFromEnv()
+	cfg.CacheDir = search.DefaultEmbeddingCacheDir(projectDir)
+	embedder, err := search.NewEmbedderFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Dim = embedder.Dim()
+	indexPath := search.DefaultDuplicateIndexPath(projectDir, cfg)
+	idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+	idx.SetHNSWConfig(search.HNSWConfigFromEnv())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, search.DuplicateDocuments(issues), 64)
+	if err != nil {
+		return nil, fmt.Errorf("building duplicate index: %w", err)
+	}
+	if !loaded || syncStats.Changed() {
+		if err := idx.Save(indexPath); err != nil {
+			return nil, fmt.Errorf("saving duplicate index: %w", err)
+		}
+	}
+	return search.DuplicatePairsFromIndex(idx, search.DefaultDuplicateNeighbors)
+}
+
 func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, weightsFlag string) (search.SearchConfig, error) {
 	if modeFlag != "" {
 		switch search.SearchMode(strings.ToLower(modeFlag)) {
@@ -85,26 +236,6 @@ func resolveSearchWeights(cfg search.SearchConfig) (search.Weights, search.Prese
 	return weights, cfg.Preset, nil
 }
 
-func buildHybridScores(results []search.SearchResult, scorer search.HybridScorer) ([]search.HybridScore, error) {
-	out := make([]search.HybridScore, 0, len(results))
-	for _, result := range results {
-		scored, err := scorer.Score(result.IssueID, result.Score)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, scored)
-	}
-
-	sort.Slice(out, func(i, j int) bool {
-		if out[i].FinalScore == out[j].FinalScore {
-			return out[i].IssueID < out[j].IssueID
-		}
-		return out[i].FinalScore > out[j].FinalScore
-	})
-
-	return out, nil
-}
-
 var issueIDPattern = regexp.MustCompile(`^[A-Za-z]+-[A-Za-z0-9]+$`)
 
 func isLikelyIssueID(query string) bool {
@@ -0,0 +1,350 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// AssigneeHealthConfig controls the assignee health windows and thresholds.
+type AssigneeHealthConfig struct {
+	Now            time.Time // Reference time; zero means time.Now()
+	WindowDays     int       // Trend window; the previous window of equal length is the baseline (default 14)
+	StaleClaimDays int       // In-progress items untouched this long count as stale claims (default 7)
+	OverloadRatio  float64   // Open load at or above this multiple of the team median is overload (default 1.5)
+}
+
+// DefaultAssigneeHealthConfig returns a two-week trend window, week-old stale
+// claims and a 1.5x overload threshold.
+func DefaultAssigneeHealthConfig() AssigneeHealthConfig {
+	return AssigneeHealthConfig{
+		WindowDays:     14,
+		StaleClaimDays: 7,
+		OverloadRatio:  1.5,
+	}
+}
+
+// AssigneeHealthReport is the output of --robot-assignee-health.
+type AssigneeHealthReport struct {
+	GeneratedAt    time.Time        `json:"generated_at"`
+	WindowDays     int              `json:"window_days"`
+	TeamMedianLoad float64          `json:"team_median_load"` // Median open items per active assignee
+	UnassignedOpen int              `json:"unassigned_open"`
+	Assignees      []AssigneeHealth `json:"assignees"` // Sorted by attention score (descending)
+}
+
+// AssigneeHealth summarizes the workload and flow of one person or agent.
+type AssigneeHealth struct {
+	Assignee    string `json:"assignee"`
+	OpenCount   int    `json:"open_count"` // Every non-closed item, including WIP and blocked
+	WIP         int    `json:"wip"`        // Items in progress
+	ClosedCount int    `json:"closed_count"`
+
+	Blocked         []AssigneeBlockedItem `json:"blocked,omitempty"`
+	BlockedCount    int                   `json:"blocked_count"`
+	BlockedByOthers int                   `json:"blocked_by_others"` // Blocked items waiting on someone else's work
+
+	StaleClaims     []string `json:"stale_claims,omitempty"`
+	StaleClaimCount int      `json:"stale_claim_count"`
+
+	Throughput AssigneeThroughput `json:"throughput"`
+	CycleTime  AssigneeCycleTime  `json:"cycle_time"`
+
+	LoadRatio  float64 `json:"load_ratio"` // Open items relative to the team median
+	Overloaded bool    `json:"overloaded"`
+
+	AttentionScore int      `json:"attention_score"` // 0-100, higher = needs a lead's attention
+	Reasons        []string `json:"reasons,omitempty"`
+}
+
+// AssigneeBlockedItem is an open item of the assignee waiting on open blockers.
+type AssigneeBlockedItem struct {
+	IssueID  string            `json:"issue_id"`
+	Title    string            `json:"title"`
+	Blockers []AssigneeBlocker `json:"blockers"`
+}
+
+// AssigneeBlocker is an open issue blocking one of the assignee's items.
+type AssigneeBlocker struct {
+	IssueID  string       `json:"issue_id"`
+	Title    string       `json:"title"`
+	Status   model.Status `json:"status"`
+	Assignee string       `json:"assignee,omitempty"`
+	External bool         `json:"external"` // Owned by someone else (or nobody)
+}
+
+// AssigneeThroughput compares closures in the current window with the one before.
+type AssigneeThroughput struct {
+	Recent  int            `json:"recent"`
+	Prior   int            `json:"prior"`
+	PerWeek float64        `json:"per_week"`
+	Trend   string         `json:"trend"`  // "improving", "stable", "declining"
+	Weekly  []VelocityWeek `json:"weekly"` // Newest first
+}
+
+// AssigneeCycleTime compares median cycle time (days) between the windows.
+type AssigneeCycleTime struct {
+	Overall     DurationStats `json:"overall"`
+	RecentDays  float64       `json:"recent_median_days"`
+	PriorDays   float64       `json:"prior_median_days"`
+	Trend       string        `json:"trend"` // "improving" (faster), "stable", "declining" (slower), "insufficient_data"
+	RecentCount int           `json:"recent_count"`
+	PriorCount  int           `json:"prior_count"`
+}
+
+// ComputeAssigneeHealth builds per-assignee workload and flow health.
+// Transitions (usually from TransitionsFromEvents) sharpen cycle times;
+// without them the snapshot timestamps are used, as in ComputeFlowReport.
+//
+// The attention score adds up four signals, each capped:
+//   - stale claims as a share of WIP (up to 30)
+//   - open items blocked by other people's work as a share of open items (up to 25)
+//   - load above the team median (up to 30, reached at twice the median)
+//   - throughput or cycle time getting worse (15)
+func ComputeAssigneeHealth(issues []model.Issue, transitions []StatusTransition, cfg AssigneeHealthConfig) AssigneeHealthReport {
+	def := DefaultAssigneeHealthConfig()
+	if cfg.WindowDays <= 0 {
+		cfg.WindowDays = def.WindowDays
+	}
+	if cfg.StaleClaimDays <= 0 {
+		cfg.StaleClaimDays = def.StaleClaimDays
+	}
+	if cfg.OverloadRatio <= 0 {
+		cfg.OverloadRatio = def.OverloadRatio
+	}
+	now := cfg.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC()
+
+	report := AssigneeHealthReport{
+		GeneratedAt: now,
+		WindowDays:  cfg.WindowDays,
+		Assignees:   []AssigneeHealth{},
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	byAssignee := make(map[string][]flowTimeline)
+	for _, tl := range buildFlowTimelines(issues, transitions, now) {
+		if tl.issue.Assignee == "" {
+			if !isClosedLikeStatus(tl.issue.Status) {
+				report.UnassignedOpen++
+			}
+			continue
+		}
+		byAssignee[tl.issue.Assignee] = append(byAssignee[tl.issue.Assignee], tl)
+	}
+	if len(byAssignee) == 0 {
+		return report
+	}
+
+	window := time.Duration(cfg.WindowDays) * 24 * time.Hour
+	recentStart := now.Add(-window)
+	priorStart := recentStart.Add(-window)
+	staleCutoff := now.AddDate(0, 0, -cfg.StaleClaimDays)
+
+	loads := make([]float64, 0, len(byAssignee))
+	for name, timelines := range byAssignee {
+		h := AssigneeHealth{Assignee: name}
+		var cycleAll, cycleRecent, cyclePrior []float64
+		weekly := make(map[time.Time]int)
+
+		for _, tl := range timelines {
+			issue := tl.issue
+			if isClosedLikeStatus(issue.Status) {
+				h.ClosedCount++
+			} else {
+				h.OpenCount++
+			}
+
+			if issue.Status == model.StatusInProgress {
+				h.WIP++
+				lastTouch := issue.UpdatedAt
+				if n := len(tl.transitions); n > 0 && tl.transitions[n-1].At.After(lastTouch) {
+					lastTouch = tl.transitions[n-1].At
+				}
+				if !lastTouch.IsZero() && lastTouch.Before(staleCutoff) {
+					h.StaleClaims = append(h.StaleClaims, issue.ID)
+				}
+			}
+
+			if !isClosedLikeStatus(issue.Status) {
+				if item, ok := assigneeBlockedItem(issue, issueMap); ok {
+					h.Blocked = append(h.Blocked, item)
+					for _, b := range item.Blockers {
+						if b.External {
+							h.BlockedByOthers++
+							break
+						}
+					}
+				}
+			}
+
+			firstProgress, closedAt := flowCycleBounds(tl)
+			if closedAt.IsZero() {
+				continue
+			}
+			switch {
+			case !closedAt.Before(recentStart):
+				h.Throughput.Recent++
+			case !closedAt.Before(priorStart):
+				h.Throughput.Prior++
+			}
+			if !closedAt.Before(priorStart) {
+				weekly[truncateToMonday(closedAt)]++
+			}
+			if firstProgress.IsZero() || firstProgress.After(closedAt) {
+				continue
+			}
+			days := closedAt.Sub(firstProgress).Hours() / 24
+			cycleAll = append(cycleAll, days)
+			switch {
+			case !closedAt.Before(recentStart):
+				cycleRecent = append(cycleRecent, days)
+			case !closedAt.Before(priorStart):
+				cyclePrior = append(cyclePrior, days)
+			}
+		}
+
+		sort.Strings(h.StaleClaims)
+		h.StaleClaimCount = len(h.StaleClaims)
+		sort.Slice(h.Blocked, func(i, j int) bool { return h.Blocked[i].IssueID < h.Blocked[j].IssueID })
+		h.BlockedCount = len(h.Blocked)
+
+		h.Throughput.PerWeek = float64(h.Throughput.Recent) / (float64(cfg.WindowDays) / 7.0)
+		h.Throughput.Trend = compareTrend(float64(h.Throughput.Recent), float64(h.Throughput.Prior), true)
+		for cursor := truncateToMonday(now); !cursor.Before(truncateToMonday(priorStart)); cursor = cursor.AddDate(0, 0, -7) {
+			h.Throughput.Weekly = append(h.Throughput.Weekly, VelocityWeek{WeekStart: cursor, Closed: weekly[cursor]})
+		}
+
+		h.CycleTime.Overall = computeDurationStats(cycleAll)
+		h.CycleTime.RecentCount = len(cycleRecent)
+		h.CycleTime.PriorCount = len(cyclePrior)
+		h.CycleTime.Trend = "insufficient_data"
+		if len(cycleRecent) > 0 && len(cyclePrior) > 0 {
+			sort.Float64s(cycleRecent)
+			sort.Float64s(cyclePrior)
+			h.CycleTime.RecentDays = nearestRank(cycleRecent, 0.5)
+			h.CycleTime.PriorDays = nearestRank(cyclePrior, 0.5)
+			// Shorter cycle times are an improvement.
+			h.CycleTime.Trend = compareTrend(h.CycleTime.RecentDays, h.CycleTime.PriorDays, false)
+		}
+
+		// Only active assignees set the team norm; people whose work all
+		// closed long ago would otherwise drag the median to zero.
+		if h.OpenCount > 0 || h.Throughput.Recent+h.Throughput.Prior > 0 {
+			loads = append(loads, float64(h.OpenCount))
+		}
+		report.Assignees = append(report.Assignees, h)
+	}
+
+	median := 0.0
+	if n := len(loads); n > 0 {
+		sort.Float64s(loads)
+		median = loads[n/2]
+		if n%2 == 0 {
+			median = (loads[n/2-1] + loads[n/2]) / 2
+		}
+	}
+	report.TeamMedianLoad = median
+
+	for i := range report.Assignees {
+		scoreAssignee(&report.Assignees[i], median, cfg)
+	}
+
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.AttentionScore != b.AttentionScore {
+			return a.AttentionScore > b.AttentionScore
+		}
+		return a.Assignee < b.Assignee
+	})
+	return report
+}
+
+// assigneeBlockedItem lists the open blockers of issue, marking those owned
+// by someone other than the issue's assignee.
+func assigneeBlockedItem(issue model.Issue, issueMap map[string]model.Issue) (AssigneeBlockedItem, bool) {
+	item := AssigneeBlockedItem{IssueID: issue.ID, Title: issue.Title}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		blocker, ok := issueMap[dep.DependsOnID]
+		if !ok || isClosedLikeStatus(blocker.Status) {
+			continue
+		}
+		item.Blockers = append(item.Blockers, AssigneeBlocker{
+			IssueID:  blocker.ID,
+			Title:    blocker.Title,
+			Status:   blocker.Status,
+			Assignee: blocker.Assignee,
+			External: blocker.Assignee != issue.Assignee,
+		})
+	}
+	return item, len(item.Blockers) > 0
+}
+
+// compareTrend labels recent vs prior as "improving", "stable" or "declining"
+// using a ±20% band. higherIsBetter says which direction counts as improving.
+func compareTrend(recent, prior float64, higherIsBetter bool) string {
+	if recent == prior {
+		return "stable"
+	}
+	if prior == 0 {
+		if higherIsBetter {
+			return "improving"
+		}
+		return "declining"
+	}
+	change := (recent - prior) / prior
+	if !higherIsBetter {
+		change = -change
+	}
+	switch {
+	case change > 0.2:
+		return "improving"
+	case change < -0.2:
+		return "declining"
+	default:
+		return "stable"
+	}
+}
+
+func scoreAssignee(h *AssigneeHealth, median float64, cfg AssigneeHealthConfig) {
+	h.LoadRatio = float64(h.OpenCount) / math.Max(median, 1)
+	h.Overloaded = h.OpenCount > 0 && h.LoadRatio >= cfg.OverloadRatio
+
+	score := 0.0
+	if h.WIP > 0 && h.StaleClaimCount > 0 {
+		score += 30 * float64(h.StaleClaimCount) / float64(h.WIP)
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%d of %d claims untouched for %d+ days", h.StaleClaimCount, h.WIP, cfg.StaleClaimDays))
+	}
+	if h.OpenCount > 0 && h.BlockedByOthers > 0 {
+		score += 25 * float64(h.BlockedByOthers) / float64(h.OpenCount)
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%d items waiting on other people's work", h.BlockedByOthers))
+	}
+	if h.LoadRatio > 1 {
+		score += 30 * math.Min(1, h.LoadRatio-1)
+		if h.Overloaded {
+			h.Reasons = append(h.Reasons, fmt.Sprintf("%.1fx the team median open load", h.LoadRatio))
+		}
+	}
+	switch {
+	case h.Throughput.Trend == "declining":
+		score += 15
+		h.Reasons = append(h.Reasons, fmt.Sprintf("throughput down (%d closed vs %d in the previous %d days)", h.Throughput.Recent, h.Throughput.Prior, cfg.WindowDays))
+	case h.CycleTime.Trend == "declining":
+		score += 15
+		h.Reasons = append(h.Reasons, fmt.Sprintf("cycle time up (%.1fd vs %.1fd median)", h.CycleTime.RecentDays, h.CycleTime.PriorDays))
+	}
+	h.AttentionScore = clampScore(int(math.Round(score)))
+}
@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeAssigneeHealth(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	closedAgo := func(days int) *time.Time { c := ago(days); return &c }
+
+	issues := []model.Issue{
+		// alice: four open items, one claim gone stale, one waiting on bob.
+		{ID: "A1", Assignee: "alice", Status: model.StatusInProgress, CreatedAt: ago(30), UpdatedAt: ago(10)},
+		{ID: "A2", Assignee: "alice", Status: model.StatusInProgress, CreatedAt: ago(5), UpdatedAt: ago(1)},
+		{ID: "A3", Assignee: "alice", Status: model.StatusOpen, CreatedAt: ago(5), UpdatedAt: ago(5),
+			Dependencies: []*model.Dependency{{DependsOnID: "B1", Type: model.DepBlocks}}},
+		{ID: "A4", Assignee: "alice", Status: model.StatusOpen, CreatedAt: ago(5), UpdatedAt: ago(5),
+			Dependencies: []*model.Dependency{{DependsOnID: "A2", Type: model.DepBlocks}}},
+		{ID: "A5", Assignee: "alice", Status: model.StatusClosed, CreatedAt: ago(25), ClosedAt: closedAgo(20)},
+		{ID: "A6", Assignee: "alice", Status: model.StatusClosed, CreatedAt: ago(25), ClosedAt: closedAgo(18)},
+
+		// bob: one open item and a steady close rate.
+		{ID: "B1", Assignee: "bob", Status: model.StatusOpen, CreatedAt: ago(3), UpdatedAt: ago(1)},
+		{ID: "B2", Assignee: "bob", Status: model.StatusClosed, CreatedAt: ago(10), ClosedAt: closedAgo(2)},
+		{ID: "B3", Assignee: "bob", Status: model.StatusClosed, CreatedAt: ago(25), ClosedAt: closedAgo(16)},
+
+		// carol: two open items.
+		{ID: "C1", Assignee: "carol", Status: model.StatusOpen, CreatedAt: ago(3), UpdatedAt: ago(1)},
+		{ID: "C2", Assignee: "carol", Status: model.StatusOpen, CreatedAt: ago(3), UpdatedAt: ago(1)},
+
+		{ID: "U1", Status: model.StatusOpen, CreatedAt: ago(3)},
+	}
+
+	// Git history shows B2 spent four days in progress.
+	transitions := []StatusTransition{
+		{IssueID: "B2", Status: model.StatusOpen, At: ago(10)},
+		{IssueID: "B2", Status: model.StatusInProgress, At: ago(6)},
+		{IssueID: "B2", Status: model.StatusClosed, At: ago(2)},
+	}
+
+	cfg := DefaultAssigneeHealthConfig()
+	cfg.Now = now
+	report := ComputeAssigneeHealth(issues, transitions, cfg)
+
+	if report.UnassignedOpen != 1 || report.TeamMedianLoad != 2 || len(report.Assignees) != 3 {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	alice := report.Assignees[0]
+	if alice.Assignee != "alice" {
+		t.Fatalf("expected alice to need the most attention, got order %s, %s, %s",
+			report.Assignees[0].Assignee, report.Assignees[1].Assignee, report.Assignees[2].Assignee)
+	}
+	if alice.OpenCount != 4 || alice.WIP != 2 || alice.ClosedCount != 2 {
+		t.Errorf("unexpected alice counts: %+v", alice)
+	}
+	if alice.StaleClaimCount != 1 || alice.StaleClaims[0] != "A1" {
+		t.Errorf("expected A1 as stale claim, got %v", alice.StaleClaims)
+	}
+	if alice.BlockedCount != 2 || alice.BlockedByOthers != 1 {
+		t.Errorf("expected 2 blocked items, 1 waiting on others, got %d/%d", alice.BlockedCount, alice.BlockedByOthers)
+	}
+	if b := alice.Blocked[0].Blockers[0]; b.IssueID != "B1" || b.Assignee != "bob" || !b.External {
+		t.Errorf("unexpected blocker for A3: %+v", b)
+	}
+	if !alice.Overloaded || alice.LoadRatio != 2 {
+		t.Errorf("expected alice overloaded at 2x median, got ratio %v", alice.LoadRatio)
+	}
+	if alice.Throughput.Recent != 0 || alice.Throughput.Prior != 2 || alice.Throughput.Trend != "declining" {
+		t.Errorf("unexpected alice throughput: %+v", alice.Throughput)
+	}
+	// 30*(1/2) stale + 25*(1/4) blocked + 30 overload + 15 declining
+	if alice.AttentionScore != 66 || len(alice.Reasons) != 4 {
+		t.Errorf("unexpected alice attention %d: %v", alice.AttentionScore, alice.Reasons)
+	}
+
+	var bob AssigneeHealth
+	for _, h := range report.Assignees {
+		if h.Assignee == "bob" {
+			bob = h
+		}
+	}
+	if bob.Throughput.Trend != "stable" || bob.AttentionScore != 0 || bob.Overloaded {
+		t.Errorf("expected bob to be healthy, got %+v", bob)
+	}
+	if bob.CycleTime.Overall.Count != 1 || bob.CycleTime.Overall.MedianDays != 4 {
+		t.Errorf("expected 4-day cycle time from transitions, got %+v", bob.CycleTime.Overall)
+	}
+}
+
+func TestCompareTrend(t *testing.T) {
+	tests := []struct {
+		recent, prior  float64
+		higherIsBetter bool
+		want           string
+	}{
+		{5, 5, true, "stable"},
+		{6, 5, true, "stable"},
+		{8, 5, true, "improving"},
+		{2, 5, true, "declining"},
+		{3, 0, true, "improving"},
+		{2, 5, false, "improving"},
+		{8, 5, false, "declining"},
+	}
+	for _, tt := range tests {
+		if got := compareTrend(tt.recent, tt.prior, tt.higherIsBetter); got != tt.want {
+			t.Errorf("compareTrend(%v, %v, %v) = %q, want %q", tt.recent, tt.prior, tt.higherIsBetter, got, tt.want)
+		}
+	}
+}
+
+func TestComputeAssigneeHealth_NoAssignees(t *testing.T) {
+	report := ComputeAssigneeHealth([]model.Issue{{ID: "X", Status: model.StatusOpen}}, nil, DefaultAssigneeHealthConfig())
+	if len(report.Assignees) != 0 || report.UnassignedOpen != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
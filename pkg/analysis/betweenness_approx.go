@@ -251,6 +251,13 @@ type BetweennessResult struct {
 
 	// TimedOut indicates if computation was interrupted by timeout
 	TimedOut bool
+
+	// Incremental is set when a previous result was patched (AnalyzeIncremental).
+	// SampleSize then counts the re-run sources out of AffectedSources, and
+	// ErrorBound is a 95% bound on the absolute per-node sampling error.
+	Incremental     bool
+	AffectedSources int
+	ErrorBound      float64
 }
 
 // ApproxBetweenness computes approximate betweenness centrality using sampling.
@@ -0,0 +1,563 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/community"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Suggestion kinds emitted by ComputeClusters.
+const (
+	ClusterSuggestUnlabeledEpic = "unlabeled_epic" // Cohesive group with no common parent epic
+	ClusterSuggestLabel         = "label"          // Dominant label missing from some members
+)
+
+// Edge kinds for ClusterEdge.
+const (
+	ClusterEdgeSharedFile   = "shared_file"
+	ClusterEdgeSharedCommit = "shared_commit"
+)
+
+// clusterDepWeights weights dependency types when building the clustering graph.
+var clusterDepWeights = map[model.DependencyType]float64{
+	model.DepBlocks:         1.0,
+	model.DepParentChild:    1.0,
+	model.DepRelated:        0.5,
+	model.DepDiscoveredFrom: 0.5,
+}
+
+// ClusterEdge is an extra undirected, weighted edge between two issues,
+// typically derived from correlation history.
+type ClusterEdge struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Weight float64 `json:"weight"`
+	Kind   string  `json:"kind"`
+}
+
+// ClusterConfig controls community detection.
+type ClusterConfig struct {
+	Resolution     float64       // Modularity resolution γ (higher = smaller clusters)
+	MinClusterSize int           // Smaller groups are reported as unclustered
+	MinEpicSize    int           // Minimum open issues for an unlabeled-epic suggestion
+	IncludeClosed  bool          // Cluster closed issues too
+	ExtraEdges     []ClusterEdge // Additional weighted edges (e.g. shared files)
+	Seed           uint64        // Seed for the Louvain node ordering
+}
+
+// DefaultClusterConfig returns the default community detection settings.
+func DefaultClusterConfig() ClusterConfig {
+	return ClusterConfig{
+		Resolution:     1.0,
+		MinClusterSize: 3,
+		MinEpicSize:    5,
+		Seed:           1,
+	}
+}
+
+// ClusterReport is the result of community detection over the issue graph.
+type ClusterReport struct {
+	Algorithm       string              `json:"algorithm"`
+	Resolution      float64             `json:"resolution"`
+	Modularity      float64             `json:"modularity"`
+	IssueCount      int                 `json:"issue_count"`
+	EdgeCount       int                 `json:"edge_count"`
+	CorrelationUsed bool                `json:"correlation_used"`
+	Clusters        []IssueCluster      `json:"clusters"`
+	Coupling        []ClusterCoupling   `json:"coupling,omitempty"`
+	Suggestions     []ClusterSuggestion `json:"suggestions,omitempty"`
+	Unclustered     []string            `json:"unclustered,omitempty"`
+}
+
+// IssueCluster is one detected community.
+type IssueCluster struct {
+	ID             int      `json:"id"` // 1-based, largest first
+	Name           string   `json:"name"`
+	Keywords       []string `json:"keywords,omitempty"`
+	TopLabels      []string `json:"top_labels,omitempty"`
+	IssueIDs       []string `json:"issue_ids"`
+	Size           int      `json:"size"`
+	OpenCount      int      `json:"open_count"`
+	InternalWeight float64  `json:"internal_weight"`
+	ExternalWeight float64  `json:"external_weight"`
+	Cohesion       float64  `json:"cohesion"` // internal / (internal + external)
+	ParentEpics    []string `json:"parent_epics,omitempty"`
+}
+
+// ClusterCoupling measures the edge weight between two clusters.
+type ClusterCoupling struct {
+	From      int     `json:"from"`
+	To        int     `json:"to"`
+	Weight    float64 `json:"weight"`
+	EdgeCount int     `json:"edge_count"`
+}
+
+// ClusterSuggestion proposes an epic or label based on cluster structure.
+type ClusterSuggestion struct {
+	Type           string   `json:"type"`
+	ClusterID      int      `json:"cluster_id"`
+	Message        string   `json:"message"`
+	IssueIDs       []string `json:"issue_ids"`
+	SuggestedName  string   `json:"suggested_name,omitempty"`
+	SuggestedLabel string   `json:"suggested_label,omitempty"`
+	Confidence     float64  `json:"confidence"`
+}
+
+// ComputeClusters runs Louvain modularity optimization over the dependency
+// graph (plus any extra edges), then splits every community into its
+// connected components so no cluster is internally disconnected, as the
+// Leiden refinement step guarantees.
+func ComputeClusters(issues []model.Issue, cfg ClusterConfig) ClusterReport {
+	if cfg.Resolution <= 0 {
+		cfg.Resolution = 1.0
+	}
+	if cfg.MinClusterSize <= 0 {
+		cfg.MinClusterSize = 3
+	}
+	if cfg.MinEpicSize <= 0 {
+		cfg.MinEpicSize = 5
+	}
+
+	report := ClusterReport{
+		Algorithm:       "louvain+connectivity-refinement",
+		Resolution:      cfg.Resolution,
+		CorrelationUsed: len(cfg.ExtraEdges) > 0,
+		Clusters:        []IssueCluster{},
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	var ids []string
+	for i := range issues {
+		iss := &issues[i]
+		if iss.Status == model.StatusTombstone || (!cfg.IncludeClosed && iss.Status == model.StatusClosed) {
+			continue
+		}
+		if _, dup := issueMap[iss.ID]; dup {
+			continue
+		}
+		issueMap[iss.ID] = iss
+		ids = append(ids, iss.ID)
+	}
+	sort.Strings(ids)
+	report.IssueCount = len(ids)
+	if len(ids) == 0 {
+		return report
+	}
+
+	// Accumulate undirected edge weights.
+	weights := make(map[[2]string]float64)
+	addEdge := func(a, b string, w float64) {
+		if a == b || w <= 0 {
+			return
+		}
+		if _, ok := issueMap[a]; !ok {
+			return
+		}
+		if _, ok := issueMap[b]; !ok {
+			return
+		}
+		if a > b {
+			a, b = b, a
+		}
+		weights[[2]string{a, b}] += w
+	}
+	for _, id := range ids {
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil {
+				continue
+			}
+			w, ok := clusterDepWeights[dep.Type]
+			if !ok {
+				w = 0.5
+			}
+			addEdge(id, dep.DependsOnID, w)
+		}
+	}
+	for _, e := range cfg.ExtraEdges {
+		addEdge(e.From, e.To, e.Weight)
+	}
+	report.EdgeCount = len(weights)
+
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	nodeOf := make(map[string]int64, len(ids))
+	idOf := make(map[int64]string, len(ids))
+	for i, id := range ids {
+		nodeOf[id] = int64(i)
+		idOf[int64(i)] = id
+		g.AddNode(simple.Node(i))
+	}
+	keys := make([][2]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	adjacency := make(map[string][]string, len(ids))
+	for _, k := range keys {
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(nodeOf[k[0]]), simple.Node(nodeOf[k[1]]), weights[k]))
+		adjacency[k[0]] = append(adjacency[k[0]], k[1])
+		adjacency[k[1]] = append(adjacency[k[1]], k[0])
+	}
+
+	var groups [][]string
+	if len(weights) == 0 {
+		for _, id := range ids {
+			groups = append(groups, []string{id})
+		}
+	} else {
+		reduced := community.Modularize(g, cfg.Resolution, rand.NewPCG(cfg.Seed, cfg.Seed))
+		for _, comm := range reduced.Communities() {
+			members := make([]string, 0, len(comm))
+			for _, n := range comm {
+				members = append(members, idOf[n.ID()])
+			}
+			groups = append(groups, splitConnected(members, adjacency)...)
+		}
+	}
+
+	// Modularity of the refined partition.
+	partition := make([][]graph.Node, 0, len(groups))
+	for _, grp := range groups {
+		nodes := make([]graph.Node, 0, len(grp))
+		for _, id := range grp {
+			nodes = append(nodes, simple.Node(nodeOf[id]))
+		}
+		partition = append(partition, nodes)
+	}
+	if len(weights) > 0 {
+		report.Modularity = community.Q(g, partition, cfg.Resolution)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return groups[i][0] < groups[j][0]
+	})
+
+	clusterOf := make(map[string]int, len(ids))
+	for _, grp := range groups {
+		if len(grp) < cfg.MinClusterSize {
+			report.Unclustered = append(report.Unclustered, grp...)
+			continue
+		}
+		c := IssueCluster{ID: len(report.Clusters) + 1, IssueIDs: grp, Size: len(grp)}
+		for _, id := range grp {
+			clusterOf[id] = c.ID
+		}
+		report.Clusters = append(report.Clusters, c)
+	}
+	sort.Strings(report.Unclustered)
+
+	// Internal/external weights and pairwise coupling.
+	coupling := make(map[[2]int]*ClusterCoupling)
+	for _, k := range keys {
+		w := weights[k]
+		ca, cb := clusterOf[k[0]], clusterOf[k[1]]
+		if ca != 0 && ca == cb {
+			report.Clusters[ca-1].InternalWeight += w
+			continue
+		}
+		if ca != 0 {
+			report.Clusters[ca-1].ExternalWeight += w
+		}
+		if cb != 0 {
+			report.Clusters[cb-1].ExternalWeight += w
+		}
+		if ca == 0 || cb == 0 {
+			continue
+		}
+		if ca > cb {
+			ca, cb = cb, ca
+		}
+		pair := [2]int{ca, cb}
+		if coupling[pair] == nil {
+			coupling[pair] = &ClusterCoupling{From: ca, To: cb}
+		}
+		coupling[pair].Weight += w
+		coupling[pair].EdgeCount++
+	}
+	for _, c := range coupling {
+		report.Coupling = append(report.Coupling, *c)
+	}
+	sort.Slice(report.Coupling, func(i, j int) bool {
+		a, b := report.Coupling[i], report.Coupling[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	for i := range report.Clusters {
+		c := &report.Clusters[i]
+		if total := c.InternalWeight + c.ExternalWeight; total > 0 {
+			c.Cohesion = c.InternalWeight / total
+		}
+		describeCluster(c, issueMap)
+		report.Suggestions = append(report.Suggestions, suggestForCluster(c, issueMap, cfg)...)
+	}
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		return report.Suggestions[i].Confidence > report.Suggestions[j].Confidence
+	})
+
+	return report
+}
+
+// splitConnected partitions members into connected components of the
+// subgraph induced by members.
+func splitConnected(members []string, adjacency map[string][]string) [][]string {
+	inGroup := make(map[string]bool, len(members))
+	for _, id := range members {
+		inGroup[id] = true
+	}
+	sort.Strings(members)
+	seen := make(map[string]bool, len(members))
+	var parts [][]string
+	for _, start := range members {
+		if seen[start] {
+			continue
+		}
+		var part []string
+		stack := []string{start}
+		seen[start] = true
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			part = append(part, id)
+			for _, next := range adjacency[id] {
+				if inGroup[next] && !seen[next] {
+					seen[next] = true
+					stack = append(stack, next)
+				}
+			}
+		}
+		sort.Strings(part)
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// describeCluster fills in the name, keywords, labels and parent epics.
+func describeCluster(c *IssueCluster, issueMap map[string]*model.Issue) {
+	labelCounts := make(map[string]int)
+	keywordCounts := make(map[string]int)
+	epicCounts := make(map[string]int)
+	for _, id := range c.IssueIDs {
+		iss := issueMap[id]
+		if !isClosedLikeStatus(iss.Status) {
+			c.OpenCount++
+		}
+		for _, l := range iss.Labels {
+			labelCounts[l]++
+		}
+		for _, kw := range extractKeywords(iss.Title, "") {
+			keywordCounts[kw]++
+		}
+		for _, dep := range iss.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if parent, ok := issueMap[dep.DependsOnID]; ok && parent.IssueType == model.TypeEpic {
+				epicCounts[parent.ID]++
+			}
+		}
+	}
+
+	c.TopLabels = topCounted(labelCounts, 3, 1)
+	c.Keywords = topCounted(keywordCounts, 3, 2)
+	if len(c.Keywords) == 0 {
+		c.Keywords = topCounted(keywordCounts, 2, 1)
+	}
+	c.ParentEpics = topCounted(epicCounts, len(epicCounts), 1)
+
+	name := strings.Join(c.Keywords, " ")
+	if len(c.TopLabels) > 0 && labelCounts[c.TopLabels[0]]*2 >= c.Size {
+		// Prefix the dominant label; don't repeat it as a keyword.
+		label := c.TopLabels[0]
+		var words []string
+		for _, kw := range topCounted(keywordCounts, 4, 2) {
+			if kw != strings.ToLower(label) && len(words) < 3 {
+				words = append(words, kw)
+			}
+		}
+		name = label
+		if len(words) > 0 {
+			name += ": " + strings.Join(words, " ")
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("cluster-%d", c.ID)
+	}
+	c.Name = name
+}
+
+// topCounted returns up to n keys with count >= minCount, by count then name.
+func topCounted(counts map[string]int, n, minCount int) []string {
+	var keys []string
+	for k, v := range counts {
+		if v >= minCount {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+func suggestForCluster(c *IssueCluster, issueMap map[string]*model.Issue, cfg ClusterConfig) []ClusterSuggestion {
+	var out []ClusterSuggestion
+
+	// Unlabeled epic: a cohesive group of open work that no epic owns.
+	var open []string
+	epicOwned := 0
+	for _, id := range c.IssueIDs {
+		iss := issueMap[id]
+		if iss.IssueType == model.TypeEpic || isClosedLikeStatus(iss.Status) {
+			continue
+		}
+		open = append(open, id)
+		for _, dep := range iss.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if parent, ok := issueMap[dep.DependsOnID]; ok && parent.IssueType == model.TypeEpic {
+				epicOwned++
+				break
+			}
+		}
+	}
+	if len(open) >= cfg.MinEpicSize && epicOwned*2 < len(open) {
+		confidence := 0.5*c.Cohesion + 0.5*(1-float64(epicOwned)/float64(len(open)))
+		out = append(out, ClusterSuggestion{
+			Type:          ClusterSuggestUnlabeledEpic,
+			ClusterID:     c.ID,
+			Message:       fmt.Sprintf("These %d issues look like an unlabeled epic: %q", len(open), c.Name),
+			IssueIDs:      open,
+			SuggestedName: c.Name,
+			Confidence:    confidence,
+		})
+	}
+
+	// Label: most members share a label, a few do not.
+	if len(c.TopLabels) > 0 {
+		label := c.TopLabels[0]
+		var missing []string
+		for _, id := range c.IssueIDs {
+			if !HasLabel(*issueMap[id], label) {
+				missing = append(missing, id)
+			}
+		}
+		have := c.Size - len(missing)
+		if len(missing) > 0 && have*2 >= c.Size {
+			out = append(out, ClusterSuggestion{
+				Type:           ClusterSuggestLabel,
+				ClusterID:      c.ID,
+				Message:        fmt.Sprintf("%d of %d issues in %q carry label %q; consider adding it to the other %d", have, c.Size, c.Name, label, len(missing)),
+				IssueIDs:       missing,
+				SuggestedLabel: label,
+				Confidence:     c.Cohesion * float64(have) / float64(c.Size),
+			})
+		}
+	}
+	return out
+}
+
+// ClusterEdgesFromHistory derives co-change edges from correlation history:
+// issues touched by the same commit, and issues whose commits touched the
+// same file. Commits and files spanning more than maxFanout issues (bulk
+// imports, lockfiles) are ignored.
+func ClusterEdgesFromHistory(report *correlation.HistoryReport, maxFanout int) []ClusterEdge {
+	if report == nil {
+		return nil
+	}
+	if maxFanout <= 0 {
+		maxFanout = 10
+	}
+
+	commitBeads := make(map[string]map[string]bool)
+	fileBeads := make(map[string]map[string]bool)
+	for beadID, h := range report.Histories {
+		for _, c := range h.Commits {
+			if commitBeads[c.SHA] == nil {
+				commitBeads[c.SHA] = make(map[string]bool)
+			}
+			commitBeads[c.SHA][beadID] = true
+			for _, f := range c.Files {
+				if strings.HasPrefix(f.Path, ".beads/") {
+					continue
+				}
+				if fileBeads[f.Path] == nil {
+					fileBeads[f.Path] = make(map[string]bool)
+				}
+				fileBeads[f.Path][beadID] = true
+			}
+		}
+	}
+
+	pairs := make(map[[2]string]*ClusterEdge)
+	addPairs := func(beads map[string]bool, kind string, w float64) {
+		if len(beads) < 2 || len(beads) > maxFanout {
+			return
+		}
+		ids := make([]string, 0, len(beads))
+		for id := range beads {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				key := [2]string{ids[i], ids[j]}
+				e := pairs[key]
+				if e == nil {
+					e = &ClusterEdge{From: ids[i], To: ids[j], Kind: kind}
+					pairs[key] = e
+				}
+				e.Weight += w
+			}
+		}
+	}
+	// Commits first so pairs that share both keep the stronger kind.
+	for _, beads := range commitBeads {
+		addPairs(beads, ClusterEdgeSharedCommit, 1.0)
+	}
+	for _, beads := range fileBeads {
+		addPairs(beads, ClusterEdgeSharedFile, 0.25)
+	}
+
+	edges := make([]ClusterEdge, 0, len(pairs))
+	for _, e := range pairs {
+		// Cap so heavy co-change never drowns out explicit dependencies.
+		if e.Weight > 2 {
+			e.Weight = 2
+		}
+		edges = append(edges, *e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
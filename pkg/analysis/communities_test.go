@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// clusterFixture builds two six-issue cliques joined by a single edge.
+// The first clique is about auth and mostly labelled "auth"; the second is
+// about billing invoices and has no labels or parent epic.
+func clusterFixture() []model.Issue {
+	var issues []model.Issue
+	add := func(prefix, title string, labels []string, n int) {
+		for i := 0; i < n; i++ {
+			id := fmt.Sprintf("%s-%d", prefix, i)
+			var deps []*model.Dependency
+			for j := 0; j < i; j++ {
+				deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: fmt.Sprintf("%s-%d", prefix, j), Type: model.DepRelated})
+			}
+			var l []string
+			if i < len(labels) {
+				l = []string{labels[i]}
+			}
+			issues = append(issues, model.Issue{
+				ID: id, Title: fmt.Sprintf("%s step %d", title, i), Status: model.StatusOpen,
+				IssueType: model.TypeTask, Labels: l, Dependencies: deps,
+			})
+		}
+	}
+	add("auth", "Auth token refresh", []string{"auth", "auth", "auth", "auth", "auth"}, 6)
+	add("bill", "Billing invoice export", nil, 6)
+	issues[len(issues)-1].Dependencies = append(issues[len(issues)-1].Dependencies,
+		&model.Dependency{DependsOnID: "auth-0", Type: model.DepBlocks})
+	return issues
+}
+
+func TestComputeClusters_SplitsCliques(t *testing.T) {
+	report := ComputeClusters(clusterFixture(), DefaultClusterConfig())
+
+	if len(report.Clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %+v", len(report.Clusters), report.Clusters)
+	}
+	if report.Modularity <= 0.3 {
+		t.Errorf("expected strong modularity for two cliques, got %v", report.Modularity)
+	}
+	for _, c := range report.Clusters {
+		if c.Size != 6 {
+			t.Errorf("cluster %q: expected 6 members, got %v", c.Name, c.IssueIDs)
+		}
+	}
+
+	byName := map[string]IssueCluster{}
+	for _, c := range report.Clusters {
+		byName[c.IssueIDs[0][:4]] = c
+	}
+	if got := byName["auth"].Name; got != "auth: refresh step token" {
+		t.Errorf("unexpected auth cluster name %q", got)
+	}
+	if got := byName["bill"].Name; got != "billing export invoice" {
+		t.Errorf("unexpected billing cluster name %q", got)
+	}
+
+	if len(report.Coupling) != 1 || report.Coupling[0].EdgeCount != 1 || report.Coupling[0].Weight != 1 {
+		t.Errorf("expected one coupling edge between clusters, got %+v", report.Coupling)
+	}
+	if c := byName["bill"]; c.Cohesion <= 0.85 || c.ExternalWeight != 1 {
+		t.Errorf("unexpected cohesion for billing cluster: %+v", c)
+	}
+}
+
+func TestComputeClusters_Suggestions(t *testing.T) {
+	report := ComputeClusters(clusterFixture(), DefaultClusterConfig())
+
+	var epics, labels []ClusterSuggestion
+	for _, s := range report.Suggestions {
+		switch s.Type {
+		case ClusterSuggestUnlabeledEpic:
+			epics = append(epics, s)
+		case ClusterSuggestLabel:
+			labels = append(labels, s)
+		}
+	}
+	if len(epics) != 2 {
+		t.Errorf("expected both clusters to be unlabeled epic candidates, got %+v", epics)
+	}
+	if len(labels) != 1 || labels[0].SuggestedLabel != "auth" || !reflect.DeepEqual(labels[0].IssueIDs, []string{"auth-5"}) {
+		t.Errorf("expected auth label suggestion for auth-5, got %+v", labels)
+	}
+}
+
+func TestComputeClusters_EpicOwnedClusterNotSuggested(t *testing.T) {
+	issues := clusterFixture()
+	issues = append(issues, model.Issue{ID: "epic", Title: "Billing", Status: model.StatusOpen, IssueType: model.TypeEpic})
+	for i := range issues {
+		if len(issues[i].ID) > 4 && issues[i].ID[:4] == "bill" {
+			issues[i].Dependencies = append(issues[i].Dependencies, &model.Dependency{DependsOnID: "epic", Type: model.DepParentChild})
+		}
+	}
+
+	report := ComputeClusters(issues, DefaultClusterConfig())
+	for _, s := range report.Suggestions {
+		if s.Type == ClusterSuggestUnlabeledEpic && s.IssueIDs[0][:4] == "bill" {
+			t.Errorf("billing work already has an epic, got suggestion %+v", s)
+		}
+	}
+}
+
+func TestComputeClusters_Deterministic(t *testing.T) {
+	a := ComputeClusters(clusterFixture(), DefaultClusterConfig())
+	b := ComputeClusters(clusterFixture(), DefaultClusterConfig())
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected identical reports for identical input")
+	}
+}
+
+func TestComputeClusters_NoEdges(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusClosed},
+	}
+	report := ComputeClusters(issues, DefaultClusterConfig())
+	if report.IssueCount != 2 || len(report.Clusters) != 0 || len(report.Unclustered) != 2 {
+		t.Errorf("expected 2 unclustered open issues, got %+v", report)
+	}
+}
+
+func TestClusterEdgesFromHistory(t *testing.T) {
+	commit := func(sha string, files ...string) correlation.CorrelatedCommit {
+		c := correlation.CorrelatedCommit{SHA: sha}
+		for _, f := range files {
+			c.Files = append(c.Files, correlation.FileChange{Path: f})
+		}
+		return c
+	}
+	report := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"A": {Commits: []correlation.CorrelatedCommit{commit("c1", "pkg/x.go", ".beads/beads.jsonl")}},
+		"B": {Commits: []correlation.CorrelatedCommit{commit("c1", "pkg/x.go")}},
+		"C": {Commits: []correlation.CorrelatedCommit{commit("c2", "pkg/x.go", ".beads/beads.jsonl")}},
+	}}
+
+	edges := ClusterEdgesFromHistory(report, 10)
+	want := []ClusterEdge{
+		{From: "A", To: "B", Weight: 1.25, Kind: ClusterEdgeSharedCommit},
+		{From: "A", To: "C", Weight: 0.25, Kind: ClusterEdgeSharedFile},
+		{From: "B", To: "C", Weight: 0.25, Kind: ClusterEdgeSharedFile},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("unexpected edges:\n got %+v\nwant %+v", edges, want)
+	}
+
+	if edges := ClusterEdgesFromHistory(report, 1); len(edges) != 0 {
+		t.Errorf("expected fan-out cap to drop all edges, got %+v", edges)
+	}
+}
@@ -0,0 +1,318 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DueDateRiskLevel classifies how likely an issue is to land by its due date.
+type DueDateRiskLevel string
+
+const (
+	DueDateOnTrack DueDateRiskLevel = "on_track"
+	DueDateAtRisk  DueDateRiskLevel = "at_risk"
+	DueDateLate    DueDateRiskLevel = "late"
+)
+
+// DueDateConfig configures due-date risk classification.
+type DueDateConfig struct {
+	// Now is the reference time; zero means time.Now().
+	Now time.Time
+	// Agents is the number of parallel workers assumed per issue (default 1).
+	Agents int
+	// AtRiskLikelihood is the on-time likelihood below which an issue is at
+	// risk (default 0.8).
+	AtRiskLikelihood float64
+}
+
+// DefaultDueDateConfig returns sensible defaults.
+func DefaultDueDateConfig() DueDateConfig {
+	return DueDateConfig{
+		Agents:           1,
+		AtRiskLikelihood: 0.8,
+	}
+}
+
+// DueDateRisk is the due-date outlook for one open issue with a due date.
+type DueDateRisk struct {
+	IssueID  string           `json:"issue_id"`
+	Title    string           `json:"title"`
+	Status   string           `json:"status"`
+	Assignee string           `json:"assignee,omitempty"`
+	Priority int              `json:"priority"`
+	DueDate  time.Time        `json:"due_date"`
+	Risk     DueDateRiskLevel `json:"risk"`
+	Overdue  bool             `json:"overdue"`
+
+	// ETA range for the issue including the time its open blockers need.
+	ETADate     time.Time `json:"eta_date"`
+	ETADateLow  time.Time `json:"eta_date_low"`
+	ETADateHigh time.Time `json:"eta_date_high"`
+
+	// OwnDays is the issue's own estimated work; BlockerDays is the extra
+	// time spent waiting on the slowest chain of open blockers.
+	OwnDays     float64 `json:"own_days"`
+	BlockerDays float64 `json:"blocker_days"`
+
+	// SlackDays is due date minus expected finish; negative means behind.
+	SlackDays float64 `json:"slack_days"`
+	// OnTimeLikelihood places the due date within the ETA range (0..1).
+	OnTimeLikelihood float64 `json:"on_time_likelihood"`
+
+	// BlockerChain is the slowest chain of open blockers, nearest first.
+	BlockerChain []string `json:"blocker_chain,omitempty"`
+	Reasons      []string `json:"reasons"`
+}
+
+// DueDateReport summarizes due-date risk across the project.
+type DueDateReport struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Total       int           `json:"total"`
+	OnTrack     int           `json:"on_track"`
+	AtRisk      int           `json:"at_risk"`
+	Late        int           `json:"late"`
+	Issues      []DueDateRisk `json:"issues"`
+}
+
+// dueFinish is an issue's finish offset from now, in days, including blockers.
+type dueFinish struct {
+	expected, low, high float64
+	own                 ETAEstimate
+	via                 string // blocker with the latest expected finish
+}
+
+// ComputeDueDateRisks compares the ETA of every open issue that has a due date
+// against that date. An issue cannot finish before its open blockers, so its
+// finish is the latest blocker finish plus its own estimate, applied to the
+// expected, optimistic and pessimistic ETA bounds alike.
+//
+// Dependency depth is deliberately not used to inflate the own estimate here:
+// the blocker chain is accounted for explicitly.
+func ComputeDueDateRisks(issues []model.Issue, cfg DueDateConfig) DueDateReport {
+	now := cfg.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if cfg.Agents <= 0 {
+		cfg.Agents = 1
+	}
+	if cfg.AtRiskLikelihood <= 0 || cfg.AtRiskLikelihood > 1 {
+		cfg.AtRiskLikelihood = DefaultDueDateConfig().AtRiskLikelihood
+	}
+
+	report := DueDateReport{GeneratedAt: now, Issues: []DueDateRisk{}}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	hasDated := false
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+		if issue.DueDate != nil && !isClosedLikeStatus(issue.Status) {
+			hasDated = true
+		}
+	}
+	if !hasDated {
+		return report
+	}
+
+	estimator := NewETAEstimator(issues, nil, now)
+	memo := make(map[string]*dueFinish)
+	visiting := make(map[string]bool)
+
+	var finish func(id string) *dueFinish
+	finish = func(id string) *dueFinish {
+		if f, ok := memo[id]; ok {
+			return f
+		}
+		issue := issueMap[id]
+		visiting[id] = true
+
+		var start dueFinish
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || visiting[dep.DependsOnID] {
+				continue
+			}
+			blocker, ok := issueMap[dep.DependsOnID]
+			if !ok || isClosedLikeStatus(blocker.Status) {
+				continue
+			}
+			bf := finish(blocker.ID)
+			if start.via == "" || bf.expected > start.expected || (bf.expected == start.expected && blocker.ID < start.via) {
+				start.expected = bf.expected
+				start.via = blocker.ID
+			}
+			start.low = max(start.low, bf.low)
+			start.high = max(start.high, bf.high)
+		}
+		visiting[id] = false
+
+		own := estimator.Estimate(issue, cfg.Agents)
+		f := &dueFinish{
+			expected: start.expected + own.EstimatedDays,
+			low:      start.low + daysBetween(now, own.ETADateLow),
+			high:     start.high + daysBetween(now, own.ETADateHigh),
+			own:      own,
+			via:      start.via,
+		}
+		memo[id] = f
+		return f
+	}
+
+	for _, issue := range issues {
+		if issue.DueDate == nil || isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		f := finish(issue.ID)
+		risk := classifyDueDate(issue, f, now, cfg)
+		for via := f.via; via != ""; via = memo[via].via {
+			risk.BlockerChain = append(risk.BlockerChain, via)
+		}
+		if len(risk.BlockerChain) > 0 {
+			risk.Reasons = append(risk.Reasons, fmt.Sprintf("waits %.1fd on blockers %s",
+				risk.BlockerDays, strings.Join(risk.BlockerChain, " → ")))
+		}
+		report.Issues = append(report.Issues, risk)
+
+		switch risk.Risk {
+		case DueDateLate:
+			report.Late++
+		case DueDateAtRisk:
+			report.AtRisk++
+		default:
+			report.OnTrack++
+		}
+	}
+	report.Total = len(report.Issues)
+
+	rank := map[DueDateRiskLevel]int{DueDateLate: 0, DueDateAtRisk: 1, DueDateOnTrack: 2}
+	sort.Slice(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if rank[a.Risk] != rank[b.Risk] {
+			return rank[a.Risk] < rank[b.Risk]
+		}
+		if !a.DueDate.Equal(b.DueDate) {
+			return a.DueDate.Before(b.DueDate)
+		}
+		return a.IssueID < b.IssueID
+	})
+	return report
+}
+
+// classifyDueDate turns an issue's finish bounds into a risk entry.
+func classifyDueDate(issue model.Issue, f *dueFinish, now time.Time, cfg DueDateConfig) DueDateRisk {
+	due := *issue.DueDate
+	dueDays := due.Sub(now).Hours() / 24
+
+	risk := DueDateRisk{
+		IssueID:     issue.ID,
+		Title:       issue.Title,
+		Status:      string(issue.Status),
+		Assignee:    issue.Assignee,
+		Priority:    issue.Priority,
+		DueDate:     due,
+		ETADate:     now.Add(durationDays(f.expected)),
+		ETADateLow:  now.Add(durationDays(f.low)),
+		ETADateHigh: now.Add(durationDays(f.high)),
+		OwnDays:     f.own.EstimatedDays,
+		BlockerDays: f.expected - f.own.EstimatedDays,
+		SlackDays:   dueDays - f.expected,
+	}
+
+	switch {
+	case dueDays < 0:
+		risk.OnTimeLikelihood = 0
+	case f.high <= f.low:
+		if dueDays >= f.expected {
+			risk.OnTimeLikelihood = 1
+		}
+	default:
+		risk.OnTimeLikelihood = clampFloat((dueDays-f.low)/(f.high-f.low), 0, 1)
+	}
+
+	dateFmt := "2006-01-02"
+	switch {
+	case dueDays < 0:
+		risk.Risk = DueDateLate
+		risk.Overdue = true
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("overdue by %.1fd (due %s)", -dueDays, due.Format(dateFmt)))
+	case risk.OnTimeLikelihood == 0:
+		risk.Risk = DueDateLate
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("earliest finish %s is after due date %s",
+			risk.ETADateLow.Format(dateFmt), due.Format(dateFmt)))
+	case risk.OnTimeLikelihood < cfg.AtRiskLikelihood:
+		risk.Risk = DueDateAtRisk
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("%.0f%% likely to finish by %s (ETA %s, range %s..%s)",
+			risk.OnTimeLikelihood*100, due.Format(dateFmt), risk.ETADate.Format(dateFmt),
+			risk.ETADateLow.Format(dateFmt), risk.ETADateHigh.Format(dateFmt)))
+	default:
+		risk.Risk = DueDateOnTrack
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("%.1fd of slack before %s", risk.SlackDays, due.Format(dateFmt)))
+	}
+	return risk
+}
+
+// daysBetween returns the non-negative number of days from now to t.
+func daysBetween(now, t time.Time) float64 {
+	if !t.After(now) {
+		return 0
+	}
+	return t.Sub(now).Hours() / 24
+}
+
+// applyDueDateBoosts raises the triage score of late and at-risk issues and
+// of the blockers on their slowest chain, then re-sorts scores. The boost is
+// weight scaled by the chance of missing the date. It returns the triage
+// reason to show for each boosted issue.
+func applyDueDateBoosts(scores []TriageScore, report DueDateReport, weight float64) map[string]string {
+	reasons := make(map[string]string)
+	if weight <= 0 {
+		return reasons
+	}
+
+	boosts := make(map[string]float64)
+	for _, r := range report.Issues {
+		if r.Risk == DueDateOnTrack {
+			continue
+		}
+		boost := weight * (1 - r.OnTimeLikelihood)
+		due := r.DueDate.Format("2006-01-02")
+
+		var reason string
+		switch {
+		case r.Overdue:
+			reason = fmt.Sprintf("⏰ Overdue since %s", due)
+		case r.Risk == DueDateLate:
+			reason = fmt.Sprintf("⏰ Will miss due date %s (ETA %s)", due, r.ETADate.Format("2006-01-02"))
+		default:
+			reason = fmt.Sprintf("⏰ At risk: %.0f%% likely to make due date %s", r.OnTimeLikelihood*100, due)
+		}
+		if boost > boosts[r.IssueID] {
+			boosts[r.IssueID] = boost
+			reasons[r.IssueID] = reason
+		}
+		for _, id := range r.BlockerChain {
+			if boost > boosts[id] {
+				boosts[id] = boost
+				reasons[id] = fmt.Sprintf("⏰ Holding up %s (%s, due %s)", r.IssueID, r.Risk, due)
+			}
+		}
+	}
+	if len(boosts) == 0 {
+		return reasons
+	}
+
+	for i := range scores {
+		boost, ok := boosts[scores[i].IssueID]
+		if !ok {
+			continue
+		}
+		scores[i].TriageFactors.DueDateBoost = boost
+		scores[i].TriageScore += boost
+		scores[i].FactorsApplied = append(scores[i].FactorsApplied, "due_date")
+	}
+	sortTriageScores(scores)
+	return reasons
+}
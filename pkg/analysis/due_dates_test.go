@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// dueFixture uses one-hour estimates with no closure history, so every open
+// issue takes 5 days (range 2.6..7.4) at the default velocity.
+func dueFixture(now time.Time) []model.Issue {
+	est := 60
+	due := func(days float64) *time.Time {
+		d := now.Add(time.Duration(days * float64(24*time.Hour)))
+		return &d
+	}
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issue := func(id string, status model.Status, dueDate *time.Time, deps []*model.Dependency) model.Issue {
+		return model.Issue{
+			ID: id, Title: id, Status: status, IssueType: model.TypeTask,
+			EstimatedMinutes: &est, DueDate: dueDate, Dependencies: deps,
+		}
+	}
+	return []model.Issue{
+		issue("ok", model.StatusOpen, due(30), nil),
+		issue("risky", model.StatusOpen, due(6), nil),
+		issue("chained", model.StatusOpen, due(7), blocks("b1")),
+		issue("b1", model.StatusOpen, nil, blocks("b2")),
+		issue("b2", model.StatusOpen, nil, nil),
+		issue("overdue", model.StatusInProgress, due(-2), nil),
+		issue("freed", model.StatusOpen, due(30), blocks("done")),
+		issue("done", model.StatusClosed, due(-10), nil),
+	}
+}
+
+func TestComputeDueDateRisks_Classification(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	report := ComputeDueDateRisks(dueFixture(now), DueDateConfig{Now: now})
+
+	if report.Total != 5 || report.Late != 2 || report.AtRisk != 1 || report.OnTrack != 2 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+
+	var order []string
+	byID := map[string]DueDateRisk{}
+	for _, r := range report.Issues {
+		order = append(order, r.IssueID)
+		byID[r.IssueID] = r
+	}
+	want := []string{"overdue", "chained", "risky", "freed", "ok"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("expected late, at-risk, on-track ordering %v, got %v", want, order)
+	}
+
+	if r := byID["overdue"]; r.Risk != DueDateLate || !r.Overdue || r.OnTimeLikelihood != 0 {
+		t.Errorf("overdue issue: %+v", r)
+	}
+
+	chained := byID["chained"]
+	if chained.Risk != DueDateLate || chained.Overdue {
+		t.Errorf("expected chained issue to be late but not overdue, got %+v", chained)
+	}
+	if !reflect.DeepEqual(chained.BlockerChain, []string{"b1", "b2"}) {
+		t.Errorf("expected blocker chain b1 → b2, got %v", chained.BlockerChain)
+	}
+	if chained.BlockerDays < 9.9 || chained.BlockerDays > 10.1 || chained.OwnDays < 4.9 || chained.OwnDays > 5.1 {
+		t.Errorf("expected 10 blocker days + 5 own days, got %.2f + %.2f", chained.BlockerDays, chained.OwnDays)
+	}
+	if !strings.Contains(strings.Join(chained.Reasons, " "), "b1 → b2") {
+		t.Errorf("expected reasons to name the blocker chain, got %v", chained.Reasons)
+	}
+
+	risky := byID["risky"]
+	if risky.Risk != DueDateAtRisk || risky.OnTimeLikelihood < 0.65 || risky.OnTimeLikelihood > 0.75 {
+		t.Errorf("expected ~71%% on-time likelihood for risky issue, got %+v", risky)
+	}
+
+	if r := byID["freed"]; len(r.BlockerChain) != 0 || r.BlockerDays != 0 {
+		t.Errorf("closed blockers should not delay an issue, got %+v", r)
+	}
+	if _, ok := byID["done"]; ok {
+		t.Error("closed issues should not be reported")
+	}
+}
+
+func TestComputeDueDateRisks_Threshold(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	report := ComputeDueDateRisks(dueFixture(now), DueDateConfig{Now: now, AtRiskLikelihood: 0.5})
+	for _, r := range report.Issues {
+		if r.IssueID == "risky" && r.Risk != DueDateOnTrack {
+			t.Errorf("expected risky issue on track at a 50%% threshold, got %s", r.Risk)
+		}
+	}
+}
+
+func TestComputeDueDateRisks_CycleTerminates(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := now.Add(60 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, DueDate: &due, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	report := ComputeDueDateRisks(issues, DueDateConfig{Now: now})
+	if report.Total != 1 || !reflect.DeepEqual(report.Issues[0].BlockerChain, []string{"B"}) {
+		t.Errorf("unexpected report for cyclic input: %+v", report)
+	}
+}
+
+func TestComputeDueDateRisks_NoDueDates(t *testing.T) {
+	report := ComputeDueDateRisks([]model.Issue{{ID: "A", Status: model.StatusOpen}}, DefaultDueDateConfig())
+	if report.Total != 0 || report.Issues == nil {
+		t.Errorf("expected empty, non-nil issue list, got %+v", report)
+	}
+}
+
+func TestComputeTriage_BoostsDueDateRisk(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "a", Title: "Undated", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2, UpdatedAt: now},
+		{ID: "b", Title: "Blocker of dated work", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2, UpdatedAt: now},
+		{ID: "z", Title: "Overdue", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2, UpdatedAt: now, DueDate: &due,
+			Dependencies: []*model.Dependency{{DependsOnID: "b", Type: model.DepBlocks}}},
+	}
+
+	triage := ComputeTriageWithOptionsAndTime(issues, TriageOptions{WaitForPhase2: true}, now)
+	if len(triage.Recommendations) == 0 {
+		t.Fatal("expected recommendations")
+	}
+
+	reasons := map[string][]string{}
+	for _, rec := range triage.Recommendations {
+		reasons[rec.ID] = rec.Reasons
+	}
+	if len(reasons["z"]) == 0 || !strings.Contains(reasons["z"][0], "Overdue") {
+		t.Errorf("expected overdue reason first for z, got %v", reasons["z"])
+	}
+	if len(reasons["b"]) == 0 || !strings.Contains(reasons["b"][0], "Holding up z") {
+		t.Errorf("expected blocker reason for b, got %v", reasons["b"])
+	}
+	if len(reasons["a"]) > 0 && strings.HasPrefix(reasons["a"][0], "⏰") {
+		t.Errorf("undated issue should not get a due-date reason, got %v", reasons["a"])
+	}
+	if triage.Recommendations[0].ID != "b" {
+		t.Errorf("expected the blocker of overdue work to rank first, got %s", triage.Recommendations[0].ID)
+	}
+}
@@ -53,6 +53,20 @@ type DuplicateConfig struct {
 	// MaxSuggestions limits the number of duplicate suggestions
 	// Default: 20
 	MaxSuggestions int
+
+	// CosineThreshold is the minimum embedding cosine similarity for a
+	// semantic match (0.0-1.0). Only used when Semantic is set.
+	// Default: 0.85
+	CosineThreshold float64
+
+	// SemanticWeight is the weight of the cosine score when blending it with
+	// the keyword score (0.0-1.0).
+	// Default: 0.6
+	SemanticWeight float64
+
+	// Semantic holds nearest-neighbour cosine scores from an embedding index
+	// (see search.SemanticDuplicatePairs). Nil means keyword-only detection.
+	Semantic []SemanticPair
 }
 
 // DefaultDuplicateConfig returns sensible defaults
@@ -62,6 +76,8 @@ func DefaultDuplicateConfig() DuplicateConfig {
 		MinKeywords:        2,
 		IgnoreClosedVsOpen: true,
 		MaxSuggestions:     20,
+		CosineThreshold:    0.85,
+		SemanticWeight:     0.6,
 	}
 }
 
@@ -74,23 +90,170 @@ type DuplicatePair struct {
 	Keywords   []string `json:"common_keywords,omitempty"`
 }
 
-// DetectDuplicates finds potential duplicate issues using keyword-based Jaccard similarity
-// Optimized with an inverted index for performance on large repositories.
+// SemanticPair is the embedding cosine similarity between two issues.
+type SemanticPair struct {
+	Issue1 string  `json:"issue1"`
+	Issue2 string  `json:"issue2"`
+	Cosine float64 `json:"cosine"`
+}
+
+// Duplicate detection methods
+const (
+	DuplicateMethodJaccard  = "jaccard"  // keyword overlap only
+	DuplicateMethodSemantic = "semantic" // embedding similarity only
+	DuplicateMethodHybrid   = "hybrid"   // both signals above threshold
+)
+
+// DuplicateMatch is a scored duplicate pair with an explanation and a
+// suggested merge direction.
+type DuplicateMatch struct {
+	Issue1        string             `json:"issue1"`
+	Issue2        string             `json:"issue2"`
+	Score         float64            `json:"score"`
+	LexicalScore  float64            `json:"lexical_score"`
+	SemanticScore float64            `json:"semantic_score,omitempty"`
+	Method        string             `json:"method"`
+	MatchedFields []string           `json:"matched_fields,omitempty"`
+	FieldScores   map[string]float64 `json:"field_scores,omitempty"`
+	Keywords      []string           `json:"common_keywords,omitempty"`
+	MergeInto     string             `json:"merge_into"`
+	MergeFrom     string             `json:"merge_from"`
+	MergeReason   string             `json:"merge_reason"`
+}
+
+// Per-field thresholds for explaining which fields matched.
+const (
+	duplicateTitleFieldMin       = 0.5
+	duplicateDescriptionFieldMin = 0.3
+)
+
+// DetectDuplicates finds potential duplicate issues using keyword-based Jaccard similarity,
+// blended with embedding similarity when config.Semantic is set.
 func DetectDuplicates(issues []model.Issue, config DuplicateConfig) []Suggestion {
+	return DuplicateSuggestions(issues, FindDuplicateMatches(issues, config))
+}
+
+// FindDuplicateMatches scores candidate duplicate pairs. A pair qualifies when its
+// keyword similarity reaches JaccardThreshold or its embedding cosine reaches
+// CosineThreshold; when both signals are known the score blends them by
+// SemanticWeight. Results are sorted by score and limited to MaxSuggestions.
+func FindDuplicateMatches(issues []model.Issue, config DuplicateConfig) []DuplicateMatch {
 	if len(issues) < 2 {
 		return nil
 	}
 
-	// 1. Extract keywords for each issue and build Inverted Index
-	// keywords[i] = unique keywords for issue i
+	indexByID := make(map[string]int, len(issues))
+	for i := range issues {
+		indexByID[issues[i].ID] = i
+	}
 	keywords := make([][]string, len(issues))
-	// index[word] = list of issue indices containing that word
-	index := make(map[string][]int)
+	for i := range issues {
+		keywords[i] = extractKeywords(issues[i].Title, issues[i].Description)
+	}
+
+	type pairKey struct{ i, j int }
+	cosines := make(map[pairKey]float64)
+	for _, sp := range config.Semantic {
+		i, ok1 := indexByID[sp.Issue1]
+		j, ok2 := indexByID[sp.Issue2]
+		if !ok1 || !ok2 || i == j {
+			continue
+		}
+		if i > j {
+			i, j = j, i
+		}
+		if sp.Cosine > cosines[pairKey{i, j}] {
+			cosines[pairKey{i, j}] = sp.Cosine
+		}
+	}
 
+	candidates := make(map[pairKey]bool)
+	for _, p := range lexicalDuplicatePairs(issues, keywords, config) {
+		candidates[pairKey{indexByID[p.Issue1], indexByID[p.Issue2]}] = true
+	}
+	for k, cos := range cosines {
+		if cos >= config.CosineThreshold {
+			candidates[k] = true
+		}
+	}
+
+	dependents := make(map[string]int)
 	for i := range issues {
-		kws := extractKeywords(issues[i].Title, issues[i].Description)
-		keywords[i] = kws
+		for _, dep := range issues[i].Dependencies {
+			if dep != nil {
+				dependents[dep.DependsOnID]++
+			}
+		}
+	}
+
+	weight := config.SemanticWeight
+	if weight < 0 || weight > 1 {
+		weight = DefaultDuplicateConfig().SemanticWeight
+	}
 
+	matches := make([]DuplicateMatch, 0, len(candidates))
+	for k := range candidates {
+		issue1, issue2 := &issues[k.i], &issues[k.j]
+		if issue1.Status == model.StatusTombstone || issue2.Status == model.StatusTombstone {
+			continue
+		}
+		if config.IgnoreClosedVsOpen && isClosedLikeDuplicateStatus(issue1.Status) != isClosedLikeDuplicateStatus(issue2.Status) {
+			continue
+		}
+
+		lexical := jaccardKeywords(keywords[k.i], keywords[k.j])
+		cos, hasCos := cosines[k]
+		lexicalHit := lexical >= config.JaccardThreshold &&
+			len(keywords[k.i]) >= config.MinKeywords && len(keywords[k.j]) >= config.MinKeywords
+		semanticHit := hasCos && cos >= config.CosineThreshold
+
+		m := DuplicateMatch{
+			Issue1:       issue1.ID,
+			Issue2:       issue2.ID,
+			Score:        lexical,
+			LexicalScore: lexical,
+			Method:       DuplicateMethodJaccard,
+			Keywords:     intersectKeywords(keywords[k.i], keywords[k.j]),
+		}
+		if hasCos {
+			m.SemanticScore = cos
+			m.Score = weight*cos + (1-weight)*lexical
+		}
+		switch {
+		case lexicalHit && semanticHit:
+			m.Method = DuplicateMethodHybrid
+		case semanticHit:
+			m.Method = DuplicateMethodSemantic
+		}
+		m.FieldScores, m.MatchedFields = duplicateFieldMatches(issue1, issue2)
+		if semanticHit {
+			m.MatchedFields = append(m.MatchedFields, "embedding")
+		}
+		m.MergeInto, m.MergeFrom, m.MergeReason = SuggestMergeDirection(issue1, issue2, dependents)
+		matches = append(matches, m)
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		if matches[a].Issue1 != matches[b].Issue1 {
+			return matches[a].Issue1 < matches[b].Issue1
+		}
+		return matches[a].Issue2 < matches[b].Issue2
+	})
+	if config.MaxSuggestions > 0 && len(matches) > config.MaxSuggestions {
+		matches = matches[:config.MaxSuggestions]
+	}
+	return matches
+}
+
+// lexicalDuplicatePairs finds keyword-overlap candidates.
+// Optimized with an inverted index for performance on large repositories.
+func lexicalDuplicatePairs(issues []model.Issue, keywords [][]string, config DuplicateConfig) []DuplicatePair {
+	// index[word] = list of issue indices containing that word
+	index := make(map[string][]int)
+	for i, kws := range keywords {
 		// Only index if enough keywords to matter
 		if len(kws) >= config.MinKeywords {
 			for _, w := range kws {
@@ -100,8 +263,6 @@ func DetectDuplicates(issues []model.Issue, config DuplicateConfig) []Suggestion
 	}
 
 	var pairs []DuplicatePair
-
-	// 2. Iterate through issues and find candidates
 	for i := range issues {
 		// Skip if this issue doesn't have enough keywords
 		if len(keywords[i]) < config.MinKeywords {
@@ -111,7 +272,6 @@ func DetectDuplicates(issues []model.Issue, config DuplicateConfig) []Suggestion
 		// Count overlaps with other issues
 		// candidateIdx -> intersection count
 		overlaps := make(map[int]int)
-
 		for _, w := range keywords[i] {
 			for _, matchIdx := range index[w] {
 				// Only look at j > i to avoid duplicates and self-compare
@@ -121,80 +281,157 @@ func DetectDuplicates(issues []model.Issue, config DuplicateConfig) []Suggestion
 			}
 		}
 
-		// 3. Evaluate candidates
 		for j, overlap := range overlaps {
-			// Skip if other doesn't have enough keywords (redundant if index logic is strict, but safe)
-			if len(keywords[j]) < config.MinKeywords {
-				continue
-			}
-
 			// Jaccard = Intersection / Union
 			// Union = |A| + |B| - |Intersection|
 			union := len(keywords[i]) + len(keywords[j]) - overlap
 			similarity := float64(overlap) / float64(union)
-
 			if similarity < config.JaccardThreshold {
 				continue
 			}
+			pairs = append(pairs, DuplicatePair{
+				Issue1:     issues[i].ID,
+				Issue2:     issues[j].ID,
+				Similarity: similarity,
+				Method:     DuplicateMethodJaccard,
+			})
+		}
+	}
+	return pairs
+}
 
-			issue1 := &issues[i]
-			issue2 := &issues[j]
+// duplicateFieldMatches scores title, description and label overlap for a pair
+// and lists the fields that look alike.
+func duplicateFieldMatches(a, b *model.Issue) (map[string]float64, []string) {
+	scores := map[string]float64{
+		"title": jaccardKeywords(extractKeywords(a.Title, ""), extractKeywords(b.Title, "")),
+	}
+	var matched []string
+	if scores["title"] >= duplicateTitleFieldMin {
+		matched = append(matched, "title")
+	}
+	if strings.TrimSpace(a.Description) != "" && strings.TrimSpace(b.Description) != "" {
+		scores["description"] = jaccardKeywords(extractKeywords(a.Description, ""), extractKeywords(b.Description, ""))
+		if scores["description"] >= duplicateDescriptionFieldMin {
+			matched = append(matched, "description")
+		}
+	}
+	if len(a.Labels) > 0 && len(b.Labels) > 0 {
+		scores["labels"] = jaccardKeywords(normalizeLabels(a.Labels), normalizeLabels(b.Labels))
+		if scores["labels"] > 0 {
+			matched = append(matched, "labels")
+		}
+	}
+	return scores, matched
+}
 
-			if issue1.Status == model.StatusTombstone || issue2.Status == model.StatusTombstone {
-				continue
-			}
+func normalizeLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l != "" && !seen[l] {
+			seen[l] = true
+			out = append(out, l)
+		}
+	}
+	return out
+}
 
-			// Skip closed vs open pairs if configured
-			if config.IgnoreClosedVsOpen {
-				if isClosedLikeDuplicateStatus(issue1.Status) != isClosedLikeDuplicateStatus(issue2.Status) {
-					continue
-				}
-			}
+// jaccardKeywords returns |A∩B| / |A∪B| for two unique keyword lists.
+func jaccardKeywords(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	overlap := len(intersectKeywords(a, b))
+	return float64(overlap) / float64(len(a)+len(b)-overlap)
+}
 
-			// Reconstruct common keywords for display (only for passing pairs)
-			common := intersectKeywords(keywords[i], keywords[j])
+// duplicateActivity scores how much work has accumulated on an issue.
+func duplicateActivity(issue *model.Issue, dependents map[string]int) int {
+	activity := len(issue.Comments) + len(issue.Dependencies) + dependents[issue.ID]
+	if issue.Assignee != "" {
+		activity++
+	}
+	if issue.Status == model.StatusInProgress {
+		activity += 2
+	}
+	return activity
+}
 
-			pairs = append(pairs, DuplicatePair{
-				Issue1:     issue1.ID,
-				Issue2:     issue2.ID,
-				Similarity: similarity,
-				Method:     "jaccard",
-				Keywords:   common,
-			})
-		}
+// SuggestMergeDirection picks which of two duplicates to keep. The issue with
+// clearly more activity (comments, dependencies, dependents, an assignee or
+// work in progress) survives; otherwise the older one does.
+func SuggestMergeDirection(a, b *model.Issue, dependents map[string]int) (into, from, reason string) {
+	actA, actB := duplicateActivity(a, dependents), duplicateActivity(b, dependents)
+	switch {
+	case actA >= actB+2:
+		return a.ID, b.ID, fmt.Sprintf("%s has more activity (%d vs %d)", a.ID, actA, actB)
+	case actB >= actA+2:
+		return b.ID, a.ID, fmt.Sprintf("%s has more activity (%d vs %d)", b.ID, actB, actA)
 	}
 
-	// Sort by similarity (highest first) and limit
-	sortPairsBySimilarity(pairs)
-	if len(pairs) > config.MaxSuggestions {
-		pairs = pairs[:config.MaxSuggestions]
+	older, newer := a, b
+	if b.CreatedAt.Before(a.CreatedAt) || (b.CreatedAt.Equal(a.CreatedAt) && actB > actA) {
+		older, newer = b, a
 	}
+	if older.CreatedAt.IsZero() || older.CreatedAt.Equal(newer.CreatedAt) {
+		return older.ID, newer.ID, fmt.Sprintf("%s has as much activity and was listed first", older.ID)
+	}
+	return older.ID, newer.ID, fmt.Sprintf("%s is older (created %s)", older.ID, older.CreatedAt.Format("2006-01-02"))
+}
 
+// DuplicateSuggestions converts duplicate matches to potential_duplicate suggestions.
+func DuplicateSuggestions(issues []model.Issue, matches []DuplicateMatch) []Suggestion {
 	// Issue lookup map for constructing suggestions
 	issueMap := make(map[string]*model.Issue, len(issues))
 	for i := range issues {
 		issueMap[issues[i].ID] = &issues[i]
 	}
 
-	// Convert to suggestions
-	suggestions := make([]Suggestion, 0, len(pairs))
-	for _, pair := range pairs {
-		issue1 := issueMap[pair.Issue1]
-		issue2 := issueMap[pair.Issue2]
+	suggestions := make([]Suggestion, 0, len(matches))
+	for _, m := range matches {
+		issue1 := issueMap[m.Issue1]
+		issue2 := issueMap[m.Issue2]
+		if issue1 == nil || issue2 == nil {
+			continue
+		}
+
+		var reason string
+		switch m.Method {
+		case DuplicateMethodJaccard:
+			reason = fmt.Sprintf("%.0f%% keyword similarity; common: %s",
+				m.LexicalScore*100,
+				strings.Join(truncateStringSlice(m.Keywords, 5), ", "))
+		default:
+			reason = fmt.Sprintf("%.0f%% embedding similarity, %.0f%% keyword similarity",
+				m.SemanticScore*100, m.LexicalScore*100)
+			if len(m.MatchedFields) > 0 {
+				reason += "; matched: " + strings.Join(m.MatchedFields, ", ")
+			}
+		}
+		reason += fmt.Sprintf("; keep %s (%s)", m.MergeInto, m.MergeReason)
 
 		sug := NewSuggestion(
 			SuggestionPotentialDuplicate,
-			pair.Issue1,
-			fmt.Sprintf("Potential duplicate of %s", pair.Issue2),
-			fmt.Sprintf("%.0f%% keyword similarity; common: %s",
-				pair.Similarity*100,
-				strings.Join(truncateStringSlice(pair.Keywords, 5), ", ")),
-			pair.Similarity,
-		).WithRelatedBead(pair.Issue2).WithMetadata("method", pair.Method)
+			m.Issue1,
+			fmt.Sprintf("Potential duplicate of %s", m.Issue2),
+			reason,
+			m.Score,
+		).WithRelatedBead(m.Issue2).
+			WithMetadata("method", m.Method).
+			WithMetadata("merge_into", m.MergeInto).
+			WithMetadata("merge_from", m.MergeFrom)
+		if len(m.MatchedFields) > 0 {
+			sug = sug.WithMetadata("matched_fields", m.MatchedFields)
+		}
+		if m.SemanticScore > 0 {
+			sug = sug.WithMetadata("semantic_score", m.SemanticScore)
+		}
 
 		// Add action command if both are open
 		if !isClosedLikeDuplicateStatus(issue1.Status) && !isClosedLikeDuplicateStatus(issue2.Status) {
-			sug = sug.WithAction(fmt.Sprintf("bd dep add %s %s --type=related", pair.Issue1, pair.Issue2))
+			sug = sug.WithAction(fmt.Sprintf("bd dep add %s %s --type=related", m.Issue1, m.Issue2))
 		}
 
 		suggestions = append(suggestions, sug)
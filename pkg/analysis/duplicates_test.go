@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -636,3 +637,84 @@ func TestDetectDuplicates_LargeIssueSet(t *testing.T) {
 		t.Error("Should find at least one duplicate pair")
 	}
 }
+
+// ============================================================================
+// FindDuplicateMatches Tests - Semantic blending and merge direction
+// ============================================================================
+
+func TestFindDuplicateMatches_SemanticParaphrase(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "A", Title: "Login fails on Safari", Description: "Users see an error", Status: model.StatusOpen, CreatedAt: now.Add(-48 * time.Hour), Labels: []string{"auth"}},
+		{ID: "B", Title: "Cannot sign in using Safari", Description: "Reported by support", Status: model.StatusOpen, CreatedAt: now, Labels: []string{"Auth"}},
+		{ID: "C", Title: "Update dashboard colors", Status: model.StatusOpen, CreatedAt: now},
+	}
+	config := DefaultDuplicateConfig()
+
+	if matches := FindDuplicateMatches(issues, config); len(matches) != 0 {
+		t.Fatalf("keyword-only detection should miss the paraphrase, got %+v", matches)
+	}
+
+	config.Semantic = []SemanticPair{
+		{Issue1: "B", Issue2: "A", Cosine: 0.91},
+		{Issue1: "A", Issue2: "C", Cosine: 0.2},
+	}
+	matches := FindDuplicateMatches(issues, config)
+	if len(matches) != 1 {
+		t.Fatalf("expected one semantic match, got %+v", matches)
+	}
+	m := matches[0]
+	if m.Issue1 != "A" || m.Issue2 != "B" || m.Method != DuplicateMethodSemantic {
+		t.Errorf("unexpected match %+v", m)
+	}
+	if m.SemanticScore != 0.91 || m.Score <= m.LexicalScore || m.Score >= m.SemanticScore {
+		t.Errorf("expected score to blend cosine %.2f with lexical %.2f, got %.2f", m.SemanticScore, m.LexicalScore, m.Score)
+	}
+	if got := strings.Join(m.MatchedFields, ","); got != "labels,embedding" {
+		t.Errorf("expected labels and embedding to match, got %q", got)
+	}
+	if m.MergeInto != "A" || m.MergeFrom != "B" || !strings.Contains(m.MergeReason, "older") {
+		t.Errorf("expected older A to survive, got into=%s from=%s (%s)", m.MergeInto, m.MergeFrom, m.MergeReason)
+	}
+
+	suggestions := DuplicateSuggestions(issues, matches)
+	if len(suggestions) != 1 || suggestions[0].Metadata["method"] != DuplicateMethodSemantic || suggestions[0].Metadata["merge_into"] != "A" {
+		t.Errorf("unexpected suggestion %+v", suggestions)
+	}
+}
+
+func TestFindDuplicateMatches_HybridAndThreshold(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Database connection timeout error", Status: model.StatusOpen},
+		{ID: "B", Title: "Database connection timeout error", Status: model.StatusOpen},
+	}
+	config := DefaultDuplicateConfig()
+	config.Semantic = []SemanticPair{{Issue1: "A", Issue2: "B", Cosine: 0.95}}
+	matches := FindDuplicateMatches(issues, config)
+	if len(matches) != 1 || matches[0].Method != DuplicateMethodHybrid {
+		t.Fatalf("expected a hybrid match, got %+v", matches)
+	}
+
+	config.CosineThreshold = 0.99
+	matches = FindDuplicateMatches(issues, config)
+	if len(matches) != 1 || matches[0].Method != DuplicateMethodJaccard {
+		t.Errorf("expected keyword match when cosine is below threshold, got %+v", matches)
+	}
+}
+
+func TestSuggestMergeDirection_PrefersActivity(t *testing.T) {
+	now := time.Now()
+	older := &model.Issue{ID: "OLD", CreatedAt: now.Add(-240 * time.Hour)}
+	busy := &model.Issue{ID: "BUSY", CreatedAt: now, Status: model.StatusInProgress, Assignee: "alice",
+		Comments: []*model.Comment{{Text: "started"}}}
+
+	into, from, reason := SuggestMergeDirection(older, busy, nil)
+	if into != "BUSY" || from != "OLD" || !strings.Contains(reason, "more activity") {
+		t.Errorf("expected busy issue to survive, got into=%s from=%s (%s)", into, from, reason)
+	}
+
+	into, _, _ = SuggestMergeDirection(older, &model.Issue{ID: "NEW", CreatedAt: now}, map[string]int{"NEW": 1})
+	if into != "OLD" {
+		t.Errorf("expected older issue to survive a small activity gap, got %s", into)
+	}
+}
@@ -0,0 +1,440 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Forecast states for EpicForecast.Status.
+const (
+	EpicForecastComplete   = "complete"    // No remaining work
+	EpicForecastProjected  = "projected"   // ETA derived from the epic's own velocity
+	EpicForecastNoVelocity = "no_velocity" // Remaining work but nothing closed recently
+	EpicForecastNoItems    = "no_items"    // Open epic without any child work
+)
+
+// EpicRollupConfig controls the rollup computation.
+type EpicRollupConfig struct {
+	Now           time.Time // Reference time (defaults to time.Now)
+	VelocityWeeks int       // Lookback window for velocity (default 8)
+}
+
+// DefaultEpicRollupConfig returns the default rollup configuration.
+func DefaultEpicRollupConfig() EpicRollupConfig {
+	return EpicRollupConfig{Now: time.Now(), VelocityWeeks: 8}
+}
+
+// EpicRollup aggregates progress for an epic and everything beneath it.
+// Work items are the leaf descendants reached through parent-child
+// dependencies, recursing through child epics.
+type EpicRollup struct {
+	EpicID       string       `json:"epic_id"`
+	Title        string       `json:"title"`
+	Status       model.Status `json:"status"`
+	Priority     int          `json:"priority"`
+	ParentEpicID string       `json:"parent_epic_id,omitempty"`
+	ChildEpics   []string     `json:"child_epics,omitempty"`
+	Depth        int          `json:"depth"` // 0 for top-level epics
+
+	TotalIssues    int     `json:"total_issues"`
+	ClosedIssues   int     `json:"closed_issues"`
+	PercentByCount float64 `json:"percent_by_count"` // 0-100
+
+	TotalMinutes     int     `json:"total_minutes"`
+	ClosedMinutes    int     `json:"closed_minutes"`
+	RemainingMinutes int     `json:"remaining_minutes"`
+	PercentByMinutes float64 `json:"percent_by_minutes"` // 0-100
+	EstimatedIssues  int     `json:"estimated_issues"`   // Items with an explicit estimate
+
+	ExternalBlockers []EpicBlocker `json:"external_blockers,omitempty"`
+	CriticalPath     EpicCritical  `json:"critical_path"`
+	Velocity         EpicVelocity  `json:"velocity"`
+	Forecast         EpicForecast  `json:"forecast"`
+}
+
+// EpicBlocker is an open issue outside the epic that blocks open work inside it.
+type EpicBlocker struct {
+	IssueID  string       `json:"issue_id"`
+	Title    string       `json:"title"`
+	Status   model.Status `json:"status"`
+	Assignee string       `json:"assignee,omitempty"`
+	Blocks   []string     `json:"blocks"` // Epic items waiting on this issue
+}
+
+// EpicCritical is the longest chain of open blocking work inside an epic,
+// weighted by remaining estimated minutes.
+type EpicCritical struct {
+	Path     []string `json:"path"` // Root blocker first
+	Minutes  int      `json:"minutes"`
+	HasCycle bool     `json:"has_cycle,omitempty"`
+}
+
+// EpicVelocity summarizes recent closures of the epic's items.
+type EpicVelocity struct {
+	ClosedLast7Days  int            `json:"closed_last_7_days"`
+	ClosedLast30Days int            `json:"closed_last_30_days"`
+	IssuesPerWeek    float64        `json:"issues_per_week"`
+	MinutesPerWeek   float64        `json:"minutes_per_week"`
+	Weekly           []VelocityWeek `json:"weekly,omitempty"` // Newest first
+}
+
+// EpicForecast projects when the epic's remaining work will be done.
+type EpicForecast struct {
+	Status         string     `json:"status"`
+	RemainingWeeks float64    `json:"remaining_weeks,omitempty"`
+	ETADate        *time.Time `json:"eta_date,omitempty"`
+	Basis          string     `json:"basis,omitempty"` // "minutes" or "count"
+}
+
+// ComputeEpicRollups computes a rollup for every epic in the issue set.
+// Results are in tree order: top-level epics by priority then ID, each
+// followed by its child epics.
+func ComputeEpicRollups(issues []model.Issue, cfg EpicRollupConfig) []EpicRollup {
+	if cfg.Now.IsZero() {
+		cfg.Now = time.Now()
+	}
+	if cfg.VelocityWeeks <= 0 {
+		cfg.VelocityWeeks = 8
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		if issues[i].Status == model.StatusTombstone {
+			continue
+		}
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	children := make(map[string][]string)
+	parentOf := make(map[string]string)
+	for _, iss := range issueMap {
+		for _, dep := range iss.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; !ok {
+				continue
+			}
+			children[dep.DependsOnID] = append(children[dep.DependsOnID], iss.ID)
+			if _, seen := parentOf[iss.ID]; !seen {
+				parentOf[iss.ID] = dep.DependsOnID
+			}
+		}
+	}
+	for id := range children {
+		sort.Strings(children[id])
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+
+	var epicIDs []string
+	for id, iss := range issueMap {
+		if iss.IssueType == model.TypeEpic {
+			epicIDs = append(epicIDs, id)
+		}
+	}
+
+	rollups := make(map[string]*EpicRollup, len(epicIDs))
+	for _, id := range epicIDs {
+		r := computeEpicRollup(issueMap[id], issueMap, children, medianMinutes, cfg)
+		rollups[id] = &r
+	}
+
+	// Link child epics and find the nearest epic ancestor of each epic.
+	for _, id := range epicIDs {
+		seen := map[string]bool{id: true}
+		for p := parentOf[id]; p != "" && !seen[p]; p = parentOf[p] {
+			seen[p] = true
+			if parent, ok := rollups[p]; ok {
+				rollups[id].ParentEpicID = p
+				parent.ChildEpics = append(parent.ChildEpics, id)
+				break
+			}
+		}
+	}
+
+	less := func(a, b string) bool {
+		ra, rb := rollups[a], rollups[b]
+		if ra.Priority != rb.Priority {
+			return ra.Priority < rb.Priority
+		}
+		return ra.EpicID < rb.EpicID
+	}
+	var roots []string
+	for _, id := range epicIDs {
+		if rollups[id].ParentEpicID == "" {
+			roots = append(roots, id)
+		}
+		sort.Slice(rollups[id].ChildEpics, func(i, j int) bool {
+			return less(rollups[id].ChildEpics[i], rollups[id].ChildEpics[j])
+		})
+	}
+	sort.Slice(roots, func(i, j int) bool { return less(roots[i], roots[j]) })
+
+	result := make([]EpicRollup, 0, len(epicIDs))
+	emitted := make(map[string]bool, len(epicIDs))
+	var emit func(id string, depth int)
+	emit = func(id string, depth int) {
+		if emitted[id] {
+			return
+		}
+		emitted[id] = true
+		r := rollups[id]
+		r.Depth = depth
+		result = append(result, *r)
+		for _, child := range r.ChildEpics {
+			emit(child, depth+1)
+		}
+	}
+	for _, id := range roots {
+		emit(id, 0)
+	}
+	// Epics trapped in parent-child cycles have no root; list them last.
+	if len(result) < len(epicIDs) {
+		sort.Strings(epicIDs)
+		for _, id := range epicIDs {
+			emit(id, 0)
+		}
+	}
+	return result
+}
+
+func computeEpicRollup(epic *model.Issue, issueMap map[string]*model.Issue, children map[string][]string, medianMinutes int, cfg EpicRollupConfig) EpicRollup {
+	r := EpicRollup{
+		EpicID:   epic.ID,
+		Title:    epic.Title,
+		Status:   epic.Status,
+		Priority: epic.Priority,
+	}
+
+	// Collect descendants; items are descendants without children of their own.
+	inEpic := map[string]bool{epic.ID: true}
+	var items []*model.Issue
+	stack := append([]string(nil), children[epic.ID]...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inEpic[id] {
+			continue
+		}
+		inEpic[id] = true
+		if kids := children[id]; len(kids) > 0 {
+			stack = append(stack, kids...)
+			continue
+		}
+		items = append(items, issueMap[id])
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	minutesFor := func(iss *model.Issue) int {
+		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+			return *iss.EstimatedMinutes
+		}
+		return medianMinutes
+	}
+
+	weekAgo := cfg.Now.AddDate(0, 0, -7)
+	monthAgo := cfg.Now.AddDate(0, 0, -30)
+	windowStart := truncateToMonday(cfg.Now).AddDate(0, 0, -7*(cfg.VelocityWeeks-1))
+	weekBuckets := make(map[time.Time]int)
+	windowClosed, windowMinutes := 0, 0
+	open := make(map[string]bool)
+
+	for _, iss := range items {
+		minutes := minutesFor(iss)
+		r.TotalIssues++
+		r.TotalMinutes += minutes
+		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+			r.EstimatedIssues++
+		}
+		if !isClosedLikeStatus(iss.Status) {
+			open[iss.ID] = true
+			continue
+		}
+		r.ClosedIssues++
+		r.ClosedMinutes += minutes
+
+		closedAt := iss.UpdatedAt
+		if iss.ClosedAt != nil {
+			closedAt = *iss.ClosedAt
+		}
+		if closedAt.IsZero() || closedAt.After(cfg.Now) {
+			continue
+		}
+		if !closedAt.Before(weekAgo) {
+			r.Velocity.ClosedLast7Days++
+		}
+		if !closedAt.Before(monthAgo) {
+			r.Velocity.ClosedLast30Days++
+		}
+		if !closedAt.Before(windowStart) {
+			weekBuckets[truncateToMonday(closedAt)]++
+			windowClosed++
+			windowMinutes += minutes
+		}
+	}
+
+	r.RemainingMinutes = r.TotalMinutes - r.ClosedMinutes
+	if r.TotalIssues > 0 {
+		r.PercentByCount = 100 * float64(r.ClosedIssues) / float64(r.TotalIssues)
+	}
+	if r.TotalMinutes > 0 {
+		r.PercentByMinutes = 100 * float64(r.ClosedMinutes) / float64(r.TotalMinutes)
+	}
+
+	cursor := truncateToMonday(cfg.Now)
+	for i := 0; i < cfg.VelocityWeeks; i++ {
+		r.Velocity.Weekly = append(r.Velocity.Weekly, VelocityWeek{WeekStart: cursor, Closed: weekBuckets[cursor]})
+		cursor = cursor.AddDate(0, 0, -7)
+	}
+	r.Velocity.IssuesPerWeek = float64(windowClosed) / float64(cfg.VelocityWeeks)
+	r.Velocity.MinutesPerWeek = float64(windowMinutes) / float64(cfg.VelocityWeeks)
+
+	// Open blockers outside the epic.
+	blockers := make(map[string]*EpicBlocker)
+	for _, iss := range items {
+		if !open[iss.ID] {
+			continue
+		}
+		for _, dep := range iss.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || inEpic[dep.DependsOnID] {
+				continue
+			}
+			blocker, ok := issueMap[dep.DependsOnID]
+			if !ok || isClosedLikeStatus(blocker.Status) {
+				continue
+			}
+			b, ok := blockers[blocker.ID]
+			if !ok {
+				b = &EpicBlocker{IssueID: blocker.ID, Title: blocker.Title, Status: blocker.Status, Assignee: blocker.Assignee}
+				blockers[blocker.ID] = b
+			}
+			b.Blocks = append(b.Blocks, iss.ID)
+		}
+	}
+	for _, b := range blockers {
+		r.ExternalBlockers = append(r.ExternalBlockers, *b)
+	}
+	sort.Slice(r.ExternalBlockers, func(i, j int) bool {
+		bi, bj := r.ExternalBlockers[i], r.ExternalBlockers[j]
+		if len(bi.Blocks) != len(bj.Blocks) {
+			return len(bi.Blocks) > len(bj.Blocks)
+		}
+		return bi.IssueID < bj.IssueID
+	})
+
+	r.CriticalPath = epicCriticalPath(items, open, minutesFor)
+	r.Forecast = forecastEpic(r, cfg.Now)
+	return r
+}
+
+// epicCriticalPath finds the heaviest chain of open items connected by
+// blocking dependencies, using remaining minutes as node weights.
+func epicCriticalPath(items []*model.Issue, open map[string]bool, minutesFor func(*model.Issue) int) EpicCritical {
+	result := EpicCritical{Path: []string{}}
+	if len(open) == 0 {
+		return result
+	}
+
+	weight := make(map[string]int, len(open))
+	blockedBy := make(map[string][]string)
+	blocks := make(map[string][]string)
+	inDegree := make(map[string]int, len(open))
+	var ids []string
+	for _, iss := range items {
+		if !open[iss.ID] {
+			continue
+		}
+		ids = append(ids, iss.ID)
+		weight[iss.ID] = minutesFor(iss)
+		seen := make(map[string]bool)
+		for _, dep := range iss.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !open[dep.DependsOnID] || seen[dep.DependsOnID] {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			blockedBy[iss.ID] = append(blockedBy[iss.ID], dep.DependsOnID)
+			blocks[dep.DependsOnID] = append(blocks[dep.DependsOnID], iss.ID)
+			inDegree[iss.ID]++
+		}
+	}
+
+	// Kahn's algorithm over the open subgraph (ids are sorted for determinism).
+	var queue, order []string
+	for _, id := range ids {
+		if inDegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, next := range blocks[id] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if len(order) < len(ids) {
+		result.HasCycle = true
+	}
+
+	dist := make(map[string]int, len(order))
+	prev := make(map[string]string, len(order))
+	best := ""
+	for _, id := range order {
+		dist[id] = weight[id]
+		for _, p := range blockedBy[id] {
+			if d, ok := dist[p]; ok && d+weight[id] > dist[id] {
+				dist[id] = d + weight[id]
+				prev[id] = p
+			}
+		}
+		if best == "" || dist[id] > dist[best] {
+			best = id
+		}
+	}
+	if best == "" {
+		return result
+	}
+
+	for id := best; id != ""; id = prev[id] {
+		result.Path = append([]string{id}, result.Path...)
+	}
+	result.Minutes = dist[best]
+	return result
+}
+
+func forecastEpic(r EpicRollup, now time.Time) EpicForecast {
+	if r.TotalIssues == 0 && !isClosedLikeStatus(r.Status) {
+		return EpicForecast{Status: EpicForecastNoItems}
+	}
+	if r.ClosedIssues == r.TotalIssues {
+		return EpicForecast{Status: EpicForecastComplete}
+	}
+
+	var weeks float64
+	basis := ""
+	switch {
+	case r.Velocity.MinutesPerWeek > 0:
+		weeks = float64(r.RemainingMinutes) / r.Velocity.MinutesPerWeek
+		basis = "minutes"
+	case r.Velocity.IssuesPerWeek > 0:
+		weeks = float64(r.TotalIssues-r.ClosedIssues) / r.Velocity.IssuesPerWeek
+		basis = "count"
+	default:
+		return EpicForecast{Status: EpicForecastNoVelocity}
+	}
+
+	eta := now.Add(durationDays(weeks * 7))
+	return EpicForecast{
+		Status:         EpicForecastProjected,
+		RemainingWeeks: weeks,
+		ETADate:        &eta,
+		Basis:          basis,
+	}
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func epicChild(parent string) *model.Dependency {
+	return &model.Dependency{DependsOnID: parent, Type: model.DepParentChild}
+}
+
+func epicBlockedBy(blocker string) *model.Dependency {
+	return &model.Dependency{DependsOnID: blocker, Type: model.DepBlocks}
+}
+
+func epicRollupFixture(now time.Time) []model.Issue {
+	est := func(m int) *int { return &m }
+	closed := func(daysAgo int) *time.Time { t := now.AddDate(0, 0, -daysAgo); return &t }
+	return []model.Issue{
+		{ID: "E1", Title: "Platform", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 1},
+		{ID: "E2", Title: "Storage", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 2,
+			Dependencies: []*model.Dependency{epicChild("E1")}},
+		{ID: "A", Title: "Schema", IssueType: model.TypeTask, Status: model.StatusClosed, EstimatedMinutes: est(120),
+			ClosedAt: closed(3), Dependencies: []*model.Dependency{epicChild("E1")}},
+		{ID: "B", Title: "API", IssueType: model.TypeTask, Status: model.StatusOpen, EstimatedMinutes: est(60),
+			Dependencies: []*model.Dependency{epicChild("E1"), epicBlockedBy("X")}},
+		{ID: "C", Title: "Disk layout", IssueType: model.TypeTask, Status: model.StatusOpen, EstimatedMinutes: est(240),
+			Dependencies: []*model.Dependency{epicChild("E2")}},
+		{ID: "D", Title: "Compaction", IssueType: model.TypeTask, Status: model.StatusOpen, EstimatedMinutes: est(180),
+			Dependencies: []*model.Dependency{epicChild("E2"), epicBlockedBy("C")}},
+		{ID: "X", Title: "Auth service", IssueType: model.TypeTask, Status: model.StatusInProgress, Assignee: "alice"},
+		{ID: "E3", Title: "Empty", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 3},
+	}
+}
+
+func TestComputeEpicRollups_TreeOrderAndCounts(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	rollups := ComputeEpicRollups(epicRollupFixture(now), EpicRollupConfig{Now: now, VelocityWeeks: 4})
+
+	if len(rollups) != 3 {
+		t.Fatalf("expected 3 epics, got %d", len(rollups))
+	}
+	order := []string{rollups[0].EpicID, rollups[1].EpicID, rollups[2].EpicID}
+	if order[0] != "E1" || order[1] != "E2" || order[2] != "E3" {
+		t.Fatalf("unexpected order %v", order)
+	}
+	if rollups[1].ParentEpicID != "E1" || rollups[1].Depth != 1 {
+		t.Errorf("expected E2 nested under E1, got parent=%q depth=%d", rollups[1].ParentEpicID, rollups[1].Depth)
+	}
+	if len(rollups[0].ChildEpics) != 1 || rollups[0].ChildEpics[0] != "E2" {
+		t.Errorf("expected E1 child epics [E2], got %v", rollups[0].ChildEpics)
+	}
+
+	e1 := rollups[0]
+	if e1.TotalIssues != 4 || e1.ClosedIssues != 1 || e1.PercentByCount != 25 {
+		t.Errorf("unexpected count rollup: total=%d closed=%d pct=%v", e1.TotalIssues, e1.ClosedIssues, e1.PercentByCount)
+	}
+	if e1.TotalMinutes != 600 || e1.ClosedMinutes != 120 || e1.RemainingMinutes != 480 || e1.PercentByMinutes != 20 {
+		t.Errorf("unexpected minute rollup: %+v", e1)
+	}
+
+	e2 := rollups[1]
+	if e2.TotalIssues != 2 || e2.ClosedIssues != 0 {
+		t.Errorf("unexpected E2 counts: %+v", e2)
+	}
+}
+
+func TestComputeEpicRollups_BlockersAndCriticalPath(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	rollups := ComputeEpicRollups(epicRollupFixture(now), EpicRollupConfig{Now: now, VelocityWeeks: 4})
+	e1 := rollups[0]
+
+	if len(e1.ExternalBlockers) != 1 {
+		t.Fatalf("expected one external blocker, got %+v", e1.ExternalBlockers)
+	}
+	b := e1.ExternalBlockers[0]
+	if b.IssueID != "X" || b.Assignee != "alice" || len(b.Blocks) != 1 || b.Blocks[0] != "B" {
+		t.Errorf("unexpected blocker: %+v", b)
+	}
+	// C blocks D inside the child epic; it is internal to E1 and E2.
+	if len(rollups[1].ExternalBlockers) != 0 {
+		t.Errorf("internal dependency reported as external: %+v", rollups[1].ExternalBlockers)
+	}
+
+	cp := e1.CriticalPath
+	if len(cp.Path) != 2 || cp.Path[0] != "C" || cp.Path[1] != "D" || cp.Minutes != 420 {
+		t.Errorf("unexpected critical path: %+v", cp)
+	}
+}
+
+func TestComputeEpicRollups_VelocityAndForecast(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	rollups := ComputeEpicRollups(epicRollupFixture(now), EpicRollupConfig{Now: now, VelocityWeeks: 4})
+
+	e1 := rollups[0]
+	if e1.Velocity.ClosedLast7Days != 1 || e1.Velocity.MinutesPerWeek != 30 {
+		t.Errorf("unexpected velocity: %+v", e1.Velocity)
+	}
+	if len(e1.Velocity.Weekly) != 4 {
+		t.Errorf("expected 4 weekly buckets, got %d", len(e1.Velocity.Weekly))
+	}
+	if e1.Forecast.Status != EpicForecastProjected || e1.Forecast.Basis != "minutes" || e1.Forecast.RemainingWeeks != 16 {
+		t.Errorf("unexpected forecast: %+v", e1.Forecast)
+	}
+	if e1.Forecast.ETADate == nil || !e1.Forecast.ETADate.Equal(now.AddDate(0, 0, 112)) {
+		t.Errorf("unexpected ETA: %v", e1.Forecast.ETADate)
+	}
+
+	if rollups[1].Forecast.Status != EpicForecastNoVelocity {
+		t.Errorf("expected no velocity for E2, got %+v", rollups[1].Forecast)
+	}
+	if rollups[2].Forecast.Status != EpicForecastNoItems {
+		t.Errorf("expected no items for empty epic, got %+v", rollups[2].Forecast)
+	}
+}
+
+func TestComputeEpicRollups_ParentChildCycle(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "E1", IssueType: model.TypeEpic, Status: model.StatusOpen, Dependencies: []*model.Dependency{epicChild("E2")}},
+		{ID: "E2", IssueType: model.TypeEpic, Status: model.StatusOpen, Dependencies: []*model.Dependency{epicChild("E1")}},
+	}
+	rollups := ComputeEpicRollups(issues, EpicRollupConfig{Now: time.Now()})
+	if len(rollups) != 2 {
+		t.Fatalf("expected both epics despite cycle, got %d", len(rollups))
+	}
+}
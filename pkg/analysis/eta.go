@@ -38,14 +38,39 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 		return ETAEstimate{}, fmt.Errorf("issue %q not found", issueID)
 	}
 
+	return NewETAEstimator(issues, stats, now).Estimate(issue, agents), nil
+}
+
+// ETAEstimator estimates ETAs for many issues of the same snapshot. The median
+// estimate and recent closure velocities are computed once, so estimating every
+// issue stays linear in the number of issues.
+type ETAEstimator struct {
+	stats         *GraphStats
+	now           time.Time
+	medianMinutes int
+	velocity      velocityIndex
+}
+
+// NewETAEstimator prepares estimates for issues as of now. stats is optional.
+func NewETAEstimator(issues []model.Issue, stats *GraphStats, now time.Time) *ETAEstimator {
+	median := computeMedianEstimatedMinutes(issues)
+	return &ETAEstimator{
+		stats:         stats,
+		now:           now,
+		medianMinutes: median,
+		velocity:      newVelocityIndex(issues, now.Add(-velocityWindowDays*24*time.Hour), median),
+	}
+}
+
+// Estimate returns the ETA for issue, which need not be part of the snapshot.
+func (e *ETAEstimator) Estimate(issue model.Issue, agents int) ETAEstimate {
 	if agents <= 0 {
 		agents = 1
 	}
+	now, medianMinutes := e.now, e.medianMinutes
+	complexityMinutes, complexityFactors := estimateComplexityMinutes(issue, e.stats, medianMinutes)
 
-	medianMinutes := computeMedianEstimatedMinutes(issues)
-	complexityMinutes, complexityFactors := estimateComplexityMinutes(issue, stats, medianMinutes)
-
-	velocityPerDay, velocitySamples, velocityFactors := estimateVelocityMinutesPerDay(issues, issue, now, medianMinutes)
+	velocityPerDay, velocitySamples, velocityFactors := e.velocity.forIssue(issue)
 	if velocityPerDay <= 0 {
 		// Conservative default: one median-sized issue per (work) week.
 		velocityPerDay = float64(medianMinutes) / 5.0
@@ -78,7 +103,7 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 	}
 
 	return ETAEstimate{
-		IssueID:               issueID,
+		IssueID:               issue.ID,
 		EstimatedMinutes:      complexityMinutes,
 		EstimatedDays:         estimatedDays,
 		ETADate:               eta,
@@ -88,7 +113,7 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 		VelocityMinutesPerDay: velocityPerDay,
 		Agents:                agents,
 		Factors:               factors,
-	}, nil
+	}
 }
 
 func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinutes int) (int, []string) {
@@ -148,13 +173,70 @@ func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinut
 	return derived, factors
 }
 
-func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now time.Time, medianMinutes int) (float64, int, []string) {
-	const windowDays = 30
-	since := now.Add(-time.Duration(windowDays) * 24 * time.Hour)
+// velocityWindowDays is how far back closures count towards velocity.
+const velocityWindowDays = 30
+
+// velocityIndex holds the estimated minutes and count of recent closures per
+// lower-cased label ("" = all closures), gathered in one pass over the issues.
+type velocityIndex struct {
+	minutes map[string]int
+	samples map[string]int
+}
+
+func newVelocityIndex(issues []model.Issue, since time.Time, medianMinutes int) velocityIndex {
+	v := velocityIndex{minutes: make(map[string]int), samples: make(map[string]int)}
+	for _, iss := range issues {
+		if iss.Status != model.StatusClosed {
+			continue
+		}
+
+		// Robust closure time: use ClosedAt if available, else UpdatedAt
+		closedAt := iss.UpdatedAt
+		if iss.ClosedAt != nil {
+			closedAt = *iss.ClosedAt
+		}
+		if closedAt.Before(since) {
+			continue
+		}
+
+		minutes := medianMinutes
+		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+			minutes = *iss.EstimatedMinutes
+		}
+		if minutes <= 0 {
+			minutes = DefaultEstimatedMinutes
+		}
 
+		seen := map[string]bool{"": true}
+		v.minutes[""] += minutes
+		v.samples[""]++
+		for _, label := range iss.Labels {
+			key := strings.ToLower(label)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			v.minutes[key] += minutes
+			v.samples[key]++
+		}
+	}
+	return v
+}
+
+// forLabel returns minutes closed per day and the sample count for label ("" = global).
+func (idx velocityIndex) forLabel(label string) (float64, int) {
+	key := strings.ToLower(label)
+	n := idx.samples[key]
+	if n == 0 {
+		return 0, 0
+	}
+	return float64(idx.minutes[key]) / velocityWindowDays, n
+}
+
+func (idx velocityIndex) forIssue(issue model.Issue) (float64, int, []string) {
 	labels := issue.Labels
 	if len(labels) == 0 {
-		v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+		v, n := idx.forLabel("")
 		return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 	}
 
@@ -163,7 +245,7 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	bestV := 0.0
 	bestN := 0
 	for _, label := range labels {
-		v, n := velocityMinutesPerDayForLabel(issues, label, since, medianMinutes)
+		v, n := idx.forLabel(label)
 		if n == 0 || v <= 0 {
 			continue
 		}
@@ -178,47 +260,12 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	}
 
 	// Fallback: global velocity.
-	v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+	v, n := idx.forLabel("")
 	return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 }
 
 func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since time.Time, medianMinutes int) (float64, int) {
-	total := 0
-	samples := 0
-
-	for _, iss := range issues {
-		if iss.Status != model.StatusClosed {
-			continue
-		}
-
-		// Robust closure time: use ClosedAt if available, else UpdatedAt
-		closedAt := iss.UpdatedAt
-		if iss.ClosedAt != nil {
-			closedAt = *iss.ClosedAt
-		}
-
-		if closedAt.Before(since) {
-			continue
-		}
-		if label != "" && !hasLabel(iss.Labels, label) {
-			continue
-		}
-
-		minutes := medianMinutes
-		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
-			minutes = *iss.EstimatedMinutes
-		}
-		if minutes <= 0 {
-			minutes = DefaultEstimatedMinutes
-		}
-		total += minutes
-		samples++
-	}
-
-	if samples == 0 {
-		return 0, 0
-	}
-	return float64(total) / 30.0, samples
+	return newVelocityIndex(issues, since, medianMinutes).forLabel(label)
 }
 
 func hasLabel(labels []string, target string) bool {
@@ -458,6 +458,35 @@ func TestVelocityMinutesPerDayForLabel_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestETAEstimator_MatchesPerIssueEstimate(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	closed := now.Add(-3 * 24 * time.Hour)
+	est := func(m int) *int { return &m }
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, Labels: []string{"API"}, EstimatedMinutes: est(90)},
+		{ID: "b", Status: model.StatusOpen},
+		{ID: "c", Status: model.StatusClosed, ClosedAt: &closed, Labels: []string{"api", "Api"}, EstimatedMinutes: est(240)},
+		{ID: "d", Status: model.StatusClosed, ClosedAt: &closed, EstimatedMinutes: est(60)},
+	}
+
+	estimator := NewETAEstimator(issues, nil, now)
+	for _, issue := range issues {
+		want, err := EstimateETAForIssue(issues, nil, issue.ID, 2, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := estimator.Estimate(issue, 2)
+		if got.EstimatedDays != want.EstimatedDays || got.VelocityMinutesPerDay != want.VelocityMinutesPerDay || got.Confidence != want.Confidence {
+			t.Errorf("%s: estimator %+v differs from EstimateETAForIssue %+v", issue.ID, got, want)
+		}
+	}
+
+	// Labels match case-insensitively and count once per issue.
+	if v, n := newVelocityIndex(issues, now.Add(-30*24*time.Hour), 60).forLabel("API"); n != 1 || v != 8 {
+		t.Errorf("expected one api sample at 8 min/day, got %v/%d", v, n)
+	}
+}
+
 func TestEstimateETAConfidence_AllCases(t *testing.T) {
 	est120 := 120
 
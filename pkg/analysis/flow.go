@@ -0,0 +1,418 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Flow source values reported in FlowReport.Source.
+const (
+	FlowSourceGit      = "git"      // Every timeline was reconstructed from git history
+	FlowSourceSnapshot = "snapshot" // Timelines approximated from created/updated/closed timestamps
+	FlowSourceMixed    = "mixed"    // Some issues had git history, others fell back to the snapshot
+)
+
+// StatusTransition records an issue entering a status at a point in time.
+type StatusTransition struct {
+	IssueID   string       `json:"issue_id"`
+	Status    model.Status `json:"status"`
+	At        time.Time    `json:"at"`
+	CommitSHA string       `json:"commit_sha,omitempty"`
+}
+
+// FlowConfig controls the flow metrics window.
+type FlowConfig struct {
+	Days int       // Length of the CFD/throughput window in days (default 30)
+	Now  time.Time // Reference time; zero means time.Now()
+}
+
+// DefaultFlowConfig returns a 30-day window ending now.
+func DefaultFlowConfig() FlowConfig {
+	return FlowConfig{Days: 30}
+}
+
+// FlowReport is the output of --robot-flow: flow metrics for the whole
+// project plus one summary per label.
+type FlowReport struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	WindowStart time.Time     `json:"window_start"`
+	WindowEnd   time.Time     `json:"window_end"`
+	Days        int           `json:"days"`
+	Source      string        `json:"source"` // git, snapshot, or mixed
+	Project     FlowSummary   `json:"project"`
+	Labels      []FlowSummary `json:"labels,omitempty"`
+}
+
+// FlowSummary holds the flow metrics for one population of issues.
+type FlowSummary struct {
+	Label      string          `json:"label,omitempty"` // Empty for the project-wide summary
+	IssueCount int             `json:"issue_count"`
+	CFD        []CFDPoint      `json:"cfd"`
+	LeadTime   DurationStats   `json:"lead_time"`  // created -> closed
+	CycleTime  DurationStats   `json:"cycle_time"` // first in_progress -> closed
+	Throughput ThroughputStats `json:"throughput"`
+	WIPAging   []WIPAgingItem  `json:"wip_aging"`
+}
+
+// CFDPoint is one day of a cumulative flow diagram: how many issues were in
+// each status at the end of that day (UTC).
+type CFDPoint struct {
+	Date       time.Time `json:"date"`
+	Open       int       `json:"open"`
+	InProgress int       `json:"in_progress"`
+	Blocked    int       `json:"blocked"`
+	Closed     int       `json:"closed"`
+}
+
+// DurationStats summarizes a distribution of durations in days.
+type DurationStats struct {
+	Count      int     `json:"count"`
+	MeanDays   float64 `json:"mean_days"`
+	MedianDays float64 `json:"median_days"`
+	P85Days    float64 `json:"p85_days"`
+	P95Days    float64 `json:"p95_days"`
+	MinDays    float64 `json:"min_days"`
+	MaxDays    float64 `json:"max_days"`
+}
+
+// ThroughputStats counts closures inside the window.
+type ThroughputStats struct {
+	Total     int            `json:"total"`
+	PerWeek   float64        `json:"per_week"`
+	Daily     []int          `json:"daily"`  // Aligned with CFD dates
+	Weekly    []VelocityWeek `json:"weekly"` // Oldest first
+	ClosedIDs []string       `json:"closed_ids,omitempty"`
+}
+
+// WIPAgingItem describes an issue currently in progress and how long it has
+// been there.
+type WIPAgingItem struct {
+	IssueID  string       `json:"issue_id"`
+	Title    string       `json:"title"`
+	Status   model.Status `json:"status"`
+	Since    time.Time    `json:"since"`
+	AgeDays  float64      `json:"age_days"`
+	OverP85  bool         `json:"over_p85"` // Older than the 85th percentile cycle time
+	Assignee string       `json:"assignee,omitempty"`
+}
+
+// TransitionsFromEvents converts git-derived bead events into status
+// transitions. Events recorded without a status fall back to the status
+// implied by their event type; plain modifications are dropped.
+func TransitionsFromEvents(events []correlation.BeadEvent) []StatusTransition {
+	transitions := make([]StatusTransition, 0, len(events))
+	for _, ev := range events {
+		status := model.Status(ev.Status)
+		if status == "" {
+			switch ev.EventType {
+			case correlation.EventCreated, correlation.EventReopened:
+				status = model.StatusOpen
+			case correlation.EventClaimed:
+				status = model.StatusInProgress
+			case correlation.EventClosed:
+				status = model.StatusClosed
+			default:
+				continue
+			}
+		}
+		transitions = append(transitions, StatusTransition{
+			IssueID:   ev.BeadID,
+			Status:    status,
+			At:        ev.Timestamp.UTC(),
+			CommitSHA: ev.CommitSHA,
+		})
+	}
+	return transitions
+}
+
+// flowTimeline is the ordered status history of a single issue.
+type flowTimeline struct {
+	issue       model.Issue
+	transitions []StatusTransition
+	fromGit     bool
+}
+
+// statusAt returns the status in effect at t, or "" if the issue did not
+// exist yet.
+func (tl flowTimeline) statusAt(t time.Time) model.Status {
+	status := model.Status("")
+	for _, tr := range tl.transitions {
+		if tr.At.After(t) {
+			break
+		}
+		status = tr.Status
+	}
+	return status
+}
+
+// ComputeFlowReport computes cumulative flow, lead/cycle time, throughput and
+// WIP aging for the project and each label. Transitions normally come from
+// TransitionsFromEvents; issues without any transitions are approximated from
+// their snapshot timestamps.
+func ComputeFlowReport(issues []model.Issue, transitions []StatusTransition, cfg FlowConfig) FlowReport {
+	if cfg.Days <= 0 {
+		cfg.Days = DefaultFlowConfig().Days
+	}
+	now := cfg.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC()
+
+	timelines := buildFlowTimelines(issues, transitions, now)
+
+	gitCount := 0
+	for _, tl := range timelines {
+		if tl.fromGit {
+			gitCount++
+		}
+	}
+	source := FlowSourceMixed
+	switch {
+	case gitCount == 0:
+		source = FlowSourceSnapshot
+	case gitCount == len(timelines):
+		source = FlowSourceGit
+	}
+
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -(cfg.Days - 1))
+
+	report := FlowReport{
+		GeneratedAt: now,
+		WindowStart: start,
+		WindowEnd:   end,
+		Days:        cfg.Days,
+		Source:      source,
+		Project:     summarizeFlow("", timelines, start, cfg.Days, now),
+	}
+
+	byLabel := make(map[string][]flowTimeline)
+	for _, tl := range timelines {
+		for _, label := range tl.issue.Labels {
+			byLabel[label] = append(byLabel[label], tl)
+		}
+	}
+	labels := make([]string, 0, len(byLabel))
+	for label := range byLabel {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		report.Labels = append(report.Labels, summarizeFlow(label, byLabel[label], start, cfg.Days, now))
+	}
+
+	return report
+}
+
+// buildFlowTimelines merges git transitions with the current snapshot so each
+// issue has an ordered status history ending in its present status.
+func buildFlowTimelines(issues []model.Issue, transitions []StatusTransition, now time.Time) []flowTimeline {
+	byIssue := make(map[string][]StatusTransition)
+	for _, tr := range transitions {
+		byIssue[tr.IssueID] = append(byIssue[tr.IssueID], tr)
+	}
+
+	timelines := make([]flowTimeline, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Status == model.StatusTombstone {
+			continue
+		}
+		trs := append([]StatusTransition(nil), byIssue[issue.ID]...)
+		sort.SliceStable(trs, func(i, j int) bool { return trs[i].At.Before(trs[j].At) })
+
+		tl := flowTimeline{issue: issue, fromGit: len(trs) > 0}
+		created := issue.CreatedAt.UTC()
+		if created.IsZero() && len(trs) > 0 {
+			created = trs[0].At
+		}
+		if created.IsZero() {
+			created = now
+		}
+		if len(trs) == 0 || trs[0].At.After(created) {
+			trs = append([]StatusTransition{{IssueID: issue.ID, Status: model.StatusOpen, At: created}}, trs...)
+		}
+
+		// Reconcile with the snapshot: uncommitted edits (or missing history)
+		// leave the current status unrecorded.
+		last := trs[len(trs)-1]
+		if last.Status != issue.Status {
+			at := snapshotStatusTime(issue, now)
+			if at.Before(last.At) {
+				at = last.At
+			}
+			trs = append(trs, StatusTransition{IssueID: issue.ID, Status: issue.Status, At: at})
+		}
+
+		// closed_at is authoritative for the final closure; the commit that
+		// recorded it may land much later (e.g. a bulk import).
+		if n := len(trs); issue.Status == model.StatusClosed && issue.ClosedAt != nil && n > 1 {
+			closedAt := issue.ClosedAt.UTC()
+			if !closedAt.Before(trs[n-2].At) {
+				trs[n-1].At = closedAt
+			}
+		}
+		tl.transitions = trs
+		timelines = append(timelines, tl)
+	}
+	return timelines
+}
+
+// snapshotStatusTime estimates when an issue entered its current status from
+// snapshot fields alone.
+func snapshotStatusTime(issue model.Issue, now time.Time) time.Time {
+	if issue.Status == model.StatusClosed && issue.ClosedAt != nil {
+		return issue.ClosedAt.UTC()
+	}
+	if !issue.UpdatedAt.IsZero() {
+		return issue.UpdatedAt.UTC()
+	}
+	return now
+}
+
+func summarizeFlow(label string, timelines []flowTimeline, start time.Time, days int, now time.Time) FlowSummary {
+	summary := FlowSummary{
+		Label:      label,
+		IssueCount: len(timelines),
+		CFD:        make([]CFDPoint, days),
+		WIPAging:   []WIPAgingItem{},
+	}
+	summary.Throughput.Daily = make([]int, days)
+
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		point := CFDPoint{Date: day}
+		endOfDay := day.Add(24*time.Hour - time.Nanosecond)
+		for _, tl := range timelines {
+			switch tl.statusAt(endOfDay) {
+			case "":
+			case model.StatusClosed:
+				point.Closed++
+			case model.StatusInProgress:
+				point.InProgress++
+			case model.StatusBlocked:
+				point.Blocked++
+			case model.StatusTombstone:
+			default:
+				point.Open++
+			}
+		}
+		summary.CFD[d] = point
+	}
+
+	var leadDays, cycleDays []float64
+	weekBuckets := make(map[time.Time]int)
+	windowEnd := start.AddDate(0, 0, days)
+	for _, tl := range timelines {
+		firstProgress, closedAt := flowCycleBounds(tl)
+		if !closedAt.IsZero() {
+			leadDays = append(leadDays, closedAt.Sub(tl.transitions[0].At).Hours()/24)
+			if !firstProgress.IsZero() && !firstProgress.After(closedAt) {
+				cycleDays = append(cycleDays, closedAt.Sub(firstProgress).Hours()/24)
+			}
+			if !closedAt.Before(start) && closedAt.Before(windowEnd) {
+				summary.Throughput.Total++
+				summary.Throughput.Daily[int(closedAt.Sub(start).Hours()/24)]++
+				summary.Throughput.ClosedIDs = append(summary.Throughput.ClosedIDs, tl.issue.ID)
+				weekBuckets[truncateToMonday(closedAt)]++
+			}
+		}
+
+		if tl.issue.Status == model.StatusInProgress {
+			since := now
+			for i := len(tl.transitions) - 1; i >= 0; i-- {
+				if tl.transitions[i].Status != model.StatusInProgress {
+					break
+				}
+				since = tl.transitions[i].At
+			}
+			summary.WIPAging = append(summary.WIPAging, WIPAgingItem{
+				IssueID:  tl.issue.ID,
+				Title:    tl.issue.Title,
+				Status:   tl.issue.Status,
+				Since:    since,
+				AgeDays:  math.Max(0, now.Sub(since).Hours()/24),
+				Assignee: tl.issue.Assignee,
+			})
+		}
+	}
+
+	summary.LeadTime = computeDurationStats(leadDays)
+	summary.CycleTime = computeDurationStats(cycleDays)
+	sort.Strings(summary.Throughput.ClosedIDs)
+	summary.Throughput.PerWeek = float64(summary.Throughput.Total) / (float64(days) / 7.0)
+	for cursor := truncateToMonday(start); cursor.Before(windowEnd); cursor = cursor.AddDate(0, 0, 7) {
+		summary.Throughput.Weekly = append(summary.Throughput.Weekly, VelocityWeek{WeekStart: cursor, Closed: weekBuckets[cursor]})
+	}
+
+	for i := range summary.WIPAging {
+		summary.WIPAging[i].OverP85 = summary.CycleTime.Count > 0 && summary.WIPAging[i].AgeDays > summary.CycleTime.P85Days
+	}
+	sort.Slice(summary.WIPAging, func(i, j int) bool {
+		if summary.WIPAging[i].AgeDays != summary.WIPAging[j].AgeDays {
+			return summary.WIPAging[i].AgeDays > summary.WIPAging[j].AgeDays
+		}
+		return summary.WIPAging[i].IssueID < summary.WIPAging[j].IssueID
+	})
+
+	return summary
+}
+
+// flowCycleBounds returns when the issue first went in progress and when it
+// was finally closed (zero if it is not closed).
+func flowCycleBounds(tl flowTimeline) (firstProgress, closedAt time.Time) {
+	for _, tr := range tl.transitions {
+		if tr.Status == model.StatusClosed {
+			closedAt = tr.At
+			continue
+		}
+		// Any other status reopens the issue: only the final closure counts
+		closedAt = time.Time{}
+		if tr.Status == model.StatusInProgress && firstProgress.IsZero() {
+			firstProgress = tr.At
+		}
+	}
+	return firstProgress, closedAt
+}
+
+// computeDurationStats summarizes samples (in days) with nearest-rank percentiles.
+func computeDurationStats(samples []float64) DurationStats {
+	if len(samples) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	return DurationStats{
+		Count:      len(sorted),
+		MeanDays:   sum / float64(len(sorted)),
+		MedianDays: nearestRank(sorted, 0.50),
+		P85Days:    nearestRank(sorted, 0.85),
+		P95Days:    nearestRank(sorted, 0.95),
+		MinDays:    sorted[0],
+		MaxDays:    sorted[len(sorted)-1],
+	}
+}
+
+// nearestRank returns the p-th percentile of sorted values.
+func nearestRank(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
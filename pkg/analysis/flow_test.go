@@ -0,0 +1,213 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func flowTestNow() time.Time {
+	return time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+}
+
+func TestTransitionsFromEvents(t *testing.T) {
+	ts := flowTestNow()
+	events := []correlation.BeadEvent{
+		{BeadID: "A", EventType: correlation.EventCreated, Timestamp: ts, Status: "open"},
+		{BeadID: "A", EventType: correlation.EventModified, Timestamp: ts, Status: "blocked"},
+		{BeadID: "A", EventType: correlation.EventClaimed, Timestamp: ts},
+		{BeadID: "A", EventType: correlation.EventModified, Timestamp: ts},
+		{BeadID: "A", EventType: correlation.EventClosed, Timestamp: ts, CommitSHA: "abc"},
+	}
+
+	got := TransitionsFromEvents(events)
+	want := []model.Status{model.StatusOpen, model.StatusBlocked, model.StatusInProgress, model.StatusClosed}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d transitions, got %d: %+v", len(want), len(got), got)
+	}
+	for i, status := range want {
+		if got[i].Status != status {
+			t.Errorf("transition %d: expected %s, got %s", i, status, got[i].Status)
+		}
+	}
+	if got[3].CommitSHA != "abc" {
+		t.Errorf("expected commit SHA to be carried over, got %q", got[3].CommitSHA)
+	}
+}
+
+func TestComputeFlowReport_GitTransitions(t *testing.T) {
+	now := flowTestNow()
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	closedAt := day(2)
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Done", Status: model.StatusClosed, CreatedAt: day(10), ClosedAt: &closedAt, Labels: []string{"api"}},
+		{ID: "B", Title: "Working", Status: model.StatusInProgress, CreatedAt: day(8), Labels: []string{"api", "ui"}},
+		{ID: "C", Title: "Waiting", Status: model.StatusOpen, CreatedAt: day(1)},
+	}
+	transitions := []StatusTransition{
+		{IssueID: "A", Status: model.StatusOpen, At: day(10)},
+		{IssueID: "A", Status: model.StatusInProgress, At: day(6)},
+		{IssueID: "A", Status: model.StatusClosed, At: day(2)},
+		{IssueID: "B", Status: model.StatusOpen, At: day(8)},
+		{IssueID: "B", Status: model.StatusInProgress, At: day(5)},
+		{IssueID: "C", Status: model.StatusOpen, At: day(1)},
+	}
+
+	report := ComputeFlowReport(issues, transitions, FlowConfig{Days: 14, Now: now})
+
+	if report.Source != FlowSourceGit {
+		t.Errorf("expected git source, got %s", report.Source)
+	}
+	if len(report.Project.CFD) != 14 {
+		t.Fatalf("expected 14 CFD points, got %d", len(report.Project.CFD))
+	}
+
+	last := report.Project.CFD[13]
+	if last.Open != 1 || last.InProgress != 1 || last.Closed != 1 {
+		t.Errorf("unexpected final CFD point: %+v", last)
+	}
+	first := report.Project.CFD[0]
+	if first.Open+first.InProgress+first.Blocked+first.Closed != 0 {
+		t.Errorf("expected empty CFD before any issue existed, got %+v", first)
+	}
+
+	if report.Project.LeadTime.Count != 1 || report.Project.LeadTime.MedianDays != 8 {
+		t.Errorf("unexpected lead time: %+v", report.Project.LeadTime)
+	}
+	if report.Project.CycleTime.Count != 1 || report.Project.CycleTime.MedianDays != 4 {
+		t.Errorf("unexpected cycle time: %+v", report.Project.CycleTime)
+	}
+	if report.Project.Throughput.Total != 1 || report.Project.Throughput.Daily[11] != 1 {
+		t.Errorf("unexpected throughput: %+v", report.Project.Throughput)
+	}
+
+	if len(report.Project.WIPAging) != 1 {
+		t.Fatalf("expected 1 WIP item, got %d", len(report.Project.WIPAging))
+	}
+	wip := report.Project.WIPAging[0]
+	if wip.IssueID != "B" || wip.AgeDays != 5 || !wip.OverP85 {
+		t.Errorf("unexpected WIP aging item: %+v", wip)
+	}
+
+	if len(report.Labels) != 2 || report.Labels[0].Label != "api" || report.Labels[1].Label != "ui" {
+		t.Fatalf("unexpected label summaries: %+v", report.Labels)
+	}
+	if report.Labels[0].IssueCount != 2 || report.Labels[1].IssueCount != 1 {
+		t.Errorf("unexpected label issue counts: api=%d ui=%d", report.Labels[0].IssueCount, report.Labels[1].IssueCount)
+	}
+	if report.Labels[1].LeadTime.Count != 0 {
+		t.Errorf("ui label has no closed issues, got lead time %+v", report.Labels[1].LeadTime)
+	}
+}
+
+func TestComputeFlowReport_SnapshotFallback(t *testing.T) {
+	now := flowTestNow()
+	closedAt := now.AddDate(0, 0, -1)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, CreatedAt: now.AddDate(0, 0, -4), ClosedAt: &closedAt},
+		{ID: "B", Status: model.StatusBlocked, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now.AddDate(0, 0, -2)},
+		{ID: "T", Status: model.StatusTombstone, CreatedAt: now.AddDate(0, 0, -3)},
+	}
+
+	report := ComputeFlowReport(issues, nil, FlowConfig{Days: 7, Now: now})
+
+	if report.Source != FlowSourceSnapshot {
+		t.Errorf("expected snapshot source, got %s", report.Source)
+	}
+	if report.Project.IssueCount != 2 {
+		t.Errorf("tombstones should be excluded, got %d issues", report.Project.IssueCount)
+	}
+	last := report.Project.CFD[len(report.Project.CFD)-1]
+	if last.Closed != 1 || last.Blocked != 1 {
+		t.Errorf("unexpected final CFD point: %+v", last)
+	}
+	if report.Project.LeadTime.Count != 1 || report.Project.LeadTime.MeanDays != 3 {
+		t.Errorf("unexpected lead time: %+v", report.Project.LeadTime)
+	}
+	if report.Project.CycleTime.Count != 0 {
+		t.Errorf("snapshot data has no in_progress history, got cycle time %+v", report.Project.CycleTime)
+	}
+}
+
+func TestComputeFlowReport_ReopenUsesFinalClose(t *testing.T) {
+	now := flowTestNow()
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, CreatedAt: day(9)},
+	}
+	transitions := []StatusTransition{
+		{IssueID: "A", Status: model.StatusInProgress, At: day(8)},
+		{IssueID: "A", Status: model.StatusClosed, At: day(7)},
+		{IssueID: "A", Status: model.StatusOpen, At: day(5)},
+		{IssueID: "A", Status: model.StatusClosed, At: day(3)},
+	}
+
+	report := ComputeFlowReport(issues, transitions, FlowConfig{Days: 10, Now: now})
+
+	if got := report.Project.LeadTime.MaxDays; got != 6 {
+		t.Errorf("expected lead time of 6 days to the final close, got %v", got)
+	}
+	if got := report.Project.CycleTime.MaxDays; got != 5 {
+		t.Errorf("expected cycle time of 5 days from first claim, got %v", got)
+	}
+	if report.Project.Throughput.Total != 1 {
+		t.Errorf("reopened issue should count once, got %d", report.Project.Throughput.Total)
+	}
+}
+
+func TestFlowCycleBounds_ReopenToInProgress(t *testing.T) {
+	now := flowTestNow()
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	tl := flowTimeline{transitions: []StatusTransition{
+		{IssueID: "A", Status: model.StatusOpen, At: day(9)},
+		{IssueID: "A", Status: model.StatusInProgress, At: day(8)},
+		{IssueID: "A", Status: model.StatusClosed, At: day(7)},
+		{IssueID: "A", Status: model.StatusInProgress, At: day(5)},
+	}}
+
+	firstProgress, closedAt := flowCycleBounds(tl)
+	if !firstProgress.Equal(day(8)) || !closedAt.IsZero() {
+		t.Errorf("reopened work should not keep the old close, got progress=%v closed=%v", firstProgress, closedAt)
+	}
+}
+
+func TestComputeDurationStats(t *testing.T) {
+	stats := computeDurationStats([]float64{5, 1, 3, 2, 4})
+	if stats.Count != 5 || stats.MinDays != 1 || stats.MaxDays != 5 {
+		t.Errorf("unexpected bounds: %+v", stats)
+	}
+	if stats.MeanDays != 3 || stats.MedianDays != 3 {
+		t.Errorf("unexpected center: %+v", stats)
+	}
+	if stats.P85Days != 5 || stats.P95Days != 5 {
+		t.Errorf("unexpected percentiles: %+v", stats)
+	}
+	if empty := computeDurationStats(nil); empty.Count != 0 {
+		t.Errorf("expected zero stats for no samples, got %+v", empty)
+	}
+}
+
+func TestComputeFlowReport_ClosedAtBeatsImportCommit(t *testing.T) {
+	now := flowTestNow()
+	created := now.AddDate(0, 0, -20)
+	closedAt := now.AddDate(0, 0, -15)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, CreatedAt: created, ClosedAt: &closedAt},
+	}
+	// The bead first appeared in git already closed, long after it was closed.
+	transitions := []StatusTransition{
+		{IssueID: "A", Status: model.StatusClosed, At: now.AddDate(0, 0, -1)},
+	}
+
+	report := ComputeFlowReport(issues, transitions, FlowConfig{Days: 7, Now: now})
+
+	if got := report.Project.LeadTime.MedianDays; got != 5 {
+		t.Errorf("expected lead time from closed_at (5 days), got %v", got)
+	}
+	if report.Project.Throughput.Total != 0 {
+		t.Errorf("closure happened before the window, got throughput %d", report.Project.Throughput.Total)
+	}
+}
@@ -0,0 +1,556 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formulaEnv supplies variable values and label lookups while evaluating a
+// compiled formula for one issue.
+type formulaEnv struct {
+	vars   map[string]float64
+	labels []string
+}
+
+// formulaExpr is a compiled, side-effect free scoring expression. It only
+// does arithmetic over known variables and a fixed set of pure functions, so
+// it is safe to evaluate user-supplied formulas.
+type formulaExpr struct {
+	source string
+	root   exprNode
+	idents []string // variables referenced, sorted
+}
+
+// Eval evaluates the expression. Non-finite results evaluate to 0.
+func (e *formulaExpr) Eval(env formulaEnv) float64 {
+	return finiteOrZero(e.root.eval(env))
+}
+
+// Expr is a compiled formula for use outside triage scoring, such as custom
+// drift rules. It shares the grammar and built-in functions of triage
+// formulas.
+type Expr struct {
+	expr *formulaExpr
+}
+
+// CompileExpr compiles src, allowing only the given variable names.
+func CompileExpr(src string, vars []string) (*Expr, error) {
+	known := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		known[v] = true
+	}
+	expr, err := compileFormula(src, known)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{expr: expr}, nil
+}
+
+// Eval evaluates the expression. Missing variables are 0 and labels back
+// has_label and label_value.
+func (e *Expr) Eval(vars map[string]float64, labels []string) float64 {
+	return e.expr.Eval(formulaEnv{vars: vars, labels: labels})
+}
+
+type exprNode interface {
+	eval(env formulaEnv) float64
+}
+
+type (
+	numNode   float64
+	strNode   string
+	identNode string
+	unaryNode struct {
+		op string
+		x  exprNode
+	}
+	binaryNode struct {
+		op   string
+		l, r exprNode
+	}
+	condNode struct {
+		cond, then, els exprNode
+	}
+	callNode struct {
+		fn   string
+		args []exprNode
+	}
+)
+
+func (n numNode) eval(formulaEnv) float64 { return float64(n) }
+
+// strNode only appears as a function argument and is never evaluated.
+func (n strNode) eval(formulaEnv) float64 { return 0 }
+
+func (n identNode) eval(env formulaEnv) float64 { return env.vars[string(n)] }
+
+func (n unaryNode) eval(env formulaEnv) float64 {
+	v := n.x.eval(env)
+	if n.op == "!" {
+		return boolToFloat(v == 0)
+	}
+	return -v
+}
+
+func (n binaryNode) eval(env formulaEnv) float64 {
+	// Short-circuit logical operators.
+	switch n.op {
+	case "&&":
+		return boolToFloat(n.l.eval(env) != 0 && n.r.eval(env) != 0)
+	case "||":
+		return boolToFloat(n.l.eval(env) != 0 || n.r.eval(env) != 0)
+	}
+	l, r := n.l.eval(env), n.r.eval(env)
+	switch n.op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	case "%":
+		if r == 0 {
+			return 0
+		}
+		return math.Mod(l, r)
+	case "^":
+		return finiteOrZero(math.Pow(l, r))
+	case "<":
+		return boolToFloat(l < r)
+	case "<=":
+		return boolToFloat(l <= r)
+	case ">":
+		return boolToFloat(l > r)
+	case ">=":
+		return boolToFloat(l >= r)
+	case "==":
+		return boolToFloat(l == r)
+	case "!=":
+		return boolToFloat(l != r)
+	}
+	return 0
+}
+
+func (n condNode) eval(env formulaEnv) float64 {
+	if n.cond.eval(env) != 0 {
+		return n.then.eval(env)
+	}
+	return n.els.eval(env)
+}
+
+func (n callNode) eval(env formulaEnv) float64 {
+	num := func(i int) float64 { return n.args[i].eval(env) }
+	switch n.fn {
+	case "min":
+		v := num(0)
+		for i := 1; i < len(n.args); i++ {
+			v = math.Min(v, num(i))
+		}
+		return v
+	case "max":
+		v := num(0)
+		for i := 1; i < len(n.args); i++ {
+			v = math.Max(v, num(i))
+		}
+		return v
+	case "abs":
+		return math.Abs(num(0))
+	case "sqrt":
+		if v := num(0); v > 0 {
+			return math.Sqrt(v)
+		}
+		return 0
+	case "log":
+		// log(1+x) so that zero-valued signals stay at zero.
+		if v := num(0); v > 0 {
+			return math.Log1p(v)
+		}
+		return 0
+	case "clamp":
+		return clampFloat(num(0), num(1), num(2))
+	case "has_label":
+		return boolToFloat(hasLabel(env.labels, string(n.args[0].(strNode))))
+	case "label_value":
+		if v, ok := labelValue(env.labels, string(n.args[0].(strNode))); ok {
+			return v
+		}
+		return num(1)
+	}
+	return 0
+}
+
+// formulaFunc describes a built-in function's signature. String arguments
+// must be literals so that formulas stay statically checkable.
+type formulaFunc struct {
+	minArgs, maxArgs int // maxArgs < 0 means variadic
+	stringArgs       map[int]bool
+}
+
+var formulaFuncs = map[string]formulaFunc{
+	"min":         {minArgs: 2, maxArgs: -1},
+	"max":         {minArgs: 2, maxArgs: -1},
+	"abs":         {minArgs: 1, maxArgs: 1},
+	"sqrt":        {minArgs: 1, maxArgs: 1},
+	"log":         {minArgs: 1, maxArgs: 1},
+	"clamp":       {minArgs: 3, maxArgs: 3},
+	"has_label":   {minArgs: 1, maxArgs: 1, stringArgs: map[int]bool{0: true}},
+	"label_value": {minArgs: 2, maxArgs: 2, stringArgs: map[int]bool{0: true}},
+}
+
+// labelValue finds a "name:value" or "name=value" label and parses the
+// value as a number.
+func labelValue(labels []string, name string) (float64, bool) {
+	prefix := strings.ToLower(name)
+	for _, l := range labels {
+		lower := strings.ToLower(l)
+		for _, sep := range []string{":", "="} {
+			if rest, ok := strings.CutPrefix(lower, prefix+sep); ok {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(rest), 64); err == nil {
+					return v, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// compileFormula parses src and checks that every identifier is in known and
+// every function call matches a built-in signature.
+func compileFormula(src string, known map[string]bool) (*formulaExpr, error) {
+	tokens, err := tokenizeFormula(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &formulaParser{tokens: tokens, known: known, used: map[string]bool{}}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	if _, ok := root.(strNode); ok {
+		return nil, fmt.Errorf("formula must be numeric, not a string")
+	}
+
+	idents := make([]string, 0, len(p.used))
+	for id := range p.used {
+		idents = append(idents, id)
+	}
+	sort.Strings(idents)
+	return &formulaExpr{source: src, root: root, idents: idents}, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNum
+	tokStr
+	tokIdent
+	tokOp
+)
+
+type formulaToken struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func tokenizeFormula(src string) ([]formulaToken, error) {
+	var tokens []formulaToken
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", text, start)
+			}
+			tokens = append(tokens, formulaToken{kind: tokNum, text: text, num: v, pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, formulaToken{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, formulaToken{kind: tokStr, text: string(runes[start+1 : i]), pos: start})
+			i++
+		default:
+			start := i
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+			switch two {
+			case "<=", ">=", "==", "!=", "&&", "||":
+				tokens = append(tokens, formulaToken{kind: tokOp, text: two, pos: start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("+-*/%^<>!?:(),", r) {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, start)
+			}
+			tokens = append(tokens, formulaToken{kind: tokOp, text: string(r), pos: start})
+			i++
+		}
+	}
+	return append(tokens, formulaToken{kind: tokEOF, text: "end of formula", pos: len(runes)}), nil
+}
+
+// formulaParser is a recursive-descent parser. Precedence, lowest first:
+// ?: || && comparisons +- */% unary ^
+type formulaParser struct {
+	tokens []formulaToken
+	pos    int
+	known  map[string]bool
+	used   map[string]bool
+}
+
+func (p *formulaParser) peek() formulaToken { return p.tokens[p.pos] }
+
+func (p *formulaParser) next() formulaToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *formulaParser) acceptOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *formulaParser) expectOp(op string) error {
+	if _, ok := p.acceptOp(op); !ok {
+		tok := p.peek()
+		return fmt.Errorf("expected %q at position %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (p *formulaParser) parseExpr() (exprNode, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.acceptOp("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(":"); err != nil {
+		return nil, err
+	}
+	els, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range []exprNode{cond, then, els} {
+		if err := checkNumeric(n, "?:"); err != nil {
+			return nil, err
+		}
+	}
+	return condNode{cond: cond, then: then, els: els}, nil
+}
+
+var formulaPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"<", "<=", ">", ">=", "==", "!="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *formulaParser) parseBinary(level int) (exprNode, error) {
+	if level == len(formulaPrecedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp(formulaPrecedence[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNumeric(left, op); err != nil {
+			return nil, err
+		}
+		if err := checkNumeric(right, op); err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, l: left, r: right}
+	}
+}
+
+func (p *formulaParser) parseUnary() (exprNode, error) {
+	if op, ok := p.acceptOp("-", "!"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNumeric(x, op); err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op, x: x}, nil
+	}
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.acceptOp("^"); ok {
+		exp, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNumeric(base, "^"); err != nil {
+			return nil, err
+		}
+		if err := checkNumeric(exp, "^"); err != nil {
+			return nil, err
+		}
+		return binaryNode{op: "^", l: base, r: exp}, nil
+	}
+	return base, nil
+}
+
+func (p *formulaParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNum:
+		return numNode(tok.num), nil
+	case tokStr:
+		return strNode(tok.text), nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return numNode(1), nil
+		case "false":
+			return numNode(0), nil
+		}
+		if _, ok := p.acceptOp("("); ok {
+			return p.parseCall(tok)
+		}
+		if !p.known[tok.text] {
+			return nil, fmt.Errorf("unknown variable %q at position %d", tok.text, tok.pos)
+		}
+		p.used[tok.text] = true
+		return identNode(tok.text), nil
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *formulaParser) parseCall(name formulaToken) (exprNode, error) {
+	fn, ok := formulaFuncs[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	var args []exprNode
+	if _, ok := p.acceptOp(")"); !ok {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.acceptOp(","); ok {
+				continue
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, fmt.Errorf("%s() at position %d: wrong number of arguments (%d)", name.text, name.pos, len(args))
+	}
+	for i, arg := range args {
+		_, isStr := arg.(strNode)
+		if fn.stringArgs[i] && !isStr {
+			return nil, fmt.Errorf("%s() at position %d: argument %d must be a quoted string", name.text, name.pos, i+1)
+		}
+		if !fn.stringArgs[i] && isStr {
+			return nil, fmt.Errorf("%s() at position %d: argument %d must be numeric", name.text, name.pos, i+1)
+		}
+	}
+	return callNode{fn: name.text, args: args}, nil
+}
+
+func checkNumeric(n exprNode, op string) error {
+	if s, ok := n.(strNode); ok {
+		return fmt.Errorf("operator %q cannot be applied to string %q", op, string(s))
+	}
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func finiteOrZero(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
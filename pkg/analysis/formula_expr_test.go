@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCompileFormula_Eval(t *testing.T) {
+	known := map[string]bool{"a": true, "b": true, "zero": true}
+	env := formulaEnv{
+		vars:   map[string]float64{"a": 2, "b": 3},
+		labels: []string{"Security", "effort:5", "team=ops"},
+	}
+
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-a + b", 1},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"a / zero", 0},
+		{"7 % 4", 3},
+		{"a < b && b < 4", 1},
+		{"a > b || !(a == 2)", 0},
+		{"a != b ? 10 : 20", 10},
+		{"zero ? 1 : a ? 2 : 3", 2},
+		{"min(a, b, 1)", 1},
+		{"max(a, b)", 3},
+		{"abs(-a)", 2},
+		{"sqrt(-1)", 0},
+		{"clamp(b, 0, a)", 2},
+		{"has_label('security')", 1},
+		{"has_label(\"backend\")", 0},
+		{"label_value('effort', 1)", 5},
+		{"label_value('team', 1)", 1},
+		{"label_value('size', 8)", 8},
+		{"true + false", 1},
+		{".5 * 4", 2},
+	}
+	for _, tt := range tests {
+		expr, err := compileFormula(tt.src, known)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.src, err)
+			continue
+		}
+		if got := expr.Eval(env); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%q = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+
+	expr, _ := compileFormula("log(a) + b * a", known)
+	if strings.Join(expr.idents, ",") != "a,b" {
+		t.Errorf("expected referenced idents a,b, got %v", expr.idents)
+	}
+}
+
+func TestCompileFormula_Errors(t *testing.T) {
+	known := map[string]bool{"a": true}
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{"a +", "unexpected \"end of formula\""},
+		{"a + c", "unknown variable \"c\""},
+		{"exec('rm')", "unknown function \"exec\""},
+		{"min(a)", "wrong number of arguments"},
+		{"has_label(a)", "must be a quoted string"},
+		{"abs('x')", "must be numeric"},
+		{"'x' + 1", "cannot be applied to string"},
+		{"'x'", "must be numeric"},
+		{"a ? 'x' : 1", "cannot be applied to string"},
+		{"(a", "expected \")\""},
+		{"a $ 1", "unexpected character"},
+		{"'open", "unterminated string"},
+		{"1.2.3", "invalid number"},
+		{"a a", "unexpected \"a\""},
+	}
+	for _, tt := range tests {
+		_, err := compileFormula(tt.src, known)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.src, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCompileExpr(t *testing.T) {
+	expr, err := CompileExpr("count > 2 && has_label('payments')", []string{"count"})
+	if err != nil {
+		t.Fatalf("CompileExpr: %v", err)
+	}
+	if got := expr.Eval(map[string]float64{"count": 3}, []string{"payments"}); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+	if got := expr.Eval(nil, []string{"payments"}); got != 0 {
+		t.Errorf("expected missing variables to be 0, got %v", got)
+	}
+	if _, err := CompileExpr("count + other", []string{"count"}); err == nil || !strings.Contains(err.Error(), "unknown variable \"other\"") {
+		t.Errorf("expected an unknown variable error, got %v", err)
+	}
+}
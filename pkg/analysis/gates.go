@@ -0,0 +1,375 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/flow"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// GateIssue is an open issue together with the amount of work it gates.
+type GateIssue struct {
+	IssueID   string       `json:"issue_id"`
+	Title     string       `json:"title"`
+	Status    model.Status `json:"status"`
+	Dominates int          `json:"dominates"` // Issues that every path to them passes through this one
+}
+
+// EpicGateChain lists the issues that every requirement path from an epic
+// down to a target passes through: finishing the target only helps the epic
+// once these are done too.
+type EpicGateChain struct {
+	EpicID string      `json:"epic_id"`
+	Title  string      `json:"title"`
+	Chain  []GateIssue `json:"chain"` // Nearest to the target first; Dominates counts epic work only
+}
+
+// GateReport answers "what gates this issue and what does it gate".
+type GateReport struct {
+	IssueID string       `json:"issue_id"`
+	Title   string       `json:"title"`
+	Status  model.Status `json:"status"`
+	// GatingChain holds the issues every path to this one goes through,
+	// outermost first and the immediate dominator last.
+	GatingChain []GateIssue `json:"gating_chain"`
+	// Dominates counts the issues that cannot start until this one is done.
+	Dominates int      `json:"dominates"`
+	Dominated []string `json:"dominated,omitempty"`
+	// Epics lists each open epic whose sub-project contains this issue.
+	Epics []EpicGateChain `json:"epics,omitempty"`
+	// SubprojectGates is set for epics: the issues that funnel the largest
+	// share of the epic's sub-project, largest first.
+	SubprojectGates []GateIssue `json:"subproject_gates,omitempty"`
+}
+
+// GateAnalysis holds dominator trees over the open blocking graph.
+//
+// The requirement graph has an edge u→v when u cannot finish before v: u has
+// a blocking dependency on v, or v is a parent-child child of u. Execution
+// runs the other way, from prerequisites to the work they unblock, starting
+// at a virtual start node wired to every issue without open requirements.
+// An issue Y dominates X when every execution path to X passes through Y,
+// so X cannot start until Y is done. Rooting the requirement graph at an epic
+// gives its post-dominator tree: Y gates X's contribution to the epic when
+// every path from X to the epic runs through Y.
+type GateAnalysis struct {
+	ids    []string
+	index  map[string]int
+	issues map[string]model.Issue
+	reqs   [][]int // requirement edges, sorted
+
+	idom     []int // immediate dominator from the virtual start (-1 = start)
+	children [][]int
+	size     []int // dominated set size (subtree size - 1)
+
+	epicTrees map[int]*epicGateTree
+}
+
+type epicGateTree struct {
+	ipdom map[int]int // immediate post-dominator toward the epic
+	size  map[int]int // sub-project issues funnelled through the node
+}
+
+// ComputeGates builds dominator trees for the open issues.
+func ComputeGates(issues []model.Issue) *GateAnalysis {
+	ga := &GateAnalysis{
+		index:     make(map[string]int),
+		issues:    make(map[string]model.Issue),
+		epicTrees: make(map[int]*epicGateTree),
+	}
+	for _, issue := range issues {
+		if isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		if _, dup := ga.issues[issue.ID]; dup {
+			continue
+		}
+		ga.issues[issue.ID] = issue
+		ga.ids = append(ga.ids, issue.ID)
+	}
+	sort.Strings(ga.ids)
+	for i, id := range ga.ids {
+		ga.index[id] = i
+	}
+
+	n := len(ga.ids)
+	reqSets := make([]map[int]bool, n)
+	addReq := func(from, to int) {
+		if from == to {
+			return
+		}
+		if reqSets[from] == nil {
+			reqSets[from] = make(map[int]bool)
+		}
+		reqSets[from][to] = true
+	}
+	for i, id := range ga.ids {
+		for _, dep := range ga.issues[id].Dependencies {
+			if dep == nil {
+				continue
+			}
+			j, ok := ga.index[dep.DependsOnID]
+			if !ok {
+				continue
+			}
+			switch {
+			case dep.Type.IsBlocking():
+				addReq(i, j)
+			case dep.Type == model.DepParentChild:
+				addReq(j, i)
+			}
+		}
+	}
+	ga.reqs = make([][]int, n)
+	for i, set := range reqSets {
+		for j := range set {
+			ga.reqs[i] = append(ga.reqs[i], j)
+		}
+		sort.Ints(ga.reqs[i])
+	}
+
+	ga.buildStartTree()
+	return ga
+}
+
+// buildStartTree computes the dominator tree of the execution graph from
+// the virtual start node.
+func (ga *GateAnalysis) buildStartTree() {
+	n := len(ga.ids)
+	start := int64(n)
+	g := simple.NewDirectedGraph()
+	g.AddNode(simple.Node(start))
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	dependents := make([][]int, n)
+	for u, reqs := range ga.reqs {
+		for _, v := range reqs {
+			g.SetEdge(simple.Edge{F: simple.Node(v), T: simple.Node(u)})
+			dependents[v] = append(dependents[v], u)
+		}
+	}
+
+	// Issues without open requirements can start right away. Cycles with no
+	// way in are entered at their lowest ID so every issue gets a tree node.
+	reached := make([]bool, n)
+	var visit func(int)
+	visit = func(v int) {
+		stack := []int{v}
+		reached[v] = true
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range dependents[u] {
+				if !reached[w] {
+					reached[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if len(ga.reqs[i]) == 0 {
+			g.SetEdge(simple.Edge{F: simple.Node(start), T: simple.Node(i)})
+			visit(i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !reached[i] {
+			g.SetEdge(simple.Edge{F: simple.Node(start), T: simple.Node(i)})
+			visit(i)
+		}
+	}
+
+	tree := flow.Dominators(simple.Node(start), g)
+	ga.idom = make([]int, n)
+	ga.children = make([][]int, n)
+	var roots []int
+	for i := 0; i < n; i++ {
+		ga.idom[i] = -1
+		if d := tree.DominatorOf(int64(i)); d != nil && d.ID() != start {
+			ga.idom[i] = int(d.ID())
+			ga.children[d.ID()] = append(ga.children[d.ID()], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+	ga.size = subtreeSizes(roots, func(v int) []int { return ga.children[v] }, n)
+}
+
+// subtreeSizes returns the number of proper descendants of every node in a
+// forest, walking it breadth-first and accumulating in reverse.
+func subtreeSizes(roots []int, children func(int) []int, n int) []int {
+	order := append([]int(nil), roots...)
+	for i := 0; i < len(order); i++ {
+		order = append(order, children(order[i])...)
+	}
+	size := make([]int, n)
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		for _, c := range children(v) {
+			size[v] += size[c] + 1
+		}
+	}
+	return size
+}
+
+// epicTree returns the post-dominator tree for an epic, computing it on
+// first use.
+func (ga *GateAnalysis) epicTree(epic int) *epicGateTree {
+	if tree, ok := ga.epicTrees[epic]; ok {
+		return tree
+	}
+	g := simple.NewDirectedGraph()
+	g.AddNode(simple.Node(epic))
+	seen := map[int]bool{epic: true}
+	stack := []int{epic}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, v := range ga.reqs[u] {
+			if !seen[v] {
+				seen[v] = true
+				g.AddNode(simple.Node(v))
+				stack = append(stack, v)
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	dom := flow.Dominators(simple.Node(epic), g)
+	tree := &epicGateTree{ipdom: make(map[int]int), size: make(map[int]int)}
+	children := make(map[int][]int)
+	for v := range seen {
+		if v == epic {
+			continue
+		}
+		if d := dom.DominatorOf(int64(v)); d != nil {
+			tree.ipdom[v] = int(d.ID())
+			children[int(d.ID())] = append(children[int(d.ID())], v)
+		}
+	}
+	sizes := subtreeSizes([]int{epic}, func(v int) []int { return children[v] }, len(ga.ids))
+	for v := range seen {
+		tree.size[v] = sizes[v]
+	}
+	ga.epicTrees[epic] = tree
+	return tree
+}
+
+// Has reports whether id is an open issue covered by the analysis.
+func (ga *GateAnalysis) Has(id string) bool {
+	_, ok := ga.index[id]
+	return ok
+}
+
+// DominatedCount returns how many issues cannot start until id is done.
+func (ga *GateAnalysis) DominatedCount(id string) int {
+	i, ok := ga.index[id]
+	if !ok {
+		return 0
+	}
+	return ga.size[i]
+}
+
+// Chain returns the issues every path to id goes through, outermost first.
+func (ga *GateAnalysis) Chain(id string) []string {
+	i, ok := ga.index[id]
+	if !ok {
+		return nil
+	}
+	var chain []string
+	for d := ga.idom[i]; d >= 0; d = ga.idom[d] {
+		chain = append(chain, ga.ids[d])
+	}
+	for l, r := 0, len(chain)-1; l < r; l, r = l+1, r-1 {
+		chain[l], chain[r] = chain[r], chain[l]
+	}
+	return chain
+}
+
+// TopGates returns the issues that dominate the most other work, largest
+// first. A limit of zero or less returns every issue that gates something.
+func (ga *GateAnalysis) TopGates(limit int) []GateIssue {
+	var gates []GateIssue
+	for i, id := range ga.ids {
+		if ga.size[i] > 0 {
+			gates = append(gates, ga.gateIssue(id, ga.size[i]))
+		}
+	}
+	sortGateIssues(gates)
+	if limit > 0 && len(gates) > limit {
+		gates = gates[:limit]
+	}
+	return gates
+}
+
+// Report describes the gates around a single issue. It returns nil for
+// closed or unknown issues.
+func (ga *GateAnalysis) Report(id string) *GateReport {
+	i, ok := ga.index[id]
+	if !ok {
+		return nil
+	}
+	issue := ga.issues[id]
+	report := &GateReport{
+		IssueID:     id,
+		Title:       issue.Title,
+		Status:      issue.Status,
+		GatingChain: []GateIssue{},
+		Dominates:   ga.size[i],
+	}
+	for _, gid := range ga.Chain(id) {
+		report.GatingChain = append(report.GatingChain, ga.gateIssue(gid, ga.size[ga.index[gid]]))
+	}
+
+	stack := append([]int(nil), ga.children[i]...)
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		report.Dominated = append(report.Dominated, ga.ids[v])
+		stack = append(stack, ga.children[v]...)
+	}
+	sort.Strings(report.Dominated)
+
+	for e, eid := range ga.ids {
+		if e == i || ga.issues[eid].IssueType != model.TypeEpic {
+			continue
+		}
+		tree := ga.epicTree(e)
+		if _, ok := tree.ipdom[i]; !ok {
+			continue
+		}
+		chain := EpicGateChain{EpicID: eid, Title: ga.issues[eid].Title, Chain: []GateIssue{}}
+		for d := tree.ipdom[i]; d != e; d = tree.ipdom[d] {
+			chain.Chain = append(chain.Chain, ga.gateIssue(ga.ids[d], tree.size[d]))
+		}
+		report.Epics = append(report.Epics, chain)
+	}
+
+	if issue.IssueType == model.TypeEpic {
+		tree := ga.epicTree(i)
+		for v, size := range tree.size {
+			if v != i && size > 0 {
+				report.SubprojectGates = append(report.SubprojectGates, ga.gateIssue(ga.ids[v], size))
+			}
+		}
+		sortGateIssues(report.SubprojectGates)
+	}
+	return report
+}
+
+func (ga *GateAnalysis) gateIssue(id string, dominates int) GateIssue {
+	issue := ga.issues[id]
+	return GateIssue{IssueID: id, Title: issue.Title, Status: issue.Status, Dominates: dominates}
+}
+
+func sortGateIssues(gates []GateIssue) {
+	sort.Slice(gates, func(a, b int) bool {
+		if gates[a].Dominates != gates[b].Dominates {
+			return gates[a].Dominates > gates[b].Dominates
+		}
+		return gates[a].IssueID < gates[b].IssueID
+	})
+}
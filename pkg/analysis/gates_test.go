@@ -0,0 +1,112 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func gateIssue(id string, typ model.IssueType, deps ...*model.Dependency) model.Issue {
+	return model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: typ, Dependencies: deps}
+}
+
+func blocks(id string) *model.Dependency {
+	return &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+}
+
+func childOf(id string) *model.Dependency {
+	return &model.Dependency{DependsOnID: id, Type: model.DepParentChild}
+}
+
+// gatesFixture: "schema" gates both "api" and "ui" through "model"; "docs"
+// is independent. The epic owns api, ui and docs, and ui also waits on api.
+func gatesFixture() []model.Issue {
+	return []model.Issue{
+		gateIssue("schema", model.TypeTask),
+		gateIssue("model", model.TypeTask, blocks("schema")),
+		gateIssue("api", model.TypeTask, blocks("model"), childOf("epic")),
+		gateIssue("ui", model.TypeTask, blocks("model"), blocks("api"), childOf("epic")),
+		gateIssue("docs", model.TypeTask, childOf("epic")),
+		gateIssue("epic", model.TypeEpic),
+		{ID: "done", Title: "done", Status: model.StatusClosed, Dependencies: []*model.Dependency{blocks("schema")}},
+	}
+}
+
+func gateIDs(gates []GateIssue) []string {
+	var ids []string
+	for _, g := range gates {
+		ids = append(ids, g.IssueID)
+	}
+	return ids
+}
+
+func TestComputeGates_DominatorTree(t *testing.T) {
+	ga := ComputeGates(gatesFixture())
+
+	wantCounts := map[string]int{"schema": 3, "model": 2, "api": 0, "ui": 0, "docs": 0, "epic": 0, "done": 0}
+	for id, want := range wantCounts {
+		if got := ga.DominatedCount(id); got != want {
+			t.Errorf("DominatedCount(%s) = %d, want %d", id, got, want)
+		}
+	}
+	// ui also waits on api, but model reaches it directly: api is no gate.
+	if got := ga.Chain("ui"); !reflect.DeepEqual(got, []string{"schema", "model"}) {
+		t.Errorf("unexpected chain for ui: %v", got)
+	}
+	// The epic also waits on docs, so nothing dominates it.
+	if got := ga.Chain("epic"); len(got) != 0 {
+		t.Errorf("expected an empty chain for epic, got %v", got)
+	}
+	if got := gateIDs(ga.TopGates(2)); !reflect.DeepEqual(got, []string{"schema", "model"}) {
+		t.Errorf("unexpected top gates: %v", got)
+	}
+	if ga.Has("done") || ga.Report("done") != nil {
+		t.Error("closed issues should be left out")
+	}
+}
+
+func TestComputeGates_Report(t *testing.T) {
+	ga := ComputeGates(gatesFixture())
+
+	report := ga.Report("model")
+	if report.Dominates != 2 || !reflect.DeepEqual(report.Dominated, []string{"api", "ui"}) {
+		t.Errorf("unexpected dominated set: %+v", report)
+	}
+	if got := gateIDs(report.GatingChain); !reflect.DeepEqual(got, []string{"schema"}) {
+		t.Errorf("unexpected gating chain: %v", got)
+	}
+	// model reaches the epic through api and ui; neither is on every path.
+	if len(report.Epics) != 1 || report.Epics[0].EpicID != "epic" || len(report.Epics[0].Chain) != 0 {
+		t.Errorf("unexpected epic chains: %+v", report.Epics)
+	}
+
+	// schema feeds the epic only through model.
+	schema := ga.Report("schema")
+	if len(schema.Epics) != 1 || !reflect.DeepEqual(gateIDs(schema.Epics[0].Chain), []string{"model"}) {
+		t.Errorf("unexpected epic chain for schema: %+v", schema.Epics)
+	}
+
+	epic := ga.Report("epic")
+	if got := gateIDs(epic.SubprojectGates); !reflect.DeepEqual(got, []string{"model"}) {
+		t.Errorf("unexpected sub-project gates: %v", got)
+	}
+	if epic.SubprojectGates[0].Dominates != 1 {
+		t.Errorf("expected model to funnel schema, got %+v", epic.SubprojectGates[0])
+	}
+}
+
+func TestComputeGates_Cycles(t *testing.T) {
+	// a and b block each other and gate c; nothing else gets in.
+	ga := ComputeGates([]model.Issue{
+		gateIssue("a", model.TypeTask, blocks("b")),
+		gateIssue("b", model.TypeTask, blocks("a")),
+		gateIssue("c", model.TypeTask, blocks("b")),
+	})
+	if got := ga.Chain("c"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("unexpected chain through the cycle: %v", got)
+	}
+	if ga.DominatedCount("a") != 2 {
+		t.Errorf("expected a to dominate the rest of the cycle, got %d", ga.DominatedCount("a"))
+	}
+}
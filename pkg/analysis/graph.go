@@ -101,6 +101,9 @@ type GraphStats struct {
 
 	// Phase 2 status flags for robot visibility
 	status MetricStatus
+
+	// Set when Phase 2 was patched from a previous run (AnalyzeIncremental)
+	incremental *IncrementalUpdate
 }
 
 // metricStatus captures per-metric computation outcome for transparency.
@@ -1127,31 +1130,7 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 		}
 	}
 
-	stats := &GraphStats{
-		OutDegree:         make(map[string]int),
-		InDegree:          make(map[string]int),
-		NodeCount:         nodeCount,
-		EdgeCount:         edgeCount,
-		Config:            config,
-		phase2Done:        make(chan struct{}),
-		pageRank:          make(map[string]float64),
-		betweenness:       make(map[string]float64),
-		eigenvector:       make(map[string]float64),
-		hubs:              make(map[string]float64),
-		authorities:       make(map[string]float64),
-		criticalPathScore: make(map[string]float64),
-		status: MetricStatus{
-			PageRank:     statusEntry{State: "pending"},
-			Betweenness:  statusEntry{State: "pending"},
-			Eigenvector:  statusEntry{State: "pending"},
-			HITS:         statusEntry{State: "pending"},
-			Critical:     statusEntry{State: "pending"},
-			Cycles:       statusEntry{State: "pending"},
-			KCore:        statusEntry{State: "pending"},
-			Articulation: statusEntry{State: "pending"},
-			Slack:        statusEntry{State: "pending"},
-		},
-	}
+	stats := newGraphStats(nodeCount, edgeCount, config)
 
 	// Handle empty graph - mark phase 2 ready immediately
 	if nodeCount == 0 {
@@ -1179,11 +1158,40 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	}
 
 	// Phase 2: Expensive metrics in background goroutine
-	go a.computePhase2(ctx, stats, config, robotCacheKey, dataHash, configHash)
+	go a.computePhase2(ctx, stats, config, robotCacheKey, dataHash, configHash, nil)
 
 	return stats
 }
 
+// newGraphStats allocates stats with every Phase 2 metric marked pending.
+func newGraphStats(nodeCount, edgeCount int, config AnalysisConfig) *GraphStats {
+	return &GraphStats{
+		OutDegree:         make(map[string]int),
+		InDegree:          make(map[string]int),
+		NodeCount:         nodeCount,
+		EdgeCount:         edgeCount,
+		Config:            config,
+		phase2Done:        make(chan struct{}),
+		pageRank:          make(map[string]float64),
+		betweenness:       make(map[string]float64),
+		eigenvector:       make(map[string]float64),
+		hubs:              make(map[string]float64),
+		authorities:       make(map[string]float64),
+		criticalPathScore: make(map[string]float64),
+		status: MetricStatus{
+			PageRank:     statusEntry{State: "pending"},
+			Betweenness:  statusEntry{State: "pending"},
+			Eigenvector:  statusEntry{State: "pending"},
+			HITS:         statusEntry{State: "pending"},
+			Critical:     statusEntry{State: "pending"},
+			Cycles:       statusEntry{State: "pending"},
+			KCore:        statusEntry{State: "pending"},
+			Articulation: statusEntry{State: "pending"},
+			Slack:        statusEntry{State: "pending"},
+		},
+	}
+}
+
 // Analyze performs synchronous graph analysis (for backward compatibility).
 // Blocks until all metrics are computed.
 func (a *Analyzer) Analyze() GraphStats {
@@ -1303,7 +1311,7 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 
 	// Phase 2: Expensive metrics synchronously with timing
 	phase2Start := time.Now()
-	a.computePhase2WithProfile(context.Background(), stats, config, profile)
+	a.computePhase2WithProfile(context.Background(), stats, config, profile, nil)
 	profile.Phase2 = time.Since(phase2Start)
 
 	stats.phase2Ready = true
@@ -1347,7 +1355,8 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 }
 
 // computePhase2WithProfile calculates expensive metrics with timing instrumentation.
-func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphStats, config AnalysisConfig, profile *StartupProfile) {
+// A non-nil seed patches the metrics of a previous run instead (see AnalyzeIncremental).
+func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphStats, config AnalysisConfig, profile *StartupProfile, seed *incrementalSeed) {
 	localPageRank := make(map[string]float64)
 	localBetweenness := make(map[string]float64)
 	localEigenvector := make(map[string]float64)
@@ -1362,6 +1371,11 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	betweennessIsApprox := false
 	actualBetweennessSample := 0
 	cyclesTruncated := false
+	update := seed.newUpdate()
+	var prIterations int
+	betweennessPatched := false
+	criticalPatched := false
+	slackPatched := false
 
 	// PageRank
 	if ctx.Err() == nil && config.ComputePageRank {
@@ -1373,7 +1387,9 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
+			pr, iterations := computePageRankFrom(a.g, 0.85, 1e-6, seed.pageRankStart(a))
+			prIterations = iterations
+			prDone <- pr
 		}()
 
 		timer := time.NewTimer(config.PageRankTimeout)
@@ -1383,6 +1399,9 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			for id, score := range pr {
 				localPageRank[a.nodeToID[id]] = score
 			}
+			if update != nil {
+				update.PageRankIterations = prIterations
+			}
 		case <-timer.C:
 			profile.PageRankTO = true
 			if len(a.issueMap) > 0 {
@@ -1409,6 +1428,10 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
+			if result, ok := seed.updateBetweenness(a, config); ok {
+				bwDone <- result
+				return
+			}
 			// Choose algorithm based on mode
 			if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
 				bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize, 1)
@@ -1435,6 +1458,12 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 				betweennessIsApprox = true
 				actualBetweennessSample = result.SampleSize
 			}
+			if result.Incremental {
+				betweennessPatched = true
+				update.BetweennessSources = result.SampleSize
+				update.BetweennessAffectedSources = result.AffectedSources
+				update.BetweennessErrorBound = result.ErrorBound
+			}
 		case <-timer.C:
 			profile.BetweennessTO = true
 		case <-ctx.Done():
@@ -1447,9 +1476,13 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	// Eigenvector
 	if ctx.Err() == nil && config.ComputeEigenvector {
 		evStart := time.Now()
-		for id, score := range computeEigenvector(a.g) {
+		scores, warm := computeEigenvectorFrom(a.g, seed.eigenvectorStart(a))
+		for id, score := range scores {
 			localEigenvector[a.nodeToID[id]] = score
 		}
+		if update != nil {
+			update.EigenvectorWarmStart = warm
+		}
 		profile.Eigenvector = time.Since(evStart)
 	}
 
@@ -1486,8 +1519,10 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	// Critical Path
 	if ctx.Err() == nil && config.ComputeCriticalPath {
 		cpStart := time.Now()
-		sorted, err := topo.Sort(a.g)
-		if err == nil {
+		if heights, ok := seed.updateHeights(a, stats.TopologicalOrder, update); ok {
+			localCriticalPath = heights
+			criticalPatched = true
+		} else if sorted, err := topo.Sort(a.g); err == nil {
 			localCriticalPath = a.computeHeights(sorted)
 		}
 		profile.CriticalPath = time.Since(cpStart)
@@ -1565,7 +1600,12 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 
 	if config.ComputeSlack {
 		slackStart := time.Now()
-		localSlack = a.computeSlack(stats.TopologicalOrder)
+		if slack, ok := seed.updateSlack(a, stats.TopologicalOrder); ok {
+			localSlack = slack
+			slackPatched = true
+		} else {
+			localSlack = a.computeSlack(stats.TopologicalOrder)
+		}
 		profile.Slack = time.Since(slackStart)
 	}
 
@@ -1589,6 +1629,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	stats.articulation = localArticulation
 	stats.slack = localSlack
 	stats.cycles = localCycles
+	stats.incremental = update
 
 	// Assign ranks
 	stats.pageRankRank = localPageRankRank
@@ -1625,6 +1666,27 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		Articulation: statusEntry{State: "computed", Elapsed: profile.Articulation}, // bv-85: computed with k-core
 		Slack:        statusEntry{State: "computed", Elapsed: profile.Slack},        // bv-85: always computed (fast)
 	}
+	if update != nil {
+		// Flag the metrics that were patched from the previous run.
+		if config.ComputePageRank && !profile.PageRankTO {
+			stats.status.PageRank.Reason = "incremental"
+		}
+		if update.EigenvectorWarmStart {
+			stats.status.Eigenvector.Reason = "incremental"
+		}
+		if betweennessPatched {
+			stats.status.Betweenness.Reason = "incremental"
+			if betweennessIsApprox {
+				stats.status.Betweenness.Reason = "incremental, approximate"
+			}
+		}
+		if criticalPatched {
+			stats.status.Critical.Reason = "incremental"
+		}
+		if slackPatched {
+			stats.status.Slack.Reason = "incremental"
+		}
+	}
 	stats.mu.Unlock()
 }
 
@@ -1672,7 +1734,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 // computePhase2 calculates expensive metrics in background.
 // Computes to local variables first, then atomically assigns under lock.
 // Respects the config to skip expensive algorithms for large graphs.
-func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config AnalysisConfig, cacheKey, dataHash, configHash string) {
+func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config AnalysisConfig, cacheKey, dataHash, configHash string, seed *incrementalSeed) {
 	defer close(stats.phase2Done)
 
 	// Recover from panics to prevent crashing the entire application
@@ -1703,7 +1765,7 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 	// Use the profiled version logic to avoid duplication
 	// We discard the profile data as this is the standard run
 	dummyProfile := &StartupProfile{}
-	a.computePhase2WithProfile(ctx, stats, config, dummyProfile)
+	a.computePhase2WithProfile(ctx, stats, config, dummyProfile, seed)
 
 	if cacheKey != "" {
 		putRobotDiskCachedStats(cacheKey, dataHash, configHash, stats)
@@ -2300,10 +2362,19 @@ func (a *Analyzer) countBlockedBy(issueID string) int {
 // It uses a deterministic power iteration with damping factor damp and terminates
 // when the L2 norm of the delta is below tol (or after a hard iteration cap).
 func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
+	ranks, _ := computePageRankFrom(g, damp, tol, nil)
+	return ranks
+}
+
+// computePageRankFrom is computePageRank with an optional starting vector.
+// Nodes missing from start begin at the uniform weight and the vector is
+// renormalized, so a previous result warm-starts the iteration after small
+// edits. It also returns the number of iterations used.
+func computePageRankFrom(g graph.Directed, damp, tol float64, start map[int64]float64) (map[int64]float64, int) {
 	nodes := graph.NodesOf(g.Nodes())
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
 	if len(nodes) == 0 {
-		return map[int64]float64{}
+		return map[int64]float64{}, 0
 	}
 	if tol <= 0 {
 		tol = 1e-6
@@ -2361,11 +2432,25 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	for i := range rank {
 		rank[i] = uniform
 	}
+	if start != nil {
+		total := 0.0
+		for i, node := range nodes {
+			if v, ok := start[node.ID()]; ok && v > 0 {
+				rank[i] = v
+			}
+			total += rank[i]
+		}
+		for i := range rank {
+			rank[i] /= total
+		}
+	}
 	next := make([]float64, len(nodes))
 
 	base := (1 - damp) / n
 	const maxIterations = 1000
+	iterations := 0
 	for iter := 0; iter < maxIterations; iter++ {
+		iterations++
 		for i := range next {
 			next[i] = base
 		}
@@ -2406,17 +2491,27 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 		ranks[node.ID()] = rank[i]
 	}
 
-	return ranks
+	return ranks, iterations
 }
 
 // computeEigenvector runs a simple power-iteration to estimate eigenvector centrality.
 func computeEigenvector(g graph.Directed) map[int64]float64 {
+	vec, _ := computeEigenvectorFrom(g, nil)
+	return vec
+}
+
+// computeEigenvectorFrom is computeEigenvector with an optional starting
+// vector. Both starts iterate until the vector stops moving (or the iteration
+// cap is hit); a warm start that does not converge (e.g. the iteration dies
+// out on a DAG) is redone from the cold start. The bool reports whether the
+// warm start was kept.
+func computeEigenvectorFrom(g graph.Directed, start map[int64]float64) (map[int64]float64, bool) {
 	nodeList := graph.NodesOf(g.Nodes())
 	sort.Slice(nodeList, func(i, j int) bool {
 		return nodeList[i].ID() < nodeList[j].ID()
 	})
 	if len(nodeList) == 0 {
-		return nil
+		return nil, false
 	}
 
 	// In this codebase, node IDs are densely allocated by gonum (0..n-1), so we
@@ -2475,7 +2570,18 @@ func computeEigenvector(g graph.Directed) map[int64]float64 {
 	}
 	work := make([]float64, n)
 
-	const iterations = 50
+	warm := start != nil
+	if warm {
+		for i, node := range nodeList {
+			vec[i] = start[node.ID()]
+		}
+	}
+	converged := false
+
+	const (
+		iterations = 50
+		tol        = 1e-6
+	)
 	for iter := 0; iter < iterations; iter++ {
 		for i := range work {
 			work[i] = 0
@@ -2493,16 +2599,26 @@ func computeEigenvector(g graph.Directed) map[int64]float64 {
 			break
 		}
 		norm := 1 / math.Sqrt(sum)
+		diff := 0.0
 		for i := range work {
-			vec[i] = work[i] * norm
+			v := work[i] * norm
+			diff += (v - vec[i]) * (v - vec[i])
+			vec[i] = v
 		}
+		if math.Sqrt(diff) < tol {
+			converged = true
+			break
+		}
+	}
+	if warm && !converged {
+		return computeEigenvectorFrom(g, nil)
 	}
 
 	res := make(map[int64]float64, n)
 	for i, node := range nodeList {
 		res[node.ID()] = vec[i]
 	}
-	return res
+	return res, warm
 }
 
 // computeFloatRanks computes rankings for a float map (descending).
@@ -0,0 +1,588 @@
+package analysis
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IncrementalUpdate summarizes how AnalyzeIncremental patched a previous
+// analysis instead of recomputing every metric from scratch.
+type IncrementalUpdate struct {
+	AddedNodes   int `json:"added_nodes"`
+	RemovedNodes int `json:"removed_nodes"`
+	AddedEdges   int `json:"added_edges"`
+	RemovedEdges int `json:"removed_edges"`
+
+	// PageRankIterations is the number of power iterations needed after
+	// warm-starting from the previous vector.
+	PageRankIterations int `json:"pagerank_iterations"`
+	// EigenvectorWarmStart is false when the warm start did not converge and
+	// the cold iteration was used instead.
+	EigenvectorWarmStart bool `json:"eigenvector_warm_start"`
+
+	// Critical path and slack are only recomputed inside the weakly connected
+	// components that contain an edited node.
+	ComponentsRecomputed int `json:"components_recomputed"`
+	NodesRecomputed      int `json:"nodes_recomputed"`
+
+	// BetweennessSources is how many Brandes sources were re-run out of the
+	// BetweennessAffectedSources whose shortest paths the edit could change.
+	// BetweennessErrorBound is a 95% bound on the absolute per-node error
+	// introduced by sampling those sources (0 when all of them were re-run).
+	BetweennessSources         int     `json:"betweenness_sources"`
+	BetweennessAffectedSources int     `json:"betweenness_affected_sources"`
+	BetweennessErrorBound      float64 `json:"betweenness_error_bound"`
+}
+
+// Incremental returns how this analysis reused a previous run, or nil when
+// every metric was computed from scratch.
+func (s *GraphStats) Incremental() *IncrementalUpdate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.incremental == nil {
+		return nil
+	}
+	update := *s.incremental
+	return &update
+}
+
+// incrementalMaxTouched caps how many nodes an edit may touch before patching
+// the previous metrics stops being cheaper than a full recomputation.
+func incrementalMaxTouched(nodeCount int) int {
+	if nodeCount/10 > 16 {
+		return nodeCount / 10
+	}
+	return 16
+}
+
+// AnalyzeIncremental is AnalyzeAsync for a graph produced by a small edit of
+// prev. Phase 2 warm-starts PageRank and eigenvector centrality from
+// prevStats, recomputes critical path and slack only in the components the
+// edit touched, and re-runs betweenness only from the sources whose shortest
+// paths could have changed. diff (from ComputeIssueDiff) narrows the search
+// for structural changes; pass nil to compare the whole graph.
+//
+// It falls back to a full AnalyzeAsync when prevStats is not usable (Phase 2
+// still running, different config) or when the edit is too large. When the
+// blocking graph is unchanged, prevStats itself is returned.
+func (a *Analyzer) AnalyzeIncremental(ctx context.Context, prev *Analyzer, prevStats *GraphStats, diff *IssueDiff) *GraphStats {
+	var config AnalysisConfig
+	if a.config != nil {
+		config = *a.config
+	} else {
+		config = ConfigForSize(len(a.issueMap), a.g.Edges().Len())
+	}
+	return a.AnalyzeIncrementalWithConfig(ctx, config, prev, prevStats, diff)
+}
+
+// AnalyzeIncrementalWithConfig is AnalyzeIncremental with a custom configuration.
+func (a *Analyzer) AnalyzeIncrementalWithConfig(ctx context.Context, config AnalysisConfig, prev *Analyzer, prevStats *GraphStats, diff *IssueDiff) *GraphStats {
+	if prev == nil || prevStats == nil || len(a.issueMap) == 0 || robotDiskCacheEnabled() ||
+		!prevStats.IsPhase2Ready() || ComputeConfigHash(&prevStats.Config) != ComputeConfigHash(&config) {
+		return a.AnalyzeAsyncWithConfig(ctx, config)
+	}
+
+	delta := diffGraphStructure(prev, a, diff)
+	if len(delta.touched) == 0 {
+		// Same nodes, same blocking edges: every graph metric carries over.
+		return prevStats
+	}
+	if len(delta.touched) > incrementalMaxTouched(len(a.issueMap)) {
+		return a.AnalyzeAsyncWithConfig(ctx, config)
+	}
+
+	stats := newGraphStats(len(a.issueMap), a.g.Edges().Len(), config)
+	seed := &incrementalSeed{prev: prev, stats: prevStats, delta: delta}
+	stats.incremental = seed.newUpdate()
+
+	a.computePhase1(stats)
+	go a.computePhase2(ctx, stats, config, "", "", "", seed)
+	return stats
+}
+
+type graphEdgeKey struct {
+	from string
+	to   string
+}
+
+// graphDelta is the structural difference between two analyzer graphs.
+type graphDelta struct {
+	addedNodes   []string
+	removedNodes []string
+	addedEdges   []graphEdgeKey
+	removedEdges []graphEdgeKey
+	// touched holds every added or removed node plus the endpoints of every
+	// added or removed edge, sorted.
+	touched []string
+}
+
+// diffGraphStructure compares the blocking graphs of prev and next. Only the
+// issues named in diff are inspected when it is non-nil.
+func diffGraphStructure(prev, next *Analyzer, diff *IssueDiff) graphDelta {
+	var candidates []string
+	if diff != nil {
+		candidates = append(candidates, diff.Added...)
+		candidates = append(candidates, diff.Removed...)
+		candidates = append(candidates, diff.DependencyChanged...)
+	} else {
+		for id := range prev.issueMap {
+			candidates = append(candidates, id)
+		}
+		for id := range next.issueMap {
+			if _, ok := prev.issueMap[id]; !ok {
+				candidates = append(candidates, id)
+			}
+		}
+	}
+
+	var delta graphDelta
+	added := make(map[graphEdgeKey]bool)
+	removed := make(map[graphEdgeKey]bool)
+	touched := make(map[string]bool)
+	for _, id := range candidates {
+		_, inPrev := prev.issueMap[id]
+		_, inNext := next.issueMap[id]
+		switch {
+		case inNext && !inPrev:
+			delta.addedNodes = append(delta.addedNodes, id)
+			touched[id] = true
+		case inPrev && !inNext:
+			delta.removedNodes = append(delta.removedNodes, id)
+			touched[id] = true
+		}
+
+		oldOut, oldIn := prev.neighborIDs(id)
+		newOut, newIn := next.neighborIDs(id)
+		for to := range newOut {
+			if !oldOut[to] {
+				added[graphEdgeKey{from: id, to: to}] = true
+			}
+		}
+		for to := range oldOut {
+			if !newOut[to] {
+				removed[graphEdgeKey{from: id, to: to}] = true
+			}
+		}
+		for from := range newIn {
+			if !oldIn[from] {
+				added[graphEdgeKey{from: from, to: id}] = true
+			}
+		}
+		for from := range oldIn {
+			if !newIn[from] {
+				removed[graphEdgeKey{from: from, to: id}] = true
+			}
+		}
+	}
+
+	for e := range added {
+		delta.addedEdges = append(delta.addedEdges, e)
+		touched[e.from] = true
+		touched[e.to] = true
+	}
+	for e := range removed {
+		delta.removedEdges = append(delta.removedEdges, e)
+		touched[e.from] = true
+		touched[e.to] = true
+	}
+	for id := range touched {
+		delta.touched = append(delta.touched, id)
+	}
+
+	sort.Strings(delta.addedNodes)
+	sort.Strings(delta.removedNodes)
+	sort.Strings(delta.touched)
+	sortEdgeKeys(delta.addedEdges)
+	sortEdgeKeys(delta.removedEdges)
+	return delta
+}
+
+func sortEdgeKeys(edges []graphEdgeKey) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		return edges[i].to < edges[j].to
+	})
+}
+
+// neighborIDs returns the issues id depends on (out) and the issues that
+// depend on it (in). Both are empty when id is not in the graph.
+func (a *Analyzer) neighborIDs(id string) (out, in map[string]bool) {
+	out = make(map[string]bool)
+	in = make(map[string]bool)
+	nodeID, ok := a.idToNode[id]
+	if !ok {
+		return out, in
+	}
+	from := a.g.From(nodeID)
+	for from.Next() {
+		out[a.nodeToID[from.Node().ID()]] = true
+	}
+	to := a.g.To(nodeID)
+	for to.Next() {
+		in[a.nodeToID[to.Node().ID()]] = true
+	}
+	return out, in
+}
+
+// incrementalSeed carries the previous analysis into a warm-started Phase 2.
+// All methods are nil-safe so the full path can pass a nil seed.
+type incrementalSeed struct {
+	prev  *Analyzer
+	stats *GraphStats
+	delta graphDelta
+
+	// component is the set of nodes (in the new graph) weakly connected to an
+	// edited node, computed on first use.
+	component map[int64]bool
+	compCount int
+}
+
+func (s *incrementalSeed) newUpdate() *IncrementalUpdate {
+	if s == nil {
+		return nil
+	}
+	return &IncrementalUpdate{
+		AddedNodes:   len(s.delta.addedNodes),
+		RemovedNodes: len(s.delta.removedNodes),
+		AddedEdges:   len(s.delta.addedEdges),
+		RemovedEdges: len(s.delta.removedEdges),
+	}
+}
+
+// reusable reports whether a previous metric finished and can seed the new run.
+func reusable(entry statusEntry) bool {
+	return entry.State == "computed" || entry.State == "approx"
+}
+
+// pageRankStart maps the previous PageRank vector onto the new graph's nodes.
+func (s *incrementalSeed) pageRankStart(a *Analyzer) map[int64]float64 {
+	if s == nil || !reusable(s.stats.Status().PageRank) {
+		return nil
+	}
+	return s.startVector(a, s.stats.PageRank())
+}
+
+// eigenvectorStart maps the previous eigenvector onto the new graph's nodes.
+func (s *incrementalSeed) eigenvectorStart(a *Analyzer) map[int64]float64 {
+	if s == nil || !reusable(s.stats.Status().Eigenvector) {
+		return nil
+	}
+	return s.startVector(a, s.stats.Eigenvector())
+}
+
+func (s *incrementalSeed) startVector(a *Analyzer, prev map[string]float64) map[int64]float64 {
+	if len(prev) == 0 {
+		return nil
+	}
+	start := make(map[int64]float64, len(a.idToNode))
+	for id, nodeID := range a.idToNode {
+		if v, ok := prev[id]; ok {
+			start[nodeID] = v
+		}
+	}
+	return start
+}
+
+// affected returns the nodes weakly connected to an edited node.
+func (s *incrementalSeed) affected(a *Analyzer) map[int64]bool {
+	if s.component != nil {
+		return s.component
+	}
+	s.component = make(map[int64]bool)
+	for _, id := range s.delta.touched {
+		root, ok := a.idToNode[id]
+		if !ok || s.component[root] {
+			continue
+		}
+		s.compCount++
+		s.component[root] = true
+		queue := []int64{root}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			visit := func(nbr int64) {
+				if !s.component[nbr] {
+					s.component[nbr] = true
+					queue = append(queue, nbr)
+				}
+			}
+			from := a.g.From(n)
+			for from.Next() {
+				visit(from.Node().ID())
+			}
+			to := a.g.To(n)
+			for to.Next() {
+				visit(to.Node().ID())
+			}
+		}
+	}
+	return s.component
+}
+
+// canPatchOrder reports whether order-based metrics can be patched: both the
+// previous and the new graph must be acyclic (non-empty topological order).
+func (s *incrementalSeed) canPatchOrder(order []string) bool {
+	return s != nil && len(order) > 0 && len(s.stats.TopologicalOrder) > 0 &&
+		reusable(s.stats.Status().Critical)
+}
+
+// updateHeights recomputes critical-path heights inside the affected
+// components and copies the previous heights everywhere else.
+func (s *incrementalSeed) updateHeights(a *Analyzer, order []string, update *IncrementalUpdate) (map[string]float64, bool) {
+	if !s.canPatchOrder(order) {
+		return nil, false
+	}
+	prevHeights := s.stats.CriticalPathScore()
+	comp := s.affected(a)
+
+	heights := make(map[string]float64, len(a.issueMap))
+	for id, nodeID := range a.idToNode {
+		if !comp[nodeID] {
+			heights[id] = prevHeights[id]
+		}
+	}
+
+	// Dependents come before their dependencies when walking order backwards,
+	// matching computeHeights' use of topo.Sort.
+	byNode := make(map[int64]float64, len(comp))
+	for i := len(order) - 1; i >= 0; i-- {
+		nodeID := a.idToNode[order[i]]
+		if !comp[nodeID] {
+			continue
+		}
+		maxParentHeight := 0.0
+		to := a.g.To(nodeID)
+		for to.Next() {
+			if h := byNode[to.Node().ID()]; h > maxParentHeight {
+				maxParentHeight = h
+			}
+		}
+		byNode[nodeID] = 1.0 + maxParentHeight
+		heights[order[i]] = byNode[nodeID]
+	}
+
+	update.ComponentsRecomputed = s.compCount
+	update.NodesRecomputed = len(comp)
+	return heights, true
+}
+
+// updateSlack recomputes longest-path distances inside the affected
+// components. Untouched nodes keep their previous through-path length
+// (previous longest path minus previous slack) and are only shifted when the
+// global longest path changes.
+func (s *incrementalSeed) updateSlack(a *Analyzer, order []string) (map[string]float64, bool) {
+	if !s.canPatchOrder(order) || !reusable(s.stats.Status().Slack) {
+		return nil, false
+	}
+	prevSlack := s.stats.Slack()
+	prevHeights := s.stats.CriticalPathScore()
+	if prevSlack == nil || len(prevHeights) == 0 {
+		return nil, false
+	}
+	prevLongest := 0.0
+	for _, h := range prevHeights {
+		if h-1 > prevLongest {
+			prevLongest = h - 1
+		}
+	}
+
+	comp := s.affected(a)
+	distFromStart := make(map[int64]float64, len(comp))
+	distToEnd := make(map[int64]float64, len(comp))
+	for i := len(order) - 1; i >= 0; i-- {
+		node := a.idToNode[order[i]]
+		if !comp[node] {
+			continue
+		}
+		from := a.g.From(node)
+		for from.Next() {
+			dep := from.Node().ID()
+			if distFromStart[dep] < distFromStart[node]+1 {
+				distFromStart[dep] = distFromStart[node] + 1
+			}
+		}
+	}
+	for _, id := range order {
+		node := a.idToNode[id]
+		if !comp[node] {
+			continue
+		}
+		from := a.g.From(node)
+		for from.Next() {
+			dep := from.Node().ID()
+			if distToEnd[node] < distToEnd[dep]+1 {
+				distToEnd[node] = distToEnd[dep] + 1
+			}
+		}
+	}
+
+	through := make(map[string]float64, len(a.issueMap))
+	longest := 0.0
+	for id, node := range a.idToNode {
+		t := prevLongest - prevSlack[id]
+		if comp[node] {
+			t = distFromStart[node] + distToEnd[node]
+		}
+		through[id] = t
+		if t > longest {
+			longest = t
+		}
+	}
+
+	slack := make(map[string]float64, len(through))
+	for id, t := range through {
+		slack[id] = longest - t
+	}
+	return slack, true
+}
+
+// updateBetweenness patches the previous betweenness scores. Only sources
+// that can reach the tail of an edited edge (in either graph) have different
+// shortest-path DAGs, so
+//
+//	BC_new(v) = BC_prev(v) - Σ_{s∈S} δ_s^prev(v) + Σ_{s∈S} δ_s^new(v)
+//
+// is exact when every affected source s ∈ S is re-run. In approximate mode S
+// is sampled down to the configured sample size and the estimate carries a
+// Hoeffding bound. Returns false when a full recomputation is cheaper.
+func (s *incrementalSeed) updateBetweenness(a *Analyzer, config AnalysisConfig) (BetweennessResult, bool) {
+	if s == nil {
+		return BetweennessResult{}, false
+	}
+	prevStatus := s.stats.Status().Betweenness
+	if !reusable(prevStatus) {
+		return BetweennessResult{}, false
+	}
+	start := time.Now()
+
+	tails := make(map[string]bool)
+	for _, e := range s.delta.addedEdges {
+		tails[e.from] = true
+	}
+	for _, e := range s.delta.removedEdges {
+		tails[e.from] = true
+	}
+	affected := make(map[string]bool)
+	s.prev.collectAncestors(tails, affected)
+	a.collectAncestors(tails, affected)
+	sources := make([]string, 0, len(affected))
+	for id := range affected {
+		sources = append(sources, id)
+	}
+	sort.Strings(sources)
+
+	n := len(a.issueMap)
+	if len(s.prev.issueMap) > n {
+		n = len(s.prev.issueMap)
+	}
+	approx := config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0
+	picked := sources
+	if approx && len(sources) > config.BetweennessSampleSize {
+		picked = make([]string, 0, config.BetweennessSampleSize)
+		for _, i := range sampleIndices(len(sources), config.BetweennessSampleSize, 1) {
+			picked = append(picked, sources[i])
+		}
+		sort.Strings(picked)
+	} else if !approx && 2*len(sources) >= len(a.issueMap) {
+		// Re-running every affected source in both graphs costs more than
+		// one exact pass over the new graph.
+		return BetweennessResult{}, false
+	}
+
+	oldContrib := s.prev.sourceBetweenness(picked)
+	newContrib := a.sourceBetweenness(picked)
+	scale := 1.0
+	errorBound := 0.0
+	if len(picked) > 0 && len(picked) < len(sources) {
+		scale = float64(len(sources)) / float64(len(picked))
+		// Each per-source change lies in [-(n-2), n-2]; Hoeffding at 95%.
+		spread := 2 * math.Max(float64(n-2), 0)
+		errorBound = float64(len(sources)) * spread * math.Sqrt(math.Log(2/0.05)/(2*float64(len(picked))))
+	}
+
+	prevScores := s.stats.Betweenness()
+	scores := make(map[int64]float64, len(prevScores))
+	for id, nodeID := range a.idToNode {
+		v := prevScores[id] + scale*(newContrib[id]-oldContrib[id])
+		if v > 1e-9 {
+			scores[nodeID] = v
+		}
+	}
+
+	result := BetweennessResult{
+		Scores:          scores,
+		Mode:            BetweennessExact,
+		SampleSize:      len(picked),
+		TotalNodes:      len(a.issueMap),
+		Elapsed:         time.Since(start),
+		Incremental:     true,
+		AffectedSources: len(sources),
+		ErrorBound:      errorBound,
+	}
+	if approx || errorBound > 0 || strings.Contains(prevStatus.Reason, "approximate") {
+		result.Mode = BetweennessApproximate
+	}
+	return result, true
+}
+
+// collectAncestors adds every issue that can reach one of targets along
+// blocking edges (including the targets themselves) to out.
+func (a *Analyzer) collectAncestors(targets map[string]bool, out map[string]bool) {
+	seen := make(map[int64]bool)
+	var queue []int64
+	for id := range targets {
+		if nodeID, ok := a.idToNode[id]; ok && !seen[nodeID] {
+			seen[nodeID] = true
+			queue = append(queue, nodeID)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		out[a.nodeToID[n]] = true
+		to := a.g.To(n)
+		for to.Next() {
+			if p := to.Node().ID(); !seen[p] {
+				seen[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+}
+
+// sourceBetweenness sums the Brandes dependencies δ_s(v) for the given
+// sources. Sources missing from the graph contribute nothing.
+func (a *Analyzer) sourceBetweenness(sources []string) map[string]float64 {
+	contrib := make(map[string]float64)
+	if len(sources) == 0 {
+		return contrib
+	}
+	nodes := pooledNodesOf(a.g.Nodes())
+	defer putPooledNodes(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(a.g, idx)
+	defer denseIndexMapPool.Put(idx.idToIdx)
+
+	buf := brandesPool.Get().(*brandesBuffers)
+	defer brandesPool.Put(buf)
+	for _, id := range sources {
+		nodeID, ok := a.idToNode[id]
+		if !ok {
+			continue
+		}
+		singleSourceBetweennessDense(adj, idx.idToIdx[nodeID], buf)
+		for _, w := range buf.stack {
+			if buf.bc[w] != 0 {
+				contrib[a.nodeToID[idx.idxToID[w]]] += buf.bc[w]
+			}
+		}
+	}
+	return contrib
+}
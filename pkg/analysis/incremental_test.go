@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// incrementalFixture builds three disjoint DAGs ("a", "b", "c") with shared
+// prerequisites so shortest paths branch and merge.
+func incrementalFixture() []model.Issue {
+	var issues []model.Issue
+	for _, prefix := range []string{"a", "b", "c"} {
+		for i := 0; i < 40; i++ {
+			issue := model.Issue{ID: fmt.Sprintf("%s%d", prefix, i), Title: "t", Status: model.StatusOpen}
+			if i > 0 {
+				issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprintf("%s%d", prefix, i/2), Type: model.DepBlocks})
+			}
+			if i > 3 && i%3 == 0 {
+				issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprintf("%s%d", prefix, i-3), Type: model.DepBlocks})
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func editIssues(issues []model.Issue, edit func(byID map[string]*model.Issue) []model.Issue) []model.Issue {
+	cloned := make([]model.Issue, len(issues))
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		cloned[i] = issues[i]
+		cloned[i].Dependencies = append([]*model.Dependency(nil), issues[i].Dependencies...)
+		byID[cloned[i].ID] = &cloned[i]
+	}
+	return append(cloned, edit(byID)...)
+}
+
+func assertMetricsClose(t *testing.T, name string, got, want map[string]float64, tol float64) {
+	t.Helper()
+	for id, w := range want {
+		if math.Abs(got[id]-w) > tol {
+			t.Errorf("%s[%s] = %v, want %v", name, id, got[id], w)
+		}
+	}
+	for id, g := range got {
+		if _, ok := want[id]; !ok && math.Abs(g) > tol {
+			t.Errorf("%s[%s] = %v, want 0", name, id, g)
+		}
+	}
+}
+
+func TestAnalyzeIncremental_MatchesFullRecompute(t *testing.T) {
+	before := incrementalFixture()
+	config := FullAnalysisConfig()
+
+	prev := NewAnalyzer(before)
+	prevStats := prev.AnalyzeAsyncWithConfig(context.Background(), config)
+	prevStats.WaitForPhase2()
+
+	after := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		// Add an edge, drop an edge and add a new blocked issue, all in "a".
+		byID["a30"].Dependencies = append(byID["a30"].Dependencies, &model.Dependency{DependsOnID: "a7", Type: model.DepBlocks})
+		byID["a12"].Dependencies = byID["a12"].Dependencies[:1]
+		return []model.Issue{{ID: "a40", Title: "new", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: "a39", Type: model.DepBlocks}}}}
+	})
+	diff := ComputeIssueDiff(before, after)
+
+	next := NewAnalyzer(after)
+	got := next.AnalyzeIncrementalWithConfig(context.Background(), config, prev, prevStats, &diff)
+	got.WaitForPhase2()
+	want := NewAnalyzer(after).AnalyzeWithConfig(config)
+
+	update := got.Incremental()
+	if update == nil {
+		t.Fatal("expected an incremental update")
+	}
+	if update.AddedNodes != 1 || update.AddedEdges != 2 || update.RemovedEdges != 1 {
+		t.Errorf("unexpected structural delta: %+v", update)
+	}
+	if update.ComponentsRecomputed != 1 || update.NodesRecomputed != 41 {
+		t.Errorf("expected only component a to be recomputed, got %+v", update)
+	}
+	if update.BetweennessSources == 0 || update.BetweennessSources >= len(after)/2 || update.BetweennessErrorBound != 0 {
+		t.Errorf("expected exact betweenness from a few sources, got %+v", update)
+	}
+
+	assertMetricsClose(t, "pagerank", got.PageRank(), want.PageRank(), 1e-5)
+	assertMetricsClose(t, "betweenness", got.Betweenness(), want.Betweenness(), 1e-6)
+	assertMetricsClose(t, "eigenvector", got.Eigenvector(), want.Eigenvector(), 1e-9)
+	assertMetricsClose(t, "critical_path", got.CriticalPathScore(), want.CriticalPathScore(), 0)
+	assertMetricsClose(t, "slack", got.Slack(), want.Slack(), 0)
+
+	status := got.Status()
+	if status.PageRank.Reason != "incremental" || status.Betweenness.Reason != "incremental" ||
+		status.Critical.Reason != "incremental" || status.Slack.Reason != "incremental" {
+		t.Errorf("expected patched metrics to be flagged, got %+v", status)
+	}
+}
+
+func TestAnalyzeIncremental_LongestPathShiftsUntouchedSlack(t *testing.T) {
+	before := incrementalFixture()
+	config := FullAnalysisConfig()
+	prev := NewAnalyzer(before)
+	prevStats := prev.AnalyzeAsyncWithConfig(context.Background(), config)
+	prevStats.WaitForPhase2()
+
+	// Lengthen the longest chain in "c"; slack in "a" and "b" must grow too.
+	after := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		var extra []model.Issue
+		for i := 40; i < 45; i++ {
+			extra = append(extra, model.Issue{ID: fmt.Sprintf("c%d", i), Status: model.StatusOpen,
+				Dependencies: []*model.Dependency{{DependsOnID: fmt.Sprintf("c%d", i-1), Type: model.DepBlocks}}})
+		}
+		return extra
+	})
+	next := NewAnalyzer(after)
+	got := next.AnalyzeIncrementalWithConfig(context.Background(), config, prev, prevStats, nil)
+	got.WaitForPhase2()
+	want := NewAnalyzer(after).AnalyzeWithConfig(config)
+
+	if got.Incremental() == nil {
+		t.Fatal("expected an incremental update")
+	}
+	assertMetricsClose(t, "slack", got.Slack(), want.Slack(), 0)
+	assertMetricsClose(t, "critical_path", got.CriticalPathScore(), want.CriticalPathScore(), 0)
+	assertMetricsClose(t, "betweenness", got.Betweenness(), want.Betweenness(), 1e-6)
+}
+
+func TestAnalyzeIncremental_ApproximateBetweennessHasErrorBound(t *testing.T) {
+	before := incrementalFixture()
+	config := FullAnalysisConfig()
+	config.BetweennessMode = BetweennessApproximate
+	config.BetweennessSampleSize = 2
+	prev := NewAnalyzer(before)
+	prevStats := prev.AnalyzeAsyncWithConfig(context.Background(), config)
+	prevStats.WaitForPhase2()
+
+	// b1 is reachable from most of "b", so many sources are affected.
+	after := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		byID["b1"].Dependencies = nil
+		return nil
+	})
+	next := NewAnalyzer(after)
+	got := next.AnalyzeIncrementalWithConfig(context.Background(), config, prev, prevStats, nil)
+	got.WaitForPhase2()
+
+	update := got.Incremental()
+	if update == nil || update.BetweennessSources != 2 || update.BetweennessAffectedSources <= 2 || update.BetweennessErrorBound <= 0 {
+		t.Fatalf("expected sampled betweenness with an error bound, got %+v", update)
+	}
+	if status := got.Status().Betweenness; status.Reason != "incremental, approximate" || status.Sample != 2 {
+		t.Errorf("unexpected betweenness status %+v", status)
+	}
+}
+
+func TestAnalyzeIncremental_Fallbacks(t *testing.T) {
+	before := incrementalFixture()
+	config := FullAnalysisConfig()
+	prev := NewAnalyzer(before)
+	prevStats := prev.AnalyzeAsyncWithConfig(context.Background(), config)
+	prevStats.WaitForPhase2()
+
+	// A status change leaves the blocking graph alone: stats carry over.
+	statusOnly := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		byID["a5"].Status = model.StatusClosed
+		return nil
+	})
+	if got := NewAnalyzer(statusOnly).AnalyzeIncrementalWithConfig(context.Background(), config, prev, prevStats, nil); got != prevStats {
+		t.Error("expected previous stats to be reused for a status-only change")
+	}
+
+	edited := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		byID["a30"].Dependencies = append(byID["a30"].Dependencies, &model.Dependency{DependsOnID: "a7", Type: model.DepBlocks})
+		return nil
+	})
+
+	// Different config: full recompute.
+	other := config
+	other.ComputeHITS = !other.ComputeHITS
+	got := NewAnalyzer(edited).AnalyzeIncrementalWithConfig(context.Background(), other, prev, prevStats, nil)
+	got.WaitForPhase2()
+	if got.Incremental() != nil {
+		t.Error("expected a full recompute when the config changed")
+	}
+
+	// Large edit: full recompute.
+	rewired := editIssues(before, func(byID map[string]*model.Issue) []model.Issue {
+		for i := 1; i < 40; i++ {
+			byID[fmt.Sprintf("b%d", i)].Dependencies = []*model.Dependency{{DependsOnID: "b0", Type: model.DepBlocks}}
+		}
+		return nil
+	})
+	got = NewAnalyzer(rewired).AnalyzeIncrementalWithConfig(context.Background(), config, prev, prevStats, nil)
+	got.WaitForPhase2()
+	if got.Incremental() != nil {
+		t.Error("expected a full recompute for a large edit")
+	}
+}
+
+func TestComputeEigenvectorFrom_WarmStartConverges(t *testing.T) {
+	// Interlocking 2- and 3-cycles (aperiodic) with a tail converge to a
+	// non-trivial eigenvector.
+	issues := []model.Issue{
+		{ID: "x", Dependencies: []*model.Dependency{{DependsOnID: "y", Type: model.DepBlocks}}},
+		{ID: "y", Dependencies: []*model.Dependency{{DependsOnID: "z", Type: model.DepBlocks}, {DependsOnID: "x", Type: model.DepBlocks}}},
+		{ID: "z", Dependencies: []*model.Dependency{{DependsOnID: "x", Type: model.DepBlocks}}},
+		{ID: "tail", Dependencies: []*model.Dependency{{DependsOnID: "x", Type: model.DepBlocks}}},
+	}
+	a := NewAnalyzer(issues)
+	cold := computeEigenvector(a.g)
+	warm, ok := computeEigenvectorFrom(a.g, cold)
+	if !ok {
+		t.Fatal("expected the warm start to converge")
+	}
+	for id, v := range cold {
+		if math.Abs(warm[id]-v) > 1e-5 {
+			t.Errorf("node %d: warm %v vs cold %v", id, warm[id], v)
+		}
+	}
+
+	// On a DAG the iteration dies out, so the cold result is used.
+	dag := NewAnalyzer(incrementalFixture())
+	if _, ok := computeEigenvectorFrom(dag.g, computeEigenvector(dag.g)); ok {
+		t.Error("expected the warm start to be dropped on a DAG")
+	}
+}
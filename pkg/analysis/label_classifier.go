@@ -0,0 +1,685 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelModelFile is the persisted label classifier filename under .bv/
+const LabelModelFile = "label_model.json"
+
+// labelModelVersion is bumped when the feature set or file layout changes;
+// older files are retrained from scratch.
+const labelModelVersion = 1
+
+// neighborFeaturePrefix marks features taken from labels on linked issues.
+const neighborFeaturePrefix = "nbr:"
+
+// LabelModelPath returns the label classifier path for a project
+func LabelModelPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", LabelModelFile)
+}
+
+// LabelClassifierConfig configures label classifier training
+type LabelClassifierConfig struct {
+	// MinExamples is how many labeled issues a label needs to be modeled
+	// Default: 3
+	MinExamples int `json:"min_examples"`
+
+	// Threshold is the calibrated probability at which a label is predicted
+	// when measuring precision and recall
+	// Default: 0.5
+	Threshold float64 `json:"threshold"`
+
+	// NeighborFeatures adds the labels of dependency neighbours as features
+	// Default: true
+	NeighborFeatures bool `json:"neighbor_features"`
+}
+
+// DefaultLabelClassifierConfig returns sensible defaults
+func DefaultLabelClassifierConfig() LabelClassifierConfig {
+	return LabelClassifierConfig{
+		MinExamples:      3,
+		Threshold:        0.5,
+		NeighborFeatures: true,
+	}
+}
+
+// PlattParams maps a raw log-odds score s to a probability sigmoid(A*s + B).
+type PlattParams struct {
+	A          float64 `json:"a"`
+	B          float64 `json:"b"`
+	Calibrated bool    `json:"calibrated"` // false when the calibration fold lacked positives or negatives
+}
+
+// LabelMetrics is held-out performance for one label
+type LabelMetrics struct {
+	Label      string  `json:"label"`
+	Examples   int     `json:"examples"`  // labeled issues carrying it
+	Support    int     `json:"support"`   // positives in the held-out fold
+	Predicted  int     `json:"predicted"` // held-out issues predicted to carry it
+	Precision  float64 `json:"precision"`
+	Recall     float64 `json:"recall"`
+	F1         float64 `json:"f1"`
+	Calibrated bool    `json:"calibrated"`
+}
+
+// LabelModelEvaluation summarizes held-out performance. Every labeled issue
+// is scored leave-one-out; roughly a fifth of them (chosen by ID hash) form
+// the held-out fold and the rest fit the calibration.
+type LabelModelEvaluation struct {
+	HeldOut        int            `json:"held_out"`
+	Threshold      float64        `json:"threshold"`
+	MacroPrecision float64        `json:"macro_precision"`
+	MacroRecall    float64        `json:"macro_recall"`
+	MacroF1        float64        `json:"macro_f1"`
+	BrierScore     float64        `json:"brier_score"`
+	Labels         []LabelMetrics `json:"labels"`
+}
+
+// LabelModelUpdate records what the last retrain changed
+type LabelModelUpdate struct {
+	Loaded    bool `json:"loaded"` // an existing model was reused
+	Retrained bool `json:"retrained"`
+	Added     int  `json:"added"`
+	Updated   int  `json:"updated"`
+	Removed   int  `json:"removed"`
+	Unchanged int  `json:"unchanged"`
+}
+
+// labelDoc is one labeled issue as the classifier saw it
+type labelDoc struct {
+	Hash     string   `json:"hash"`
+	Features []string `json:"features"`
+	Labels   []string `json:"labels"`
+}
+
+// LabelClassifier is a one-vs-rest multinomial naive Bayes model over title
+// and description keywords plus the labels of dependency neighbours, with
+// Platt-scaled probabilities. Counts are kept per document so the model can
+// be updated incrementally as issues change.
+type LabelClassifier struct {
+	Version    int                       `json:"version"`
+	DataHash   string                    `json:"data_hash"`
+	TrainedAt  time.Time                 `json:"trained_at"`
+	Config     LabelClassifierConfig     `json:"config"`
+	Docs       map[string]labelDoc       `json:"docs"`
+	LabelDocs  map[string]int            `json:"label_docs"`  // label -> docs carrying it
+	LabelToks  map[string]int            `json:"label_toks"`  // label -> feature occurrences in those docs
+	TokenLabel map[string]map[string]int `json:"token_label"` // label -> feature -> docs with both
+	TokenDocs  map[string]int            `json:"token_docs"`  // feature -> docs containing it
+	TotalToks  int                       `json:"total_toks"`
+	Platt      map[string]PlattParams    `json:"platt"`
+	Evaluation LabelModelEvaluation      `json:"evaluation"`
+
+	LastUpdate LabelModelUpdate `json:"-"`
+}
+
+// LabelPrediction is a calibrated label probability for one issue
+type LabelPrediction struct {
+	Label       string   `json:"label"`
+	Probability float64  `json:"probability"`
+	Calibrated  bool     `json:"calibrated"`
+	TopFeatures []string `json:"top_features,omitempty"`
+}
+
+// LabelModelSummary is the classifier section of --robot-suggest output
+type LabelModelSummary struct {
+	DataHash   string               `json:"data_hash"`
+	TrainedAt  string               `json:"trained_at"`
+	Documents  int                  `json:"documents"`
+	Labels     []string             `json:"labels"`
+	Update     LabelModelUpdate     `json:"update"`
+	Evaluation LabelModelEvaluation `json:"evaluation"`
+}
+
+func newLabelClassifier(cfg LabelClassifierConfig) *LabelClassifier {
+	return &LabelClassifier{
+		Version:    labelModelVersion,
+		Config:     cfg,
+		Docs:       make(map[string]labelDoc),
+		LabelDocs:  make(map[string]int),
+		LabelToks:  make(map[string]int),
+		TokenLabel: make(map[string]map[string]int),
+		TokenDocs:  make(map[string]int),
+		Platt:      make(map[string]PlattParams),
+	}
+}
+
+// TrainLabelClassifier trains a classifier from scratch on the labeled issues.
+func TrainLabelClassifier(issues []model.Issue, dataHash string, cfg LabelClassifierConfig) *LabelClassifier {
+	return UpdateLabelClassifier(nil, issues, dataHash, cfg)
+}
+
+// UpdateLabelClassifier brings c up to date with issues. When the data hash is
+// unchanged c is returned as is; otherwise only issues whose features or
+// labels changed are re-counted, then calibration and evaluation are redone.
+// A nil c, or one with a different version or config, is retrained from scratch.
+func UpdateLabelClassifier(c *LabelClassifier, issues []model.Issue, dataHash string, cfg LabelClassifierConfig) *LabelClassifier {
+	if c != nil && (c.Version != labelModelVersion || c.Config != cfg || c.Docs == nil) {
+		c = nil
+	}
+	if c == nil {
+		c = newLabelClassifier(cfg)
+	} else {
+		c.LastUpdate = LabelModelUpdate{Loaded: true}
+		if dataHash != "" && c.DataHash == dataHash {
+			c.LastUpdate.Unchanged = len(c.Docs)
+			return c
+		}
+	}
+
+	neighbors := labelNeighbors(issues)
+	current := make(map[string]labelDoc)
+	for _, issue := range issues {
+		if issue.Status == model.StatusTombstone || len(issue.Labels) == 0 {
+			continue
+		}
+		doc := labelDocFor(issue, neighbors, cfg)
+		if len(doc.Labels) > 0 {
+			current[issue.ID] = doc
+		}
+	}
+
+	for id, old := range c.Docs {
+		doc, ok := current[id]
+		switch {
+		case !ok:
+			c.count(old, -1)
+			delete(c.Docs, id)
+			c.LastUpdate.Removed++
+		case doc.Hash != old.Hash:
+			c.count(old, -1)
+			c.count(doc, 1)
+			c.Docs[id] = doc
+			c.LastUpdate.Updated++
+		default:
+			c.LastUpdate.Unchanged++
+		}
+	}
+	for id, doc := range current {
+		if _, ok := c.Docs[id]; !ok {
+			c.count(doc, 1)
+			c.Docs[id] = doc
+			c.LastUpdate.Added++
+		}
+	}
+
+	c.LastUpdate.Retrained = true
+	c.DataHash = dataHash
+	c.TrainedAt = time.Now().UTC()
+	c.calibrateAndEvaluate()
+	return c
+}
+
+// count adds (sign=1) or removes (sign=-1) a document's contribution
+func (c *LabelClassifier) count(doc labelDoc, sign int) {
+	n := len(doc.Features)
+	c.TotalToks += sign * n
+	for _, f := range doc.Features {
+		c.TokenDocs[f] += sign
+		if c.TokenDocs[f] <= 0 {
+			delete(c.TokenDocs, f)
+		}
+	}
+	for _, l := range doc.Labels {
+		c.LabelDocs[l] += sign
+		c.LabelToks[l] += sign * n
+		if c.TokenLabel[l] == nil {
+			c.TokenLabel[l] = make(map[string]int)
+		}
+		for _, f := range doc.Features {
+			c.TokenLabel[l][f] += sign
+			if c.TokenLabel[l][f] <= 0 {
+				delete(c.TokenLabel[l], f)
+			}
+		}
+		if c.LabelDocs[l] <= 0 {
+			delete(c.LabelDocs, l)
+			delete(c.LabelToks, l)
+			delete(c.TokenLabel, l)
+		}
+	}
+}
+
+// ModeledLabels returns the labels with enough positive and negative examples, sorted.
+func (c *LabelClassifier) ModeledLabels() []string {
+	if c == nil {
+		return nil
+	}
+	minExamples := c.Config.MinExamples
+	if minExamples < 1 {
+		minExamples = 1
+	}
+	var labels []string
+	for l, n := range c.LabelDocs {
+		if n >= minExamples && n < len(c.Docs) {
+			labels = append(labels, l)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// score returns the naive Bayes log-odds that features carry label, leaving
+// out the contribution of exclude (the issue's own training document).
+func (c *LabelClassifier) score(label string, features []string, exclude *labelDoc) (float64, map[string]float64) {
+	n := len(c.Docs)
+	nL := c.LabelDocs[label]
+	totAll := c.TotalToks
+	totL := c.LabelToks[label]
+	excludeHas := false
+	excluded := make(map[string]bool)
+	if exclude != nil {
+		n--
+		totAll -= len(exclude.Features)
+		for _, l := range exclude.Labels {
+			if l == label {
+				excludeHas = true
+			}
+		}
+		if excludeHas {
+			nL--
+			totL -= len(exclude.Features)
+		}
+		for _, f := range exclude.Features {
+			excluded[f] = true
+		}
+	}
+	nNot := n - nL
+	totNot := totAll - totL
+	vocab := float64(len(c.TokenDocs))
+
+	s := math.Log(float64(nL)+1) - math.Log(float64(nNot)+1)
+	contrib := make(map[string]float64, len(features))
+	for _, f := range features {
+		docs := c.TokenDocs[f]
+		cL := c.TokenLabel[label][f]
+		if excluded[f] {
+			docs--
+			if excludeHas {
+				cL--
+			}
+		}
+		if docs <= 0 {
+			continue
+		}
+		cNot := docs - cL
+		w := math.Log((float64(cL)+1)/(float64(totL)+vocab)) - math.Log((float64(cNot)+1)/(float64(totNot)+vocab))
+		contrib[f] = w
+		s += w
+	}
+	return s, contrib
+}
+
+// probability applies the label's calibration to a raw score
+func (c *LabelClassifier) probability(label string, s float64) (float64, bool) {
+	p, ok := c.Platt[label]
+	if !ok {
+		p = PlattParams{A: 1}
+	}
+	return sigmoid(p.A*s + p.B), p.Calibrated
+}
+
+// Predict returns calibrated probabilities for every modeled label the issue
+// does not already carry, highest first.
+func (c *LabelClassifier) Predict(issue model.Issue, issues []model.Issue) []LabelPrediction {
+	if c == nil {
+		return nil
+	}
+	return c.predict(issue, labelNeighbors(issues))
+}
+
+func (c *LabelClassifier) predict(issue model.Issue, neighbors map[string][]string) []LabelPrediction {
+	doc := labelDocFor(issue, neighbors, c.Config)
+	var exclude *labelDoc
+	if trained, ok := c.Docs[issue.ID]; ok {
+		exclude = &trained
+	}
+	has := make(map[string]bool, len(doc.Labels))
+	for _, l := range doc.Labels {
+		has[l] = true
+	}
+
+	var preds []LabelPrediction
+	for _, label := range c.ModeledLabels() {
+		if has[label] {
+			continue
+		}
+		s, contrib := c.score(label, doc.Features, exclude)
+		prob, calibrated := c.probability(label, s)
+		preds = append(preds, LabelPrediction{
+			Label:       label,
+			Probability: prob,
+			Calibrated:  calibrated,
+			TopFeatures: topLabelFeatures(contrib, 3),
+		})
+	}
+	sort.Slice(preds, func(i, j int) bool {
+		if preds[i].Probability != preds[j].Probability {
+			return preds[i].Probability > preds[j].Probability
+		}
+		return preds[i].Label < preds[j].Label
+	})
+	return preds
+}
+
+// calibrateAndEvaluate scores every training document leave-one-out, fits
+// Platt scaling per label on the calibration fold and measures precision and
+// recall on the held-out fold.
+func (c *LabelClassifier) calibrateAndEvaluate() {
+	labels := c.ModeledLabels()
+	c.Platt = make(map[string]PlattParams, len(labels))
+	threshold := c.Config.Threshold
+	if threshold <= 0 || threshold >= 1 {
+		threshold = 0.5
+	}
+	eval := LabelModelEvaluation{Threshold: threshold}
+
+	ids := make([]string, 0, len(c.Docs))
+	for id := range c.Docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	heldOut := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if labelHoldoutFold(id) {
+			heldOut[id] = true
+			eval.HeldOut++
+		}
+	}
+
+	var brierSum float64
+	var brierN int
+	var precSum, recSum, f1Sum float64
+	var precN, recN int
+	for _, label := range labels {
+		var calScores, testScores []float64
+		var calY, testY []bool
+		for _, id := range ids {
+			doc := c.Docs[id]
+			s, _ := c.score(label, doc.Features, &doc)
+			y := false
+			for _, l := range doc.Labels {
+				if l == label {
+					y = true
+					break
+				}
+			}
+			if heldOut[id] {
+				testScores = append(testScores, s)
+				testY = append(testY, y)
+			} else {
+				calScores = append(calScores, s)
+				calY = append(calY, y)
+			}
+		}
+		c.Platt[label] = fitPlatt(calScores, calY)
+
+		m := LabelMetrics{Label: label, Examples: c.LabelDocs[label], Calibrated: c.Platt[label].Calibrated}
+		tp := 0
+		for i, s := range testScores {
+			p, _ := c.probability(label, s)
+			predicted := p >= threshold
+			if testY[i] {
+				m.Support++
+			}
+			if predicted {
+				m.Predicted++
+				if testY[i] {
+					tp++
+				}
+			}
+			y := 0.0
+			if testY[i] {
+				y = 1
+			}
+			brierSum += (p - y) * (p - y)
+			brierN++
+		}
+		if m.Predicted > 0 {
+			m.Precision = float64(tp) / float64(m.Predicted)
+			precSum += m.Precision
+			precN++
+		}
+		if m.Support > 0 {
+			m.Recall = float64(tp) / float64(m.Support)
+			if m.Precision+m.Recall > 0 {
+				m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+			}
+			recSum += m.Recall
+			f1Sum += m.F1
+			recN++
+		}
+		eval.Labels = append(eval.Labels, m)
+	}
+	if precN > 0 {
+		eval.MacroPrecision = precSum / float64(precN)
+	}
+	if recN > 0 {
+		eval.MacroRecall = recSum / float64(recN)
+		eval.MacroF1 = f1Sum / float64(recN)
+	}
+	if brierN > 0 {
+		eval.BrierScore = brierSum / float64(brierN)
+	}
+	c.Evaluation = eval
+}
+
+// Summary returns the classifier section of --robot-suggest output
+func (c *LabelClassifier) Summary() *LabelModelSummary {
+	if c == nil {
+		return nil
+	}
+	labels := c.ModeledLabels()
+	if labels == nil {
+		labels = []string{}
+	}
+	return &LabelModelSummary{
+		DataHash:   c.DataHash,
+		TrainedAt:  c.TrainedAt.Format(time.RFC3339),
+		Documents:  len(c.Docs),
+		Labels:     labels,
+		Update:     c.LastUpdate,
+		Evaluation: c.Evaluation,
+	}
+}
+
+// LoadLabelClassifier reads a persisted classifier. A missing file returns (nil, nil).
+func LoadLabelClassifier(path string) (*LabelClassifier, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label model: %w", err)
+	}
+	var c LabelClassifier
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse label model: %w", err)
+	}
+	return &c, nil
+}
+
+// Save persists the classifier, creating the parent directory if needed.
+func (c *LabelClassifier) Save(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal label model: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create label model directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write label model: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write label model: %w", err)
+	}
+	return nil
+}
+
+// LoadOrTrainLabelClassifier loads .bv/label_model.json, updates it for the
+// current issues and saves it when it changed. An unreadable model is
+// retrained; a failed save still returns the usable model alongside the error.
+func LoadOrTrainLabelClassifier(projectDir string, issues []model.Issue, dataHash string) (*LabelClassifier, error) {
+	path := LabelModelPath(projectDir)
+	existing, _ := LoadLabelClassifier(path)
+	c := UpdateLabelClassifier(existing, issues, dataHash, DefaultLabelClassifierConfig())
+	if !c.LastUpdate.Retrained {
+		return c, nil
+	}
+	return c, c.Save(path)
+}
+
+// labelDocFor extracts classifier features and normalized labels for an issue
+func labelDocFor(issue model.Issue, neighbors map[string][]string, cfg LabelClassifierConfig) labelDoc {
+	features := extractKeywords(issue.Title, issue.Description)
+	if cfg.NeighborFeatures {
+		for _, l := range neighbors[issue.ID] {
+			features = append(features, neighborFeaturePrefix+l)
+		}
+	}
+	features = uniqueStrings(features)
+	sort.Strings(features)
+	labels := normalizeLabels(issue.Labels)
+	sort.Strings(labels)
+
+	h := sha256.New()
+	h.Write([]byte(strings.Join(features, "\x00")))
+	h.Write([]byte{0xff})
+	h.Write([]byte(strings.Join(labels, "\x00")))
+	return labelDoc{Hash: hex.EncodeToString(h.Sum(nil))[:16], Features: features, Labels: labels}
+}
+
+// labelNeighbors maps each issue to the labels of the issues it depends on
+// or that depend on it.
+func labelNeighbors(issues []model.Issue) map[string][]string {
+	labelsByID := make(map[string][]string, len(issues))
+	for _, issue := range issues {
+		if len(issue.Labels) > 0 {
+			labelsByID[issue.ID] = normalizeLabels(issue.Labels)
+		}
+	}
+	neighbors := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.DependsOnID == issue.ID {
+				continue
+			}
+			neighbors[issue.ID] = append(neighbors[issue.ID], labelsByID[dep.DependsOnID]...)
+			neighbors[dep.DependsOnID] = append(neighbors[dep.DependsOnID], labelsByID[issue.ID]...)
+		}
+	}
+	return neighbors
+}
+
+// labelHoldoutFold reports whether an issue belongs to the held-out fold (~20%).
+func labelHoldoutFold(id string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return h.Sum32()%5 == 0
+}
+
+// topLabelFeatures returns the features that pushed hardest toward the label
+func topLabelFeatures(contrib map[string]float64, n int) []string {
+	type fw struct {
+		f string
+		w float64
+	}
+	var pos []fw
+	for f, w := range contrib {
+		if w > 0 {
+			pos = append(pos, fw{f, w})
+		}
+	}
+	sort.Slice(pos, func(i, j int) bool {
+		if pos[i].w != pos[j].w {
+			return pos[i].w > pos[j].w
+		}
+		return pos[i].f < pos[j].f
+	})
+	var out []string
+	for i := 0; i < len(pos) && i < n; i++ {
+		out = append(out, pos[i].f)
+	}
+	return out
+}
+
+// fitPlatt fits sigmoid(A*s + B) to labels by Newton's method on the
+// regularized targets from Platt (1999). Without both positives and
+// negatives it falls back to the raw naive Bayes posterior.
+func fitPlatt(scores []float64, ys []bool) PlattParams {
+	var pos, neg int
+	for _, y := range ys {
+		if y {
+			pos++
+		} else {
+			neg++
+		}
+	}
+	if pos == 0 || neg == 0 {
+		return PlattParams{A: 1}
+	}
+	hiTarget := (float64(pos) + 1) / (float64(pos) + 2)
+	loTarget := 1 / (float64(neg) + 2)
+	targets := make([]float64, len(ys))
+	for i, y := range ys {
+		if y {
+			targets[i] = hiTarget
+		} else {
+			targets[i] = loTarget
+		}
+	}
+
+	a, b := 0.0, math.Log((float64(pos)+1)/(float64(neg)+1))
+	const ridge = 1e-3
+	for iter := 0; iter < 100; iter++ {
+		var gA, gB, hAA, hAB, hBB float64
+		for i, s := range scores {
+			p := sigmoid(a*s + b)
+			d := p - targets[i]
+			w := p * (1 - p)
+			gA += d * s
+			gB += d
+			hAA += w * s * s
+			hAB += w * s
+			hBB += w
+		}
+		gA += ridge * a
+		hAA += ridge
+		hBB += ridge
+		det := hAA*hBB - hAB*hAB
+		if det <= 0 {
+			break
+		}
+		dA := (hBB*gA - hAB*gB) / det
+		dB := (hAA*gB - hAB*gA) / det
+		a -= dA
+		b -= dB
+		if math.Abs(dA) < 1e-9 && math.Abs(dB) < 1e-9 {
+			break
+		}
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return PlattParams{A: 1}
+	}
+	return PlattParams{A: a, B: b, Calibrated: true}
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
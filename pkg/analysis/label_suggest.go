@@ -29,6 +29,10 @@ type LabelSuggestionConfig struct {
 	// BuiltinMappings enables built-in keyword-to-label mappings
 	// Default: true
 	BuiltinMappings bool
+
+	// Classifier, when set and modeling at least one label, replaces the
+	// keyword heuristics with calibrated classifier probabilities
+	Classifier *LabelClassifier
 }
 
 // DefaultLabelSuggestionConfig returns sensible defaults
@@ -99,14 +103,41 @@ type LabelMatch struct {
 	Confidence   float64  `json:"confidence"`
 	Reason       string   `json:"reason"`
 	MatchedWords []string `json:"matched_words,omitempty"`
+	Source       string   `json:"source,omitempty"` // "classifier" when from LabelClassifier
+	Calibrated   bool     `json:"calibrated,omitempty"`
 }
 
-// SuggestLabels analyzes issues for potential label suggestions
+// SuggestLabels analyzes issues for potential label suggestions. With a
+// trained classifier, it predicts the labels it models and keyword heuristics
+// cover the rest.
 func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggestion {
 	if len(issues) == 0 {
 		return nil
 	}
 
+	var matches []LabelMatch
+	modeled := make(map[string]bool)
+	if config.Classifier != nil {
+		for _, label := range config.Classifier.ModeledLabels() {
+			modeled[label] = true
+		}
+		if len(modeled) > 0 {
+			matches = classifierLabelMatches(issues, config)
+		}
+	}
+	matches = append(matches, heuristicLabelMatches(issues, config, modeled, matches)...)
+	return labelMatchSuggestions(matches, config)
+}
+
+// heuristicLabelMatches suggests labels from builtin and learned keyword
+// mappings, skipping labels in modeled. Matches already made for an issue
+// count towards its MaxSuggestionsPerIssue.
+func heuristicLabelMatches(issues []model.Issue, config LabelSuggestionConfig, modeled map[string]bool, prior []LabelMatch) []LabelMatch {
+	perIssue := make(map[string]int)
+	for _, m := range prior {
+		perIssue[m.IssueID]++
+	}
+
 	// Build learned mappings from existing labeled issues
 	learnedMappings := make(map[string]map[string]int) // keyword -> label -> count
 	if config.LearnFromExisting {
@@ -129,11 +160,14 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 			continue
 		}
 
-		// Get existing labels for this issue
+		// Get existing labels for this issue; modeled labels are left to the classifier
 		existingLabels := make(map[string]bool)
 		for _, l := range issue.Labels {
 			existingLabels[strings.ToLower(l)] = true
 		}
+		for l := range modeled {
+			existingLabels[l] = true
+		}
 
 		// Extract keywords
 		keywords := extractKeywords(issue.Title, issue.Description)
@@ -195,7 +229,7 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 			return candidates[i].score > candidates[j].score
 		})
 
-		issueMatches := 0
+		issueMatches := perIssue[issue.ID]
 		for _, candidate := range candidates {
 			if candidate.score < config.MinConfidence {
 				continue
@@ -224,6 +258,59 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 		}
 	}
 
+	return matches
+}
+
+// classifierLabelMatches predicts labels for open issues with the trained classifier
+func classifierLabelMatches(issues []model.Issue, config LabelSuggestionConfig) []LabelMatch {
+	c := config.Classifier
+	neighbors := labelNeighbors(issues)
+
+	var matches []LabelMatch
+	for _, issue := range issues {
+		if isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		issueMatches := 0
+		for _, pred := range c.predict(issue, neighbors) {
+			if pred.Probability < config.MinConfidence || issueMatches >= config.MaxSuggestionsPerIssue {
+				break
+			}
+			var words, nbrs []string
+			for _, f := range pred.TopFeatures {
+				if strings.HasPrefix(f, neighborFeaturePrefix) {
+					nbrs = append(nbrs, strings.TrimPrefix(f, neighborFeaturePrefix))
+				} else {
+					words = append(words, f)
+				}
+			}
+			reason := fmt.Sprintf("classifier: %.0f%%", pred.Probability*100)
+			if !pred.Calibrated {
+				reason += " (uncalibrated)"
+			}
+			if len(words) > 0 {
+				reason += "; keywords: " + strings.Join(words, ", ")
+			}
+			if len(nbrs) > 0 {
+				reason += "; linked issues labeled: " + strings.Join(nbrs, ", ")
+			}
+			matches = append(matches, LabelMatch{
+				IssueID:      issue.ID,
+				Label:        pred.Label,
+				Confidence:   pred.Probability,
+				Reason:       reason,
+				MatchedWords: pred.TopFeatures,
+				Source:       "classifier",
+				Calibrated:   pred.Calibrated,
+			})
+			issueMatches++
+		}
+	}
+	return matches
+}
+
+// labelMatchSuggestions sorts and limits matches and converts them to suggestions
+func labelMatchSuggestions(matches []LabelMatch, config LabelSuggestionConfig) []Suggestion {
 	// Sort by confidence and limit
 	sortLabelMatchesByConfidence(matches)
 	if len(matches) > config.MaxTotalSuggestions {
@@ -242,6 +329,9 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 		).WithAction(fmt.Sprintf("bd update %s --add-label=%s", match.IssueID, match.Label)).
 			WithMetadata("suggested_label", match.Label).
 			WithMetadata("matched_keywords", match.MatchedWords)
+		if match.Source != "" {
+			sug = sug.WithMetadata("source", match.Source).WithMetadata("calibrated", match.Calibrated)
+		}
 
 		suggestions = append(suggestions, sug)
 	}
@@ -0,0 +1,371 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Scope change actions
+const (
+	ScopeAdded   = "added"
+	ScopeRemoved = "removed"
+)
+
+// SprintBurndownDay is the sprint state at the end of one day, as recorded
+// in git at the time rather than as the beads file reads today.
+type SprintBurndownDay struct {
+	Date                  time.Time `json:"date"`
+	Scope                 int       `json:"scope"`
+	Completed             int       `json:"completed"`
+	Remaining             int       `json:"remaining"`
+	ScopeMinutes          int       `json:"scope_minutes"`
+	RemainingMinutes      int       `json:"remaining_minutes"`
+	IdealRemaining        float64   `json:"ideal_remaining"`
+	IdealRemainingMinutes float64   `json:"ideal_remaining_minutes"`
+	Added                 int       `json:"added,omitempty"`
+	Removed               int       `json:"removed,omitempty"`
+	CommitSHA             string    `json:"commit_sha,omitempty"` // Last commit at or before the end of the day
+}
+
+// SprintScopeChange records an issue entering or leaving the sprint
+type SprintScopeChange struct {
+	Date      time.Time `json:"date"`
+	CommitSHA string    `json:"commit_sha"`
+	IssueID   string    `json:"issue_id"`
+	Title     string    `json:"title,omitempty"`
+	Action    string    `json:"action"` // ScopeAdded or ScopeRemoved
+	Minutes   int       `json:"estimated_minutes"`
+}
+
+// SprintCarryover is an issue still open when the sprint ended (or, for a
+// running sprint, open now)
+type SprintCarryover struct {
+	IssueID        string       `json:"issue_id"`
+	Title          string       `json:"title,omitempty"`
+	Status         model.Status `json:"status"`
+	Minutes        int          `json:"estimated_minutes"`
+	AddedMidSprint bool         `json:"added_mid_sprint"`
+}
+
+// SprintCompletion is an issue closed by the time the sprint ended (or, for a
+// running sprint, closed now)
+type SprintCompletion struct {
+	IssueID        string `json:"issue_id"`
+	Title          string `json:"title,omitempty"`
+	Minutes        int    `json:"estimated_minutes"`
+	AddedMidSprint bool   `json:"added_mid_sprint"`
+}
+
+// SprintBurndown is a day-by-day burndown replayed from git history
+type SprintBurndown struct {
+	SprintID         string              `json:"sprint_id"`
+	StartDate        time.Time           `json:"start_date"`
+	EndDate          time.Time           `json:"end_date"`
+	Revisions        int                 `json:"revisions"` // Commits replayed
+	InitialScope     int                 `json:"initial_scope"`
+	InitialMinutes   int                 `json:"initial_minutes"`
+	Days             []SprintBurndownDay `json:"days"`
+	ScopeChanges     []SprintScopeChange `json:"scope_changes"`
+	Carryover        []SprintCarryover   `json:"carryover"`
+	CarryoverMinutes int                 `json:"carryover_minutes"`
+	Completed        []SprintCompletion  `json:"completed"`
+	CompletedMinutes int                 `json:"completed_minutes"`
+	Final            bool                `json:"final"` // True once the sprint has ended
+}
+
+// sprintState is the replayed scope and issue state at one snapshot
+type sprintState struct {
+	sha     string
+	at      time.Time
+	scope   []string
+	defined bool // scope came from the sprints file at this commit
+	issues  map[string]model.Issue
+}
+
+// ReconstructSprintBurndown replays sprint snapshots (oldest first, as
+// returned by loader.GitLoader.SprintSnapshots) into a daily burndown, a
+// scope-change log and the end-of-sprint carryover and completions. Snapshots where the
+// sprint is not yet defined inherit the earliest known bead list, falling
+// back to sprint.BeadIDs when the sprints file was never committed.
+func ReconstructSprintBurndown(sprint model.Sprint, snapshots []loader.SprintSnapshot, now time.Time) SprintBurndown {
+	out := SprintBurndown{
+		SprintID:  sprint.ID,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+		Revisions: len(snapshots),
+		Final:     !sprint.EndDate.IsZero() && now.After(sprint.EndDate),
+	}
+	if len(snapshots) == 0 || sprint.StartDate.IsZero() || sprint.EndDate.IsZero() {
+		return out
+	}
+
+	states := replaySprintStates(sprint, snapshots)
+	// Before the first snapshot nothing has been committed yet: no scope, no issues.
+	notYetCreated := &sprintState{issues: map[string]model.Issue{}}
+	stateAt := func(t time.Time) *sprintState {
+		st := notYetCreated
+		for i := range states {
+			if !states[i].at.After(t) {
+				st = &states[i]
+			}
+		}
+		return st
+	}
+
+	// A sprint first committed after it started is planned against its
+	// earliest recorded scope.
+	initial := stateAt(sprint.StartDate)
+	if initial == notYetCreated {
+		initial = &states[0]
+	}
+	out.InitialScope = len(initial.scope)
+	for _, id := range initial.scope {
+		out.InitialMinutes += sprintIssueMinutes(initial.issues, id)
+	}
+	initialSet := make(map[string]bool, len(initial.scope))
+	for _, id := range initial.scope {
+		initialSet[id] = true
+	}
+
+	// Scope changes between commits that rewrote the sprint inside its window.
+	// Scope carried into commits that only touched issues is unchanged, so
+	// comparing with the previous state diffs against the last definition.
+	seenDefined := false
+	for i := range states {
+		cur := &states[i]
+		if !cur.defined {
+			continue
+		}
+		if !seenDefined {
+			seenDefined = true
+			continue
+		}
+		prev := &states[i-1]
+		if !cur.at.After(sprint.StartDate) || cur.at.After(sprint.EndDate) {
+			continue
+		}
+		removed := setDifference(prev.scope, cur.scope)
+		added := setDifference(cur.scope, prev.scope)
+		for _, id := range removed {
+			out.ScopeChanges = append(out.ScopeChanges, sprintScopeChange(cur, prev, id, ScopeRemoved))
+		}
+		for _, id := range added {
+			out.ScopeChanges = append(out.ScopeChanges, sprintScopeChange(cur, prev, id, ScopeAdded))
+		}
+	}
+
+	totalDays := int(sprint.EndDate.Sub(sprint.StartDate).Hours()/24) + 1
+	day := 0
+	for d := sprint.StartDate; !d.After(sprint.EndDate) && !d.After(now); d = d.AddDate(0, 0, 1) {
+		dayEnd := d.Add(24*time.Hour - time.Second)
+		st := stateAt(dayEnd)
+		point := SprintBurndownDay{Date: d, Scope: len(st.scope), CommitSHA: st.sha}
+		for _, id := range st.scope {
+			minutes := sprintIssueMinutes(st.issues, id)
+			point.ScopeMinutes += minutes
+			if iss, ok := st.issues[id]; ok && iss.Status.IsClosed() {
+				point.Completed++
+				continue
+			}
+			point.Remaining++
+			point.RemainingMinutes += minutes
+		}
+		left := float64(totalDays-day-1) / float64(totalDays)
+		point.IdealRemaining = float64(out.InitialScope) * left
+		point.IdealRemainingMinutes = float64(out.InitialMinutes) * left
+		for _, c := range out.ScopeChanges {
+			if !c.Date.Before(d) && !c.Date.After(dayEnd) {
+				if c.Action == ScopeAdded {
+					point.Added++
+				} else {
+					point.Removed++
+				}
+			}
+		}
+		out.Days = append(out.Days, point)
+		day++
+	}
+
+	cutoff := sprint.EndDate
+	if now.Before(cutoff) {
+		cutoff = now
+	}
+	last := stateAt(cutoff)
+	for _, id := range last.scope {
+		iss, ok := last.issues[id]
+		if ok && iss.Status.IsClosed() {
+			done := SprintCompletion{
+				IssueID:        id,
+				Title:          iss.Title,
+				Minutes:        sprintIssueMinutes(last.issues, id),
+				AddedMidSprint: !initialSet[id],
+			}
+			out.CompletedMinutes += done.Minutes
+			out.Completed = append(out.Completed, done)
+			continue
+		}
+		carry := SprintCarryover{
+			IssueID:        id,
+			Status:         model.StatusOpen,
+			Minutes:        sprintIssueMinutes(last.issues, id),
+			AddedMidSprint: !initialSet[id],
+		}
+		if ok {
+			carry.Title = iss.Title
+			carry.Status = iss.Status
+		}
+		out.CarryoverMinutes += carry.Minutes
+		out.Carryover = append(out.Carryover, carry)
+	}
+	return out
+}
+
+// replaySprintStates resolves scope and issue state for every snapshot,
+// carrying the last known values over commits that lack them.
+func replaySprintStates(sprint model.Sprint, snapshots []loader.SprintSnapshot) []sprintState {
+	// Commits before the sprint was first written use its first bead list.
+	fallback := sprint.BeadIDs
+	for _, snap := range snapshots {
+		if snap.Sprint != nil {
+			fallback = snap.Sprint.BeadIDs
+			break
+		}
+	}
+
+	states := make([]sprintState, len(snapshots))
+	scope := fallback
+	issues := map[string]model.Issue{}
+	for i, snap := range snapshots {
+		if snap.Sprint != nil {
+			scope = snap.Sprint.BeadIDs
+		}
+		if snap.Issues != nil {
+			issues = make(map[string]model.Issue, len(snap.Issues))
+			for _, iss := range snap.Issues {
+				issues[iss.ID] = iss
+			}
+		}
+		states[i] = sprintState{
+			sha:     snap.Revision.SHA,
+			at:      snap.Revision.Timestamp,
+			scope:   dedupeSprintScope(scope, issues),
+			defined: snap.Sprint != nil,
+			issues:  issues,
+		}
+	}
+	return states
+}
+
+// dedupeSprintScope drops duplicate and tombstoned bead IDs
+func dedupeSprintScope(ids []string, issues map[string]model.Issue) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] || issues[id].Status.IsTombstone() {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+func sprintScopeChange(cur, prev *sprintState, id, action string) SprintScopeChange {
+	iss, ok := cur.issues[id]
+	if !ok {
+		iss = prev.issues[id]
+	}
+	return SprintScopeChange{
+		Date:      cur.at.UTC(),
+		CommitSHA: cur.sha,
+		IssueID:   id,
+		Title:     iss.Title,
+		Action:    action,
+		Minutes:   sprintIssueMinutes(cur.issues, id),
+	}
+}
+
+// sprintIssueMinutes returns an issue's estimate, or the default when it has
+// none or is unknown at that commit.
+func sprintIssueMinutes(issues map[string]model.Issue, id string) int {
+	if iss, ok := issues[id]; ok && iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+		return *iss.EstimatedMinutes
+	}
+	return DefaultEstimatedMinutes
+}
+
+// setDifference returns the members of a missing from b, sorted
+func setDifference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+	var out []string
+	for _, id := range a {
+		if !inB[id] {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// WriteCSV writes the daily series as CSV, one row per sprint day.
+func (b SprintBurndown) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"date", "scope", "completed", "remaining", "scope_minutes", "remaining_minutes",
+		"ideal_remaining", "ideal_remaining_minutes", "added", "removed", "commit_sha",
+	}); err != nil {
+		return err
+	}
+	for _, d := range b.Days {
+		row := []string{
+			d.Date.Format("2006-01-02"),
+			strconv.Itoa(d.Scope),
+			strconv.Itoa(d.Completed),
+			strconv.Itoa(d.Remaining),
+			strconv.Itoa(d.ScopeMinutes),
+			strconv.Itoa(d.RemainingMinutes),
+			strconv.FormatFloat(d.IdealRemaining, 'f', 2, 64),
+			strconv.FormatFloat(d.IdealRemainingMinutes, 'f', 0, 64),
+			strconv.Itoa(d.Added),
+			strconv.Itoa(d.Removed),
+			d.CommitSHA,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing burndown CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// LoadSprintBurndown replays a sprint's window from the git history of the
+// repo at repoPath. It returns nil without error for sprints without dates
+// or when the beads files have no history yet.
+func LoadSprintBurndown(repoPath string, sprint model.Sprint, now time.Time) (*SprintBurndown, error) {
+	if sprint.StartDate.IsZero() || sprint.EndDate.IsZero() {
+		return nil, nil
+	}
+	until := sprint.EndDate
+	if now.Before(until) {
+		until = now
+	}
+	snapshots, err := loader.NewGitLoader(repoPath).SprintSnapshots(sprint.ID, sprint.StartDate, until)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	b := ReconstructSprintBurndown(sprint, snapshots, now)
+	return &b, nil
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func sprintSnapshotFixture(start time.Time) (model.Sprint, []loader.SprintSnapshot) {
+	est := func(m int) *int { return &m }
+	issue := func(id string, status model.Status, minutes int) model.Issue {
+		return model.Issue{ID: id, Title: "Issue " + id, Status: status, EstimatedMinutes: est(minutes)}
+	}
+	sprintWith := func(ids ...string) *model.Sprint {
+		return &model.Sprint{ID: "s1", Name: "Sprint 1", StartDate: start, EndDate: start.AddDate(0, 0, 3), BeadIDs: ids}
+	}
+	snap := func(sha string, at time.Time, sprint *model.Sprint, issues ...model.Issue) loader.SprintSnapshot {
+		return loader.SprintSnapshot{Revision: loader.RevisionInfo{SHA: sha, Timestamp: at}, Issues: issues, Sprint: sprint}
+	}
+
+	snapshots := []loader.SprintSnapshot{
+		// Planned before the sprint starts: A and B.
+		snap("c0", start.Add(-time.Hour), sprintWith("A", "B"),
+			issue("A", model.StatusOpen, 120), issue("B", model.StatusOpen, 60), issue("C", model.StatusOpen, 30)),
+		// Day 1: A closes.
+		snap("c1", start.Add(5*time.Hour), nil,
+			issue("A", model.StatusClosed, 120), issue("B", model.StatusOpen, 60), issue("C", model.StatusOpen, 30)),
+		// Day 2: C joins, B leaves.
+		snap("c2", start.Add(30*time.Hour), sprintWith("A", "C"),
+			issue("A", model.StatusClosed, 120), issue("B", model.StatusOpen, 60), issue("C", model.StatusOpen, 30)),
+	}
+	return *sprintWith("A", "C"), snapshots
+}
+
+func TestReconstructSprintBurndown_Days(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint, snapshots := sprintSnapshotFixture(start)
+	b := ReconstructSprintBurndown(sprint, snapshots, start.Add(50*time.Hour))
+
+	if b.Revisions != 3 || b.InitialScope != 2 || b.InitialMinutes != 180 {
+		t.Fatalf("unexpected initial state %+v", b)
+	}
+	if len(b.Days) != 3 {
+		t.Fatalf("expected 3 days up to now, got %d", len(b.Days))
+	}
+	d1, d2 := b.Days[0], b.Days[1]
+	if d1.Scope != 2 || d1.Completed != 1 || d1.Remaining != 1 || d1.RemainingMinutes != 60 || d1.CommitSHA != "c1" {
+		t.Errorf("unexpected day 1 %+v", d1)
+	}
+	if d2.Scope != 2 || d2.Remaining != 1 || d2.RemainingMinutes != 30 || d2.Added != 1 || d2.Removed != 1 {
+		t.Errorf("unexpected day 2 %+v", d2)
+	}
+	// Ideal line burns the initial scope down to zero on the last of 4 days.
+	if d1.IdealRemaining != 1.5 || d1.IdealRemainingMinutes != 135 {
+		t.Errorf("unexpected ideal on day 1: %.2f / %.0f", d1.IdealRemaining, d1.IdealRemainingMinutes)
+	}
+}
+
+func TestReconstructSprintBurndown_ScopeAndCarryover(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint, snapshots := sprintSnapshotFixture(start)
+	b := ReconstructSprintBurndown(sprint, snapshots, start.AddDate(0, 0, 10))
+
+	if len(b.ScopeChanges) != 2 {
+		t.Fatalf("expected two scope changes, got %+v", b.ScopeChanges)
+	}
+	if c := b.ScopeChanges[0]; c.IssueID != "B" || c.Action != ScopeRemoved || c.CommitSHA != "c2" {
+		t.Errorf("expected B removed in c2, got %+v", c)
+	}
+	if c := b.ScopeChanges[1]; c.IssueID != "C" || c.Action != ScopeAdded || c.Minutes != 30 || c.Title != "Issue C" {
+		t.Errorf("expected C added, got %+v", c)
+	}
+	if !b.Final || len(b.Days) != 4 {
+		t.Errorf("expected a finished 4-day sprint, got final=%v days=%d", b.Final, len(b.Days))
+	}
+	if len(b.Carryover) != 1 || b.Carryover[0].IssueID != "C" || !b.Carryover[0].AddedMidSprint || b.CarryoverMinutes != 30 {
+		t.Errorf("expected C to carry over, got %+v", b.Carryover)
+	}
+	if len(b.Completed) != 1 || b.Completed[0].IssueID != "A" || b.Completed[0].AddedMidSprint || b.CompletedMinutes != 120 {
+		t.Errorf("expected A completed, got %+v", b.Completed)
+	}
+}
+
+func TestReconstructSprintBurndown_DaysBeforeFirstCommit(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint, snapshots := sprintSnapshotFixture(start)
+	// History only begins on day 2, so day 1 had nothing committed yet.
+	b := ReconstructSprintBurndown(sprint, snapshots[2:], start.Add(50*time.Hour))
+
+	if len(b.Days) != 3 {
+		t.Fatalf("expected 3 days up to now, got %d", len(b.Days))
+	}
+	if d1 := b.Days[0]; d1.Scope != 0 || d1.Completed != 0 || d1.ScopeMinutes != 0 || d1.CommitSHA != "" {
+		t.Errorf("expected an empty day 1, got %+v", d1)
+	}
+	if d2 := b.Days[1]; d2.Scope != 2 || d2.Completed != 1 || d2.CommitSHA != "c2" {
+		t.Errorf("unexpected day 2 %+v", d2)
+	}
+	if b.InitialScope != 2 || b.InitialMinutes != 150 {
+		t.Errorf("expected the first recorded scope as the plan, got %d / %d", b.InitialScope, b.InitialMinutes)
+	}
+}
+
+func TestReconstructSprintBurndown_WriteCSV(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint, snapshots := sprintSnapshotFixture(start)
+	b := ReconstructSprintBurndown(sprint, snapshots, start.Add(50*time.Hour))
+
+	var buf bytes.Buffer
+	if err := b.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "date,scope,completed,remaining") {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+	if lines[1] != "2026-03-02,2,1,1,180,60,1.50,135,0,0,c1" {
+		t.Errorf("unexpected first row %q", lines[1])
+	}
+
+	if empty := ReconstructSprintBurndown(sprint, nil, start); len(empty.Days) != 0 || empty.Revisions != 0 {
+		t.Errorf("expected no days without history, got %+v", empty)
+	}
+}
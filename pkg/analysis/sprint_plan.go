@@ -0,0 +1,522 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultSprintCapacityMinutes is the planning capacity used when neither an
+// explicit capacity nor an earlier sprint's capacity is available (one 40h week)
+const DefaultSprintCapacityMinutes = 40 * 60
+
+// Reasons an open issue was left out of a sprint plan
+const (
+	PlanExcludedCapacity   = "capacity"            // Fit on its own, but lost to higher value per minute
+	PlanExcludedTooLarge   = "too_large"           // Estimate (with blockers) exceeds the whole capacity
+	PlanExcludedScheduled  = "in_sprint"           // Already in an unfinished sprint
+	PlanExcludedBlocked    = "blocked"             // Status blocked with no open blocker to pull in
+	PlanExcludedCycle      = "cycle"               // Part of a dependency cycle
+	PlanExcludedBlockerOut = "blocker_unavailable" // Needs a blocker that cannot be planned
+)
+
+// SprintPlanOptions configures PlanSprint
+type SprintPlanOptions struct {
+	CapacityMinutes int            // Default DefaultSprintCapacityMinutes
+	Sprints         []model.Sprint // Beads in sprints that have not ended are not re-planned
+	EpicBonus       float64        // Extra value for planning all of an epic's open children; default 0.1
+	Triage          TriageOptions  // Scoring, including the .bv/triage.yaml formula
+	Now             time.Time
+}
+
+// SprintPlanItem is one issue chosen for the sprint
+type SprintPlanItem struct {
+	IssueID     string   `json:"issue_id"`
+	Title       string   `json:"title"`
+	Minutes     int      `json:"estimated_minutes"`
+	Score       float64  `json:"triage_score"`
+	EpicID      string   `json:"epic_id,omitempty"`
+	PulledInFor []string `json:"pulled_in_for,omitempty"` // Planned dependents that need this blocker first
+}
+
+// SprintPlanExclusion is an open issue left out of the plan and why
+type SprintPlanExclusion struct {
+	IssueID string  `json:"issue_id"`
+	Title   string  `json:"title"`
+	Minutes int     `json:"estimated_minutes"`
+	Score   float64 `json:"triage_score"`
+	Reason  string  `json:"reason"`
+	Detail  string  `json:"detail"`
+}
+
+// SprintPlanEpic reports how much of an epic the plan covers
+type SprintPlanEpic struct {
+	EpicID   string `json:"epic_id"`
+	Title    string `json:"title"`
+	Planned  int    `json:"planned"`
+	Open     int    `json:"open"`
+	Complete bool   `json:"complete"` // Every open child is planned
+}
+
+// SprintPlan is the chosen set of issues for a sprint, in execution order
+// (blockers before dependents)
+type SprintPlan struct {
+	CapacityMinutes int                   `json:"capacity_minutes"`
+	PlannedMinutes  int                   `json:"planned_minutes"`
+	TotalScore      float64               `json:"total_score"`
+	Items           []SprintPlanItem      `json:"items"`
+	Excluded        []SprintPlanExclusion `json:"excluded"`
+	Epics           []SprintPlanEpic      `json:"epics,omitempty"`
+}
+
+// BeadIDs returns the planned issue IDs in execution order
+func (p SprintPlan) BeadIDs() []string {
+	ids := make([]string, len(p.Items))
+	for i, item := range p.Items {
+		ids[i] = item.IssueID
+	}
+	return ids
+}
+
+// NewSprint turns the plan into a sprint of the given length starting today,
+// or when the latest existing sprint ends if that is later. The plan's capacity
+// is recorded on the sprint for the next plan.
+func (p SprintPlan) NewSprint(id, name string, days int, existing []model.Sprint, now time.Time) model.Sprint {
+	if days <= 0 {
+		days = 14
+	}
+	start := now.UTC().Truncate(24 * time.Hour)
+	for _, s := range existing {
+		if s.EndDate.After(start) {
+			start = s.EndDate.UTC().Truncate(24 * time.Hour)
+		}
+	}
+	return model.Sprint{
+		ID:              id,
+		Name:            name,
+		StartDate:       start,
+		EndDate:         start.AddDate(0, 0, days),
+		BeadIDs:         p.BeadIDs(),
+		CapacityMinutes: p.CapacityMinutes,
+		CreatedAt:       now.UTC(),
+		UpdatedAt:       now.UTC(),
+	}
+}
+
+// SprintCapacityMinutes returns the capacity of the most recent sprint that
+// records one, or DefaultSprintCapacityMinutes.
+func SprintCapacityMinutes(sprints []model.Sprint) int {
+	var latest *model.Sprint
+	for i := range sprints {
+		s := &sprints[i]
+		if s.CapacityMinutes > 0 && (latest == nil || s.StartDate.After(latest.StartDate)) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		return DefaultSprintCapacityMinutes
+	}
+	return latest.CapacityMinutes
+}
+
+// NextSprintID returns "sprint-N" for the smallest N above the number of
+// existing sprints that is not already taken.
+func NextSprintID(existing []model.Sprint) string {
+	taken := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		taken[s.ID] = true
+	}
+	for n := len(existing) + 1; ; n++ {
+		if id := fmt.Sprintf("sprint-%d", n); !taken[id] {
+			return id
+		}
+	}
+}
+
+// planUnit is a set of issues that must be planned together: an issue and
+// its open blockers, or every open child of an epic
+type planUnit struct {
+	ids     []string
+	minutes int
+	value   float64
+}
+
+// PlanSprint fills a sprint to capacity by triage impact. Each candidate is
+// priced with its transitive open blockers, so choosing it pulls them in;
+// epics are offered as a whole with a small bonus so plans finish epics
+// rather than scatter across them. Units are chosen by a 0/1 knapsack over
+// estimated minutes, then leftover capacity (freed when units share
+// blockers) is filled greedily by value per minute.
+func PlanSprint(issues []model.Issue, opts SprintPlanOptions) SprintPlan {
+	if opts.CapacityMinutes <= 0 {
+		opts.CapacityMinutes = DefaultSprintCapacityMinutes
+	}
+	if opts.EpicBonus == 0 {
+		opts.EpicBonus = 0.1
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	plan := SprintPlan{CapacityMinutes: opts.CapacityMinutes}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		if !issues[i].Status.IsTombstone() {
+			issueMap[issues[i].ID] = &issues[i]
+		}
+	}
+	scores := make(map[string]float64, len(issues))
+	for _, s := range ComputeRankedTriageScores(issues, opts.Triage, opts.Now) {
+		scores[s.IssueID] = math.Max(s.TriageScore, 0.01)
+	}
+	minutes := func(id string) int {
+		if m := issueMap[id].EstimatedMinutes; m != nil && *m > 0 {
+			return *m
+		}
+		return DefaultEstimatedMinutes
+	}
+
+	scheduled := make(map[string]string)
+	for _, s := range opts.Sprints {
+		if !s.EndDate.IsZero() && s.EndDate.Before(opts.Now) {
+			continue
+		}
+		for _, id := range s.BeadIDs {
+			scheduled[id] = s.ID
+		}
+	}
+
+	isOpen := func(id string) bool {
+		iss, ok := issueMap[id]
+		return ok && !iss.Status.IsClosed()
+	}
+	epicOf := make(map[string]string)
+	children := make(map[string][]string)
+	blockers := make(map[string][]string)
+	var candidates []string
+	for id, iss := range issueMap {
+		for _, dep := range iss.Dependencies {
+			if dep == nil || !isOpen(dep.DependsOnID) {
+				continue
+			}
+			if dep.Type == model.DepParentChild {
+				if issueMap[dep.DependsOnID].IssueType == model.TypeEpic {
+					if _, seen := epicOf[id]; !seen {
+						epicOf[id] = dep.DependsOnID
+					}
+				}
+				continue
+			}
+			if !dep.Type.IsBlocking() {
+				continue
+			}
+			// Epics block through their children; work scheduled elsewhere is
+			// assumed done by then.
+			if issueMap[dep.DependsOnID].IssueType == model.TypeEpic || scheduled[dep.DependsOnID] != "" {
+				continue
+			}
+			blockers[id] = append(blockers[id], dep.DependsOnID)
+		}
+		if isOpen(id) && iss.IssueType != model.TypeEpic && scheduled[id] == "" {
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Strings(candidates)
+	for id, epic := range epicOf {
+		if isOpen(id) && issueMap[id].IssueType != model.TypeEpic {
+			children[epic] = append(children[epic], id)
+		}
+	}
+
+	// closure returns id and its transitive open blockers, and whether id is
+	// reachable from its own blockers (a cycle).
+	closure := func(id string) ([]string, bool) {
+		seen := map[string]bool{id: true}
+		stack := []string{id}
+		cyclic := false
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, b := range blockers[cur] {
+				if b == id {
+					cyclic = true
+				}
+				if !seen[b] {
+					seen[b] = true
+					stack = append(stack, b)
+				}
+			}
+		}
+		ids := make([]string, 0, len(seen))
+		for b := range seen {
+			ids = append(ids, b)
+		}
+		sort.Strings(ids)
+		return ids, cyclic
+	}
+
+	excluded := make(map[string]SprintPlanExclusion)
+	exclude := func(id, reason, detail string) {
+		excluded[id] = SprintPlanExclusion{
+			IssueID: id, Title: issueMap[id].Title, Minutes: minutes(id), Score: scores[id],
+			Reason: reason, Detail: detail,
+		}
+	}
+	closures := make(map[string][]string, len(candidates))
+	for _, id := range candidates {
+		ids, cyclic := closure(id)
+		switch {
+		case cyclic:
+			exclude(id, PlanExcludedCycle, "blocks itself through its dependencies")
+		case issueMap[id].Status == model.StatusBlocked && len(blockers[id]) == 0:
+			exclude(id, PlanExcludedBlocked, "status is blocked with no open blocker to plan")
+		default:
+			closures[id] = ids
+		}
+	}
+	// A candidate is plannable only if every blocker in its closure is.
+	for _, id := range candidates {
+		ids, ok := closures[id]
+		if !ok {
+			continue
+		}
+		for _, b := range ids {
+			if b == id {
+				continue
+			}
+			if _, planable := closures[b]; !planable {
+				reason := "not plannable"
+				if ex, ok := excluded[b]; ok {
+					reason = ex.Reason
+				}
+				exclude(id, PlanExcludedBlockerOut, fmt.Sprintf("needs %s (%s)", b, reason))
+				delete(closures, id)
+				break
+			}
+		}
+	}
+
+	makeUnit := func(ids []string, bonus float64) planUnit {
+		u := planUnit{ids: ids}
+		for _, id := range ids {
+			u.minutes += minutes(id)
+			u.value += scores[id]
+		}
+		u.value *= 1 + bonus
+		return u
+	}
+	var units []planUnit
+	for _, id := range candidates {
+		if ids, ok := closures[id]; ok {
+			units = append(units, makeUnit(ids, 0))
+		}
+	}
+	epicIDs := make([]string, 0, len(children))
+	for epic := range children {
+		epicIDs = append(epicIDs, epic)
+	}
+	sort.Strings(epicIDs)
+	for _, epic := range epicIDs {
+		kids := children[epic]
+		if len(kids) < 2 {
+			continue
+		}
+		set := make(map[string]bool)
+		whole := true
+		for _, kid := range kids {
+			ids, ok := closures[kid]
+			if !ok {
+				whole = false
+				break
+			}
+			for _, id := range ids {
+				set[id] = true
+			}
+		}
+		if !whole {
+			continue
+		}
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		units = append(units, makeUnit(ids, opts.EpicBonus))
+	}
+
+	selected := make(map[string]bool)
+	for _, u := range knapsackUnits(units, opts.CapacityMinutes) {
+		for _, id := range u.ids {
+			selected[id] = true
+		}
+	}
+	used := 0
+	for id := range selected {
+		used += minutes(id)
+	}
+	// Units sharing blockers leave room the knapsack could not see.
+	for {
+		best, bestDensity, bestCost := -1, 0.0, 0
+		for i, u := range units {
+			cost, value := 0, 0.0
+			for _, id := range u.ids {
+				if !selected[id] {
+					cost += minutes(id)
+					value += scores[id]
+				}
+			}
+			if cost == 0 || used+cost > opts.CapacityMinutes {
+				continue
+			}
+			if d := value / float64(cost); d > bestDensity {
+				best, bestDensity, bestCost = i, d, cost
+			}
+		}
+		if best < 0 {
+			break
+		}
+		for _, id := range units[best].ids {
+			selected[id] = true
+		}
+		used += bestCost
+	}
+
+	plan.Items = orderPlanItems(selected, blockers, scores, func(id string) SprintPlanItem {
+		return SprintPlanItem{IssueID: id, Title: issueMap[id].Title, Minutes: minutes(id), Score: scores[id], EpicID: epicOf[id]}
+	})
+	for _, item := range plan.Items {
+		plan.PlannedMinutes += item.Minutes
+		plan.TotalScore += item.Score
+	}
+
+	for id, sprintID := range scheduled {
+		if isOpen(id) && issueMap[id].IssueType != model.TypeEpic {
+			exclude(id, PlanExcludedScheduled, "already in sprint "+sprintID)
+		}
+	}
+	for _, id := range candidates {
+		if selected[id] {
+			continue
+		}
+		ids, ok := closures[id]
+		if !ok {
+			continue
+		}
+		need := 0
+		for _, b := range ids {
+			if !selected[b] {
+				need += minutes(b)
+			}
+		}
+		if total := makeUnit(ids, 0).minutes; total > opts.CapacityMinutes {
+			exclude(id, PlanExcludedTooLarge, fmt.Sprintf("needs %dm with blockers; capacity is %dm", total, opts.CapacityMinutes))
+		} else {
+			exclude(id, PlanExcludedCapacity, fmt.Sprintf("needs %dm more; %dm left after higher-value work", need, opts.CapacityMinutes-plan.PlannedMinutes))
+		}
+	}
+	for _, ex := range excluded {
+		plan.Excluded = append(plan.Excluded, ex)
+	}
+	sort.Slice(plan.Excluded, func(i, j int) bool {
+		if plan.Excluded[i].Score != plan.Excluded[j].Score {
+			return plan.Excluded[i].Score > plan.Excluded[j].Score
+		}
+		return plan.Excluded[i].IssueID < plan.Excluded[j].IssueID
+	})
+
+	for _, epic := range epicIDs {
+		e := SprintPlanEpic{EpicID: epic, Title: issueMap[epic].Title}
+		for _, kid := range children[epic] {
+			if scheduled[kid] != "" {
+				continue
+			}
+			e.Open++
+			if selected[kid] {
+				e.Planned++
+			}
+		}
+		if e.Planned > 0 {
+			e.Complete = e.Planned == e.Open
+			plan.Epics = append(plan.Epics, e)
+		}
+	}
+	return plan
+}
+
+// knapsackUnits picks the units with the greatest total value whose summed
+// minutes fit the capacity. Minutes are bucketed (rounding up, so the pick
+// never overflows) to keep the table small for large capacities.
+func knapsackUnits(units []planUnit, capacity int) []planUnit {
+	bucket := max(15, (capacity+999)/1000)
+	slots := capacity / bucket
+	weight := func(u planUnit) int { return (u.minutes + bucket - 1) / bucket }
+
+	best := make([]float64, slots+1)
+	take := make([][]bool, len(units))
+	for i, u := range units {
+		take[i] = make([]bool, slots+1)
+		w := weight(u)
+		for c := slots; c >= w; c-- {
+			if v := best[c-w] + u.value; v > best[c] {
+				best[c] = v
+				take[i][c] = true
+			}
+		}
+	}
+
+	var chosen []planUnit
+	c := slots
+	for i := len(units) - 1; i >= 0; i-- {
+		if take[i][c] {
+			chosen = append(chosen, units[i])
+			c -= weight(units[i])
+		}
+	}
+	return chosen
+}
+
+// orderPlanItems orders the selection so blockers come before dependents,
+// taking the highest-scoring ready issue first.
+func orderPlanItems(selected map[string]bool, blockers map[string][]string, scores map[string]float64, item func(string) SprintPlanItem) []SprintPlanItem {
+	waiting := make(map[string]int, len(selected))
+	dependents := make(map[string][]string)
+	for id := range selected {
+		for _, b := range blockers[id] {
+			if selected[b] {
+				waiting[id]++
+				dependents[b] = append(dependents[b], id)
+			}
+		}
+	}
+	var ready []string
+	for id := range selected {
+		if waiting[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	items := make([]SprintPlanItem, 0, len(selected))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			if scores[ready[i]] != scores[ready[j]] {
+				return scores[ready[i]] > scores[ready[j]]
+			}
+			return ready[i] < ready[j]
+		})
+		id := ready[0]
+		ready = ready[1:]
+		it := item(id)
+		it.PulledInFor = append(it.PulledInFor, dependents[id]...)
+		sort.Strings(it.PulledInFor)
+		items = append(items, it)
+		for _, d := range dependents[id] {
+			if waiting[d]--; waiting[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,176 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func planIssue(id string, minutes int, status model.Status, deps ...*model.Dependency) model.Issue {
+	return model.Issue{
+		ID: id, Title: "Issue " + id, Status: status, Priority: 2, IssueType: model.TypeTask,
+		EstimatedMinutes: &minutes, Dependencies: deps,
+	}
+}
+
+func planBlocker(id string) *model.Dependency {
+	return &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+}
+
+func TestPlanSprint_PullsBlockersFirst(t *testing.T) {
+	issues := []model.Issue{
+		planIssue("X", 60, model.StatusOpen),
+		planIssue("Y", 60, model.StatusOpen, planBlocker("X")),
+		planIssue("Z", 60, model.StatusOpen, planBlocker("Y")),
+		planIssue("done", 60, model.StatusClosed),
+	}
+	plan := PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 180})
+
+	ids := plan.BeadIDs()
+	if len(ids) != 3 || ids[0] != "X" || ids[1] != "Y" || ids[2] != "Z" {
+		t.Fatalf("expected X, Y, Z in dependency order, got %v", ids)
+	}
+	if got := plan.Items[0].PulledInFor; len(got) != 1 || got[0] != "Y" {
+		t.Errorf("expected X pulled in for Y, got %v", got)
+	}
+	if plan.PlannedMinutes != 180 || len(plan.Excluded) != 0 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+
+	// Without room for the whole chain, Z cannot be planned without its blockers.
+	plan = PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 120})
+	for _, item := range plan.Items {
+		if item.IssueID == "Z" {
+			t.Fatalf("Z planned without room for its blockers: %v", plan.BeadIDs())
+		}
+	}
+}
+
+func TestPlanSprint_Exclusions(t *testing.T) {
+	now := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		planIssue("A", 120, model.StatusOpen),
+		planIssue("B", 120, model.StatusOpen),
+		planIssue("C", 120, model.StatusOpen),
+		planIssue("big", 600, model.StatusOpen),
+		planIssue("cyc1", 30, model.StatusOpen, planBlocker("cyc2")),
+		planIssue("cyc2", 30, model.StatusOpen, planBlocker("cyc1")),
+		planIssue("stuck", 30, model.StatusBlocked),
+		planIssue("after-stuck", 30, model.StatusOpen, planBlocker("stuck")),
+		planIssue("taken", 30, model.StatusOpen),
+	}
+	sprints := []model.Sprint{
+		{ID: "current", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 4), BeadIDs: []string{"taken"}},
+		{ID: "old", StartDate: now.AddDate(0, 0, -20), EndDate: now.AddDate(0, 0, -10), BeadIDs: []string{"A"}},
+	}
+	plan := PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 240, Sprints: sprints, Now: now})
+
+	if len(plan.Items) != 2 || plan.PlannedMinutes != 240 {
+		t.Fatalf("expected two of A/B/C to fill 240m, got %v (%dm)", plan.BeadIDs(), plan.PlannedMinutes)
+	}
+	reasons := make(map[string]SprintPlanExclusion)
+	for _, ex := range plan.Excluded {
+		reasons[ex.IssueID] = ex
+	}
+	want := map[string]string{
+		"big":         PlanExcludedTooLarge,
+		"cyc1":        PlanExcludedCycle,
+		"cyc2":        PlanExcludedCycle,
+		"stuck":       PlanExcludedBlocked,
+		"after-stuck": PlanExcludedBlockerOut,
+		"taken":       PlanExcludedScheduled,
+	}
+	for id, reason := range want {
+		if reasons[id].Reason != reason {
+			t.Errorf("%s: reason %q, want %q (%+v)", id, reasons[id].Reason, reason, reasons[id])
+		}
+	}
+	if got := reasons["after-stuck"].Detail; got != "needs stuck (blocked)" {
+		t.Errorf("unexpected detail %q", got)
+	}
+	capacityOut := 0
+	for _, id := range []string{"A", "B", "C"} {
+		if reasons[id].Reason == PlanExcludedCapacity {
+			capacityOut++
+		}
+	}
+	if capacityOut != 1 || len(plan.Excluded) != len(want)+1 {
+		t.Errorf("expected exactly one of A/B/C left out for capacity, got %+v", plan.Excluded)
+	}
+}
+
+func TestPlanSprint_KeepsEpicsTogether(t *testing.T) {
+	child := func(id string) model.Issue {
+		return planIssue(id, 60, model.StatusOpen, &model.Dependency{DependsOnID: "E", Type: model.DepParentChild})
+	}
+	issues := []model.Issue{
+		{ID: "E", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic, Priority: 2},
+		child("E1"),
+		child("E2"),
+		planIssue("solo1", 60, model.StatusOpen),
+		planIssue("solo2", 60, model.StatusOpen),
+	}
+	// All five score alike; the epic bonus decides.
+	plan := PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 120})
+
+	if len(plan.Epics) != 1 || !plan.Epics[0].Complete || plan.Epics[0].Planned != 2 {
+		t.Fatalf("expected the whole epic planned, got items %v epics %+v", plan.BeadIDs(), plan.Epics)
+	}
+	for _, item := range plan.Items {
+		if item.EpicID != "E" {
+			t.Errorf("expected only epic children, got %+v", item)
+		}
+	}
+}
+
+func TestSprintPlan_NewSprint(t *testing.T) {
+	now := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	existing := []model.Sprint{
+		{ID: "sprint-1", StartDate: now.AddDate(0, 0, -20), EndDate: now.AddDate(0, 0, -6), CapacityMinutes: 1800, VelocityTarget: 12},
+		{ID: "sprint-2", StartDate: now.AddDate(0, 0, -6), EndDate: now.AddDate(0, 0, 8)},
+	}
+	if got := SprintCapacityMinutes(existing); got != 1800 {
+		t.Errorf("expected the latest recorded capacity, got %dm", got)
+	}
+	if got := SprintCapacityMinutes(nil); got != DefaultSprintCapacityMinutes {
+		t.Errorf("expected the default capacity, got %dm", got)
+	}
+	if id := NextSprintID(existing); id != "sprint-3" {
+		t.Errorf("unexpected next sprint ID %q", id)
+	}
+
+	plan := SprintPlan{CapacityMinutes: 1800, Items: []SprintPlanItem{{IssueID: "A"}, {IssueID: "B"}}}
+	s := plan.NewSprint("sprint-3", "Sprint 3", 7, existing, now)
+	wantStart := time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC)
+	if !s.StartDate.Equal(wantStart) || !s.EndDate.Equal(wantStart.AddDate(0, 0, 7)) {
+		t.Errorf("expected the sprint to start when sprint-2 ends, got %v - %v", s.StartDate, s.EndDate)
+	}
+	if len(s.BeadIDs) != 2 || s.CapacityMinutes != 1800 || s.VelocityTarget != 0 || s.Validate() != nil {
+		t.Errorf("unexpected sprint %+v", s)
+	}
+}
+
+func TestPlanSprint_UsesTriageFormula(t *testing.T) {
+	now := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		planIssue("A", 60, model.StatusOpen),
+		planIssue("B", 60, model.StatusOpen),
+	}
+	issues[0].Priority = 0
+	issues[1].Labels = []string{"customer"}
+
+	plan := PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 60, Now: now})
+	if ids := plan.BeadIDs(); len(ids) != 1 || ids[0] != "A" {
+		t.Fatalf("expected the P0 issue with built-in scoring, got %v", ids)
+	}
+
+	formula, err := ParseTriageFormula([]byte("label_multipliers:\n  customer: 10\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan = PlanSprint(issues, SprintPlanOptions{CapacityMinutes: 60, Now: now, Triage: TriageOptions{Formula: formula}})
+	if ids := plan.BeadIDs(); len(ids) != 1 || ids[0] != "B" {
+		t.Errorf("expected the label multiplier to win the slot, got %v", ids)
+	}
+}
@@ -0,0 +1,507 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Retro defaults
+const (
+	DefaultRetroOutlierFactor = 2.0 // Cycle time over this multiple of the sprint median is an outlier
+	DefaultRetroTopN          = 5   // Most blocked items and previous sprints shown
+	DefaultRetroFiles         = 10  // Churned files shown
+)
+
+// SprintRetroOptions configures BuildSprintRetro
+type SprintRetroOptions struct {
+	Sprints       []model.Sprint             // All sprints, for the velocity trend
+	Burndown      *SprintBurndown            // Git-replayed burndown; nil falls back to current state
+	History       *correlation.HistoryReport // Correlated commits; nil skips blocked time and churn
+	OutlierFactor float64                    // Default DefaultRetroOutlierFactor
+	TopN          int                        // Default DefaultRetroTopN
+	Files         int                        // Default DefaultRetroFiles
+	Now           time.Time
+}
+
+// RetroItem is an issue delivered during the sprint
+type RetroItem struct {
+	IssueID string  `json:"issue_id"`
+	Title   string  `json:"title"`
+	Minutes int     `json:"estimated_minutes"`
+	Planned bool    `json:"planned"` // In scope when the sprint started
+	Days    float64 `json:"cycle_time_days,omitempty"`
+}
+
+// RetroCycleOutlier is a delivered issue that took much longer than its peers
+type RetroCycleOutlier struct {
+	IssueID string  `json:"issue_id"`
+	Title   string  `json:"title"`
+	Days    float64 `json:"cycle_time_days"`
+	Ratio   float64 `json:"ratio_to_median"`
+}
+
+// RetroBlockedItem is time an issue spent blocked inside the sprint window
+type RetroBlockedItem struct {
+	IssueID      string   `json:"issue_id"`
+	Title        string   `json:"title"`
+	BlockedHours float64  `json:"blocked_hours"`
+	Periods      int      `json:"periods"`
+	Blockers     []string `json:"blockers,omitempty"` // Current blocking dependencies
+}
+
+// RetroVelocityPoint is the delivery of one sprint
+type RetroVelocityPoint struct {
+	SprintID         string    `json:"sprint_id"`
+	Name             string    `json:"name"`
+	EndDate          time.Time `json:"end_date"`
+	Delivered        int       `json:"delivered"`
+	DeliveredMinutes int       `json:"delivered_minutes"`
+	Current          bool      `json:"current,omitempty"`
+}
+
+// SprintRetro summarizes how a sprint went
+type SprintRetro struct {
+	SprintID         string    `json:"sprint_id"`
+	Name             string    `json:"name"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	Final            bool      `json:"final"`  // False for a sprint still running
+	Source           string    `json:"source"` // "git" when scope was replayed from history, else "snapshot"
+	Planned          int       `json:"planned"`
+	PlannedMinutes   int       `json:"planned_minutes"`
+	Added            int       `json:"added"`
+	Removed          int       `json:"removed"`
+	FinalScope       int       `json:"final_scope"`
+	Delivered        int       `json:"delivered"`
+	DeliveredMinutes int       `json:"delivered_minutes"`
+	DeliveredPlanned int       `json:"delivered_planned"` // Delivered items that were planned at the start
+	CompletionRatio  float64   `json:"completion_ratio"`  // Delivered / FinalScope
+
+	DeliveredItems []RetroItem               `json:"delivered_items"`
+	Carryover      []SprintCarryover         `json:"carryover"`
+	ScopeChanges   []SprintScopeChange       `json:"scope_changes,omitempty"`
+	CycleTime      DurationStats             `json:"cycle_time"`
+	CycleOutliers  []RetroCycleOutlier       `json:"cycle_outliers"`
+	MostBlocked    []RetroBlockedItem        `json:"most_blocked"`
+	ChurnedFiles   []correlation.FileHotspot `json:"churned_files"`
+	Velocity       []RetroVelocityPoint      `json:"velocity"` // Previous sprints oldest first, then this one
+	VelocityTrend  string                    `json:"velocity_trend"`
+}
+
+// BuildSprintRetro summarizes a sprint: planned vs delivered, carryover,
+// cycle-time outliers, blocked time, churned files and the velocity trend
+// against earlier sprints. With opts.Burndown the final scope and what was
+// closed come from the replayed state at the end of the sprint; otherwise the
+// sprint's current bead list and issue states are used.
+func BuildSprintRetro(sprint model.Sprint, issues []model.Issue, opts SprintRetroOptions) SprintRetro {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.OutlierFactor <= 0 {
+		opts.OutlierFactor = DefaultRetroOutlierFactor
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = DefaultRetroTopN
+	}
+	if opts.Files <= 0 {
+		opts.Files = DefaultRetroFiles
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, iss := range issues {
+		issueMap[iss.ID] = iss
+	}
+	cutoff := sprint.EndDate
+	if cutoff.IsZero() || opts.Now.Before(cutoff) {
+		cutoff = opts.Now
+	}
+
+	r := SprintRetro{
+		SprintID:  sprint.ID,
+		Name:      sprint.Name,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+		Final:     !sprint.EndDate.IsZero() && opts.Now.After(sprint.EndDate),
+		Source:    "snapshot",
+	}
+
+	// Scope, delivery and carryover as of the cutoff: replayed from git when
+	// a burndown is available, else read from the current beads.
+	var scope []string
+	var done []RetroItem
+	if b := opts.Burndown; b != nil {
+		r.Source = "git"
+		r.ScopeChanges = b.ScopeChanges
+		for _, c := range b.ScopeChanges {
+			if c.Action == ScopeAdded {
+				r.Added++
+			} else {
+				r.Removed++
+			}
+		}
+		r.Planned, r.PlannedMinutes = b.InitialScope, b.InitialMinutes
+		for _, c := range b.Completed {
+			title := c.Title
+			if title == "" {
+				title = issueMap[c.IssueID].Title
+			}
+			scope = append(scope, c.IssueID)
+			done = append(done, RetroItem{IssueID: c.IssueID, Title: title, Minutes: c.Minutes, Planned: !c.AddedMidSprint})
+		}
+		r.Carryover = b.Carryover
+		for _, c := range b.Carryover {
+			scope = append(scope, c.IssueID)
+		}
+	} else {
+		scope = dedupeSprintScope(sprint.BeadIDs, issueMap)
+		r.Planned = len(scope)
+		for _, id := range scope {
+			iss := issueMap[id]
+			minutes := sprintIssueMinutes(issueMap, id)
+			r.PlannedMinutes += minutes
+			if retroClosedBy(iss, cutoff) {
+				done = append(done, RetroItem{IssueID: id, Title: iss.Title, Minutes: minutes, Planned: true})
+				continue
+			}
+			carry := SprintCarryover{IssueID: id, Title: iss.Title, Status: iss.Status, Minutes: minutes}
+			if carry.Status == "" || carry.Status.IsClosed() {
+				carry.Status = model.StatusOpen // Unknown bead or closed after the sprint
+			}
+			r.Carryover = append(r.Carryover, carry)
+		}
+	}
+	r.FinalScope = len(scope)
+	if r.Carryover == nil {
+		r.Carryover = []SprintCarryover{}
+	}
+
+	r.DeliveredItems = []RetroItem{}
+	var cycleDays []float64
+	for _, item := range done {
+		if days, ok := retroCycleDays(issueMap[item.IssueID], opts.History); ok {
+			item.Days = days
+			cycleDays = append(cycleDays, days)
+		}
+		r.Delivered++
+		r.DeliveredMinutes += item.Minutes
+		if item.Planned {
+			r.DeliveredPlanned++
+		}
+		r.DeliveredItems = append(r.DeliveredItems, item)
+	}
+	if r.FinalScope > 0 {
+		r.CompletionRatio = float64(r.Delivered) / float64(r.FinalScope)
+	}
+
+	r.CycleTime = computeDurationStats(cycleDays)
+	r.CycleOutliers = []RetroCycleOutlier{}
+	if r.CycleTime.Count >= 3 && r.CycleTime.MedianDays > 0 {
+		for _, item := range r.DeliveredItems {
+			if ratio := item.Days / r.CycleTime.MedianDays; ratio > opts.OutlierFactor {
+				r.CycleOutliers = append(r.CycleOutliers, RetroCycleOutlier{IssueID: item.IssueID, Title: item.Title, Days: item.Days, Ratio: ratio})
+			}
+		}
+		sort.SliceStable(r.CycleOutliers, func(i, j int) bool { return r.CycleOutliers[i].Days > r.CycleOutliers[j].Days })
+	}
+
+	touched := append(append([]string(nil), scope...), retroRemovedIDs(r.ScopeChanges)...)
+	r.MostBlocked = retroMostBlocked(touched, issueMap, opts.History, sprint.StartDate, cutoff, opts.TopN)
+	r.ChurnedFiles = retroChurnedFiles(touched, opts.History, sprint.StartDate, cutoff, opts.Files)
+
+	r.Velocity, r.VelocityTrend = retroVelocity(sprint, r, issueMap, opts.Sprints, opts.TopN)
+	return r
+}
+
+// retroClosedBy reports whether the issue was closed at the cutoff. Closed
+// issues without a closed_at are counted as closed in time.
+func retroClosedBy(iss model.Issue, cutoff time.Time) bool {
+	if !iss.Status.IsClosed() {
+		return false
+	}
+	return iss.ClosedAt == nil || !iss.ClosedAt.After(cutoff)
+}
+
+// retroCycleDays prefers the claim-to-close time seen in git, then
+// create-to-close, then the issue's own timestamps.
+func retroCycleDays(iss model.Issue, history *correlation.HistoryReport) (float64, bool) {
+	if history != nil {
+		if h, ok := history.Histories[iss.ID]; ok && h.CycleTime != nil {
+			if d := h.CycleTime.ClaimToClose; d != nil && *d > 0 {
+				return d.Hours() / 24, true
+			}
+			if d := h.CycleTime.CreateToClose; d != nil && *d > 0 {
+				return d.Hours() / 24, true
+			}
+		}
+	}
+	if iss.ClosedAt != nil && !iss.CreatedAt.IsZero() && iss.ClosedAt.After(iss.CreatedAt) {
+		return iss.ClosedAt.Sub(iss.CreatedAt).Hours() / 24, true
+	}
+	return 0, false
+}
+
+func retroRemovedIDs(changes []SprintScopeChange) []string {
+	var ids []string
+	for _, c := range changes {
+		if c.Action == ScopeRemoved {
+			ids = append(ids, c.IssueID)
+		}
+	}
+	return ids
+}
+
+// retroMostBlocked sums blocked periods from the causality chains, clipped to
+// the sprint window, and returns the longest first.
+func retroMostBlocked(ids []string, issueMap map[string]model.Issue, history *correlation.HistoryReport, start, end time.Time, limit int) []RetroBlockedItem {
+	out := []RetroBlockedItem{}
+	if history == nil {
+		return out
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result := history.BuildCausalityChain(id, correlation.CausalityOptions{})
+		if result == nil || result.Insights == nil {
+			continue
+		}
+		var blocked time.Duration
+		periods := 0
+		for _, p := range result.Insights.BlockedPeriods {
+			from, to := p.StartTime, p.EndTime
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			if to.After(from) {
+				blocked += to.Sub(from)
+				periods++
+			}
+		}
+		if blocked <= 0 {
+			continue
+		}
+		item := RetroBlockedItem{IssueID: id, Title: issueMap[id].Title, BlockedHours: blocked.Hours(), Periods: periods}
+		if item.Title == "" {
+			item.Title = result.Chain.Title
+		}
+		for _, dep := range issueMap[id].Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				item.Blockers = append(item.Blockers, dep.DependsOnID)
+			}
+		}
+		out = append(out, item)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].BlockedHours != out[j].BlockedHours {
+			return out[i].BlockedHours > out[j].BlockedHours
+		}
+		return out[i].IssueID < out[j].IssueID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// retroChurnedFiles ranks files by how many sprint beads changed them in
+// commits inside the sprint window.
+func retroChurnedFiles(ids []string, history *correlation.HistoryReport, start, end time.Time, limit int) []correlation.FileHotspot {
+	if history == nil {
+		return []correlation.FileHotspot{}
+	}
+	window := &correlation.HistoryReport{Histories: make(map[string]correlation.BeadHistory, len(ids))}
+	for _, id := range ids {
+		h, ok := history.Histories[id]
+		if !ok {
+			continue
+		}
+		var commits []correlation.CorrelatedCommit
+		for _, c := range h.Commits {
+			if !c.Timestamp.Before(start) && !c.Timestamp.After(end) {
+				commits = append(commits, c)
+			}
+		}
+		h.Commits = commits
+		window.Histories[id] = h
+	}
+	// GetHotspots breaks ties in map order; re-sort for a stable report.
+	hotspots := correlation.NewFileLookup(window).GetHotspots(0)
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		if hotspots[i].TotalBeads != hotspots[j].TotalBeads {
+			return hotspots[i].TotalBeads > hotspots[j].TotalBeads
+		}
+		return hotspots[i].FilePath < hotspots[j].FilePath
+	})
+	if len(hotspots) > limit {
+		hotspots = hotspots[:limit]
+	}
+	return hotspots
+}
+
+// retroVelocity lists up to limit sprints that ended before this one started,
+// oldest first, followed by this sprint, and compares this sprint's delivery
+// with their average.
+func retroVelocity(sprint model.Sprint, r SprintRetro, issueMap map[string]model.Issue, sprints []model.Sprint, limit int) ([]RetroVelocityPoint, string) {
+	var previous []model.Sprint
+	for _, s := range sprints {
+		if s.ID == sprint.ID || s.EndDate.IsZero() || sprint.StartDate.IsZero() || s.EndDate.After(sprint.StartDate) {
+			continue
+		}
+		previous = append(previous, s)
+	}
+	sort.SliceStable(previous, func(i, j int) bool { return previous[i].EndDate.Before(previous[j].EndDate) })
+	if len(previous) > limit {
+		previous = previous[len(previous)-limit:]
+	}
+
+	points := make([]RetroVelocityPoint, 0, len(previous)+1)
+	total := 0
+	for _, s := range previous {
+		p := RetroVelocityPoint{SprintID: s.ID, Name: s.Name, EndDate: s.EndDate}
+		for _, id := range dedupeSprintScope(s.BeadIDs, issueMap) {
+			iss, ok := issueMap[id]
+			if !ok || !retroClosedBy(iss, s.EndDate) || (iss.ClosedAt != nil && iss.ClosedAt.Before(s.StartDate)) {
+				continue
+			}
+			p.Delivered++
+			p.DeliveredMinutes += sprintIssueMinutes(issueMap, id)
+		}
+		total += p.Delivered
+		points = append(points, p)
+	}
+	points = append(points, RetroVelocityPoint{
+		SprintID:         sprint.ID,
+		Name:             sprint.Name,
+		EndDate:          sprint.EndDate,
+		Delivered:        r.Delivered,
+		DeliveredMinutes: r.DeliveredMinutes,
+		Current:          true,
+	})
+
+	if len(previous) == 0 {
+		return points, "insufficient_data"
+	}
+	return points, compareTrend(float64(r.Delivered), float64(total)/float64(len(previous)), true)
+}
+
+// WriteMarkdown renders the retrospective as a Markdown document.
+func (r SprintRetro) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	name := r.Name
+	if name == "" {
+		name = r.SprintID
+	}
+	fmt.Fprintf(&sb, "# Sprint Retrospective: %s\n\n", name)
+	if !r.StartDate.IsZero() && !r.EndDate.IsZero() {
+		fmt.Fprintf(&sb, "*%s – %s*", r.StartDate.Format("2006-01-02"), r.EndDate.Format("2006-01-02"))
+		if !r.Final {
+			sb.WriteString(" *(in progress)*")
+		}
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("## Planned vs Delivered\n\n")
+	sb.WriteString("| | Items | Estimate |\n|---|---:|---:|\n")
+	fmt.Fprintf(&sb, "| Planned | %d | %s |\n", r.Planned, retroMinutes(r.PlannedMinutes))
+	fmt.Fprintf(&sb, "| Added mid-sprint | %d | |\n", r.Added)
+	fmt.Fprintf(&sb, "| Removed mid-sprint | %d | |\n", r.Removed)
+	fmt.Fprintf(&sb, "| Delivered | %d | %s |\n", r.Delivered, retroMinutes(r.DeliveredMinutes))
+	carryMinutes := 0
+	for _, c := range r.Carryover {
+		carryMinutes += c.Minutes
+	}
+	fmt.Fprintf(&sb, "| Carried over | %d | %s |\n\n", len(r.Carryover), retroMinutes(carryMinutes))
+	fmt.Fprintf(&sb, "%.0f%% of the final scope was delivered; %d of %d planned items.\n\n",
+		r.CompletionRatio*100, r.DeliveredPlanned, r.Planned)
+
+	if len(r.Carryover) > 0 {
+		sb.WriteString("## Carried Over\n\n")
+		sb.WriteString("| ID | Title | Status | Estimate | |\n|---|---|---|---:|---|\n")
+		for _, c := range r.Carryover {
+			note := ""
+			if c.AddedMidSprint {
+				note = "added mid-sprint"
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", c.IssueID, retroCell(c.Title), c.Status, retroMinutes(c.Minutes), note)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Cycle Time\n\n")
+	if r.CycleTime.Count == 0 {
+		sb.WriteString("No cycle times recorded for delivered items.\n\n")
+	} else {
+		fmt.Fprintf(&sb, "Median %.1f days, p85 %.1f days, max %.1f days over %d items.\n\n",
+			r.CycleTime.MedianDays, r.CycleTime.P85Days, r.CycleTime.MaxDays, r.CycleTime.Count)
+		if len(r.CycleOutliers) > 0 {
+			sb.WriteString("| ID | Title | Days | × median |\n|---|---|---:|---:|\n")
+			for _, o := range r.CycleOutliers {
+				fmt.Fprintf(&sb, "| %s | %s | %.1f | %.1f |\n", o.IssueID, retroCell(o.Title), o.Days, o.Ratio)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(r.MostBlocked) > 0 {
+		sb.WriteString("## Most Blocked\n\n")
+		sb.WriteString("| ID | Title | Blocked | Periods | Blockers |\n|---|---|---:|---:|---|\n")
+		for _, b := range r.MostBlocked {
+			fmt.Fprintf(&sb, "| %s | %s | %.1fh | %d | %s |\n", b.IssueID, retroCell(b.Title), b.BlockedHours, b.Periods, strings.Join(b.Blockers, ", "))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.ChurnedFiles) > 0 {
+		sb.WriteString("## Churned Files\n\n")
+		sb.WriteString("| File | Beads | Open |\n|---|---:|---:|\n")
+		for _, f := range r.ChurnedFiles {
+			fmt.Fprintf(&sb, "| `%s` | %d | %d |\n", f.FilePath, f.TotalBeads, f.OpenBeads)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Velocity\n\n")
+	sb.WriteString("| Sprint | Ended | Delivered | Estimate |\n|---|---|---:|---:|\n")
+	for _, p := range r.Velocity {
+		label := p.Name
+		if label == "" {
+			label = p.SprintID
+		}
+		if p.Current {
+			label = "**" + label + "**"
+		}
+		ended := ""
+		if !p.EndDate.IsZero() {
+			ended = p.EndDate.Format("2006-01-02")
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %d | %s |\n", label, ended, p.Delivered, retroMinutes(p.DeliveredMinutes))
+	}
+	fmt.Fprintf(&sb, "\nTrend: %s\n", strings.ReplaceAll(r.VelocityTrend, "_", " "))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// retroMinutes formats minutes as hours for the Markdown tables
+func retroMinutes(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%.1fh", float64(minutes)/60)
+}
+
+// retroCell escapes pipes so titles do not break table rows
+func retroCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
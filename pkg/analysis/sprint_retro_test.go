@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func retroIssue(id string, status model.Status, created time.Time, closedAfter time.Duration) model.Issue {
+	minutes := 60
+	iss := model.Issue{ID: id, Title: "Issue " + id, Status: status, CreatedAt: created, EstimatedMinutes: &minutes}
+	if closedAfter > 0 {
+		closed := created.Add(closedAfter)
+		iss.ClosedAt = &closed
+	}
+	return iss
+}
+
+func TestBuildSprintRetro_PlannedVsDelivered(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	sprint := model.Sprint{ID: "s2", Name: "Sprint 2", StartDate: start, EndDate: start.AddDate(0, 0, 9), BeadIDs: []string{"A", "B", "C", "D", "E"}}
+	issues := []model.Issue{
+		retroIssue("A", model.StatusClosed, start, day),
+		retroIssue("B", model.StatusClosed, start, day),
+		retroIssue("C", model.StatusClosed, start, day),
+		retroIssue("D", model.StatusClosed, start, 8*day), // Slow
+		retroIssue("E", model.StatusInProgress, start, 0),
+	}
+
+	r := BuildSprintRetro(sprint, issues, SprintRetroOptions{Now: start.AddDate(0, 0, 20)})
+	if r.Source != "snapshot" || !r.Final {
+		t.Fatalf("expected a finished snapshot retro, got source=%s final=%v", r.Source, r.Final)
+	}
+	if r.Planned != 5 || r.Delivered != 4 || r.DeliveredMinutes != 240 || r.DeliveredPlanned != 4 {
+		t.Errorf("unexpected totals %+v", r)
+	}
+	if r.CompletionRatio != 0.8 {
+		t.Errorf("expected 0.8 completion, got %.2f", r.CompletionRatio)
+	}
+	if len(r.Carryover) != 1 || r.Carryover[0].IssueID != "E" || r.Carryover[0].Status != model.StatusInProgress {
+		t.Errorf("expected E to carry over, got %+v", r.Carryover)
+	}
+	if len(r.CycleOutliers) != 1 || r.CycleOutliers[0].IssueID != "D" || r.CycleOutliers[0].Ratio != 8 {
+		t.Errorf("expected D as the only outlier, got %+v", r.CycleOutliers)
+	}
+	if r.VelocityTrend != "insufficient_data" || len(r.Velocity) != 1 || !r.Velocity[0].Current {
+		t.Errorf("expected only the current sprint in velocity, got %s %+v", r.VelocityTrend, r.Velocity)
+	}
+}
+
+func TestBuildSprintRetro_BurndownScope(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint, snapshots := sprintSnapshotFixture(start)
+	now := start.AddDate(0, 0, 10)
+	b := ReconstructSprintBurndown(sprint, snapshots, now)
+	issues := []model.Issue{
+		retroIssue("A", model.StatusClosed, start, time.Hour),
+		retroIssue("B", model.StatusOpen, start, 0),
+		retroIssue("C", model.StatusClosed, start, 20*24*time.Hour), // Closed after the sprint
+	}
+
+	r := BuildSprintRetro(sprint, issues, SprintRetroOptions{Burndown: &b, Now: now})
+	if r.Source != "git" || r.Planned != 2 || r.Added != 1 || r.Removed != 1 {
+		t.Fatalf("unexpected scope %+v", r)
+	}
+	if r.Delivered != 1 || r.DeliveredPlanned != 1 || len(r.Carryover) != 1 || r.Carryover[0].IssueID != "C" {
+		t.Errorf("expected A delivered and C carried, got delivered=%+v carryover=%+v", r.DeliveredItems, r.Carryover)
+	}
+
+	// Edits after the sprint (A reopened, D added to the bead list) do not
+	// rewrite what the replayed history says happened.
+	issues[0].Status = model.StatusOpen
+	issues = append(issues, retroIssue("D", model.StatusClosed, start, time.Hour))
+	sprint.BeadIDs = append(sprint.BeadIDs, "D")
+	r = BuildSprintRetro(sprint, issues, SprintRetroOptions{Burndown: &b, Now: now})
+	if r.FinalScope != 2 || r.Delivered != 1 || r.DeliveredItems[0].IssueID != "A" || r.DeliveredMinutes != 120 {
+		t.Errorf("expected the replayed final scope, got scope=%d delivered=%+v", r.FinalScope, r.DeliveredItems)
+	}
+}
+
+func TestBuildSprintRetro_BlockedAndChurn(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint := model.Sprint{ID: "s1", StartDate: start, EndDate: start.AddDate(0, 0, 9), BeadIDs: []string{"A", "B"}}
+	issues := []model.Issue{
+		retroIssue("A", model.StatusClosed, start, 48*time.Hour),
+		retroIssue("B", model.StatusClosed, start, 24*time.Hour),
+	}
+	issues[0].Dependencies = []*model.Dependency{{IssueID: "A", DependsOnID: "X", Type: model.DepBlocks}}
+
+	files := func(paths ...string) []correlation.FileChange {
+		var out []correlation.FileChange
+		for _, p := range paths {
+			out = append(out, correlation.FileChange{Path: p, Action: "M"})
+		}
+		return out
+	}
+	history := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"A": {
+			BeadID: "A",
+			Status: "closed",
+			Events: []correlation.BeadEvent{
+				// Blocked from a day before the sprint until 12h into it.
+				{EventType: correlation.EventModified, Timestamp: start.Add(-24 * time.Hour), Status: "blocked"},
+				{EventType: correlation.EventClaimed, Timestamp: start.Add(12 * time.Hour), Status: "in_progress"},
+				{EventType: correlation.EventClosed, Timestamp: start.Add(48 * time.Hour), Status: "closed"},
+			},
+			Commits: []correlation.CorrelatedCommit{
+				{SHA: "a1", Timestamp: start.Add(20 * time.Hour), Files: files("pkg/a.go", "pkg/shared.go")},
+				{SHA: "a0", Timestamp: start.Add(-48 * time.Hour), Files: files("old.go")}, // Before the sprint
+			},
+		},
+		"B": {
+			BeadID:  "B",
+			Status:  "closed",
+			Commits: []correlation.CorrelatedCommit{{SHA: "b1", Timestamp: start.Add(30 * time.Hour), Files: files("pkg/shared.go")}},
+		},
+	}}
+
+	r := BuildSprintRetro(sprint, issues, SprintRetroOptions{History: history, Now: start.AddDate(0, 0, 20)})
+	if len(r.MostBlocked) != 1 || r.MostBlocked[0].IssueID != "A" || r.MostBlocked[0].BlockedHours != 12 {
+		t.Fatalf("expected A blocked 12h inside the sprint, got %+v", r.MostBlocked)
+	}
+	if got := r.MostBlocked[0].Blockers; len(got) != 1 || got[0] != "X" {
+		t.Errorf("expected blocker X, got %v", got)
+	}
+	if len(r.ChurnedFiles) != 2 || r.ChurnedFiles[0].FilePath != "pkg/shared.go" || r.ChurnedFiles[0].TotalBeads != 2 {
+		t.Errorf("expected shared.go first and old.go excluded, got %+v", r.ChurnedFiles)
+	}
+}
+
+func TestBuildSprintRetro_VelocityTrend(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprintAt := func(id string, from time.Time, beads ...string) model.Sprint {
+		return model.Sprint{ID: id, Name: id, StartDate: from, EndDate: from.AddDate(0, 0, 9), BeadIDs: beads}
+	}
+	s1 := sprintAt("s1", start.AddDate(0, 0, -28), "P1", "P2")
+	s2 := sprintAt("s2", start.AddDate(0, 0, -14), "Q1", "Q2")
+	cur := sprintAt("s3", start, "A", "B", "C", "D")
+	issues := []model.Issue{
+		retroIssue("P1", model.StatusClosed, s1.StartDate, 24*time.Hour),
+		retroIssue("P2", model.StatusClosed, s1.StartDate, 24*time.Hour),
+		retroIssue("Q1", model.StatusClosed, s2.StartDate, 24*time.Hour),
+		retroIssue("Q2", model.StatusClosed, s2.StartDate, 30*24*time.Hour), // Missed its sprint
+		retroIssue("A", model.StatusClosed, start, 24*time.Hour),
+		retroIssue("B", model.StatusClosed, start, 24*time.Hour),
+		retroIssue("C", model.StatusClosed, start, 24*time.Hour),
+		retroIssue("D", model.StatusClosed, start, 24*time.Hour),
+	}
+
+	r := BuildSprintRetro(cur, issues, SprintRetroOptions{Sprints: []model.Sprint{s2, cur, s1}, Now: start.AddDate(0, 0, 20)})
+	if len(r.Velocity) != 3 || r.Velocity[0].SprintID != "s1" || r.Velocity[1].Delivered != 1 || r.Velocity[2].Delivered != 4 {
+		t.Fatalf("unexpected velocity %+v", r.Velocity)
+	}
+	if r.VelocityTrend != "improving" {
+		t.Errorf("expected improving trend, got %s", r.VelocityTrend)
+	}
+}
+
+func TestSprintRetro_WriteMarkdown(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint := model.Sprint{ID: "s1", Name: "Sprint 1", StartDate: start, EndDate: start.AddDate(0, 0, 9), BeadIDs: []string{"A", "B"}}
+	issues := []model.Issue{
+		retroIssue("A", model.StatusClosed, start, 24*time.Hour),
+		retroIssue("B", model.StatusOpen, start, 0),
+	}
+	issues[1].Title = "Pipe | title"
+
+	var buf bytes.Buffer
+	if err := BuildSprintRetro(sprint, issues, SprintRetroOptions{Now: start.AddDate(0, 0, 20)}).WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	md := buf.String()
+	for _, want := range []string{
+		"# Sprint Retrospective: Sprint 1",
+		"| Delivered | 1 | 1h |",
+		"## Carried Over",
+		`| B | Pipe \| title | open | 1h |`,
+		"50% of the final scope was delivered",
+		"Trend: insufficient data",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in:\n%s", want, md)
+		}
+	}
+}
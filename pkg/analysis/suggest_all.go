@@ -21,6 +21,9 @@ type SuggestAllConfig struct {
 	// Cycles warning config
 	Cycles CycleWarningConfig
 
+	// Redundant dependency config
+	Redundancy RedundancyConfig
+
 	// EnableDuplicates enables duplicate detection
 	EnableDuplicates bool
 
@@ -33,6 +36,9 @@ type SuggestAllConfig struct {
 	// EnableCycles enables cycle warnings
 	EnableCycles bool
 
+	// EnableRedundancy enables redundant dependency detection
+	EnableRedundancy bool
+
 	// MinConfidence filters suggestions below this threshold
 	MinConfidence float64
 
@@ -53,10 +59,12 @@ func DefaultSuggestAllConfig() SuggestAllConfig {
 		Dependencies:       DefaultDependencySuggestionConfig(),
 		Labels:             DefaultLabelSuggestionConfig(),
 		Cycles:             DefaultCycleWarningConfig(),
+		Redundancy:         DefaultRedundancyConfig(),
 		EnableDuplicates:   true,
 		EnableDependencies: true,
 		EnableLabels:       true,
 		EnableCycles:       true,
+		EnableRedundancy:   true,
 		MinConfidence:      0.0,
 		MaxSuggestions:     50,
 	}
@@ -87,6 +95,11 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 		allSuggestions = append(allSuggestions, cycles...)
 	}
 
+	if config.EnableRedundancy && (config.FilterType == "" || config.FilterType == SuggestionRedundantDependency) {
+		redundant := DetectRedundantDependencies(issues, config.Redundancy)
+		allSuggestions = append(allSuggestions, redundant...)
+	}
+
 	// Apply filters
 	filtered := make([]Suggestion, 0, len(allSuggestions))
 	for _, sug := range allSuggestions {
@@ -123,11 +136,12 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 
 // RobotSuggestOutput is the JSON output structure for --robot-suggest
 type RobotSuggestOutput struct {
-	GeneratedAt string        `json:"generated_at"`
-	DataHash    string        `json:"data_hash"`
-	Filters     SuggestFilter `json:"filters"`
-	Set         SuggestionSet `json:"suggestions"`
-	UsageHints  []string      `json:"usage_hints"`
+	GeneratedAt string             `json:"generated_at"`
+	DataHash    string             `json:"data_hash"`
+	Filters     SuggestFilter      `json:"filters"`
+	Set         SuggestionSet      `json:"suggestions"`
+	LabelModel  *LabelModelSummary `json:"label_model,omitempty"`
+	UsageHints  []string           `json:"usage_hints"`
 }
 
 // SuggestFilter describes applied filters
@@ -141,6 +155,11 @@ type SuggestFilter struct {
 func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, dataHash string) RobotSuggestOutput {
 	set := GenerateAllSuggestions(issues, config, dataHash)
 
+	var labelModel *LabelModelSummary
+	if config.EnableLabels && (config.FilterType == "" || config.FilterType == SuggestionLabelSuggestion) {
+		labelModel = config.Labels.Classifier.Summary()
+	}
+
 	return RobotSuggestOutput{
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		DataHash:    dataHash,
@@ -149,7 +168,8 @@ func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, d
 			MinConfidence: config.MinConfidence,
 			BeadID:        config.FilterBead,
 		},
-		Set: set,
+		Set:        set,
+		LabelModel: labelModel,
 		UsageHints: []string{
 			"jq '.suggestions.suggestions[:5]' - Top 5 suggestions by confidence",
 			"jq '.suggestions.suggestions[] | select(.type==\"potential_duplicate\")' - Filter duplicates",
@@ -157,6 +177,8 @@ func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, d
 			"jq '.suggestions.stats.by_type' - Count by suggestion type",
 			"jq '.suggestions.suggestions[].action_command' - All action commands",
 			"--suggest-type=dependency - Filter to dependency suggestions",
+			"--suggest-type=redundant - Blocking edges implied by a longer chain",
+			"jq '.label_model.evaluation | {macro_precision, macro_recall}' - Held-out label classifier quality",
 			"--suggest-confidence=0.7 - Minimum confidence threshold",
 			"--suggest-bead=<id> - Suggestions for specific bead",
 		},
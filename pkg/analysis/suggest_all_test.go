@@ -29,6 +29,9 @@ func TestDefaultSuggestAllConfig(t *testing.T) {
 	if !config.EnableCycles {
 		t.Error("EnableCycles should be true by default")
 	}
+	if !config.EnableRedundancy {
+		t.Error("EnableRedundancy should be true by default")
+	}
 
 	// Default limits
 	if config.MinConfidence != 0.0 {
@@ -22,6 +22,9 @@ const (
 
 	// SuggestionCycleWarning warns about potential dependency cycles
 	SuggestionCycleWarning SuggestionType = "cycle_warning"
+
+	// SuggestionRedundantDependency flags blocking edges implied by a longer chain
+	SuggestionRedundantDependency SuggestionType = "redundant_dependency"
 )
 
 // Suggestion represents a smart recommendation for project hygiene
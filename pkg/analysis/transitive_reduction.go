@@ -0,0 +1,328 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// RedundantEdge is a blocking dependency implied by a longer chain of
+// blocking dependencies. From depends on To, and Path (From first, To last)
+// is another route between them that makes the direct edge unnecessary.
+type RedundantEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Path []string `json:"path"`
+}
+
+// TransitiveReduction describes the transitive reduction of the blocking DAG.
+type TransitiveReduction struct {
+	// TotalEdges counts blocking edges between known issues.
+	TotalEdges int `json:"total_edges"`
+	// ReducedEdges counts the edges left after removing redundant ones.
+	ReducedEdges int `json:"reduced_edges"`
+	// Redundant lists the removable edges, sorted by From then To.
+	Redundant []RedundantEdge `json:"redundant"`
+	// CyclicIssues lists issues on dependency cycles. Edges touching them
+	// are left alone because a cyclic graph has no unique reduction.
+	CyclicIssues []string `json:"cyclic_issues,omitempty"`
+}
+
+// ComputeTransitiveReduction finds every blocking edge A→C for which another
+// blocking path A→…→C exists. Removing all of them at once keeps the same
+// reachability, so the reduced graph has the same "what blocks what" answers
+// with fewer edges.
+func ComputeTransitiveReduction(issues []model.Issue) TransitiveReduction {
+	result, reduced := findRedundantEdges(issues)
+	for i := range result.Redundant {
+		e := &result.Redundant[i]
+		e.Path = alternatePath(reduced, e.From, e.To)
+	}
+	return result
+}
+
+// findRedundantEdges is ComputeTransitiveReduction without the explaining
+// paths. It also returns the reduced adjacency for alternatePath.
+//
+// An edge A→C is redundant when another successor B of A reaches C. Reachability
+// is precomputed once per strongly connected component as a bitset, so the check
+// costs O(E·V/64) overall instead of a graph search per edge.
+func findRedundantEdges(issues []model.Issue) (TransitiveReduction, map[string][]string) {
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+
+	adj := make(map[string][]string)
+	var result TransitiveReduction
+	for _, issue := range issues {
+		seen := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !known[dep.DependsOnID] ||
+				dep.DependsOnID == issue.ID || seen[dep.DependsOnID] {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			adj[issue.ID] = append(adj[issue.ID], dep.DependsOnID)
+			result.TotalEdges++
+		}
+	}
+
+	ids := make([]string, 0, len(adj))
+	for id := range adj {
+		sort.Strings(adj[id])
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	g := condense(adj)
+	cyclic := g.cyclicNodes()
+	for id := range cyclic {
+		result.CyclicIssues = append(result.CyclicIssues, id)
+	}
+	sort.Strings(result.CyclicIssues)
+
+	// First decide which edges are redundant, then explain each one with a
+	// path through the reduced graph so no explanation leans on another
+	// edge that is itself being removed.
+	reduced := make(map[string][]string, len(adj))
+	for _, from := range ids {
+		for _, to := range adj[from] {
+			if !cyclic[from] && !cyclic[to] && g.reachableAvoiding(adj[from], to) {
+				result.Redundant = append(result.Redundant, RedundantEdge{From: from, To: to})
+				continue
+			}
+			reduced[from] = append(reduced[from], to)
+		}
+	}
+
+	result.ReducedEdges = result.TotalEdges - len(result.Redundant)
+	return result, reduced
+}
+
+// alternatePath returns the shortest path from→…→to that does not use a
+// direct edge, or nil if there is none.
+func alternatePath(adj map[string][]string, from, to string) []string {
+	parent := map[string]string{from: ""}
+	queue := []string{}
+	for _, next := range adj[from] {
+		if next != to {
+			parent[next] = from
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range adj[cur] {
+			if _, ok := parent[next]; ok {
+				continue
+			}
+			parent[next] = cur
+			if next == to {
+				var path []string
+				for n := to; n != ""; n = parent[n] {
+					path = append(path, n)
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// condensation maps each node to its strongly connected component and holds,
+// per component, the bitset of components reachable from it (itself included).
+type condensation struct {
+	comp  map[string]int
+	size  []int
+	reach [][]uint64
+}
+
+// condense runs Tarjan's algorithm. Components are numbered in the order they
+// complete, which is sinks first, so every successor's reach set is final
+// by the time a component's own set is built.
+func condense(adj map[string][]string) condensation {
+	nodes := make([]string, 0, len(adj))
+	for id := range adj {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	next := 0
+	g := condensation{comp: make(map[string]int)}
+	var members [][]string
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		index[v] = next
+		low[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, seen := index[w]; !seen {
+				strongConnect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+
+		if low[v] == index[v] {
+			c := len(members)
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				g.comp[w] = c
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			members = append(members, component)
+		}
+	}
+
+	for _, v := range nodes {
+		if _, seen := index[v]; !seen {
+			strongConnect(v)
+		}
+	}
+
+	words := (len(members) + 63) / 64
+	g.size = make([]int, len(members))
+	g.reach = make([][]uint64, len(members))
+	for c, component := range members {
+		g.size[c] = len(component)
+		set := make([]uint64, words)
+		set[c/64] |= 1 << (c % 64)
+		for _, v := range component {
+			for _, w := range adj[v] {
+				if d := g.comp[w]; d != c {
+					for k, bits := range g.reach[d] {
+						set[k] |= bits
+					}
+				}
+			}
+		}
+		g.reach[c] = set
+	}
+	return g
+}
+
+// cyclicNodes returns the nodes that belong to a strongly connected
+// component with more than one member.
+func (g condensation) cyclicNodes() map[string]bool {
+	cyclic := make(map[string]bool)
+	for id, c := range g.comp {
+		if g.size[c] > 1 {
+			cyclic[id] = true
+		}
+	}
+	return cyclic
+}
+
+// reachableAvoiding reports whether to is reachable from a node in succs
+// other than to itself.
+func (g condensation) reachableAvoiding(succs []string, to string) bool {
+	target := g.comp[to]
+	for _, s := range succs {
+		if s != to && g.reach[g.comp[s]][target/64]&(1<<(target%64)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyTransitiveReduction returns a copy of issues with the redundant
+// blocking dependencies removed. The input slice is not modified.
+func ApplyTransitiveReduction(issues []model.Issue, reduction TransitiveReduction) []model.Issue {
+	drop := make(map[string]map[string]bool)
+	for _, e := range reduction.Redundant {
+		if drop[e.From] == nil {
+			drop[e.From] = make(map[string]bool)
+		}
+		drop[e.From][e.To] = true
+	}
+
+	out := make([]model.Issue, len(issues))
+	for i, issue := range issues {
+		out[i] = issue
+		targets := drop[issue.ID]
+		if targets == nil {
+			continue
+		}
+		deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && targets[dep.DependsOnID] {
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		out[i].Dependencies = deps
+	}
+	return out
+}
+
+// RedundancyConfig configures redundant dependency suggestions
+type RedundancyConfig struct {
+	// MaxSuggestions caps the number of redundant edges reported
+	// Default: 20
+	MaxSuggestions int
+}
+
+// DefaultRedundancyConfig returns sensible defaults
+func DefaultRedundancyConfig() RedundancyConfig {
+	return RedundancyConfig{
+		MaxSuggestions: 20,
+	}
+}
+
+// DetectRedundantDependencies suggests removing blocking edges that are
+// already implied by a longer blocking chain.
+func DetectRedundantDependencies(issues []model.Issue, config RedundancyConfig) []Suggestion {
+	reduction, reduced := findRedundantEdges(issues)
+
+	var suggestions []Suggestion
+	for _, e := range reduction.Redundant {
+		if config.MaxSuggestions > 0 && len(suggestions) >= config.MaxSuggestions {
+			break
+		}
+		// Only the reported edges need an explaining path.
+		e.Path = alternatePath(reduced, e.From, e.To)
+
+		// Short detours are almost certainly bookkeeping leftovers; a long
+		// detour may be a deliberate shortcut someone wants to keep visible.
+		confidence := 0.95 - float64(len(e.Path)-3)*0.05
+		if confidence < 0.7 {
+			confidence = 0.7
+		}
+
+		sug := NewSuggestion(
+			SuggestionRedundantDependency,
+			e.From,
+			fmt.Sprintf("%s → %s is implied by another blocking chain", e.From, e.To),
+			fmt.Sprintf("Already blocked via %s", strings.Join(e.Path, " → ")),
+			confidence,
+		).WithRelatedBead(e.To).
+			WithAction(fmt.Sprintf("bd dep remove %s %s", e.From, e.To)).
+			WithMetadata("path", e.Path)
+
+		suggestions = append(suggestions, sug)
+	}
+	return suggestions
+}
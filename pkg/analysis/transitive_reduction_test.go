@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func blockedBy(id string, blockers ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: model.StatusOpen}
+	for _, b := range blockers {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return issue
+}
+
+func TestComputeTransitiveReduction(t *testing.T) {
+	// A→B→C→D plus shortcuts A→C and A→D; E relates to A but that is not blocking.
+	issues := []model.Issue{
+		blockedBy("A", "B", "C", "D"),
+		blockedBy("B", "C"),
+		blockedBy("C", "D"),
+		blockedBy("D"),
+		{ID: "E", Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+	}
+
+	r := ComputeTransitiveReduction(issues)
+	if r.TotalEdges != 5 || r.ReducedEdges != 3 {
+		t.Fatalf("expected 5 edges reduced to 3, got %d → %d", r.TotalEdges, r.ReducedEdges)
+	}
+	want := []RedundantEdge{
+		{From: "A", To: "C", Path: []string{"A", "B", "C"}},
+		{From: "A", To: "D", Path: []string{"A", "B", "C", "D"}},
+	}
+	if !reflect.DeepEqual(r.Redundant, want) {
+		t.Errorf("unexpected redundant edges:\n got %+v\nwant %+v", r.Redundant, want)
+	}
+
+	reduced := ApplyTransitiveReduction(issues, r)
+	if len(reduced[0].Dependencies) != 1 || reduced[0].Dependencies[0].DependsOnID != "B" {
+		t.Errorf("expected A to keep only its edge to B, got %+v", reduced[0].Dependencies)
+	}
+	if len(issues[0].Dependencies) != 3 {
+		t.Error("ApplyTransitiveReduction modified its input")
+	}
+	if len(reduced[4].Dependencies) != 1 {
+		t.Error("non-blocking dependencies should be kept")
+	}
+}
+
+func TestComputeTransitiveReduction_LeavesCyclesAlone(t *testing.T) {
+	// A and B block each other and both block C; removing either A→C or
+	// B→C via the other would be safe alone but not together.
+	issues := []model.Issue{
+		blockedBy("A", "B", "C"),
+		blockedBy("B", "A", "C"),
+		blockedBy("C"),
+		blockedBy("X", "Y", "C"),
+		blockedBy("Y", "C"),
+	}
+
+	r := ComputeTransitiveReduction(issues)
+	if !reflect.DeepEqual(r.CyclicIssues, []string{"A", "B"}) {
+		t.Errorf("expected A and B reported as cyclic, got %v", r.CyclicIssues)
+	}
+	if len(r.Redundant) != 1 || r.Redundant[0].From != "X" || r.Redundant[0].To != "C" {
+		t.Errorf("expected only X→C to be redundant, got %+v", r.Redundant)
+	}
+}
+
+func TestComputeTransitiveReduction_MatchesSearchThroughCycles(t *testing.T) {
+	// Layered graph with a cycle in the middle: P→A↔B→Q, plus shortcuts and
+	// stragglers. Every edge's verdict must match a plain graph search.
+	issues := []model.Issue{
+		blockedBy("P", "A", "Q", "R"),
+		blockedBy("A", "B"),
+		blockedBy("B", "A", "Q"),
+		blockedBy("Q", "R"),
+		blockedBy("R"),
+		blockedBy("S", "P", "R"),
+	}
+	adj := map[string][]string{}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			adj[issue.ID] = append(adj[issue.ID], dep.DependsOnID)
+		}
+	}
+	cyclic := condense(adj).cyclicNodes()
+
+	redundant := map[[2]string]bool{}
+	r := ComputeTransitiveReduction(issues)
+	for _, e := range r.Redundant {
+		redundant[[2]string{e.From, e.To}] = true
+		if len(e.Path) < 3 || e.Path[0] != e.From || e.Path[len(e.Path)-1] != e.To {
+			t.Errorf("bad explaining path for %s→%s: %v", e.From, e.To, e.Path)
+		}
+	}
+	for from, tos := range adj {
+		for _, to := range tos {
+			want := !cyclic[from] && !cyclic[to] && alternatePath(adj, from, to) != nil
+			if redundant[[2]string{from, to}] != want {
+				t.Errorf("%s→%s: redundant=%v, graph search says %v", from, to, !want, want)
+			}
+		}
+	}
+	if !redundant[[2]string{"P", "Q"}] {
+		t.Error("P→Q is implied through the A↔B cycle and should be redundant")
+	}
+}
+
+func TestDetectRedundantDependencies(t *testing.T) {
+	issues := []model.Issue{
+		blockedBy("A", "B", "C", "D"),
+		blockedBy("B", "C"),
+		blockedBy("C", "D"),
+		blockedBy("D"),
+	}
+
+	sugs := DetectRedundantDependencies(issues, DefaultRedundancyConfig())
+	if len(sugs) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(sugs))
+	}
+	s := sugs[0]
+	if s.Type != SuggestionRedundantDependency || s.TargetBead != "A" || s.RelatedBead != "C" ||
+		s.ActionCommand != "bd dep remove A C" || s.Confidence != 0.95 {
+		t.Errorf("unexpected suggestion: %+v", s)
+	}
+	if sugs[1].Confidence >= s.Confidence {
+		t.Errorf("expected longer detour to lower confidence, got %v", sugs[1].Confidence)
+	}
+
+	set := GenerateAllSuggestions(issues, SuggestAllConfig{EnableRedundancy: true, Redundancy: RedundancyConfig{MaxSuggestions: 1}}, "h")
+	if set.Stats.ByType[SuggestionRedundantDependency] != 1 {
+		t.Errorf("expected GenerateAllSuggestions to honor the redundancy cap, got %+v", set.Stats.ByType)
+	}
+}
@@ -39,6 +39,7 @@ type TriageMeta struct {
 	Phase2Ready   bool      `json:"phase2_ready"`
 	IssueCount    int       `json:"issue_count"`
 	ComputeTimeMs int64     `json:"compute_time_ms"`
+	Formula       string    `json:"formula,omitempty"` // Custom scoring formula from .bv/triage.yaml, if any
 }
 
 // QuickRef provides at-a-glance summary for fast decisions
@@ -299,6 +300,9 @@ type TriageOptions struct {
 	// bv-87: Track/label-aware recommendation grouping for multi-agent coordination
 	GroupByTrack bool // Group recommendations by execution track (connected component)
 	GroupByLabel bool // Group recommendations by primary label
+
+	// Formula overrides scoring and ranking (.bv/triage.yaml); nil keeps the built-in score
+	Formula *TriageFormula
 }
 
 // TrackRecommendationGroup groups recommendations by execution track (bv-87)
@@ -389,12 +393,23 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	// Compute counts (uses cached actionable issues)
 	counts := computeCountsWithContext(issues, triageCtx)
 
-	// Compute enhanced triage scores (bv-147)
-	triageScores := computeTriageScoresFromImpact(impactScores, unblocksMap, analyzer, DefaultTriageScoringOptions())
+	// Compute enhanced triage scores (bv-147), due-date boosts and the team formula
+	triageScores, dueReasons, formulaReasons := rankTriageScores(analyzer, triageCtx, impactScores, unblocksMap, issues, opts.Formula, now)
 
 	// Build recommendations using enhanced scores (bv-148)
 	// Pass triageCtx instead of analyzer for cached blocker lookups (bv-k4az)
 	recommendations := buildRecommendationsFromTriageScores(triageScores, triageCtx, opts.TopN)
+	for i := range recommendations {
+		id := recommendations[i].ID
+		var extra []string
+		extra = append(extra, formulaReasons[id]...)
+		if reason, ok := dueReasons[id]; ok {
+			extra = append(extra, reason)
+		}
+		if len(extra) > 0 {
+			recommendations[i].Reasons = append(extra, recommendations[i].Reasons...)
+		}
+	}
 
 	// Build quick wins
 	quickWins := buildQuickWins(impactScores, unblocksMap, opts.QuickWinN)
@@ -431,6 +446,7 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 			Phase2Ready:   stats.IsPhase2Ready(),
 			IssueCount:    len(issues),
 			ComputeTimeMs: elapsed.Milliseconds(),
+			Formula:       formulaSource(opts.Formula),
 		},
 		QuickRef: QuickRef{
 			OpenCount:       counts.Open,
@@ -454,6 +470,51 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	}
 }
 
+// ComputeRankedTriageScores scores every issue the way ComputeTriage ranks
+// them: the enhanced triage score with due-date boosts, then opts.Formula when
+// set. Issues excluded by a formula rule are left out.
+func ComputeRankedTriageScores(issues []model.Issue, opts TriageOptions, now time.Time) []TriageScore {
+	if len(issues) == 0 {
+		return nil
+	}
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	impactScores := analyzer.ComputeImpactScoresFromStats(&stats, now)
+	unblocksMap := buildUnblocksMap(analyzer, issues)
+	scores, _, _ := rankTriageScores(analyzer, NewTriageContext(analyzer), impactScores, unblocksMap, issues, opts.Formula, now)
+	return scores
+}
+
+// rankTriageScores turns impact scores into the final triage ranking and
+// returns the due-date and formula reasons added per issue.
+func rankTriageScores(analyzer *Analyzer, triageCtx *TriageContext, impactScores []ImpactScore, unblocksMap map[string][]string, issues []model.Issue, formula *TriageFormula, now time.Time) ([]TriageScore, map[string]string, map[string][]string) {
+	scoringOpts := DefaultTriageScoringOptions()
+	triageScores := computeTriageScoresFromImpact(impactScores, unblocksMap, analyzer, scoringOpts)
+
+	// Boost items that are late or at risk of missing their due date, along
+	// with the blockers holding them up
+	dueReport := ComputeDueDateRisks(issues, DueDateConfig{Now: now})
+	dueReasons := applyDueDateBoosts(triageScores, dueReport, scoringOpts.DueDateWeight)
+
+	// Team-defined formula, label multipliers and hard rules (.bv/triage.yaml)
+	var formulaReasons map[string][]string
+	if formula != nil {
+		dueByID := make(map[string]*DueDateRisk, len(dueReport.Issues))
+		for i := range dueReport.Issues {
+			dueByID[dueReport.Issues[i].IssueID] = &dueReport.Issues[i]
+		}
+		triageScores, formulaReasons = formula.apply(triageScores, func(score TriageScore) formulaEnv {
+			return triageFormulaEnv(score, triageFormulaInputs{
+				issue:    analyzer.GetIssue(score.IssueID),
+				unblocks: len(unblocksMap[score.IssueID]),
+				blocked:  len(triageCtx.OpenBlockers(score.IssueID)) > 0,
+				due:      dueByID[score.IssueID],
+			}, now)
+		})
+	}
+	return triageScores, dueReasons, formulaReasons
+}
+
 // buildUnblocksMap computes what each issue unblocks
 func buildUnblocksMap(analyzer *Analyzer, issues []model.Issue) map[string][]string {
 	// O(E) unblocks computation.
@@ -911,6 +972,7 @@ type TriageScore struct {
 type TriageFactors struct {
 	UnblockBoost   float64 `json:"unblock_boost"`             // Boost for items that unblock many others
 	QuickWinBoost  float64 `json:"quick_win_boost"`           // Boost for low-effort high-impact items
+	DueDateBoost   float64 `json:"due_date_boost,omitempty"`  // Boost for late or at-risk due dates
 	LabelHealth    float64 `json:"label_health,omitempty"`    // Phase 2: Label health factor
 	ClaimPenalty   float64 `json:"claim_penalty,omitempty"`   // Phase 3: Penalty for claimed items
 	AttentionScore float64 `json:"attention_score,omitempty"` // Phase 4: Attention-weighted health
@@ -922,6 +984,7 @@ type TriageScoringOptions struct {
 	BaseScoreWeight    float64 // Default 0.70
 	UnblockBoostWeight float64 // Default 0.15
 	QuickWinWeight     float64 // Default 0.15
+	DueDateWeight      float64 // Default 0.15; max boost for late/at-risk due dates
 
 	// Thresholds
 	UnblockThreshold int // Min unblocks to get full boost (default 5)
@@ -940,6 +1003,7 @@ func DefaultTriageScoringOptions() TriageScoringOptions {
 		BaseScoreWeight:    0.70,
 		UnblockBoostWeight: 0.15,
 		QuickWinWeight:     0.15,
+		DueDateWeight:      0.15,
 		UnblockThreshold:   5,
 		QuickWinMaxDepth:   2,
 		// All optional features off by default (MVP mode)
@@ -991,15 +1055,18 @@ func computeTriageScoresFromImpact(baseScores []ImpactScore, unblocksMap map[str
 		triageScores = append(triageScores, ts)
 	}
 
-	// Sort by triage score descending
+	sortTriageScores(triageScores)
+	return triageScores
+}
+
+// sortTriageScores sorts by triage score descending, then by ID
+func sortTriageScores(triageScores []TriageScore) {
 	sort.Slice(triageScores, func(i, j int) bool {
 		if triageScores[i].TriageScore != triageScores[j].TriageScore {
 			return triageScores[i].TriageScore > triageScores[j].TriageScore
 		}
 		return triageScores[i].IssueID < triageScores[j].IssueID
 	})
-
-	return triageScores
 }
 
 // computeSingleTriageScore calculates the triage score for a single issue
@@ -0,0 +1,408 @@
+package analysis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// TriageFormulaFile is the project-level triage scoring config filename
+const TriageFormulaFile = "triage.yaml"
+
+// TriageFormulaPath returns the triage scoring config path for a project
+func TriageFormulaPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", TriageFormulaFile)
+}
+
+// TriageFormulaVariable documents one variable available to triage formulas.
+type TriageFormulaVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TriageFormulaVariables lists the variables a formula may reference.
+var TriageFormulaVariables = []TriageFormulaVariable{
+	{"pagerank", "PageRank, normalized 0..1"},
+	{"betweenness", "Betweenness centrality, normalized 0..1"},
+	{"blocker_ratio", "Share of issues this one blocks, normalized 0..1"},
+	{"staleness", "Days since update, normalized 0..1"},
+	{"priority_boost", "Priority as a 0..1 boost (P0 = 1)"},
+	{"time_to_impact", "Critical-path depth and estimate urgency, 0..1"},
+	{"urgency", "Urgent labels and age decay, 0..1"},
+	{"risk", "Volatility and risk signals, 0..1"},
+	{"priority", "Raw priority (0 = P0 .. 4 = P4)"},
+	{"unblocks", "Number of issues this one directly unblocks"},
+	{"blocked", "1 if the issue has open blockers, else 0"},
+	{"in_progress", "1 if the issue is in progress, else 0"},
+	{"due_risk", "Chance of missing the due date (0 when on track or undated)"},
+	{"overdue", "1 if the due date has passed, else 0"},
+	{"has_due", "1 if the issue has a due date, else 0"},
+	{"days_until_due", "Days until the due date (negative when overdue, 0 when undated)"},
+	{"age_days", "Days since creation"},
+	{"days_since_update", "Days since the last update"},
+	{"estimated_minutes", "Explicit estimate in minutes (0 when unset)"},
+	{"base_score", "Built-in impact score"},
+	{"triage_score", "Built-in triage score, including unblock, quick-win and due-date boosts"},
+}
+
+// TriageRule is a hard ranking rule applied after scoring. An issue matches
+// when it has Label (if set) and When (if set) evaluates to non-zero.
+type TriageRule struct {
+	Name    string `yaml:"name" json:"name"`
+	Label   string `yaml:"label,omitempty" json:"label,omitempty"`
+	When    string `yaml:"when,omitempty" json:"when,omitempty"`
+	Top     int    `yaml:"top,omitempty" json:"top,omitempty"`         // Keep matches within the top N
+	Exclude bool   `yaml:"exclude,omitempty" json:"exclude,omitempty"` // Drop matches from recommendations
+
+	when *formulaExpr
+}
+
+// TriageFormula is a team-defined scoring formula loaded from .bv/triage.yaml.
+// It replaces the built-in triage score with a safe arithmetic expression,
+// then applies per-label multipliers and hard rules.
+type TriageFormula struct {
+	// Formula computes the score; empty keeps the built-in triage score
+	Formula string `yaml:"formula,omitempty" json:"formula,omitempty"`
+	// Fields are named helper expressions usable in Formula and rules
+	Fields map[string]string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	// LabelMultipliers scale the score of issues carrying the label
+	LabelMultipliers map[string]float64 `yaml:"label_multipliers,omitempty" json:"label_multipliers,omitempty"`
+	// Rules are applied in order after scoring; earlier rules win
+	Rules []TriageRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+
+	formula    *formulaExpr
+	fields     map[string]*formulaExpr
+	fieldNames []string
+}
+
+// LoadTriageFormula loads and validates .bv/triage.yaml.
+// Returns nil (and no error) if the file doesn't exist.
+func LoadTriageFormula(projectDir string) (*TriageFormula, error) {
+	path := TriageFormulaPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading triage config: %w", err)
+	}
+	f, err := ParseTriageFormula(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// ParseTriageFormula parses and validates a triage formula config.
+// Unknown keys are rejected so typos don't silently fall back to defaults.
+func ParseTriageFormula(data []byte) (*TriageFormula, error) {
+	var f TriageFormula
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing triage config: %w", err)
+	}
+	if err := f.Compile(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Compile validates the config and compiles all expressions.
+func (f *TriageFormula) Compile() error {
+	known := make(map[string]bool, len(TriageFormulaVariables)+len(f.Fields))
+	for _, v := range TriageFormulaVariables {
+		known[v.Name] = true
+	}
+
+	// Fields may only reference built-in variables, so they can be
+	// evaluated in any order.
+	builtins := make(map[string]bool, len(known))
+	for k := range known {
+		builtins[k] = true
+	}
+	f.fields = make(map[string]*formulaExpr, len(f.Fields))
+	f.fieldNames = f.fieldNames[:0]
+	for name := range f.Fields {
+		f.fieldNames = append(f.fieldNames, name)
+	}
+	sort.Strings(f.fieldNames)
+	for _, name := range f.fieldNames {
+		if !isFormulaIdent(name) {
+			return fmt.Errorf("field %q: name must be letters, digits and underscores", name)
+		}
+		if builtins[name] || name == "true" || name == "false" {
+			return fmt.Errorf("field %q: shadows a built-in variable", name)
+		}
+		if _, isFunc := formulaFuncs[name]; isFunc {
+			return fmt.Errorf("field %q: shadows a built-in function", name)
+		}
+		expr, err := compileFormula(f.Fields[name], builtins)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+		f.fields[name] = expr
+		known[name] = true
+	}
+
+	f.formula = nil
+	if strings.TrimSpace(f.Formula) != "" {
+		expr, err := compileFormula(f.Formula, known)
+		if err != nil {
+			return fmt.Errorf("formula: %w", err)
+		}
+		f.formula = expr
+	}
+
+	for label, mult := range f.LabelMultipliers {
+		if mult <= 0 || math.IsInf(mult, 0) || math.IsNaN(mult) {
+			return fmt.Errorf("label_multipliers[%q]: must be positive (use an exclude rule to drop issues)", label)
+		}
+	}
+
+	for i := range f.Rules {
+		r := &f.Rules[i]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if r.Label == "" && strings.TrimSpace(r.When) == "" {
+			return fmt.Errorf("rule %q: needs a label or a when expression", r.Name)
+		}
+		if (r.Top > 0) == r.Exclude {
+			return fmt.Errorf("rule %q: set exactly one of top (> 0) or exclude", r.Name)
+		}
+		if r.Top < 0 {
+			return fmt.Errorf("rule %q: top must be positive", r.Name)
+		}
+		r.when = nil
+		if strings.TrimSpace(r.When) != "" {
+			expr, err := compileFormula(r.When, known)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", r.Name, err)
+			}
+			r.when = expr
+		}
+	}
+	return nil
+}
+
+func isFormulaIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// triageFormulaInputs gathers per-issue inputs for formula variables.
+type triageFormulaInputs struct {
+	issue    *model.Issue
+	unblocks int
+	blocked  bool
+	due      *DueDateRisk
+}
+
+// triageFormulaEnv builds the variable environment for one scored issue.
+func triageFormulaEnv(score TriageScore, in triageFormulaInputs, now time.Time) formulaEnv {
+	bd := score.Breakdown
+	vars := map[string]float64{
+		"pagerank":       bd.PageRankNorm,
+		"betweenness":    bd.BetweennessNorm,
+		"blocker_ratio":  bd.BlockerRatioNorm,
+		"staleness":      bd.StalenessNorm,
+		"priority_boost": bd.PriorityBoostNorm,
+		"time_to_impact": bd.TimeToImpactNorm,
+		"urgency":        bd.UrgencyNorm,
+		"risk":           bd.RiskNorm,
+		"priority":       float64(score.Priority),
+		"unblocks":       float64(in.unblocks),
+		"blocked":        boolToFloat(in.blocked),
+		"in_progress":    boolToFloat(score.Status == string(model.StatusInProgress)),
+		"base_score":     score.BaseScore,
+		"triage_score":   score.TriageScore,
+	}
+
+	var labels []string
+	if in.issue != nil {
+		labels = in.issue.Labels
+		if !in.issue.CreatedAt.IsZero() {
+			vars["age_days"] = math.Max(0, now.Sub(in.issue.CreatedAt).Hours()/24)
+		}
+		if !in.issue.UpdatedAt.IsZero() {
+			vars["days_since_update"] = math.Max(0, now.Sub(in.issue.UpdatedAt).Hours()/24)
+		}
+		if in.issue.EstimatedMinutes != nil {
+			vars["estimated_minutes"] = float64(*in.issue.EstimatedMinutes)
+		}
+		if in.issue.DueDate != nil {
+			vars["has_due"] = 1
+			vars["days_until_due"] = in.issue.DueDate.Sub(now).Hours() / 24
+		}
+	}
+	if in.due != nil {
+		if in.due.Risk != DueDateOnTrack {
+			vars["due_risk"] = 1 - in.due.OnTimeLikelihood
+		}
+		vars["overdue"] = boolToFloat(in.due.Overdue)
+	}
+	return formulaEnv{vars: vars, labels: labels}
+}
+
+// apply rescores, applies label multipliers, sorts and enforces rules. It
+// returns the reordered scores (excluded issues removed) and the reasons to
+// prepend to each affected recommendation.
+func (f *TriageFormula) apply(scores []TriageScore, envFor func(TriageScore) formulaEnv) ([]TriageScore, map[string][]string) {
+	reasons := make(map[string][]string)
+	envs := make(map[string]formulaEnv, len(scores))
+
+	for i := range scores {
+		s := &scores[i]
+		env := envFor(*s)
+		for _, name := range f.fieldNames {
+			env.vars[name] = f.fields[name].Eval(env)
+		}
+		envs[s.IssueID] = env
+
+		if f.formula != nil {
+			s.TriageScore = f.formula.Eval(env)
+			s.FactorsApplied = append(s.FactorsApplied, "formula")
+			reasons[s.IssueID] = append(reasons[s.IssueID], formulaReason(s.TriageScore, f.formula, env))
+		}
+
+		mult := 1.0
+		var multLabels []string
+		for _, label := range env.labels {
+			for name, m := range f.LabelMultipliers {
+				if strings.EqualFold(name, label) && m != 1 {
+					mult *= m
+					multLabels = append(multLabels, label)
+				}
+			}
+		}
+		if len(multLabels) > 0 {
+			s.TriageScore *= mult
+			s.FactorsApplied = append(s.FactorsApplied, "label_multiplier")
+			reasons[s.IssueID] = append(reasons[s.IssueID],
+				fmt.Sprintf("⚖️ Label multiplier ×%.2f (%s)", mult, strings.Join(multLabels, ", ")))
+		}
+	}
+	sortTriageScores(scores)
+
+	matches := func(r TriageRule, s TriageScore) bool {
+		env := envs[s.IssueID]
+		if r.Label != "" && !hasLabel(env.labels, r.Label) {
+			return false
+		}
+		return r.when == nil || r.when.Eval(env) != 0
+	}
+
+	// Exclusions first, then pins from last to first so earlier rules
+	// have the final say on the top slots.
+	for _, r := range f.Rules {
+		if !r.Exclude {
+			continue
+		}
+		kept := scores[:0]
+		for _, s := range scores {
+			if !matches(r, s) {
+				kept = append(kept, s)
+			}
+		}
+		scores = kept
+	}
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		r := f.Rules[i]
+		if r.Top <= 0 {
+			continue
+		}
+		var pinned []string
+		for _, s := range scores {
+			if len(pinned) < r.Top && matches(r, s) {
+				pinned = append(pinned, s.IssueID)
+			}
+		}
+		if len(pinned) == 0 {
+			continue
+		}
+		scores = pinTriageScores(scores, pinned, r.Top)
+		for _, id := range pinned {
+			reasons[id] = append(reasons[id], fmt.Sprintf("📌 Rule '%s' keeps this in the top %d", r.Name, r.Top))
+		}
+	}
+	return scores, reasons
+}
+
+// pinTriageScores moves the pinned issues into the first top slots while
+// keeping everything else in score order.
+func pinTriageScores(scores []TriageScore, pinned []string, top int) []TriageScore {
+	isPinned := make(map[string]bool, len(pinned))
+	for _, id := range pinned {
+		isPinned[id] = true
+	}
+	free := top - len(pinned)
+	out := make([]TriageScore, 0, len(scores))
+	var rest []TriageScore
+	for _, s := range scores {
+		switch {
+		case isPinned[s.IssueID]:
+			out = append(out, s)
+		case free > 0:
+			out = append(out, s)
+			free--
+		default:
+			rest = append(rest, s)
+		}
+	}
+	return append(out, rest...)
+}
+
+// formulaReason explains a formula score with the variables it used.
+func formulaReason(score float64, expr *formulaExpr, env formulaEnv) string {
+	var parts []string
+	for _, id := range expr.idents {
+		v := env.vars[id]
+		if v == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", id, formatFormulaValue(v)))
+		if len(parts) == 4 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("🧮 Custom formula score %.3f", score)
+	}
+	return fmt.Sprintf("🧮 Custom formula score %.3f (%s)", score, strings.Join(parts, ", "))
+}
+
+func formatFormulaValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e6 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formulaSource(f *TriageFormula) string {
+	if f == nil {
+		return ""
+	}
+	if f.Formula == "" {
+		return "(built-in score)"
+	}
+	return f.Formula
+}
@@ -0,0 +1,195 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestParseTriageFormula_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"unknown key", "formual: pagerank", "field formual not found"},
+		{"bad formula", "formula: pagerank +", "formula:"},
+		{"unknown variable", "formula: velocity * 2", `unknown variable "velocity"`},
+		{"field shadows builtin", "fields:\n  pagerank: '1'", "shadows a built-in variable"},
+		{"field uses field", "fields:\n  x: '1'\n  y: x + 1", `field "y": unknown variable "x"`},
+		{"bad multiplier", "label_multipliers:\n  security: 0", "must be positive"},
+		{"rule without match", "rules:\n  - name: r\n    top: 3", "needs a label or a when"},
+		{"rule without action", "rules:\n  - label: security", "set exactly one of top"},
+		{"rule with both", "rules:\n  - label: security\n    top: 3\n    exclude: true", "set exactly one of top"},
+		{"bad rule expr", "rules:\n  - when: priority >\n    exclude: true", `rule "rule 1"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseTriageFormula([]byte(tt.yaml))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	f, err := ParseTriageFormula([]byte(`
+formula: effort_bonus + pagerank
+fields:
+  effort_bonus: "1 / max(label_value('effort', 3), 1)"
+label_multipliers:
+  security: 1.5
+rules:
+  - name: security first
+    label: security
+    top: 3
+  - when: "has_label('wontfix') || effort_bonus < 0.1"
+    exclude: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error for valid config: %v", err)
+	}
+	if f.Rules[1].Name != "rule 2" {
+		t.Errorf("expected default rule name, got %q", f.Rules[1].Name)
+	}
+}
+
+func TestLoadTriageFormula(t *testing.T) {
+	dir := t.TempDir()
+	f, err := LoadTriageFormula(dir)
+	if f != nil || err != nil {
+		t.Fatalf("expected nil config without a file, got %v, %v", f, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TriageFormulaPath(dir), []byte("formula: nope("), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTriageFormula(dir); err == nil || !strings.Contains(err.Error(), "triage.yaml") {
+		t.Errorf("expected error naming the file, got %v", err)
+	}
+}
+
+func formulaTriageFixture(now time.Time) []model.Issue {
+	mk := func(id string, priority int, labels ...string) model.Issue {
+		return model.Issue{
+			ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask,
+			Priority: priority, Labels: labels, CreatedAt: now, UpdatedAt: now,
+		}
+	}
+	return []model.Issue{
+		mk("p0", 0), mk("p1", 1), mk("p2", 2), mk("p3", 3),
+		mk("sec", 4, "security"),
+		mk("chore", 1, "chore"),
+	}
+}
+
+func triageOrder(t *testing.T, issues []model.Issue, cfg string, now time.Time) ([]string, map[string][]string) {
+	t.Helper()
+	f, err := ParseTriageFormula([]byte(cfg))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	triage := ComputeTriageWithOptionsAndTime(issues, TriageOptions{WaitForPhase2: true, Formula: f}, now)
+	var ids []string
+	reasons := map[string][]string{}
+	for _, rec := range triage.Recommendations {
+		ids = append(ids, rec.ID)
+		reasons[rec.ID] = rec.Reasons
+	}
+	if triage.Meta.Formula == "" {
+		t.Error("expected meta.formula to report the custom formula")
+	}
+	return ids, reasons
+}
+
+func TestComputeTriage_Formula(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	issues := formulaTriageFixture(now)
+
+	// Lowest priority number first, purely from the formula.
+	ids, reasons := triageOrder(t, issues, "formula: 10 - priority", now)
+	if want := []string{"p0", "chore", "p1", "p2", "p3", "sec"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if len(reasons["p2"]) == 0 || reasons["p2"][0] != "🧮 Custom formula score 8.000 (priority=2)" {
+		t.Errorf("expected formula reason first, got %v", reasons["p2"])
+	}
+
+	// A multiplier lifts the P4 security issue (6 × 2 = 12) past P0 work.
+	ids, reasons = triageOrder(t, issues, "formula: 10 - priority\nlabel_multipliers:\n  security: 2", now)
+	if ids[0] != "sec" || ids[1] != "p0" {
+		t.Errorf("expected sec (12) ahead of p0 (10), got %v", ids)
+	}
+	if !strings.Contains(strings.Join(reasons["sec"], " "), "×2.00 (security)") {
+		t.Errorf("expected multiplier reason, got %v", reasons["sec"])
+	}
+}
+
+func TestComputeTriage_FormulaRules(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	issues := formulaTriageFixture(now)
+
+	ids, reasons := triageOrder(t, issues, `
+formula: 10 - priority
+rules:
+  - name: security first
+    label: security
+    top: 2
+  - name: no chores
+    when: has_label('chore')
+    exclude: true
+`, now)
+	if want := []string{"p0", "sec", "p1", "p2", "p3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if !strings.Contains(strings.Join(reasons["sec"], " "), "Rule 'security first' keeps this in the top 2") {
+		t.Errorf("expected rule reason, got %v", reasons["sec"])
+	}
+
+	// Earlier rules win when pins compete for the same slots.
+	ids, _ = triageOrder(t, issues, `
+formula: 10 - priority
+rules:
+  - label: chore
+    top: 1
+  - label: security
+    top: 1
+`, now)
+	if ids[0] != "chore" || ids[1] != "sec" {
+		t.Errorf("expected chore pinned first and sec bumped behind it, got %v", ids)
+	}
+}
+
+func TestComputeTriage_FormulaOnlyMultipliers(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	issues := formulaTriageFixture(now)
+
+	base := ComputeTriageWithOptionsAndTime(issues, TriageOptions{WaitForPhase2: true}, now)
+	scores := map[string]float64{}
+	for _, rec := range base.Recommendations {
+		scores[rec.ID] = rec.Score
+	}
+
+	f, err := ParseTriageFormula([]byte("label_multipliers:\n  security: 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := ComputeTriageWithOptionsAndTime(issues, TriageOptions{WaitForPhase2: true, Formula: f}, now)
+	for _, rec := range custom.Recommendations {
+		want := scores[rec.ID]
+		if rec.ID == "sec" {
+			want *= 2
+		}
+		if diff := rec.Score - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s: expected score %v, got %v", rec.ID, want, rec.Score)
+		}
+	}
+	if custom.Meta.Formula != "(built-in score)" {
+		t.Errorf("unexpected meta.formula %q", custom.Meta.Formula)
+	}
+}
@@ -0,0 +1,441 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// Kinds of CrossRepoCycle.
+const (
+	CrossRepoCycleIssues = "issue" // Blocking cycle between issues of different repos: nothing in it can finish
+	CrossRepoCycleRepos  = "repo"  // Repos block each other through different issues: releases must be coordinated
+)
+
+// WorkspaceRepo identifies one repository of a workspace by the prefix the
+// aggregate loader namespaced its issue IDs with.
+type WorkspaceRepo struct {
+	Name   string
+	Prefix string
+	Error  string // Load error, if the repo failed to load
+}
+
+// WorkspaceHealthConfig controls the workspace health computation.
+type WorkspaceHealthConfig struct {
+	Now                time.Time // Reference time; zero means time.Now()
+	StaleThresholdDays int       // Days without updates before an issue counts as stale (default 14)
+}
+
+// DefaultWorkspaceHealthConfig returns the default workspace health configuration.
+func DefaultWorkspaceHealthConfig() WorkspaceHealthConfig {
+	return WorkspaceHealthConfig{StaleThresholdDays: DefaultStaleThresholdDays}
+}
+
+// WorkspaceHealthReport is the output of --robot-workspace-health.
+type WorkspaceHealthReport struct {
+	GeneratedAt       time.Time          `json:"generated_at"`
+	Repos             []RepoHealth       `json:"repos"` // Sorted by repo name
+	Flow              RepoFlow           `json:"flow"`
+	CrossRepoBlockers []CrossRepoBlocker `json:"cross_repo_blockers"` // Sorted by downstream impact (descending)
+	Cycles            []CrossRepoCycle   `json:"cycles"`
+	UnmatchedIssues   int                `json:"unmatched_issues,omitempty"` // Issues whose ID matches no repo prefix
+}
+
+// RepoHealth summarizes one repository of the workspace.
+type RepoHealth struct {
+	Repo      string `json:"repo"`
+	Prefix    string `json:"prefix"`
+	LoadError string `json:"load_error,omitempty"`
+
+	IssueCount      int `json:"issue_count"`
+	OpenCount       int `json:"open_count"` // Every non-closed issue, including in-progress and blocked
+	InProgressCount int `json:"in_progress_count"`
+	BlockedCount    int `json:"blocked_count"` // Open issues with at least one open blocker
+	ClosedCount     int `json:"closed_count"`
+
+	BlockedByOtherRepos int      `json:"blocked_by_other_repos"` // Open issues waiting on another repo
+	BlockingOtherRepos  int      `json:"blocking_other_repos"`   // Open issues in other repos waiting on this one
+	UpstreamRepos       []string `json:"upstream_repos,omitempty"`
+	DownstreamRepos     []string `json:"downstream_repos,omitempty"`
+
+	Velocity  VelocityMetrics  `json:"velocity"`
+	Freshness FreshnessMetrics `json:"freshness"`
+
+	FlowScore   int    `json:"flow_score"`  // 0-100, lower when waiting on other repos
+	ReadyScore  int    `json:"ready_score"` // 0-100, share of open work not blocked
+	Health      int    `json:"health"`      // Mean of velocity, freshness, flow and ready scores
+	HealthLevel string `json:"health_level"`
+}
+
+// RepoFlow is the repo-to-repo analogue of CrossLabelFlow.
+type RepoFlow struct {
+	Repos              []string         `json:"repos"`
+	FlowMatrix         [][]int          `json:"flow_matrix"` // [from][to]: open blocking deps from a blocker in "from" to an issue in "to"
+	Dependencies       []RepoDependency `json:"dependencies"`
+	TotalCrossRepoDeps int              `json:"total_cross_repo_deps"`
+}
+
+// RepoDependency aggregates the blocking dependencies from one repo to another.
+type RepoDependency struct {
+	FromRepo      string             `json:"from_repo"` // Blocking repo
+	ToRepo        string             `json:"to_repo"`   // Blocked repo
+	IssueCount    int                `json:"issue_count"`
+	BlockingPairs []RepoBlockingPair `json:"blocking_pairs"`
+}
+
+// RepoBlockingPair is a single issue blocking another across repos.
+type RepoBlockingPair struct {
+	BlockerID string `json:"blocker_id"`
+	BlockedID string `json:"blocked_id"`
+}
+
+// CrossRepoBlocker is an open issue that blocks open work in another repo.
+type CrossRepoBlocker struct {
+	IssueID      string       `json:"issue_id"`
+	Title        string       `json:"title"`
+	Repo         string       `json:"repo"`
+	Status       model.Status `json:"status"`
+	Assignee     string       `json:"assignee,omitempty"`
+	Blocks       []string     `json:"blocks"`        // Open issues in other repos depending on it directly
+	BlockedRepos []string     `json:"blocked_repos"` // Repos of those issues
+	// DownstreamImpact counts the open issues transitively waiting on it in
+	// every repo; CrossRepoImpact counts those outside its own repo.
+	DownstreamImpact int `json:"downstream_impact"`
+	CrossRepoImpact  int `json:"cross_repo_impact"`
+}
+
+// CrossRepoCycle is a dependency cycle that spans repositories.
+type CrossRepoCycle struct {
+	Kind     string   `json:"kind"` // CrossRepoCycleIssues or CrossRepoCycleRepos
+	Repos    []string `json:"repos"`
+	IssueIDs []string `json:"issue_ids,omitempty"` // Issues on the cycle (issue cycles only)
+}
+
+// ComputeWorkspaceHealth reports per-repo health, the repo-to-repo blocking
+// flow, cross-repo blockers and cross-repo cycles for a workspace. Issues
+// belong to the repo with the longest matching ID prefix; only open issues
+// and open blockers take part in the flow.
+func ComputeWorkspaceHealth(issues []model.Issue, repos []WorkspaceRepo, cfg WorkspaceHealthConfig) WorkspaceHealthReport {
+	now := cfg.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if cfg.StaleThresholdDays <= 0 {
+		cfg.StaleThresholdDays = DefaultStaleThresholdDays
+	}
+
+	sorted := append([]WorkspaceRepo(nil), repos...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	byPrefix := append([]WorkspaceRepo(nil), sorted...)
+	sort.SliceStable(byPrefix, func(i, j int) bool { return len(byPrefix[i].Prefix) > len(byPrefix[j].Prefix) })
+	repoOf := func(id string) string {
+		for _, r := range byPrefix {
+			if r.Prefix != "" && strings.HasPrefix(id, r.Prefix) {
+				return r.Name
+			}
+		}
+		return ""
+	}
+
+	report := WorkspaceHealthReport{
+		GeneratedAt:       now,
+		Repos:             []RepoHealth{},
+		CrossRepoBlockers: []CrossRepoBlocker{},
+		Cycles:            []CrossRepoCycle{},
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	issueRepo := make(map[string]string, len(issues))
+	repoIssues := make(map[string][]model.Issue)
+	for _, issue := range issues {
+		repo := repoOf(issue.ID)
+		if repo == "" {
+			report.UnmatchedIssues++
+			continue
+		}
+		issueMap[issue.ID] = issue
+		issueRepo[issue.ID] = repo
+		repoIssues[repo] = append(repoIssues[repo], issue)
+	}
+
+	// Open blocking edges between open issues: blocker -> blocked.
+	dependents := make(map[string][]string)
+	var edges []RepoBlockingPair
+	blocked := make(map[string]bool)
+	for _, issue := range issues {
+		if _, ok := issueMap[issue.ID]; !ok || isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || dep.DependsOnID == issue.ID || seen[dep.DependsOnID] {
+				continue
+			}
+			blocker, ok := issueMap[dep.DependsOnID]
+			if !ok || isClosedLikeStatus(blocker.Status) {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			blocked[issue.ID] = true
+			dependents[blocker.ID] = append(dependents[blocker.ID], issue.ID)
+			edges = append(edges, RepoBlockingPair{BlockerID: blocker.ID, BlockedID: issue.ID})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].BlockerID != edges[j].BlockerID {
+			return edges[i].BlockerID < edges[j].BlockerID
+		}
+		return edges[i].BlockedID < edges[j].BlockedID
+	})
+
+	report.Flow = computeRepoFlow(sorted, edges, issueRepo)
+
+	// Per-repo cross-repo counts.
+	blockedByOther := make(map[string]map[string]bool)
+	waitingOn := make(map[string]map[string]bool)
+	upstream := make(map[string]map[string]bool)
+	downstream := make(map[string]map[string]bool)
+	mark := func(m map[string]map[string]bool, key, val string) {
+		if m[key] == nil {
+			m[key] = make(map[string]bool)
+		}
+		m[key][val] = true
+	}
+	for _, e := range edges {
+		from, to := issueRepo[e.BlockerID], issueRepo[e.BlockedID]
+		if from == to {
+			continue
+		}
+		mark(blockedByOther, to, e.BlockedID)
+		mark(waitingOn, from, e.BlockedID)
+		mark(upstream, to, from)
+		mark(downstream, from, to)
+	}
+
+	for _, r := range sorted {
+		h := RepoHealth{Repo: r.Name, Prefix: r.Prefix, LoadError: r.Error}
+		own := repoIssues[r.Name]
+		h.IssueCount = len(own)
+		for _, issue := range own {
+			if isClosedLikeStatus(issue.Status) {
+				h.ClosedCount++
+				continue
+			}
+			h.OpenCount++
+			if issue.Status == model.StatusInProgress {
+				h.InProgressCount++
+			}
+			if blocked[issue.ID] {
+				h.BlockedCount++
+			}
+		}
+		h.BlockedByOtherRepos = len(blockedByOther[r.Name])
+		h.BlockingOtherRepos = len(waitingOn[r.Name])
+		h.UpstreamRepos = sortedKeys(upstream[r.Name])
+		h.DownstreamRepos = sortedKeys(downstream[r.Name])
+		h.Velocity = ComputeVelocityMetrics(own, now)
+		h.Freshness = ComputeFreshnessMetrics(own, now, cfg.StaleThresholdDays)
+		h.FlowScore = clampScore(100 - h.BlockedByOtherRepos*10)
+		h.ReadyScore = 100
+		if h.OpenCount > 0 {
+			h.ReadyScore = clampScore(100 * (h.OpenCount - h.BlockedCount) / h.OpenCount)
+		}
+		if r.Error != "" {
+			h.Health = 0
+		} else {
+			h.Health = clampScore(int(float64(h.Velocity.VelocityScore+h.Freshness.FreshnessScore+h.FlowScore+h.ReadyScore)/4 + 0.5))
+		}
+		h.HealthLevel = HealthLevelFromScore(h.Health)
+		report.Repos = append(report.Repos, h)
+	}
+
+	report.CrossRepoBlockers = crossRepoBlockers(edges, dependents, issueMap, issueRepo)
+	report.Cycles = crossRepoCycles(edges, issueRepo, report.Flow)
+	return report
+}
+
+func computeRepoFlow(repos []WorkspaceRepo, edges []RepoBlockingPair, issueRepo map[string]string) RepoFlow {
+	flow := RepoFlow{Repos: make([]string, len(repos)), FlowMatrix: make([][]int, len(repos)), Dependencies: []RepoDependency{}}
+	index := make(map[string]int, len(repos))
+	for i, r := range repos {
+		flow.Repos[i] = r.Name
+		flow.FlowMatrix[i] = make([]int, len(repos))
+		index[r.Name] = i
+	}
+
+	type pairKey struct{ from, to string }
+	depMap := make(map[pairKey]*RepoDependency)
+	for _, e := range edges {
+		from, to := issueRepo[e.BlockerID], issueRepo[e.BlockedID]
+		if from == to {
+			continue
+		}
+		flow.FlowMatrix[index[from]][index[to]]++
+		flow.TotalCrossRepoDeps++
+		key := pairKey{from, to}
+		entry, ok := depMap[key]
+		if !ok {
+			entry = &RepoDependency{FromRepo: from, ToRepo: to}
+			depMap[key] = entry
+		}
+		entry.IssueCount++
+		entry.BlockingPairs = append(entry.BlockingPairs, e)
+	}
+	for _, d := range depMap {
+		flow.Dependencies = append(flow.Dependencies, *d)
+	}
+	sort.Slice(flow.Dependencies, func(i, j int) bool {
+		a, b := flow.Dependencies[i], flow.Dependencies[j]
+		if a.FromRepo != b.FromRepo {
+			return a.FromRepo < b.FromRepo
+		}
+		return a.ToRepo < b.ToRepo
+	})
+	return flow
+}
+
+func crossRepoBlockers(edges []RepoBlockingPair, dependents map[string][]string, issueMap map[string]model.Issue, issueRepo map[string]string) []CrossRepoBlocker {
+	direct := make(map[string][]string)
+	for _, e := range edges {
+		if issueRepo[e.BlockerID] != issueRepo[e.BlockedID] {
+			direct[e.BlockerID] = append(direct[e.BlockerID], e.BlockedID)
+		}
+	}
+
+	blockers := []CrossRepoBlocker{}
+	for id, blocks := range direct {
+		issue := issueMap[id]
+		repo := issueRepo[id]
+		b := CrossRepoBlocker{
+			IssueID:  id,
+			Title:    issue.Title,
+			Repo:     repo,
+			Status:   issue.Status,
+			Assignee: issue.Assignee,
+			Blocks:   append([]string(nil), blocks...),
+		}
+		sort.Strings(b.Blocks)
+		repos := make(map[string]bool)
+		for _, blockedID := range b.Blocks {
+			repos[issueRepo[blockedID]] = true
+		}
+		b.BlockedRepos = sortedKeys(repos)
+
+		seen := map[string]bool{id: true}
+		queue := []string{id}
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			for _, next := range dependents[cur] {
+				if seen[next] {
+					continue
+				}
+				seen[next] = true
+				queue = append(queue, next)
+				b.DownstreamImpact++
+				if issueRepo[next] != repo {
+					b.CrossRepoImpact++
+				}
+			}
+		}
+		blockers = append(blockers, b)
+	}
+	sort.Slice(blockers, func(i, j int) bool {
+		a, b := blockers[i], blockers[j]
+		if a.DownstreamImpact != b.DownstreamImpact {
+			return a.DownstreamImpact > b.DownstreamImpact
+		}
+		if a.CrossRepoImpact != b.CrossRepoImpact {
+			return a.CrossRepoImpact > b.CrossRepoImpact
+		}
+		return a.IssueID < b.IssueID
+	})
+	return blockers
+}
+
+// crossRepoCycles finds issue-level blocking cycles that span repos, then
+// repo-level cycles in the flow matrix.
+func crossRepoCycles(edges []RepoBlockingPair, issueRepo map[string]string, flow RepoFlow) []CrossRepoCycle {
+	cycles := []CrossRepoCycle{}
+
+	ids := make(map[string]int64)
+	var names []string
+	g := simple.NewDirectedGraph()
+	node := func(id string) int64 {
+		if n, ok := ids[id]; ok {
+			return n
+		}
+		n := int64(len(names))
+		ids[id] = n
+		names = append(names, id)
+		g.AddNode(simple.Node(n))
+		return n
+	}
+	for _, e := range edges {
+		g.SetEdge(simple.Edge{F: simple.Node(node(e.BlockerID)), T: simple.Node(node(e.BlockedID))})
+	}
+	for _, scc := range topo.TarjanSCC(g) {
+		if len(scc) < 2 {
+			continue
+		}
+		repos := make(map[string]bool)
+		var members []string
+		for _, n := range scc {
+			id := names[n.ID()]
+			members = append(members, id)
+			repos[issueRepo[id]] = true
+		}
+		if len(repos) < 2 {
+			continue
+		}
+		sort.Strings(members)
+		cycles = append(cycles, CrossRepoCycle{Kind: CrossRepoCycleIssues, Repos: sortedKeys(repos), IssueIDs: members})
+	}
+
+	rg := simple.NewDirectedGraph()
+	for i := range flow.Repos {
+		rg.AddNode(simple.Node(i))
+	}
+	for i, row := range flow.FlowMatrix {
+		for j, count := range row {
+			if count > 0 && i != j {
+				rg.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+			}
+		}
+	}
+	for _, scc := range topo.TarjanSCC(rg) {
+		if len(scc) < 2 {
+			continue
+		}
+		var repos []string
+		for _, n := range scc {
+			repos = append(repos, flow.Repos[n.ID()])
+		}
+		sort.Strings(repos)
+		cycles = append(cycles, CrossRepoCycle{Kind: CrossRepoCycleRepos, Repos: repos})
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		if cycles[i].Kind != cycles[j].Kind {
+			return cycles[i].Kind == CrossRepoCycleIssues
+		}
+		return strings.Join(cycles[i].Repos, ",") < strings.Join(cycles[j].Repos, ",")
+	})
+	return cycles
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func workspaceFixture(now time.Time) ([]model.Issue, []WorkspaceRepo) {
+	mk := func(id string, status model.Status, deps ...string) model.Issue {
+		issue := model.Issue{ID: id, Title: id, Status: status, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now.AddDate(0, 0, -1)}
+		for _, d := range deps {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: d, Type: model.DepBlocks})
+		}
+		return issue
+	}
+	issues := []model.Issue{
+		// lib-1 blocks api-1, which blocks web-1 and web-2.
+		mk("lib-1", model.StatusOpen),
+		mk("api-1", model.StatusInProgress, "lib-1"),
+		mk("web-1", model.StatusOpen, "api-1"),
+		mk("web-2", model.StatusOpen, "api-1", "web-1"),
+		// web-3 and api-2 block each other.
+		mk("web-3", model.StatusOpen, "api-2"),
+		mk("api-2", model.StatusOpen, "web-3"),
+		// Closed blockers do not count.
+		mk("lib-2", model.StatusClosed),
+		mk("api-3", model.StatusOpen, "lib-2"),
+		mk("stray", model.StatusOpen),
+	}
+	repos := []WorkspaceRepo{
+		{Name: "web", Prefix: "web-"},
+		{Name: "api", Prefix: "api-"},
+		{Name: "lib", Prefix: "lib-"},
+		{Name: "docs", Prefix: "docs-", Error: "no beads"},
+	}
+	return issues, repos
+}
+
+func TestComputeWorkspaceHealth_Flow(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issues, repos := workspaceFixture(now)
+	report := ComputeWorkspaceHealth(issues, repos, WorkspaceHealthConfig{Now: now})
+
+	if report.UnmatchedIssues != 1 {
+		t.Errorf("expected stray to be unmatched, got %d", report.UnmatchedIssues)
+	}
+	flow := report.Flow
+	if !reflect.DeepEqual(flow.Repos, []string{"api", "docs", "lib", "web"}) {
+		t.Fatalf("unexpected repos %v", flow.Repos)
+	}
+	// [from][to]: api->web 3 (web-1, web-2, web-3), web->api 1, lib->api 1.
+	want := [][]int{{0, 0, 0, 3}, {0, 0, 0, 0}, {1, 0, 0, 0}, {1, 0, 0, 0}}
+	if !reflect.DeepEqual(flow.FlowMatrix, want) || flow.TotalCrossRepoDeps != 5 {
+		t.Errorf("unexpected flow matrix %v (total %d)", flow.FlowMatrix, flow.TotalCrossRepoDeps)
+	}
+	if len(flow.Dependencies) != 3 || flow.Dependencies[0].FromRepo != "api" || flow.Dependencies[0].IssueCount != 3 {
+		t.Errorf("unexpected dependencies %+v", flow.Dependencies)
+	}
+}
+
+func TestComputeWorkspaceHealth_RepoHealth(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issues, repos := workspaceFixture(now)
+	report := ComputeWorkspaceHealth(issues, repos, WorkspaceHealthConfig{Now: now})
+
+	byName := map[string]RepoHealth{}
+	for _, h := range report.Repos {
+		byName[h.Repo] = h
+	}
+	api := byName["api"]
+	if api.OpenCount != 3 || api.InProgressCount != 1 || api.BlockedCount != 2 {
+		t.Errorf("unexpected api counts %+v", api)
+	}
+	if api.BlockedByOtherRepos != 2 || api.BlockingOtherRepos != 3 {
+		t.Errorf("expected api waiting on 2 and blocking 3 cross-repo issues, got %d/%d", api.BlockedByOtherRepos, api.BlockingOtherRepos)
+	}
+	if !reflect.DeepEqual(api.UpstreamRepos, []string{"lib", "web"}) || !reflect.DeepEqual(api.DownstreamRepos, []string{"web"}) {
+		t.Errorf("unexpected neighbours up=%v down=%v", api.UpstreamRepos, api.DownstreamRepos)
+	}
+	if lib := byName["lib"]; lib.ClosedCount != 1 || lib.ReadyScore != 100 || lib.FlowScore != 100 {
+		t.Errorf("unexpected lib health %+v", lib)
+	}
+	if docs := byName["docs"]; docs.LoadError == "" || docs.Health != 0 || docs.HealthLevel != HealthLevelCritical {
+		t.Errorf("expected failed repo to be critical, got %+v", docs)
+	}
+}
+
+func TestComputeWorkspaceHealth_BlockersAndCycles(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issues, repos := workspaceFixture(now)
+	report := ComputeWorkspaceHealth(issues, repos, WorkspaceHealthConfig{Now: now})
+
+	var ids []string
+	for _, b := range report.CrossRepoBlockers {
+		ids = append(ids, b.IssueID)
+	}
+	// lib-1 transitively holds up api-1, web-1 and web-2.
+	if !reflect.DeepEqual(ids, []string{"lib-1", "api-1", "api-2", "web-3"}) {
+		t.Fatalf("unexpected blocker ranking %v", ids)
+	}
+	top := report.CrossRepoBlockers[0]
+	if top.DownstreamImpact != 3 || top.CrossRepoImpact != 3 || !reflect.DeepEqual(top.BlockedRepos, []string{"api"}) {
+		t.Errorf("unexpected top blocker %+v", top)
+	}
+	if api1 := report.CrossRepoBlockers[1]; api1.DownstreamImpact != 2 || !reflect.DeepEqual(api1.Blocks, []string{"web-1", "web-2"}) {
+		t.Errorf("unexpected api-1 blocker %+v", api1)
+	}
+
+	if len(report.Cycles) != 2 {
+		t.Fatalf("expected an issue cycle and a repo cycle, got %+v", report.Cycles)
+	}
+	if c := report.Cycles[0]; c.Kind != CrossRepoCycleIssues || !reflect.DeepEqual(c.IssueIDs, []string{"api-2", "web-3"}) {
+		t.Errorf("unexpected issue cycle %+v", c)
+	}
+	if c := report.Cycles[1]; c.Kind != CrossRepoCycleRepos || !reflect.DeepEqual(c.Repos, []string{"api", "web"}) {
+		t.Errorf("unexpected repo cycle %+v", c)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -33,6 +35,9 @@ type Baseline struct {
 	// Description is an optional user-provided note
 	Description string `json:"description,omitempty"`
 
+	// Name identifies a named baseline (empty for the default baseline)
+	Name string `json:"name,omitempty"`
+
 	// Stats contains the graph statistics snapshot
 	Stats GraphStats `json:"stats"`
 
@@ -41,6 +46,15 @@ type Baseline struct {
 
 	// Cycles stores detected cycles
 	Cycles [][]string `json:"cycles,omitempty"`
+
+	// RuleMetrics holds custom drift rule values, keyed by rule name
+	RuleMetrics map[string]RuleMetric `json:"rule_metrics,omitempty"`
+}
+
+// RuleMetric is the value of a custom drift rule's metric in a snapshot
+type RuleMetric struct {
+	Value   float64  `json:"value"`
+	Matches []string `json:"matches,omitempty"` // IDs of the issues the rule matched
 }
 
 // GraphStats contains basic graph statistics
@@ -85,11 +99,80 @@ const CurrentVersion = 1
 // DefaultFilename is the default baseline filename
 const DefaultFilename = "baseline.json"
 
+// NamedDir is the directory under .bv holding named baselines
+const NamedDir = "baselines"
+
+// validName matches baseline names: letters, digits, dots, dashes and
+// underscores, not starting with a dot
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
 // DefaultPath returns the default baseline path for a project
 func DefaultPath(projectDir string) string {
 	return filepath.Join(projectDir, ".bv", DefaultFilename)
 }
 
+// NamedPath returns the path of a named baseline, e.g. one per release or
+// sprint start, stored side by side under .bv/baselines.
+func NamedPath(projectDir, name string) string {
+	return filepath.Join(projectDir, ".bv", NamedDir, name+".json")
+}
+
+// ValidateName checks that a baseline name is safe to use as a file name
+func ValidateName(name string) error {
+	if len(name) > 100 || !validName.MatchString(name) {
+		return fmt.Errorf("invalid baseline name %q: use letters, digits, '.', '-' and '_'", name)
+	}
+	return nil
+}
+
+// Entry describes a saved named baseline
+type Entry struct {
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	CreatedAt   time.Time `json:"created_at"`
+	CommitSHA   string    `json:"commit_sha,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// List returns the named baselines of a project, oldest first. Files that
+// cannot be parsed are skipped.
+func List(projectDir string) ([]Entry, error) {
+	dir := filepath.Join(projectDir, ".bv", NamedDir)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading baselines: %w", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		b, err := Load(path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{
+			Name:        strings.TrimSuffix(f.Name(), ".json"),
+			Path:        path,
+			CreatedAt:   b.CreatedAt,
+			CommitSHA:   b.CommitSHA,
+			Description: b.Description,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
 // Save writes the baseline to a file
 func (b *Baseline) Save(path string) error {
 	// Ensure parent directory exists
@@ -155,6 +238,23 @@ func GetGitInfo(dir string) (sha, message, branch string) {
 	return sha, message, branch
 }
 
+// GetCommitInfo resolves a revision to its commit SHA, subject and author date
+func GetCommitInfo(dir, revision string) (sha, message string, at time.Time, err error) {
+	out, err := runGit(dir, "log", "-1", "--format=%H|%aI|%s", "--end-of-options", revision)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("resolving %s: %w", revision, err)
+	}
+	parts := strings.SplitN(strings.TrimSpace(out), "|", 3)
+	if len(parts) != 3 {
+		return "", "", time.Time{}, fmt.Errorf("resolving %s: unexpected git output", revision)
+	}
+	at, err = time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("parsing commit date: %w", err)
+	}
+	return parts[0], parts[2], at, nil
+}
+
 // runGit runs a git command and returns output
 func runGit(dir string, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
@@ -170,6 +270,9 @@ func runGit(dir string, args ...string) (string, error) {
 func (b *Baseline) Summary() string {
 	var sb strings.Builder
 
+	if b.Name != "" {
+		sb.WriteString(fmt.Sprintf("Baseline: %s\n", b.Name))
+	}
 	sb.WriteString(fmt.Sprintf("Baseline created: %s\n", b.CreatedAt.Format(time.RFC1123)))
 
 	if b.CommitSHA != "" {
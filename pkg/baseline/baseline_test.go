@@ -214,3 +214,46 @@ func TestLoadInvalidJSON(t *testing.T) {
 		t.Error("expected error for invalid JSON")
 	}
 }
+
+func TestNamedBaselines(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, name := range []string{"v1.0", "sprint-3", "release_2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".hidden", "../escape", "a/b", "has space"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+
+	expected := filepath.Join(tmpDir, ".bv", "baselines", "v1.0.json")
+	if got := NamedPath(tmpDir, "v1.0"); got != expected {
+		t.Errorf("NamedPath = %q, want %q", got, expected)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"later", "earlier"} {
+		b := &Baseline{Version: CurrentVersion, Name: name, CreatedAt: base.AddDate(0, 0, 10-i*5), CommitSHA: "sha-" + name}
+		if err := b.Save(NamedPath(tmpDir, name)); err != nil {
+			t.Fatalf("Save %s: %v", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".bv", "baselines", "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := List(tmpDir)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name != "earlier" || entries[1].Name != "later" || entries[1].CommitSHA != "sha-later" {
+		t.Errorf("expected earlier then later, got %+v", entries)
+	}
+
+	if entries, err := List(t.TempDir()); err != nil || len(entries) != 0 {
+		t.Errorf("expected no baselines in an empty project, got %v %v", entries, err)
+	}
+}
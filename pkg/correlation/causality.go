@@ -123,11 +123,23 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 	}
 	var rawEvents []rawEvent
 
-	// Add lifecycle events
+	// Add lifecycle events. Entering and leaving the blocked status are
+	// recorded alongside whatever else the commit did: leaving it before the
+	// lifecycle event (unblocked, then closed), entering it after (created,
+	// then blocked).
+	blocked := false
 	for _, event := range history.Events {
+		if blocked && event.Status != "" && event.Status != "blocked" {
+			blocked = false
+			rawEvents = append(rawEvents, rawEvent{
+				timestamp:   event.Timestamp,
+				eventType:   CausalUnblocked,
+				description: "Bead unblocked",
+			})
+		}
+
 		var causalType CausalEventType
 		var desc string
-
 		switch event.EventType {
 		case EventCreated:
 			causalType = CausalCreated
@@ -141,15 +153,24 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 		case EventReopened:
 			causalType = CausalReopened
 			desc = "Bead reopened"
-		default:
-			continue // Skip modified events for now
+		}
+		// Modified events are skipped for now
+		if causalType != "" {
+			rawEvents = append(rawEvents, rawEvent{
+				timestamp:   event.Timestamp,
+				eventType:   causalType,
+				description: desc,
+			})
 		}
 
-		rawEvents = append(rawEvents, rawEvent{
-			timestamp:   event.Timestamp,
-			eventType:   causalType,
-			description: desc,
-		})
+		if event.Status == "blocked" && !blocked {
+			blocked = true
+			rawEvents = append(rawEvents, rawEvent{
+				timestamp:   event.Timestamp,
+				eventType:   CausalBlocked,
+				description: "Bead blocked",
+			})
+		}
 	}
 
 	// Add commit events if requested
@@ -170,8 +191,8 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 		}
 	}
 
-	// Sort by timestamp
-	sort.Slice(rawEvents, func(i, j int) bool {
+	// Sort by timestamp, keeping same-commit events in the order added
+	sort.SliceStable(rawEvents, func(i, j int) bool {
 		return rawEvents[i].timestamp.Before(rawEvents[j].timestamp)
 	})
 
@@ -275,6 +296,17 @@ func buildInsights(chain *CausalChain, history BeadHistory) *CausalInsights {
 			inBlockedState = false
 		}
 	}
+	// A bead still blocked stays blocked until the end of the chain
+	if inBlockedState && chain.EndTime.After(blockedStart) {
+		period := BlockedPeriod{
+			StartTime: blockedStart,
+			EndTime:   chain.EndTime,
+			Duration:  chain.EndTime.Sub(blockedStart),
+			BlockerID: currentBlocker,
+		}
+		insights.BlockedPeriods = append(insights.BlockedPeriods, period)
+		insights.BlockedDuration += period.Duration
+	}
 
 	// Calculate active duration and blocked percentage
 	insights.ActiveDuration = insights.TotalDuration - insights.BlockedDuration
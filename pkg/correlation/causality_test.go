@@ -569,3 +569,73 @@ func endsWithEllipsis(s string) bool {
 	last3 := string(runes[len(runes)-3:])
 	return last3 == "..."
 }
+
+func TestBuildCausalityChain_BlockedPeriods(t *testing.T) {
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-blk": {
+				BeadID: "bv-blk",
+				Status: "closed",
+				Events: []BeadEvent{
+					{EventType: EventCreated, Timestamp: testTime(0), Status: "open"},
+					{EventType: EventModified, Timestamp: testTime(2), Status: "blocked"},
+					{EventType: EventClaimed, Timestamp: testTime(8), Status: "in_progress"},
+					{EventType: EventClosed, Timestamp: testTime(10), Status: "closed"},
+				},
+			},
+		},
+	}
+
+	result := report.BuildCausalityChain("bv-blk", CausalityOptions{})
+	if result == nil {
+		t.Fatal("Expected non-nil result")
+	}
+
+	expectedOrder := []CausalEventType{CausalCreated, CausalBlocked, CausalUnblocked, CausalClaimed, CausalClosed}
+	if len(result.Chain.Events) != len(expectedOrder) {
+		t.Fatalf("Expected %d events, got %d", len(expectedOrder), len(result.Chain.Events))
+	}
+	for i, expected := range expectedOrder {
+		if result.Chain.Events[i].Type != expected {
+			t.Errorf("Event %d: expected type '%s', got '%s'", i, expected, result.Chain.Events[i].Type)
+		}
+	}
+
+	if len(result.Insights.BlockedPeriods) != 1 || result.Insights.BlockedDuration != 6*time.Hour {
+		t.Errorf("Expected one 6h blocked period, got %+v", result.Insights.BlockedPeriods)
+	}
+	if result.Insights.BlockedPercentage != 60 {
+		t.Errorf("Expected 60%% blocked, got %.1f%%", result.Insights.BlockedPercentage)
+	}
+}
+
+func TestBuildCausalityChain_BlockedOnLifecycleEvent(t *testing.T) {
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-blk": {
+				BeadID: "bv-blk",
+				Status: "blocked",
+				Events: []BeadEvent{
+					{EventType: EventCreated, Timestamp: testTime(0), Status: "blocked"},
+					{EventType: EventClosed, Timestamp: testTime(2), Status: "closed"},
+					{EventType: EventReopened, Timestamp: testTime(4), Status: "blocked"},
+				},
+			},
+		},
+	}
+
+	result := report.BuildCausalityChain("bv-blk", CausalityOptions{})
+	if result == nil {
+		t.Fatal("Expected non-nil result")
+	}
+
+	expectedOrder := []CausalEventType{CausalCreated, CausalBlocked, CausalUnblocked, CausalClosed, CausalReopened, CausalBlocked}
+	if len(result.Chain.Events) != len(expectedOrder) {
+		t.Fatalf("Expected %d events, got %+v", len(expectedOrder), result.Chain.Events)
+	}
+	for i, expected := range expectedOrder {
+		if result.Chain.Events[i].Type != expected {
+			t.Errorf("Event %d: expected type '%s', got '%s'", i, expected, result.Chain.Events[i].Type)
+		}
+	}
+}
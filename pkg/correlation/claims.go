@@ -0,0 +1,249 @@
+// Package correlation provides git evidence for claimed (in_progress) beads.
+package correlation
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ClaimEvidenceKind identifies the kind of git activity backing a claim
+type ClaimEvidenceKind string
+
+const (
+	// EvidenceBeadCommit is a commit correlated with the bead itself
+	EvidenceBeadCommit ClaimEvidenceKind = "bead_commit"
+	// EvidenceAssigneeCommit is a commit by the assignee touching a file
+	// previously co-committed with the bead
+	EvidenceAssigneeCommit ClaimEvidenceKind = "assignee_commit"
+	// EvidenceBranch is an unmerged branch whose name contains the bead ID
+	EvidenceBranch ClaimEvidenceKind = "branch"
+)
+
+// ClaimEvidence is one piece of git activity supporting a claim
+type ClaimEvidence struct {
+	Kind      ClaimEvidenceKind `json:"kind"`
+	Ref       string            `json:"ref"` // Short SHA or branch name
+	Author    string            `json:"author,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Detail    string            `json:"detail,omitempty"`
+}
+
+// String renders the evidence as a single line for alert details
+func (e ClaimEvidence) String() string {
+	s := fmt.Sprintf("%s %s %s", e.Kind, e.Ref, e.Timestamp.UTC().Format("2006-01-02"))
+	if e.Author != "" {
+		s += " by " + e.Author
+	}
+	if e.Detail != "" {
+		s += ": " + e.Detail
+	}
+	return s
+}
+
+// ClaimActivity summarizes git activity for one in_progress bead
+type ClaimActivity struct {
+	BeadID       string          `json:"bead_id"`
+	Assignee     string          `json:"assignee,omitempty"`
+	ClaimedAt    time.Time       `json:"claimed_at,omitempty"`    // Zero when the claim predates the analyzed history
+	LastActivity time.Time       `json:"last_activity,omitempty"` // Zero when there is no evidence
+	Evidence     []ClaimEvidence `json:"evidence"`                // Newest first
+}
+
+// Branch is a git branch and the time of its tip commit
+type Branch struct {
+	Name      string    `json:"name"`
+	Author    string    `json:"author,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ClaimActivities gathers git evidence for every in_progress issue: the
+// latest commit correlated with the bead, the assignee's latest commit to a
+// file co-committed with the bead, and unmerged branches named after it.
+// A nil report yields branch evidence only.
+func ClaimActivities(report *HistoryReport, issues []model.Issue, branches []Branch) map[string]ClaimActivity {
+	var all []CorrelatedCommit
+	if report != nil {
+		seen := make(map[string]bool)
+		for _, h := range report.Histories {
+			for _, c := range h.Commits {
+				if !seen[c.SHA] {
+					seen[c.SHA] = true
+					all = append(all, c)
+				}
+			}
+		}
+	}
+
+	result := make(map[string]ClaimActivity)
+	for _, issue := range issues {
+		if issue.Status != model.StatusInProgress {
+			continue
+		}
+		act := ClaimActivity{BeadID: issue.ID, Assignee: issue.Assignee}
+
+		var hist BeadHistory
+		if report != nil {
+			hist = report.Histories[issue.ID]
+		}
+		if hist.Milestones.Claimed != nil {
+			act.ClaimedAt = hist.Milestones.Claimed.Timestamp
+		}
+
+		own := make(map[string]bool, len(hist.Commits))
+		files := make(map[string]bool)
+		var lastBead *CorrelatedCommit
+		for i := range hist.Commits {
+			c := &hist.Commits[i]
+			own[c.SHA] = true
+			for _, f := range c.Files {
+				files[f.Path] = true
+			}
+			if lastBead == nil || c.Timestamp.After(lastBead.Timestamp) {
+				lastBead = c
+			}
+		}
+		if lastBead != nil {
+			act.Evidence = append(act.Evidence, ClaimEvidence{
+				Kind:      EvidenceBeadCommit,
+				Ref:       lastBead.ShortSHA,
+				Author:    lastBead.Author,
+				Timestamp: lastBead.Timestamp,
+				Detail:    fmt.Sprintf("%s: %s", lastBead.Method, firstLine(lastBead.Message)),
+			})
+		}
+
+		if issue.Assignee != "" && len(files) > 0 {
+			var lastFile *CorrelatedCommit
+			var touched string
+			for i := range all {
+				c := &all[i]
+				if own[c.SHA] || !authorMatches(issue.Assignee, c.Author, c.AuthorEmail) {
+					continue
+				}
+				if lastFile != nil && !c.Timestamp.After(lastFile.Timestamp) {
+					continue
+				}
+				for _, f := range c.Files {
+					if files[f.Path] {
+						lastFile, touched = c, f.Path
+						break
+					}
+				}
+			}
+			if lastFile != nil {
+				act.Evidence = append(act.Evidence, ClaimEvidence{
+					Kind:      EvidenceAssigneeCommit,
+					Ref:       lastFile.ShortSHA,
+					Author:    lastFile.Author,
+					Timestamp: lastFile.Timestamp,
+					Detail:    "touched " + touched,
+				})
+			}
+		}
+
+		for _, b := range branches {
+			if branchMentionsBead(b.Name, issue.ID) {
+				act.Evidence = append(act.Evidence, ClaimEvidence{
+					Kind:      EvidenceBranch,
+					Ref:       b.Name,
+					Author:    b.Author,
+					Timestamp: b.Timestamp,
+					Detail:    "unmerged",
+				})
+			}
+		}
+
+		sort.SliceStable(act.Evidence, func(i, j int) bool {
+			return act.Evidence[i].Timestamp.After(act.Evidence[j].Timestamp)
+		})
+		if len(act.Evidence) > 0 {
+			act.LastActivity = act.Evidence[0].Timestamp
+		}
+		result[issue.ID] = act
+	}
+	return result
+}
+
+// ListUnmergedBranches returns local and remote-tracking branches that are
+// not merged into HEAD, with the time of their tip commit.
+func ListUnmergedBranches(repoPath string) ([]Branch, error) {
+	cmd := exec.Command("git", "for-each-ref", "--no-merged=HEAD",
+		"--format=%(refname)%1f%(refname:short)%1f%(authorname)%1f%(committerdate:iso-strict)",
+		"refs/heads", "refs/remotes")
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git for-each-ref failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git for-each-ref failed: %w", err)
+	}
+	return parseBranches(string(out)), nil
+}
+
+// parseBranches parses the for-each-ref output of ListUnmergedBranches,
+// skipping symbolic remote HEADs.
+func parseBranches(out string) []Branch {
+	var branches []Branch
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Split(line, "\x1f")
+		if len(parts) != 4 || strings.HasSuffix(parts[0], "/HEAD") {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, parts[3])
+		if err != nil {
+			continue
+		}
+		branches = append(branches, Branch{Name: parts[1], Author: parts[2], Timestamp: ts})
+	}
+	return branches
+}
+
+// branchMentionsBead reports whether a branch name mentions the bead ID as a
+// whole token, so "bv-1" does not match "feature/bv-12".
+func branchMentionsBead(name, id string) bool {
+	if id == "" {
+		return false
+	}
+	name, id = strings.ToLower(name), strings.ToLower(id)
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	for start := 0; ; {
+		i := strings.Index(name[start:], id)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(id)
+		before := i == 0 || !isWord(rune(name[i-1]))
+		after := end == len(name) || !isWord(rune(name[end]))
+		if before && after {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// authorMatches reports whether a commit author is the given assignee,
+// comparing against the author name, email, and email user.
+func authorMatches(assignee, name, email string) bool {
+	a := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(assignee), "@"))
+	if a == "" {
+		return false
+	}
+	email = strings.ToLower(email)
+	user, _, _ := strings.Cut(email, "@")
+	return a == strings.ToLower(name) || a == email || a == user
+}
+
+// firstLine returns the subject line of a commit message
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+	return strings.TrimSpace(line)
+}
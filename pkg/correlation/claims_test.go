@@ -0,0 +1,149 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestClaimActivities(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {
+				BeadID:     "bv-1",
+				Milestones: BeadMilestones{Claimed: &BeadEvent{Timestamp: base}},
+				Commits: []CorrelatedCommit{
+					{SHA: "a1", ShortSHA: "a1", Message: "start bv-1\n\nbody", Author: "Alice", Timestamp: base.Add(time.Hour), Method: MethodExplicitID,
+						Files: []FileChange{{Path: "pkg/auth.go"}}},
+				},
+			},
+			"bv-2": {
+				BeadID: "bv-2",
+				Commits: []CorrelatedCommit{
+					// Alice later touches auth.go while working on another bead.
+					{SHA: "b1", ShortSHA: "b1", Author: "Alice Smith", AuthorEmail: "alice@example.com", Timestamp: base.Add(48 * time.Hour),
+						Files: []FileChange{{Path: "pkg/auth.go"}}},
+					{SHA: "b2", ShortSHA: "b2", Author: "Bob", Timestamp: base.Add(72 * time.Hour),
+						Files: []FileChange{{Path: "pkg/auth.go"}}},
+				},
+			},
+		},
+	}
+	issues := []model.Issue{
+		{ID: "bv-1", Status: model.StatusInProgress, Assignee: "alice"},
+		{ID: "bv-2", Status: model.StatusOpen},
+		{ID: "bv-3", Status: model.StatusInProgress},
+	}
+	branches := []Branch{
+		{Name: "feature/bv-1-auth", Author: "Alice", Timestamp: base.Add(24 * time.Hour)},
+		{Name: "origin/bv-12", Timestamp: base.Add(96 * time.Hour)},
+	}
+
+	claims := ClaimActivities(report, issues, branches)
+	if len(claims) != 2 {
+		t.Fatalf("expected activity for both in_progress issues, got %+v", claims)
+	}
+
+	act := claims["bv-1"]
+	if !act.ClaimedAt.Equal(base) || !act.LastActivity.Equal(base.Add(48*time.Hour)) {
+		t.Errorf("unexpected claim times %+v", act)
+	}
+	var kinds []ClaimEvidenceKind
+	for _, e := range act.Evidence {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []ClaimEvidenceKind{EvidenceAssigneeCommit, EvidenceBranch, EvidenceBeadCommit}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected evidence %v, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("expected evidence %v, got %v", want, kinds)
+		}
+	}
+	if e := act.Evidence[0]; e.Ref != "b1" || e.Detail != "touched pkg/auth.go" {
+		t.Errorf("expected Alice's auth.go commit, got %+v", e)
+	}
+	if got := act.Evidence[2].String(); got != "bead_commit a1 2026-05-01 by Alice: explicit_id: start bv-1" {
+		t.Errorf("unexpected evidence line %q", got)
+	}
+
+	if idle := claims["bv-3"]; len(idle.Evidence) != 0 || !idle.LastActivity.IsZero() {
+		t.Errorf("expected no evidence for bv-3, got %+v", idle)
+	}
+}
+
+func TestBranchMentionsBead(t *testing.T) {
+	tests := []struct {
+		name, id string
+		want     bool
+	}{
+		{"feature/bv-1", "bv-1", true},
+		{"BV-1-fix", "bv-1", true},
+		{"feature/bv-12", "bv-1", false},
+		{"xbv-1", "bv-1", false},
+		{"feature/bv-12-and-bv-1", "bv-1", true},
+		{"main", "", false},
+	}
+	for _, tt := range tests {
+		if got := branchMentionsBead(tt.name, tt.id); got != tt.want {
+			t.Errorf("branchMentionsBead(%q, %q) = %v, want %v", tt.name, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorMatches(t *testing.T) {
+	if !authorMatches("alice", "Alice", "") || !authorMatches("@alice", "A. Smith", "alice@example.com") ||
+		!authorMatches("alice@example.com", "x", "Alice@Example.com") {
+		t.Error("expected name, email user and email matches")
+	}
+	if authorMatches("alice", "Bob", "bob@example.com") || authorMatches("", "", "") {
+		t.Error("unexpected match")
+	}
+}
+
+func TestListUnmergedBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-b", "main")
+	write("a.txt")
+	git("add", ".")
+	git("commit", "-m", "init")
+	git("branch", "merged")
+	git("checkout", "-b", "feature/bv-7")
+	write("b.txt")
+	git("add", ".")
+	git("commit", "-m", "wip")
+	git("checkout", "main")
+
+	branches, err := ListUnmergedBranches(dir)
+	if err != nil {
+		t.Fatalf("ListUnmergedBranches: %v", err)
+	}
+	if len(branches) != 1 || branches[0].Name != "feature/bv-7" || branches[0].Author != "Test" || branches[0].Timestamp.IsZero() {
+		t.Errorf("expected only the unmerged feature branch, got %+v", branches)
+	}
+}
@@ -408,6 +408,9 @@ func TestParseDiff(t *testing.T) {
 		if events[0].EventType != EventClaimed {
 			t.Errorf("Expected EventClaimed, got %v", events[0].EventType)
 		}
+		if events[0].Status != "in_progress" {
+			t.Errorf("Expected status in_progress, got %q", events[0].Status)
+		}
 	})
 
 	t.Run("status change to closed", func(t *testing.T) {
@@ -45,6 +45,7 @@ type BeadEvent struct {
 	CommitMsg   string    `json:"commit_message"`
 	Author      string    `json:"author"`
 	AuthorEmail string    `json:"author_email"`
+	Status      string    `json:"status,omitempty"` // Bead status after this commit
 }
 
 // CorrelationMethod describes how a commit was linked to a bead
@@ -45,6 +45,15 @@ type Config struct {
 	BlockingCascadeInfo    int `yaml:"blocking_cascade_info_threshold" json:"blocking_cascade_info_threshold"`
 	BlockingCascadeWarning int `yaml:"blocking_cascade_warning_threshold" json:"blocking_cascade_warning_threshold"`
 
+	// DueDateAtRiskLikelihood flags an issue as at risk when the chance of
+	// finishing by its due date drops below this value (0..1; 0 turns
+	// at-risk alerts off, overdue and late alerts still fire)
+	DueDateAtRiskLikelihood float64 `yaml:"due_date_at_risk_likelihood" json:"due_date_at_risk_likelihood"`
+
+	// Abandoned claim thresholds (days without code activity on an in_progress issue)
+	AbandonedClaimWarningDays  int `yaml:"abandoned_claim_warning_days" json:"abandoned_claim_warning_days"`
+	AbandonedClaimCriticalDays int `yaml:"abandoned_claim_critical_days" json:"abandoned_claim_critical_days"`
+
 	// Alert type enable/disable flags (bv-167)
 	// Disabled alert types will not generate alerts
 	DisabledAlerts []string `yaml:"disabled_alerts,omitempty" json:"disabled_alerts,omitempty"`
@@ -52,6 +61,9 @@ type Config struct {
 	// Per-label staleness overrides (bv-167)
 	// Labels can have tighter or looser thresholds than the default
 	LabelOverrides map[string]*LabelConfig `yaml:"label_overrides,omitempty" json:"label_overrides,omitempty"`
+
+	// Rules are user-defined alerts evaluated alongside the built-in ones
+	Rules []Rule `yaml:"rules,omitempty" json:"rules,omitempty"`
 }
 
 // LabelConfig allows per-label threshold customization (bv-167)
@@ -80,6 +92,9 @@ func DefaultConfig() *Config {
 		InProgressStaleMultiplier:    0.5, // In-progress thresholds are half as long
 		BlockingCascadeInfo:          3,   // Info alert when unblocks >=3
 		BlockingCascadeWarning:       5,   // Warning when unblocks >=5
+		DueDateAtRiskLikelihood:      0.8, // At risk below 80% on-time likelihood
+		AbandonedClaimWarningDays:    7,   // Warn after a week without code activity
+		AbandonedClaimCriticalDays:   14,  // Critical after two weeks
 	}
 }
 
@@ -161,6 +176,12 @@ func (c *Config) Validate() error {
 	if c.InProgressStaleMultiplier == 0 {
 		c.InProgressStaleMultiplier = DefaultConfig().InProgressStaleMultiplier
 	}
+	if c.AbandonedClaimWarningDays == 0 {
+		c.AbandonedClaimWarningDays = DefaultConfig().AbandonedClaimWarningDays
+	}
+	if c.AbandonedClaimCriticalDays == 0 {
+		c.AbandonedClaimCriticalDays = DefaultConfig().AbandonedClaimCriticalDays
+	}
 
 	if c.DensityWarningPct < 0 || c.DensityWarningPct > 1000 {
 		return fmt.Errorf("density_warning_pct must be between 0 and 1000")
@@ -201,6 +222,15 @@ func (c *Config) Validate() error {
 	if c.BlockingCascadeWarning < c.BlockingCascadeInfo {
 		return fmt.Errorf("blocking_cascade_warning_threshold must be >= blocking_cascade_info_threshold")
 	}
+	if c.DueDateAtRiskLikelihood < 0 || c.DueDateAtRiskLikelihood > 1 {
+		return fmt.Errorf("due_date_at_risk_likelihood must be between 0 and 1")
+	}
+	if c.AbandonedClaimWarningDays <= 0 || c.AbandonedClaimCriticalDays <= 0 {
+		return fmt.Errorf("abandoned_claim_warning_days and abandoned_claim_critical_days must be positive")
+	}
+	if c.AbandonedClaimCriticalDays < c.AbandonedClaimWarningDays {
+		return fmt.Errorf("abandoned_claim_critical_days must be >= abandoned_claim_warning_days")
+	}
 	// Validate label overrides (bv-167)
 	for label, lc := range c.LabelOverrides {
 		if lc == nil {
@@ -216,7 +246,7 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("label %q: in_progress_stale_multiplier must be between 0 and 5", label)
 		}
 	}
-	return nil
+	return validateRules(c.Rules)
 }
 
 // IsAlertDisabled returns true if the given alert type is in the disabled list (bv-167)
@@ -315,12 +345,20 @@ in_progress_stale_multiplier: 0.5  # In-progress items age twice as fast
 blocking_cascade_info_threshold: 3   # Info alert if completing an issue unblocks 3+ items
 blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 
+# Due-date risk (ETA includes open blockers)
+due_date_at_risk_likelihood: 0.8  # Warn when on-time likelihood drops below 80%
+
+# Abandoned claims (in_progress issues checked against git activity)
+abandoned_claim_warning_days: 7    # Warn after 7+ days without commits, file changes or branch pushes
+abandoned_claim_critical_days: 14  # Critical after 14+ days
+
 # Disable specific alert types (bv-167)
 # Uncomment to disable:
 # disabled_alerts:
 #   - stale_issue
 #   - new_cycle
 #   - blocking_cascade
+#   - due_date_at_risk
 
 # Per-label staleness overrides (bv-167)
 # Use tighter thresholds for urgent/priority labels
@@ -331,5 +369,26 @@ blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 #   low-priority:
 #     stale_warning_days: 30
 #     stale_critical_days: 60
+
+# Custom rules: filter issues, aggregate a metric and compare it to a
+# threshold, either directly (compare: value) or against the baseline
+# (compare: delta or percent). Baselines store rule values when saved.
+# rules:
+#   - name: p0-bugs-blocked
+#     filter: "type:bug priority:0 -status:closed"
+#     where: "blocked_days > 2"
+#     metric: count
+#     op: ">"
+#     threshold: 0
+#     severity: critical
+#     message: "{count} P0 bug(s) blocked for more than 2 days: {ids}"
+#   - name: payments-growth
+#     filter: "label:payments -status:closed"
+#     metric: count
+#     compare: percent
+#     op: ">="
+#     threshold: 30
+#     severity: warning
+#     message: "Open payments work grew {percent}% ({baseline} → {value})"
 `
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -38,6 +39,9 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertDueDateAtRisk      AlertType = "due_date_at_risk"
+	AlertDueDateLate        AlertType = "due_date_late"
+	AlertCustomRule         AlertType = "custom_rule"
 )
 
 // Alert represents a single drift detection alert
@@ -52,6 +56,7 @@ type Alert struct {
 	IssueID     string    `json:"issue_id,omitempty"`
 	Label       string    `json:"label,omitempty"`
 	DetectedAt  time.Time `json:"detected_at,omitempty"`
+	Rule        string    `json:"rule,omitempty"` // Name of the custom rule that raised the alert
 
 	// Blocking cascade specific fields (bv-165)
 	UnblocksCount         int `json:"unblocks_count,omitempty"`
@@ -78,6 +83,11 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+
+	duplicates    []analysis.DuplicateMatch
+	hasDuplicates bool
+
+	claims map[string]correlation.ClaimActivity
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -98,6 +108,20 @@ func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
 }
 
+// SetDuplicates supplies precomputed duplicate matches (e.g., blended with
+// embedding similarity). Without it, potential_duplicate alerts fall back to
+// keyword-only detection over the attached issues.
+func (c *Calculator) SetDuplicates(matches []analysis.DuplicateMatch) {
+	c.duplicates = matches
+	c.hasDuplicates = true
+}
+
+// SetClaimActivity supplies git evidence for in_progress issues, keyed by
+// issue ID. Without it, abandoned_claim alerts are not raised.
+func (c *Calculator) SetClaimActivity(claims map[string]correlation.ClaimActivity) {
+	c.claims = claims
+}
+
 // Calculate performs drift detection and returns results
 func (c *Calculator) Calculate() *Result {
 	result := &Result{
@@ -128,6 +152,18 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check due dates against ETAs (uses current issues if provided)
+	c.checkDueDates(result)
+
+	// Check potential duplicates (uses SetDuplicates or current issues)
+	c.checkPotentialDuplicates(result)
+
+	// Check claims against git activity (uses SetClaimActivity)
+	c.checkAbandonedClaims(result)
+
+	// Check user-defined rules from the config
+	c.checkRules(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -512,6 +548,215 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 	}
 }
 
+// checkDueDates raises alerts for open issues whose ETA, including the time
+// their open blockers need, misses or may miss their due date.
+// Late issues are critical; at-risk issues are warnings.
+func (c *Calculator) checkDueDates(result *Result) {
+	lateDisabled := c.config.IsAlertDisabled(string(AlertDueDateLate))
+	// Nothing is less likely than 0, so a zero threshold never flags at-risk work.
+	atRiskDisabled := c.config.IsAlertDisabled(string(AlertDueDateAtRisk)) || c.config.DueDateAtRiskLikelihood == 0
+	if lateDisabled && atRiskDisabled {
+		return
+	}
+
+	if len(c.issues) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	cfg := analysis.DefaultDueDateConfig()
+	cfg.Now = now
+	cfg.AtRiskLikelihood = c.config.DueDateAtRiskLikelihood
+	report := analysis.ComputeDueDateRisks(c.issues, cfg)
+
+	for _, r := range report.Issues {
+		alert := Alert{
+			IssueID:    r.IssueID,
+			DetectedAt: now,
+			CurrentVal: r.OnTimeLikelihood,
+			Delta:      r.SlackDays,
+			Details: []string{
+				fmt.Sprintf("due=%s", r.DueDate.Format(time.RFC3339)),
+				fmt.Sprintf("eta=%s", r.ETADate.Format(time.RFC3339)),
+				fmt.Sprintf("eta_range=%s..%s", r.ETADateLow.Format("2006-01-02"), r.ETADateHigh.Format("2006-01-02")),
+				fmt.Sprintf("slack_days=%.1f", r.SlackDays),
+			},
+		}
+		if len(r.BlockerChain) > 0 {
+			alert.Details = append(alert.Details, fmt.Sprintf("blocked_by=%s", strings.Join(r.BlockerChain, " -> ")))
+		}
+
+		switch r.Risk {
+		case analysis.DueDateLate:
+			if lateDisabled {
+				continue
+			}
+			alert.Type = AlertDueDateLate
+			alert.Severity = SeverityCritical
+			if r.Overdue {
+				alert.Message = fmt.Sprintf("Issue %s is past its due date (%.0f days overdue)", r.IssueID, now.Sub(r.DueDate).Hours()/24)
+			} else {
+				alert.Message = fmt.Sprintf("Issue %s will miss its due date %s (ETA %s)",
+					r.IssueID, r.DueDate.Format("2006-01-02"), r.ETADate.Format("2006-01-02"))
+			}
+		case analysis.DueDateAtRisk:
+			if atRiskDisabled {
+				continue
+			}
+			alert.Type = AlertDueDateAtRisk
+			alert.Severity = SeverityWarning
+			alert.Message = fmt.Sprintf("Issue %s at risk of missing due date %s (%.0f%% on-time likelihood)",
+				r.IssueID, r.DueDate.Format("2006-01-02"), r.OnTimeLikelihood*100)
+		default:
+			continue
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+}
+
+// checkPotentialDuplicates raises an alert for each pair of open issues that
+// look like duplicates. Pairs confirmed by both keyword and embedding
+// similarity, or scoring 90%+, are warnings; the rest are info.
+func (c *Calculator) checkPotentialDuplicates(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertPotentialDuplicate)) {
+		return
+	}
+
+	matches := c.duplicates
+	if !c.hasDuplicates {
+		if len(c.issues) == 0 {
+			return
+		}
+		matches = analysis.FindDuplicateMatches(c.issues, analysis.DefaultDuplicateConfig())
+	}
+
+	status := make(map[string]model.Status, len(c.issues))
+	for _, iss := range c.issues {
+		status[iss.ID] = iss.Status
+	}
+	closed := func(id string) bool {
+		s := status[id]
+		return s == model.StatusClosed || s == model.StatusTombstone
+	}
+
+	now := time.Now().UTC()
+	for _, m := range matches {
+		if closed(m.Issue1) || closed(m.Issue2) {
+			continue
+		}
+		severity := SeverityInfo
+		if m.Method == analysis.DuplicateMethodHybrid || m.Score >= 0.9 {
+			severity = SeverityWarning
+		}
+		details := []string{
+			fmt.Sprintf("duplicate_of=%s", m.MergeInto),
+			fmt.Sprintf("merge=%s -> %s (%s)", m.MergeFrom, m.MergeInto, m.MergeReason),
+			fmt.Sprintf("method=%s", m.Method),
+		}
+		if len(m.MatchedFields) > 0 {
+			details = append(details, fmt.Sprintf("matched=%s", strings.Join(m.MatchedFields, ",")))
+		}
+		result.Alerts = append(result.Alerts, Alert{
+			Type:       AlertPotentialDuplicate,
+			Severity:   severity,
+			Message:    fmt.Sprintf("%s may duplicate %s (%.0f%% similar)", m.MergeFrom, m.MergeInto, m.Score*100),
+			IssueID:    m.MergeFrom,
+			CurrentVal: m.Score,
+			Details:    details,
+			DetectedAt: now,
+		})
+	}
+}
+
+// checkAbandonedClaims compares each in_progress issue with its git activity.
+// A claim with no commits, file changes or branch updates for the configured
+// number of days is abandoned (warning, critical past the second threshold).
+// A claim whose issue has gone quiet while code keeps landing is reported as
+// info so it is not mistaken for a stale issue.
+func (c *Calculator) checkAbandonedClaims(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertAbandonedClaim)) {
+		return
+	}
+	if len(c.claims) == 0 || len(c.issues) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	warn := float64(c.config.AbandonedClaimWarningDays)
+	crit := float64(c.config.AbandonedClaimCriticalDays)
+	daysSince := func(t time.Time) float64 { return now.Sub(t).Hours() / 24.0 }
+
+	for _, issue := range c.issues {
+		if issue.Status != model.StatusInProgress {
+			continue
+		}
+		act, ok := c.claims[issue.ID]
+		if !ok {
+			continue
+		}
+
+		lastUpdate := issue.UpdatedAt
+		if lastUpdate.IsZero() {
+			lastUpdate = issue.CreatedAt
+		}
+		// Code idleness counts from the claim when nothing has landed since.
+		since := act.LastActivity
+		if act.ClaimedAt.After(since) {
+			since = act.ClaimedAt
+		}
+		if since.IsZero() {
+			since = lastUpdate
+		}
+		if since.IsZero() {
+			continue
+		}
+
+		codeIdle := daysSince(since)
+		var alert Alert
+		switch {
+		case codeIdle >= warn:
+			severity := SeverityWarning
+			if codeIdle >= crit {
+				severity = SeverityCritical
+			}
+			alert = Alert{
+				Severity: severity,
+				Message:  fmt.Sprintf("%s in_progress but no code activity for %.0f days", issue.ID, codeIdle),
+				Details:  []string{"classification=no_code_activity"},
+			}
+		case len(act.Evidence) > 0 && !lastUpdate.IsZero() && daysSince(lastUpdate) >= warn:
+			alert = Alert{
+				Severity: SeverityInfo,
+				Message: fmt.Sprintf("%s quiet for %.0f days but actively committing (last activity %.0f days ago)",
+					issue.ID, daysSince(lastUpdate), codeIdle),
+				Details: []string{"classification=active_commits"},
+			}
+		default:
+			continue
+		}
+
+		if issue.Assignee != "" {
+			alert.Details = append(alert.Details, fmt.Sprintf("assignee=%s", issue.Assignee))
+		}
+		if !act.ClaimedAt.IsZero() {
+			alert.Details = append(alert.Details, fmt.Sprintf("claimed=%s", act.ClaimedAt.Format(time.RFC3339)))
+		}
+		if !act.LastActivity.IsZero() {
+			alert.Details = append(alert.Details, fmt.Sprintf("last_code_activity=%s", act.LastActivity.Format(time.RFC3339)))
+		} else {
+			alert.Details = append(alert.Details, "last_code_activity=none")
+		}
+		for _, e := range act.Evidence {
+			alert.Details = append(alert.Details, "evidence="+e.String())
+		}
+
+		alert.Type = AlertAbandonedClaim
+		alert.IssueID = issue.ID
+		alert.CurrentVal = codeIdle
+		alert.DetectedAt = now
+		result.Alerts = append(result.Alerts, alert)
+	}
+}
+
 // cycleKey creates a normalized key for a cycle for comparison.
 // It rotates the cycle so the lexicographically smallest element is first,
 // preserving the order (direction) of elements.
@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"gopkg.in/yaml.v3"
 )
@@ -300,6 +302,180 @@ func TestCalculatorBlockingCascadeWithPriorities(t *testing.T) {
 	}
 }
 
+func TestCalculatorDueDates(t *testing.T) {
+	now := time.Now().UTC()
+	est := 60
+	past := now.Add(-48 * time.Hour)
+	soon := now.Add(6 * 24 * time.Hour)
+	later := now.Add(60 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "LATE", Status: model.StatusOpen, EstimatedMinutes: &est, DueDate: &past, UpdatedAt: now},
+		{ID: "RISKY", Status: model.StatusOpen, EstimatedMinutes: &est, DueDate: &soon, UpdatedAt: now},
+		{ID: "SAFE", Status: model.StatusOpen, EstimatedMinutes: &est, DueDate: &later, UpdatedAt: now},
+		{ID: "DONE", Status: model.StatusClosed, EstimatedMinutes: &est, DueDate: &past, UpdatedAt: now.Add(-90 * 24 * time.Hour)},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+
+	calc := NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	result := calc.Calculate()
+
+	got := map[string]Alert{}
+	for _, a := range result.Alerts {
+		if a.Type == AlertDueDateLate || a.Type == AlertDueDateAtRisk {
+			got[a.IssueID] = a
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected due-date alerts for LATE and RISKY, got %+v", got)
+	}
+	if a := got["LATE"]; a.Type != AlertDueDateLate || a.Severity != SeverityCritical {
+		t.Errorf("expected critical late alert, got %+v", a)
+	}
+	if a := got["RISKY"]; a.Type != AlertDueDateAtRisk || a.Severity != SeverityWarning {
+		t.Errorf("expected at-risk warning, got %+v", a)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertDueDateAtRisk)}
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(issues)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertDueDateAtRisk {
+			t.Errorf("at-risk alerts should be disabled, got %+v", a)
+		}
+	}
+
+	// A zero threshold never flags at-risk work but still reports late work.
+	cfg = DefaultConfig()
+	cfg.DueDateAtRiskLikelihood = 0
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(issues)
+	late := false
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertDueDateAtRisk {
+			t.Errorf("zero threshold should not flag at-risk work, got %+v", a)
+		}
+		late = late || a.Type == AlertDueDateLate
+	}
+	if !late {
+		t.Error("late alerts should still fire with a zero at-risk threshold")
+	}
+}
+
+func TestCalculatorPotentialDuplicates(t *testing.T) {
+	now := time.Now().UTC()
+	issues := []model.Issue{
+		{ID: "OLD", Title: "Login button broken on Safari browser", Status: model.StatusOpen, CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now},
+		{ID: "NEW", Title: "Login button broken on Safari browser", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "GONE", Title: "Login button broken on Safari browser", Status: model.StatusClosed, CreatedAt: now, UpdatedAt: now},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+
+	calc := NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	var dups []Alert
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertPotentialDuplicate {
+			dups = append(dups, a)
+		}
+	}
+	if len(dups) != 1 {
+		t.Fatalf("expected one duplicate alert between open issues, got %+v", dups)
+	}
+	if a := dups[0]; a.IssueID != "NEW" || a.Severity != SeverityWarning || a.Details[0] != "duplicate_of=OLD" {
+		t.Errorf("expected NEW to fold into the older OLD, got %+v", a)
+	}
+
+	// Precomputed matches replace keyword-only detection.
+	calc = NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	calc.SetDuplicates([]analysis.DuplicateMatch{{
+		Issue1: "OLD", Issue2: "NEW", Score: 0.7, Method: analysis.DuplicateMethodSemantic,
+		MergeInto: "OLD", MergeFrom: "NEW", MatchedFields: []string{"embedding"},
+	}})
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertPotentialDuplicate && (a.Severity != SeverityInfo || a.CurrentVal != 0.7) {
+			t.Errorf("expected info alert from the supplied match, got %+v", a)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertPotentialDuplicate)}
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(issues)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertPotentialDuplicate {
+			t.Errorf("duplicate alerts should be disabled, got %+v", a)
+		}
+	}
+}
+
+func TestCalculatorAbandonedClaims(t *testing.T) {
+	now := time.Now().UTC()
+	days := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+	issues := []model.Issue{
+		{ID: "IDLE", Status: model.StatusInProgress, Assignee: "alice", CreatedAt: days(40), UpdatedAt: days(20)},
+		{ID: "BUSY", Status: model.StatusInProgress, Assignee: "bob", CreatedAt: days(40), UpdatedAt: days(10)},
+		{ID: "FRESH", Status: model.StatusInProgress, CreatedAt: days(3), UpdatedAt: days(1)},
+		{ID: "DONE", Status: model.StatusClosed, CreatedAt: days(40), UpdatedAt: days(20)},
+	}
+	claims := map[string]correlation.ClaimActivity{
+		"IDLE": {BeadID: "IDLE", ClaimedAt: days(30), LastActivity: days(16), Evidence: []correlation.ClaimEvidence{
+			{Kind: correlation.EvidenceBeadCommit, Ref: "abc1234", Timestamp: days(16)},
+		}},
+		"BUSY": {BeadID: "BUSY", ClaimedAt: days(30), LastActivity: days(1), Evidence: []correlation.ClaimEvidence{
+			{Kind: correlation.EvidenceBranch, Ref: "feature/BUSY", Timestamp: days(1)},
+			{Kind: correlation.EvidenceAssigneeCommit, Ref: "def5678", Author: "bob", Timestamp: days(2), Detail: "touched pkg/a.go"},
+		}},
+		"FRESH": {BeadID: "FRESH", ClaimedAt: days(1)},
+		"DONE":  {BeadID: "DONE"},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+
+	calc := NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	calc.SetClaimActivity(claims)
+	got := map[string]Alert{}
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertAbandonedClaim {
+			got[a.IssueID] = a
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected alerts for IDLE and BUSY only, got %+v", got)
+	}
+	idle := got["IDLE"]
+	if idle.Severity != SeverityCritical || idle.Details[0] != "classification=no_code_activity" || !strings.Contains(idle.Message, "no code activity for 16 days") {
+		t.Errorf("unexpected IDLE alert %+v", idle)
+	}
+	busy := got["BUSY"]
+	if busy.Severity != SeverityInfo || busy.Details[0] != "classification=active_commits" {
+		t.Errorf("unexpected BUSY alert %+v", busy)
+	}
+	var evidence []string
+	for _, d := range busy.Details {
+		if strings.HasPrefix(d, "evidence=") {
+			evidence = append(evidence, d)
+		}
+	}
+	if len(evidence) != 2 || !strings.Contains(evidence[0], "branch feature/BUSY") || !strings.Contains(evidence[1], "by bob: touched pkg/a.go") {
+		t.Errorf("expected branch then assignee evidence, got %v", evidence)
+	}
+
+	// Without git activity the alert is not raised.
+	calc = NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertAbandonedClaim {
+			t.Errorf("unexpected alert without claim activity: %+v", a)
+		}
+	}
+}
+
 func TestResultSummary(t *testing.T) {
 	result := &Result{
 		HasDrift: true,
@@ -1455,6 +1631,30 @@ func TestLabelOverrides(t *testing.T) {
 	}
 }
 
+func TestLoadConfigDueDateAtRiskLikelihood(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(content string) {
+		if err := os.WriteFile(ConfigPath(dir), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("stale_warning_days: 10\n")
+	cfg, err := LoadConfig(dir)
+	if err != nil || cfg.DueDateAtRiskLikelihood != 0.8 {
+		t.Fatalf("absent key should keep the default, got %v (%v)", cfg.DueDateAtRiskLikelihood, err)
+	}
+
+	write("due_date_at_risk_likelihood: 0\n")
+	cfg, err = LoadConfig(dir)
+	if err != nil || cfg.DueDateAtRiskLikelihood != 0 {
+		t.Fatalf("explicit 0 should be kept, got %v (%v)", cfg.DueDateAtRiskLikelihood, err)
+	}
+}
+
 // TestLabelOverridesValidation verifies validation of label overrides (bv-167)
 func TestLabelOverridesValidation(t *testing.T) {
 	cfg := DefaultConfig()
@@ -0,0 +1,494 @@
+package drift
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Rule comparison modes
+const (
+	RuleCompareValue   = "value"   // The metric itself
+	RuleCompareDelta   = "delta"   // Metric minus its baseline value
+	RuleComparePercent = "percent" // Percent change from the baseline value
+)
+
+// ruleDetailLimit caps the matching issue IDs listed on a rule alert
+const ruleDetailLimit = 10
+
+// Rule is a user-defined alert from the rules section of drift.yaml. The
+// filter and where expression select issues, the metric aggregates them and
+// the result (or its change from the baseline) is compared to threshold.
+type Rule struct {
+	Name string `yaml:"name" json:"name"`
+
+	// Filter is a space-separated list of field:value terms that must all
+	// match: status, type, priority, label or assignee. Commas list
+	// alternatives and a leading "-" negates, e.g. "type:bug -label:wontfix".
+	Filter string `yaml:"filter,omitempty" json:"filter,omitempty"`
+
+	// Where is a per-issue expression over RuleIssueVariables, e.g. "blocked_days > 2"
+	Where string `yaml:"where,omitempty" json:"where,omitempty"`
+
+	// Metric is an expression over RuleMetricVariables (default "count")
+	Metric string `yaml:"metric,omitempty" json:"metric,omitempty"`
+
+	// Compare is "value" (default), "delta" or "percent"
+	Compare string `yaml:"compare,omitempty" json:"compare,omitempty"`
+
+	// Op is one of >, >=, <, <=, ==, != (default >)
+	Op        string  `yaml:"op,omitempty" json:"op,omitempty"`
+	Threshold float64 `yaml:"threshold" json:"threshold"`
+
+	// Severity of the alert (default warning)
+	Severity Severity `yaml:"severity,omitempty" json:"severity,omitempty"`
+
+	// Message is a template with {name}, {value}, {baseline}, {delta},
+	// {percent}, {threshold}, {count} and {ids} placeholders
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// RuleIssueVariables are available to a rule's where expression
+var RuleIssueVariables = []string{
+	"priority",
+	"age_days",
+	"days_since_update",
+	"blocked_days",     // Days since an open blocker was linked (else since a blocked issue's last update)
+	"in_progress_days", // Days since the last update of an in_progress issue
+	"estimated_minutes",
+	"has_due",
+	"days_until_due",
+	"overdue",
+	"dependency_count",
+}
+
+// RuleMetricVariables are available to a rule's metric expression
+var RuleMetricVariables = []string{
+	"count",
+	"open_count",
+	"in_progress_count",
+	"blocked_count",
+	"closed_count",
+	"estimated_minutes",
+	"max_age_days",
+	"avg_age_days",
+	"max_days_since_update",
+	"max_blocked_days",
+}
+
+var ruleFilterFields = map[string]bool{
+	"status": true, "type": true, "priority": true, "label": true, "assignee": true,
+}
+
+// ruleTerm is one field:value term of a rule filter
+type ruleTerm struct {
+	field  string
+	values []string
+	negate bool
+}
+
+// compiledRule is a validated rule ready for evaluation
+type compiledRule struct {
+	filter []ruleTerm
+	where  *analysis.Expr
+	metric *analysis.Expr
+}
+
+func (r Rule) compareMode() string {
+	if r.Compare == "" {
+		return RuleCompareValue
+	}
+	return r.Compare
+}
+
+func (r Rule) op() string {
+	if r.Op == "" {
+		return ">"
+	}
+	return r.Op
+}
+
+func (r Rule) severity() Severity {
+	if r.Severity == "" {
+		return SeverityWarning
+	}
+	return r.Severity
+}
+
+func (r Rule) metricSource() string {
+	if strings.TrimSpace(r.Metric) == "" {
+		return "count"
+	}
+	return r.Metric
+}
+
+// compile validates the rule and compiles its filter and expressions
+func (r Rule) compile() (*compiledRule, error) {
+	if strings.TrimSpace(r.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	switch r.compareMode() {
+	case RuleCompareValue, RuleCompareDelta, RuleComparePercent:
+	default:
+		return nil, fmt.Errorf("compare must be value, delta or percent")
+	}
+	switch r.op() {
+	case ">", ">=", "<", "<=", "==", "!=":
+	default:
+		return nil, fmt.Errorf("op must be one of >, >=, <, <=, ==, !=")
+	}
+	switch r.severity() {
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	default:
+		return nil, fmt.Errorf("severity must be critical, warning or info")
+	}
+
+	cr := &compiledRule{}
+	for _, field := range strings.Fields(r.Filter) {
+		term := ruleTerm{}
+		if strings.HasPrefix(field, "-") {
+			term.negate = true
+			field = field[1:]
+		}
+		name, value, ok := strings.Cut(field, ":")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("filter term %q must be field:value", field)
+		}
+		term.field = strings.ToLower(name)
+		if !ruleFilterFields[term.field] {
+			return nil, fmt.Errorf("unknown filter field %q", name)
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				term.values = append(term.values, strings.ToLower(v))
+			}
+		}
+		cr.filter = append(cr.filter, term)
+	}
+
+	if strings.TrimSpace(r.Where) != "" {
+		expr, err := analysis.CompileExpr(r.Where, RuleIssueVariables)
+		if err != nil {
+			return nil, fmt.Errorf("where: %w", err)
+		}
+		cr.where = expr
+	}
+	expr, err := analysis.CompileExpr(r.metricSource(), RuleMetricVariables)
+	if err != nil {
+		return nil, fmt.Errorf("metric: %w", err)
+	}
+	cr.metric = expr
+	return cr, nil
+}
+
+// validateRules checks rule definitions and name uniqueness
+func validateRules(rules []Rule) error {
+	seen := make(map[string]bool, len(rules))
+	for i, r := range rules {
+		if _, err := r.compile(); err != nil {
+			if r.Name == "" {
+				return fmt.Errorf("rule %d: %w", i+1, err)
+			}
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("rule %q: duplicate name", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
+
+// EvaluateRules computes each rule's metric over issues as of now, keyed by
+// rule name, for storing in a baseline snapshot. Rules that fail to compile
+// are skipped (LoadConfig rejects them). Returns nil when there are no rules.
+func (c *Config) EvaluateRules(issues []model.Issue, now time.Time) map[string]baseline.RuleMetric {
+	if len(c.Rules) == 0 {
+		return nil
+	}
+	metrics := make(map[string]baseline.RuleMetric, len(c.Rules))
+	for _, r := range c.Rules {
+		cr, err := r.compile()
+		if err != nil {
+			continue
+		}
+		metrics[r.Name] = cr.evaluate(issues, now)
+	}
+	return metrics
+}
+
+// evaluate aggregates the issues matching the rule into its metric
+func (cr *compiledRule) evaluate(issues []model.Issue, now time.Time) baseline.RuleMetric {
+	var matches []string
+	agg := map[string]float64{}
+	var ageSum float64
+	statusByID := make(map[string]model.Status, len(issues))
+	for i := range issues {
+		statusByID[issues[i].ID] = issues[i].Status
+	}
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status == model.StatusTombstone || !cr.matches(issue) {
+			continue
+		}
+		vars := ruleIssueVars(issue, statusByID, now)
+		if cr.where != nil && cr.where.Eval(vars, issue.Labels) == 0 {
+			continue
+		}
+		matches = append(matches, issue.ID)
+		agg["count"]++
+		switch issue.Status {
+		case model.StatusClosed:
+			agg["closed_count"]++
+		case model.StatusBlocked:
+			agg["blocked_count"]++
+		case model.StatusInProgress:
+			agg["in_progress_count"]++
+			agg["open_count"]++
+		default:
+			agg["open_count"]++
+		}
+		agg["estimated_minutes"] += vars["estimated_minutes"]
+		ageSum += vars["age_days"]
+		agg["max_age_days"] = math.Max(agg["max_age_days"], vars["age_days"])
+		agg["max_days_since_update"] = math.Max(agg["max_days_since_update"], vars["days_since_update"])
+		agg["max_blocked_days"] = math.Max(agg["max_blocked_days"], vars["blocked_days"])
+	}
+	if agg["count"] > 0 {
+		agg["avg_age_days"] = ageSum / agg["count"]
+	}
+	sort.Strings(matches)
+	return baseline.RuleMetric{Value: cr.metric.Eval(agg, nil), Matches: matches}
+}
+
+// matches reports whether an issue satisfies every filter term
+func (cr *compiledRule) matches(issue *model.Issue) bool {
+	for _, term := range cr.filter {
+		var fieldValues []string
+		switch term.field {
+		case "status":
+			fieldValues = []string{string(issue.Status)}
+		case "type":
+			fieldValues = []string{string(issue.IssueType)}
+		case "priority":
+			fieldValues = []string{strconv.Itoa(issue.Priority)}
+		case "assignee":
+			fieldValues = []string{issue.Assignee}
+		case "label":
+			fieldValues = issue.Labels
+		}
+		hit := false
+		for _, fv := range fieldValues {
+			for _, want := range term.values {
+				if strings.EqualFold(fv, want) || (term.field == "priority" && "p"+fv == want) {
+					hit = true
+				}
+			}
+		}
+		if hit == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleIssueVars builds the where-expression variables for one issue.
+// statusByID resolves its blockers.
+func ruleIssueVars(issue *model.Issue, statusByID map[string]model.Status, now time.Time) map[string]float64 {
+	days := func(t time.Time) float64 {
+		if t.IsZero() {
+			return 0
+		}
+		return math.Max(0, now.Sub(t).Hours()/24)
+	}
+	vars := map[string]float64{
+		"priority":          float64(issue.Priority),
+		"age_days":          days(issue.CreatedAt),
+		"days_since_update": days(issue.UpdatedAt),
+		"dependency_count":  float64(len(issue.Dependencies)),
+	}
+	if since, ok := blockedSince(issue, statusByID); ok {
+		vars["blocked_days"] = days(since)
+	}
+	if issue.Status == model.StatusInProgress {
+		vars["in_progress_days"] = vars["days_since_update"]
+	}
+	if issue.EstimatedMinutes != nil {
+		vars["estimated_minutes"] = float64(*issue.EstimatedMinutes)
+	}
+	if issue.DueDate != nil {
+		vars["has_due"] = 1
+		vars["days_until_due"] = issue.DueDate.Sub(now).Hours() / 24
+		if issue.Status != model.StatusClosed && now.After(*issue.DueDate) {
+			vars["overdue"] = 1
+		}
+	}
+	return vars
+}
+
+// blockedSince reports whether an unclosed issue is blocked and since when: the
+// earliest link to a blocker that is still open. A blocked status without such a
+// link, or links without timestamps, fall back to the last update, since issues
+// carry no status history.
+func blockedSince(issue *model.Issue, statusByID map[string]model.Status) (time.Time, bool) {
+	if issue.Status == model.StatusClosed {
+		return time.Time{}, false
+	}
+	var since time.Time
+	blocked := issue.Status == model.StatusBlocked
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		status, ok := statusByID[dep.DependsOnID]
+		if !ok || status == model.StatusClosed || status == model.StatusTombstone {
+			continue
+		}
+		blocked = true
+		if !dep.CreatedAt.IsZero() && (since.IsZero() || dep.CreatedAt.Before(since)) {
+			since = dep.CreatedAt
+		}
+	}
+	if blocked && since.IsZero() {
+		since = issue.UpdatedAt
+	}
+	return since, blocked
+}
+
+// checkRules evaluates the user-defined rules from the config
+func (c *Calculator) checkRules(result *Result) {
+	if len(c.config.Rules) == 0 || c.config.IsAlertDisabled(string(AlertCustomRule)) {
+		return
+	}
+	current := c.current.RuleMetrics
+	if current == nil && c.issues != nil {
+		current = c.config.EvaluateRules(c.issues, time.Now())
+	}
+
+	for _, r := range c.config.Rules {
+		cur, ok := current[r.Name]
+		if !ok {
+			continue
+		}
+		compared, base, pct := cur.Value, 0.0, 0.0
+		if r.compareMode() != RuleCompareValue {
+			bm, ok := c.baseline.RuleMetrics[r.Name]
+			if !ok {
+				continue // Baseline predates the rule
+			}
+			base = bm.Value
+			pct = rulePercentChange(base, cur.Value)
+			compared = cur.Value - base
+			if r.compareMode() == RuleComparePercent {
+				compared = pct
+			}
+		}
+		if !compareRuleValue(compared, r.op(), r.Threshold) {
+			continue
+		}
+
+		details := cur.Matches
+		if len(details) > ruleDetailLimit {
+			details = append(append([]string{}, details[:ruleDetailLimit]...),
+				fmt.Sprintf("... and %d more", len(cur.Matches)-ruleDetailLimit))
+		}
+		alert := Alert{
+			Type:        AlertCustomRule,
+			Severity:    r.severity(),
+			Message:     ruleMessage(r, cur, base, pct),
+			BaselineVal: base,
+			CurrentVal:  cur.Value,
+			Delta:       cur.Value - base,
+			Details:     details,
+			Rule:        r.Name,
+			DetectedAt:  time.Now().UTC(),
+		}
+		if r.compareMode() == RuleCompareValue {
+			alert.Delta = 0
+		}
+		if len(cur.Matches) == 1 {
+			alert.IssueID = cur.Matches[0]
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+}
+
+// rulePercentChange is the percent change from base to cur. Growth from a
+// zero baseline counts as 100%.
+func rulePercentChange(base, cur float64) float64 {
+	if base == 0 {
+		switch {
+		case cur > 0:
+			return 100
+		case cur < 0:
+			return -100
+		}
+		return 0
+	}
+	return (cur - base) / math.Abs(base) * 100
+}
+
+func compareRuleValue(v float64, op string, threshold float64) bool {
+	switch op {
+	case ">":
+		return v > threshold
+	case ">=":
+		return v >= threshold
+	case "<":
+		return v < threshold
+	case "<=":
+		return v <= threshold
+	case "==":
+		return v == threshold
+	case "!=":
+		return v != threshold
+	}
+	return false
+}
+
+// ruleMessage renders the rule's message template, or a default message
+func ruleMessage(r Rule, cur baseline.RuleMetric, base, pct float64) string {
+	tmpl := r.Message
+	if tmpl == "" {
+		switch r.compareMode() {
+		case RuleCompareValue:
+			tmpl = "Rule {name}: " + r.metricSource() + " is {value} (" + r.op() + " {threshold})"
+		case RuleCompareDelta:
+			tmpl = "Rule {name}: " + r.metricSource() + " changed by {delta} ({baseline} → {value})"
+		default:
+			tmpl = "Rule {name}: " + r.metricSource() + " changed by {percent}% ({baseline} → {value})"
+		}
+	}
+	replacer := strings.NewReplacer(
+		"{name}", r.Name,
+		"{value}", formatRuleNumber(cur.Value),
+		"{baseline}", formatRuleNumber(base),
+		"{delta}", signedRuleNumber(cur.Value-base),
+		"{percent}", signedRuleNumber(math.Round(pct)),
+		"{threshold}", formatRuleNumber(r.Threshold),
+		"{count}", strconv.Itoa(len(cur.Matches)),
+		"{ids}", strings.Join(cur.Matches, ", "),
+	)
+	return replacer.Replace(tmpl)
+}
+
+func formatRuleNumber(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func signedRuleNumber(v float64) string {
+	if v > 0 {
+		return "+" + formatRuleNumber(v)
+	}
+	return formatRuleNumber(v)
+}
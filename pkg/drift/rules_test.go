@@ -0,0 +1,215 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func ruleIssues(now time.Time) []model.Issue {
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	return []model.Issue{
+		{ID: "BUG-1", IssueType: model.TypeBug, Priority: 0, Status: model.StatusBlocked, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(3)},
+		{ID: "BUG-2", IssueType: model.TypeBug, Priority: 0, Status: model.StatusBlocked, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(1)},
+		{ID: "BUG-3", IssueType: model.TypeBug, Priority: 1, Status: model.StatusBlocked, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(5)},
+		{ID: "PAY-1", IssueType: model.TypeTask, Status: model.StatusOpen, Labels: []string{"payments"}, CreatedAt: daysAgo(4), UpdatedAt: daysAgo(4)},
+		{ID: "PAY-2", IssueType: model.TypeTask, Status: model.StatusInProgress, Labels: []string{"Payments"}, CreatedAt: daysAgo(2), UpdatedAt: daysAgo(2)},
+		{ID: "PAY-3", IssueType: model.TypeTask, Status: model.StatusClosed, Labels: []string{"payments"}, CreatedAt: daysAgo(8), UpdatedAt: daysAgo(1)},
+		{ID: "GONE", IssueType: model.TypeBug, Priority: 0, Status: model.StatusTombstone, UpdatedAt: daysAgo(9)},
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{Name: "blocked-p0", Filter: "type:bug priority:p0 status:blocked", Where: "blocked_days > 2"},
+		{Name: "payments-open", Filter: "label:payments -status:closed"},
+		{Name: "payments-ratio", Filter: "label:payments", Metric: "closed_count / count * 100"},
+		{Name: "bug-age", Filter: "type:bug,feature", Metric: "max_age_days"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	metrics := cfg.EvaluateRules(ruleIssues(now), now)
+	if m := metrics["blocked-p0"]; m.Value != 1 || len(m.Matches) != 1 || m.Matches[0] != "BUG-1" {
+		t.Errorf("expected only BUG-1 blocked > 2 days, got %+v", m)
+	}
+	if m := metrics["payments-open"]; m.Value != 2 || strings.Join(m.Matches, ",") != "PAY-1,PAY-2" {
+		t.Errorf("expected PAY-1 and PAY-2 open, got %+v", m)
+	}
+	if m := metrics["payments-ratio"]; m.Value < 33.3 || m.Value > 33.4 {
+		t.Errorf("expected a third of payments closed, got %v", m.Value)
+	}
+	if m := metrics["bug-age"]; m.Value != 10 {
+		t.Errorf("expected tombstones excluded and max age 10, got %+v", m)
+	}
+}
+
+func TestEvaluateRules_BlockedDaysFromOpenBlockers(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	blockedBy := func(id string, linked time.Time) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks, CreatedAt: linked}}
+	}
+	issues := []model.Issue{
+		// Open, blocked by an open dependency for 4 days; a comment today does not reset it.
+		{ID: "BUG-1", IssueType: model.TypeBug, Status: model.StatusOpen, UpdatedAt: now, Dependencies: blockedBy("CORE", daysAgo(4))},
+		// Blocked only by a closed dependency.
+		{ID: "BUG-2", IssueType: model.TypeBug, Status: model.StatusOpen, UpdatedAt: daysAgo(5), Dependencies: blockedBy("DONE", daysAgo(5))},
+		// Blocked by an open dependency linked yesterday.
+		{ID: "BUG-3", IssueType: model.TypeBug, Status: model.StatusBlocked, UpdatedAt: daysAgo(6), Dependencies: blockedBy("CORE", daysAgo(1))},
+		{ID: "CORE", IssueType: model.TypeTask, Status: model.StatusInProgress, UpdatedAt: now},
+		{ID: "DONE", IssueType: model.TypeTask, Status: model.StatusClosed, UpdatedAt: now},
+	}
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{Name: "blocked-bugs", Filter: "type:bug", Where: "blocked_days > 2"},
+		{Name: "longest-block", Filter: "type:bug", Metric: "max_blocked_days"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	metrics := cfg.EvaluateRules(issues, now)
+	if m := metrics["blocked-bugs"]; strings.Join(m.Matches, ",") != "BUG-1" {
+		t.Errorf("expected only BUG-1 blocked > 2 days, got %+v", m)
+	}
+	if m := metrics["longest-block"]; m.Value != 4 {
+		t.Errorf("expected the longest block to be 4 days, got %v", m.Value)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	now := time.Now()
+	issues := ruleIssues(now)
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{Name: "blocked-p0", Filter: "type:bug priority:0 status:blocked", Where: "blocked_days > 2",
+			Severity: SeverityCritical, Message: "{count} P0 bug(s) blocked for over 2 days: {ids}"},
+		{Name: "payments-growth", Filter: "label:payments -status:closed", Compare: RuleComparePercent,
+			Op: ">=", Threshold: 30},
+		{Name: "quiet", Filter: "label:nothing"},
+	}
+
+	bl := &baseline.Baseline{RuleMetrics: map[string]baseline.RuleMetric{"payments-growth": {Value: 1}}}
+	calc := NewCalculator(bl, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	result := calc.Calculate()
+
+	byRule := map[string]Alert{}
+	for _, a := range result.Alerts {
+		if a.Type == AlertCustomRule {
+			byRule[a.Rule] = a
+		}
+	}
+	if len(byRule) != 2 {
+		t.Fatalf("expected two rule alerts, got %+v", result.Alerts)
+	}
+	p0 := byRule["blocked-p0"]
+	if p0.Severity != SeverityCritical || p0.IssueID != "BUG-1" || p0.Message != "1 P0 bug(s) blocked for over 2 days: BUG-1" {
+		t.Errorf("unexpected P0 alert %+v", p0)
+	}
+	growth := byRule["payments-growth"]
+	if growth.Severity != SeverityWarning || growth.BaselineVal != 1 || growth.CurrentVal != 2 || growth.Delta != 1 {
+		t.Errorf("unexpected growth alert %+v", growth)
+	}
+	if !strings.Contains(growth.Message, "+100% (1 → 2)") {
+		t.Errorf("expected the default percent message, got %q", growth.Message)
+	}
+	if result.ExitCode() != 1 {
+		t.Errorf("expected the critical rule to fail CI, got exit %d", result.ExitCode())
+	}
+
+	// Snapshot values are used as is, and baseline-relative rules are
+	// skipped when the baseline predates them.
+	current := &baseline.Baseline{RuleMetrics: cfg.EvaluateRules(issues[3:], now)}
+	result = NewCalculator(&baseline.Baseline{}, current, cfg).Calculate()
+	for _, a := range result.Alerts {
+		if a.Type == AlertCustomRule {
+			t.Errorf("expected no rule alerts, got %+v", a)
+		}
+	}
+
+	cfg.DisabledAlerts = []string{string(AlertCustomRule)}
+	calc = NewCalculator(bl, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	if result := calc.Calculate(); result.ExitCode() != 0 {
+		t.Errorf("expected disabled rules to raise nothing, got %+v", result.Alerts)
+	}
+}
+
+func TestCheckRules_DeltaAndDetailLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{{Name: "growth", Compare: RuleCompareDelta, Threshold: 2, Severity: SeverityInfo}}
+	var ids []string
+	for i := 0; i < 12; i++ {
+		ids = append(ids, string(rune('a'+i)))
+	}
+	bl := &baseline.Baseline{RuleMetrics: map[string]baseline.RuleMetric{"growth": {Value: 9}}}
+	cur := &baseline.Baseline{RuleMetrics: map[string]baseline.RuleMetric{"growth": {Value: 12, Matches: ids}}}
+
+	result := NewCalculator(bl, cur, cfg).Calculate()
+	if len(result.Alerts) != 1 || result.ExitCode() != 0 {
+		t.Fatalf("expected one info alert, got %+v", result.Alerts)
+	}
+	a := result.Alerts[0]
+	if a.Message != "Rule growth: count changed by +3 (9 → 12)" {
+		t.Errorf("unexpected message %q", a.Message)
+	}
+	if len(a.Details) != ruleDetailLimit+1 || a.Details[ruleDetailLimit] != "... and 2 more" {
+		t.Errorf("expected capped details, got %v", a.Details)
+	}
+}
+
+func TestLoadConfig_Rules(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		if err := os.MkdirAll(filepath.Join(tmpDir, ".bv"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(ConfigPath(tmpDir), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`rules:
+  - name: p0-blocked
+    filter: "type:bug priority:0 status:blocked"
+    where: "blocked_days > 2"
+    severity: critical
+`)
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Where != "blocked_days > 2" || cfg.StaleWarningDays != 14 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	for _, tt := range []struct {
+		yaml    string
+		wantErr string
+	}{
+		{"rules:\n  - filter: \"type:bug\"\n", "rule 1: name is required"},
+		{"rules:\n  - name: a\n    filter: \"color:red\"\n", "unknown filter field"},
+		{"rules:\n  - name: a\n    filter: \"bug\"\n", "must be field:value"},
+		{"rules:\n  - name: a\n    where: \"blocked_days >\"\n", "where:"},
+		{"rules:\n  - name: a\n    metric: \"blocked_days\"\n", "unknown variable"},
+		{"rules:\n  - name: a\n    compare: ratio\n", "compare must be"},
+		{"rules:\n  - name: a\n    op: \"=>\"\n", "op must be"},
+		{"rules:\n  - name: a\n    severity: fatal\n", "severity must be"},
+		{"rules:\n  - name: a\n  - name: a\n", "duplicate name"},
+	} {
+		write(tt.yaml)
+		if _, err := LoadConfig(tmpDir); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.yaml, tt.wantErr, err)
+		}
+	}
+}
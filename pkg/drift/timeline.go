@@ -0,0 +1,107 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// TimelinePoint is one snapshot between the two ends of a drift range,
+// typically a commit that touched the beads file.
+type TimelinePoint struct {
+	Label     string             `json:"label"` // Baseline name, ref or short SHA
+	CommitSHA string             `json:"commit_sha,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+	Baseline  *baseline.Baseline `json:"-"`
+}
+
+// TimelineEntry records when an alert first appeared in a drift range
+type TimelineEntry struct {
+	Type         AlertType `json:"type"`
+	Severity     Severity  `json:"severity"`
+	Message      string    `json:"message"`
+	IssueID      string    `json:"issue_id,omitempty"`
+	Label        string    `json:"label,omitempty"`
+	Rule         string    `json:"rule,omitempty"`
+	Detail       string    `json:"detail,omitempty"` // The cycle, for new_cycle alerts
+	FirstSeen    string    `json:"first_seen"`       // Label of the first point with the alert
+	FirstSeenSHA string    `json:"first_seen_sha,omitempty"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	Active       bool      `json:"active"` // Still raised at the last point
+}
+
+// BuildTimeline compares every point (oldest first) against from and
+// returns each distinct alert with the first point that raised it, in order
+// of appearance. Cycle alerts are tracked per cycle, since one alert lists
+// every new cycle.
+func BuildTimeline(from *baseline.Baseline, points []TimelinePoint, cfg *Config) []TimelineEntry {
+	var entries []TimelineEntry
+	index := make(map[string]int)
+	for i, p := range points {
+		if p.Baseline == nil {
+			continue
+		}
+		last := i == len(points)-1
+		result := NewCalculator(from, p.Baseline, cfg).Calculate()
+		for _, alert := range result.Alerts {
+			for _, detail := range timelineDetails(alert) {
+				key := strings.Join([]string{string(alert.Type), alert.Rule, alert.IssueID, alert.Label, detail}, "\x00")
+				if idx, ok := index[key]; ok {
+					entries[idx].Active = last
+					continue
+				}
+				index[key] = len(entries)
+				entries = append(entries, TimelineEntry{
+					Type:         alert.Type,
+					Severity:     alert.Severity,
+					Message:      alert.Message,
+					IssueID:      alert.IssueID,
+					Label:        alert.Label,
+					Rule:         alert.Rule,
+					Detail:       detail,
+					FirstSeen:    p.Label,
+					FirstSeenSHA: p.CommitSHA,
+					FirstSeenAt:  p.Timestamp,
+					Active:       last,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// timelineDetails splits cycle alerts into one key per cycle
+func timelineDetails(alert Alert) []string {
+	if alert.Type == AlertNewCycle && len(alert.Details) > 0 {
+		return alert.Details
+	}
+	return []string{""}
+}
+
+// FormatTimeline renders a timeline as human-readable text
+func FormatTimeline(entries []TimelineEntry) string {
+	if len(entries) == 0 {
+		return "Timeline: no alerts raised in the range.\n"
+	}
+	var sb strings.Builder
+	sb.WriteString("Drift Timeline\n")
+	sb.WriteString("==============\n\n")
+	for _, e := range entries {
+		state := "resolved"
+		if e.Active {
+			state = "active"
+		}
+		when := e.FirstSeen
+		if !e.FirstSeenAt.IsZero() {
+			when = fmt.Sprintf("%s %s", e.FirstSeenAt.Format("2006-01-02"), e.FirstSeen)
+		}
+		sb.WriteString(fmt.Sprintf("  %s  [%s] %s (%s)\n", when, e.Type, e.Message, state))
+		if e.Detail != "" {
+			sb.WriteString(fmt.Sprintf("      - %s\n", e.Detail))
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
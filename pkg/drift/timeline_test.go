@@ -0,0 +1,53 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+func TestBuildTimeline_FirstAppearance(t *testing.T) {
+	stats := baseline.GraphStats{NodeCount: 20, EdgeCount: 30, Density: 0.08, OpenCount: 15, BlockedCount: 2, ActionableCount: 10}
+	from := &baseline.Baseline{Stats: stats}
+	snap := func(blocked int, cycles ...[]string) *baseline.Baseline {
+		s := stats
+		s.BlockedCount = blocked
+		s.CycleCount = len(cycles)
+		return &baseline.Baseline{Stats: s, Cycles: cycles}
+	}
+	day := func(n int) time.Time { return time.Date(2026, 2, n, 0, 0, 0, 0, time.UTC) }
+	cycleAB := []string{"A", "B", "A"}
+	cycleCD := []string{"C", "D", "C"}
+
+	points := []TimelinePoint{
+		{Label: "c1", CommitSHA: "c1", Timestamp: day(1), Baseline: snap(2)},
+		{Label: "c2", CommitSHA: "c2", Timestamp: day(2), Baseline: snap(2, cycleAB)},
+		{Label: "c3", CommitSHA: "c3", Timestamp: day(3), Baseline: snap(8, cycleAB, cycleCD)},
+		{Label: "working tree", Timestamp: day(4), Baseline: snap(2, cycleCD)},
+	}
+	entries := BuildTimeline(from, points, DefaultConfig())
+
+	if len(entries) != 3 {
+		t.Fatalf("expected cycle A-B, cycle C-D and blocked increase, got %+v", entries)
+	}
+	ab, cd := entries[0], entries[1]
+	if ab.Type != AlertNewCycle || ab.Detail != "A → B → A" || ab.FirstSeenSHA != "c2" || !ab.FirstSeenAt.Equal(day(2)) || ab.Active {
+		t.Errorf("expected A-B first seen in c2 and since resolved, got %+v", ab)
+	}
+	if cd.Detail != "C → D → C" || cd.FirstSeen != "c3" || !cd.Active {
+		t.Errorf("expected C-D first seen in c3 and still active, got %+v", cd)
+	}
+	if blocked := entries[2]; blocked.Type != AlertBlockedIncrease || blocked.FirstSeen != "c3" || blocked.Active {
+		t.Errorf("expected a resolved blocked increase from c3, got %+v", blocked)
+	}
+
+	text := FormatTimeline(entries)
+	if !strings.Contains(text, "2026-02-02 c2  [new_cycle]") || !strings.Contains(text, "(resolved)") || !strings.Contains(text, "(active)") {
+		t.Errorf("unexpected timeline text:\n%s", text)
+	}
+	if got := FormatTimeline(nil); !strings.Contains(got, "no alerts") {
+		t.Errorf("unexpected empty timeline text %q", got)
+	}
+}
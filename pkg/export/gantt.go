@@ -0,0 +1,651 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"git.sr.ht/~sbinet/gg"
+	"github.com/ajstarks/svgo"
+	"golang.org/x/image/font/basicfont"
+)
+
+// GanttGroupBy controls how scheduled tasks are grouped into Gantt sections.
+type GanttGroupBy string
+
+const (
+	GanttGroupByEpic  GanttGroupBy = "epic"  // Nearest ancestor epic via parent-child deps
+	GanttGroupByLabel GanttGroupBy = "label" // First label (alphabetical)
+	GanttGroupByTrack GanttGroupBy = "track" // Execution plan track
+)
+
+// ParseGanttGroupBy converts a user-supplied grouping name, defaulting to epic.
+func ParseGanttGroupBy(s string) (GanttGroupBy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "epic":
+		return GanttGroupByEpic, nil
+	case "label":
+		return GanttGroupByLabel, nil
+	case "track":
+		return GanttGroupByTrack, nil
+	default:
+		return "", fmt.Errorf("unknown gantt grouping %q (want epic, label or track)", s)
+	}
+}
+
+// GanttOptions configures schedule construction.
+type GanttOptions struct {
+	GroupBy GanttGroupBy         // Section grouping (default: epic)
+	Stats   *analysis.GraphStats // Optional; improves ETA depth factors
+	Now     time.Time            // Schedule origin (default: time.Now())
+}
+
+// GanttTask is a single scheduled bar.
+type GanttTask struct {
+	ID               string       `json:"id"`
+	Title            string       `json:"title"`
+	Status           model.Status `json:"status"`
+	Priority         int          `json:"priority"`
+	Section          string       `json:"section"`
+	Track            string       `json:"track"`
+	Start            time.Time    `json:"start"`
+	End              time.Time    `json:"end"`
+	EstimatedMinutes int          `json:"estimated_minutes"`
+	Confidence       float64      `json:"confidence"`
+	DependsOn        []string     `json:"depends_on,omitempty"` // Open blockers scheduled before this task
+}
+
+// GanttSection groups tasks under a shared heading (epic, label or track).
+type GanttSection struct {
+	Name  string      `json:"name"`
+	Tasks []GanttTask `json:"tasks"`
+}
+
+// GanttSchedule is the complete forecast schedule.
+type GanttSchedule struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	GroupBy     GanttGroupBy   `json:"group_by"`
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+	TaskCount   int            `json:"task_count"`
+	Sections    []GanttSection `json:"sections"`
+}
+
+// BuildGanttSchedule turns ETA forecasts and execution plan tracks into a
+// dependency-respecting schedule. Each execution track is treated as a single
+// lane of work: tasks in the same track run sequentially, tasks in different
+// tracks run in parallel, and no task starts before its open blockers finish.
+// Closed issues and epics are not scheduled (epics appear as sections).
+func BuildGanttSchedule(issues []model.Issue, opts GanttOptions) GanttSchedule {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	groupBy := opts.GroupBy
+	if groupBy == "" {
+		groupBy = GanttGroupByEpic
+	}
+
+	schedule := GanttSchedule{
+		GeneratedAt: now.UTC(),
+		GroupBy:     groupBy,
+		Start:       now,
+		End:         now,
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, iss := range issues {
+		issueMap[iss.ID] = iss
+	}
+
+	// Collect schedulable issues in deterministic order.
+	var pending []string
+	for _, iss := range issues {
+		if isClosedLikeStatus(iss.Status) || iss.IssueType == model.TypeEpic {
+			continue
+		}
+		pending = append(pending, iss.ID)
+	}
+	if len(pending) == 0 {
+		return schedule
+	}
+	sort.Strings(pending)
+
+	// Execution plan tracks seed lane assignment for actionable issues.
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlan()
+	trackOf := make(map[string]string)
+	for _, track := range plan.Tracks {
+		for _, item := range track.Items {
+			trackOf[item.ID] = track.TrackID
+		}
+	}
+
+	// Open blockers among scheduled issues.
+	blockers := make(map[string][]string, len(pending))
+	dependents := make(map[string][]string)
+	scheduled := make(map[string]bool, len(pending))
+	for _, id := range pending {
+		scheduled[id] = true
+	}
+	for _, id := range pending {
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !scheduled[dep.DependsOnID] || dep.DependsOnID == id {
+				continue
+			}
+			blockers[id] = append(blockers[id], dep.DependsOnID)
+			dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], id)
+		}
+		sort.Strings(blockers[id])
+	}
+
+	order := ganttTopoOrder(pending, blockers, dependents, issueMap)
+
+	estimator := analysis.NewETAEstimator(issues, opts.Stats, now)
+	laneFree := make(map[string]time.Time)
+	ends := make(map[string]time.Time, len(order))
+	var tasks []GanttTask
+
+	for _, id := range order {
+		iss := issueMap[id]
+
+		track := trackOf[id]
+		if track == "" {
+			// Blocked work inherits the lane of its first scheduled blocker.
+			for _, b := range blockers[id] {
+				if t := trackOf[b]; t != "" {
+					track = t
+					break
+				}
+			}
+		}
+		if track == "" {
+			track = "track-?"
+		}
+		trackOf[id] = track
+
+		start := now
+		if free, ok := laneFree[track]; ok && free.After(start) {
+			start = free
+		}
+		var dependsOn []string
+		for _, b := range blockers[id] {
+			end, ok := ends[b]
+			if !ok {
+				continue // cycle member scheduled later; ignore edge
+			}
+			dependsOn = append(dependsOn, b)
+			if end.After(start) {
+				start = end
+			}
+		}
+
+		eta := estimator.Estimate(iss, 1)
+		minutes := eta.EstimatedMinutes
+		confidence := eta.Confidence
+		duration := time.Duration(eta.EstimatedDays * float64(24*time.Hour))
+		if duration < time.Hour {
+			duration = time.Hour
+		}
+		end := start.Add(duration)
+
+		ends[id] = end
+		laneFree[track] = end
+		if end.After(schedule.End) {
+			schedule.End = end
+		}
+
+		tasks = append(tasks, GanttTask{
+			ID:               id,
+			Title:            iss.Title,
+			Status:           iss.Status,
+			Priority:         iss.Priority,
+			Section:          ganttSectionName(iss, groupBy, track, issueMap),
+			Track:            track,
+			Start:            start,
+			End:              end,
+			EstimatedMinutes: minutes,
+			Confidence:       confidence,
+			DependsOn:        dependsOn,
+		})
+	}
+
+	schedule.Sections = groupGanttTasks(tasks)
+	schedule.TaskCount = len(tasks)
+	return schedule
+}
+
+// ganttTopoOrder orders issues so blockers come first, breaking ties by
+// priority then ID. Cycle members are appended in ID order.
+func ganttTopoOrder(ids []string, blockers, dependents map[string][]string, issueMap map[string]model.Issue) []string {
+	indegree := make(map[string]int, len(ids))
+	for _, id := range ids {
+		indegree[id] = len(blockers[id])
+	}
+
+	less := func(a, b string) bool {
+		pa, pb := issueMap[a].Priority, issueMap[b].Priority
+		if pa != pb {
+			return pa < pb
+		}
+		return a < b
+	}
+
+	var ready []string
+	for _, id := range ids {
+		if indegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(ids))
+	done := make(map[string]bool, len(ids))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		done[id] = true
+		for _, dep := range dependents[id] {
+			indegree[dep]--
+			if indegree[dep] == 0 {
+				ready = append(ready, dep)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		if !done[id] {
+			order = append(order, id)
+		}
+	}
+	return order
+}
+
+func ganttSectionName(iss model.Issue, groupBy GanttGroupBy, track string, issueMap map[string]model.Issue) string {
+	switch groupBy {
+	case GanttGroupByLabel:
+		if len(iss.Labels) == 0 {
+			return "Unlabeled"
+		}
+		labels := append([]string(nil), iss.Labels...)
+		sort.Strings(labels)
+		return labels[0]
+	case GanttGroupByTrack:
+		return track
+	default:
+		if epic, ok := findAncestorEpic(iss, issueMap); ok {
+			return epic.ID + " " + epic.Title
+		}
+		return "No epic"
+	}
+}
+
+// findAncestorEpic walks parent-child dependencies upward to the nearest epic.
+func findAncestorEpic(iss model.Issue, issueMap map[string]model.Issue) (model.Issue, bool) {
+	visited := map[string]bool{iss.ID: true}
+	current := iss
+	for {
+		parentID := ""
+		for _, dep := range current.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				if _, ok := issueMap[dep.DependsOnID]; ok {
+					parentID = dep.DependsOnID
+					break
+				}
+			}
+		}
+		if parentID == "" || visited[parentID] {
+			return model.Issue{}, false
+		}
+		visited[parentID] = true
+		parent := issueMap[parentID]
+		if parent.IssueType == model.TypeEpic {
+			return parent, true
+		}
+		current = parent
+	}
+}
+
+// groupGanttTasks buckets tasks by section, ordering sections by earliest start.
+func groupGanttTasks(tasks []GanttTask) []GanttSection {
+	bySection := make(map[string][]GanttTask)
+	for _, t := range tasks {
+		bySection[t.Section] = append(bySection[t.Section], t)
+	}
+
+	sections := make([]GanttSection, 0, len(bySection))
+	for name, ts := range bySection {
+		sort.SliceStable(ts, func(i, j int) bool {
+			if !ts[i].Start.Equal(ts[j].Start) {
+				return ts[i].Start.Before(ts[j].Start)
+			}
+			return ts[i].ID < ts[j].ID
+		})
+		sections = append(sections, GanttSection{Name: name, Tasks: ts})
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		si, sj := sections[i].Tasks[0].Start, sections[j].Tasks[0].Start
+		if !si.Equal(sj) {
+			return si.Before(sj)
+		}
+		return sections[i].Name < sections[j].Name
+	})
+	return sections
+}
+
+// --- Mermaid -----------------------------------------------------------------
+
+const ganttMermaidTimeLayout = "2006-01-02 15:04"
+
+// GenerateMermaidGantt renders a schedule as a Mermaid `gantt` block (without
+// the surrounding code fence).
+func GenerateMermaidGantt(schedule GanttSchedule, title string) string {
+	var sb strings.Builder
+
+	sb.WriteString("gantt\n")
+	if title != "" {
+		sb.WriteString(fmt.Sprintf("    title %s\n", sanitizeGanttText(title)))
+	}
+	sb.WriteString("    dateFormat YYYY-MM-DD HH:mm\n")
+	sb.WriteString("    axisFormat %m-%d\n")
+
+	safeIDs := make(map[string]string)
+	used := make(map[string]bool)
+	for _, section := range schedule.Sections {
+		for _, t := range section.Tasks {
+			base := sanitizeMermaidID(t.ID)
+			safe := base
+			for n := 2; used[safe]; n++ {
+				safe = fmt.Sprintf("%s_%d", base, n)
+			}
+			used[safe] = true
+			safeIDs[t.ID] = safe
+		}
+	}
+
+	for _, section := range schedule.Sections {
+		sb.WriteString(fmt.Sprintf("    section %s\n", sanitizeGanttText(section.Name)))
+		for _, t := range section.Tasks {
+			var tags []string
+			switch t.Status {
+			case model.StatusInProgress:
+				tags = append(tags, "active")
+			case model.StatusBlocked:
+				tags = append(tags, "crit")
+			}
+			tags = append(tags, safeIDs[t.ID], t.Start.Format(ganttMermaidTimeLayout), t.End.Format(ganttMermaidTimeLayout))
+			name := sanitizeGanttText(t.ID + " " + t.Title)
+			sb.WriteString(fmt.Sprintf("    %s :%s\n", name, strings.Join(tags, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+// sanitizeGanttText strips characters that terminate Mermaid gantt fields.
+func sanitizeGanttText(text string) string {
+	replacer := strings.NewReplacer(
+		":", " -",
+		"#", "",
+		";", ",",
+		"\n", " ",
+		"\r", "",
+	)
+	return sanitizeMermaidText(replacer.Replace(text))
+}
+
+// --- SVG / PNG ---------------------------------------------------------------
+
+// GanttExportOptions controls Gantt chart file export.
+type GanttExportOptions struct {
+	Path     string        // Output path; format inferred from extension when Format empty
+	Format   string        // "mermaid", "svg" or "png" (case-insensitive)
+	Title    string        // Optional chart title
+	Schedule GanttSchedule // Schedule to render
+}
+
+// SaveGanttChart writes a schedule as Mermaid text, SVG or PNG.
+func SaveGanttChart(opts GanttExportOptions) error {
+	if opts.Path == "" {
+		return fmt.Errorf("output path is required")
+	}
+	if opts.Schedule.TaskCount == 0 {
+		return fmt.Errorf("no open issues to schedule")
+	}
+
+	format := strings.ToLower(strings.TrimPrefix(opts.Format, "."))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(opts.Path)) {
+		case ".png":
+			format = "png"
+		case ".mmd", ".mermaid", ".md":
+			format = "mermaid"
+		default:
+			format = "svg"
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+
+	switch format {
+	case "mermaid":
+		content := GenerateMermaidGantt(opts.Schedule, opts.Title)
+		if strings.EqualFold(filepath.Ext(opts.Path), ".md") {
+			content = "```mermaid\n" + content + "```\n"
+		}
+		return os.WriteFile(opts.Path, []byte(content), 0o644)
+	case "svg":
+		file, err := os.Create(opts.Path)
+		if err != nil {
+			return err
+		}
+		if err := renderGanttSVGToWriter(file, buildGanttLayout(opts.Schedule, opts.Title)); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	case "png":
+		return renderGanttPNG(opts.Path, buildGanttLayout(opts.Schedule, opts.Title))
+	default:
+		return fmt.Errorf("unsupported format %q (want mermaid, svg or png)", format)
+	}
+}
+
+type ganttRow struct {
+	Section bool // section header row
+	Label   string
+	Status  model.Status
+	X, W    float64 // bar geometry (task rows only)
+	Y       float64
+}
+
+type ganttTick struct {
+	X     float64
+	Label string
+}
+
+type ganttLayout struct {
+	Title     string
+	Subtitle  string
+	Width     int
+	Height    int
+	Header    float64
+	LabelW    float64
+	RowH      float64
+	ChartTop  float64
+	ChartLeft float64
+	Rows      []ganttRow
+	Ticks     []ganttTick
+	NowX      float64
+}
+
+func buildGanttLayout(schedule GanttSchedule, title string) ganttLayout {
+	const (
+		labelW    = 320.0
+		rowH      = 24.0
+		header    = 96.0
+		minChartW = 720.0
+		maxChartW = 2400.0
+		margin    = 24.0
+	)
+
+	if title == "" {
+		title = "Forecast Schedule"
+	}
+
+	spanDays := schedule.End.Sub(schedule.Start).Hours() / 24
+	if spanDays < 1 {
+		spanDays = 1
+	}
+	pxPerDay := math.Max(12, math.Min(60, maxChartW/spanDays))
+	chartW := math.Max(minChartW, spanDays*pxPerDay)
+	pxPerDay = chartW / spanDays
+
+	layout := ganttLayout{
+		Title:     title,
+		Subtitle:  fmt.Sprintf("%d tasks | %s to %s | grouped by %s", schedule.TaskCount, schedule.Start.Format("2006-01-02"), schedule.End.Format("2006-01-02"), schedule.GroupBy),
+		Header:    header,
+		LabelW:    labelW,
+		RowH:      rowH,
+		ChartTop:  header + rowH, // leave a row for tick labels
+		ChartLeft: margin + labelW,
+	}
+
+	xFor := func(t time.Time) float64 {
+		return layout.ChartLeft + t.Sub(schedule.Start).Hours()/24*pxPerDay
+	}
+
+	y := layout.ChartTop
+	for _, section := range schedule.Sections {
+		layout.Rows = append(layout.Rows, ganttRow{Section: true, Label: truncate(section.Name, 44), Y: y})
+		y += rowH
+		for _, t := range section.Tasks {
+			x := xFor(t.Start)
+			w := math.Max(3, xFor(t.End)-x)
+			layout.Rows = append(layout.Rows, ganttRow{
+				Label:  truncate(t.ID+" "+t.Title, 44),
+				Status: t.Status,
+				X:      x,
+				W:      w,
+				Y:      y,
+			})
+			y += rowH
+		}
+	}
+
+	// Day ticks, thinned so labels never overlap (~70px apart).
+	step := int(math.Ceil(70 / pxPerDay))
+	if step < 1 {
+		step = 1
+	}
+	startDay := time.Date(schedule.Start.Year(), schedule.Start.Month(), schedule.Start.Day(), 0, 0, 0, 0, schedule.Start.Location())
+	for d := startDay; !d.After(schedule.End); d = d.AddDate(0, 0, step) {
+		if d.Before(schedule.Start) {
+			continue
+		}
+		layout.Ticks = append(layout.Ticks, ganttTick{X: xFor(d), Label: d.Format("01-02")})
+	}
+
+	layout.NowX = xFor(schedule.GeneratedAt.In(schedule.Start.Location()))
+	layout.Width = int(layout.ChartLeft + chartW + margin)
+	layout.Height = int(y + margin)
+	return layout
+}
+
+func renderGanttPNG(path string, layout ganttLayout) error {
+	dc := gg.NewContext(layout.Width, layout.Height)
+	dc.SetColor(colorBackdrop)
+	dc.Clear()
+
+	dc.SetColor(colorHeaderBG)
+	dc.DrawRoundedRectangle(16, 16, float64(layout.Width)-32, layout.Header-24, 10)
+	dc.Fill()
+
+	dc.SetFontFace(basicfont.Face7x13)
+	dc.SetColor(colorText)
+	dc.DrawStringAnchored(layout.Title, 32, 44, 0, 0.5)
+	dc.SetColor(colorSubtle)
+	dc.DrawStringAnchored(layout.Subtitle, 32, 64, 0, 0.5)
+
+	bottom := float64(layout.Height) - 24
+	dc.SetLineWidth(1)
+	for _, tick := range layout.Ticks {
+		dc.SetColor(colorLegendBG)
+		dc.DrawLine(tick.X, layout.ChartTop, tick.X, bottom)
+		dc.Stroke()
+		dc.SetColor(colorSubtle)
+		dc.DrawStringAnchored(tick.Label, tick.X, layout.ChartTop-layout.RowH/2, 0.5, 0.5)
+	}
+
+	for _, row := range layout.Rows {
+		if row.Section {
+			dc.SetColor(colorHeaderBG)
+			dc.DrawRectangle(24, row.Y, float64(layout.Width)-48, layout.RowH)
+			dc.Fill()
+			dc.SetColor(colorText)
+			dc.DrawStringAnchored(row.Label, 32, row.Y+layout.RowH/2, 0, 0.5)
+			continue
+		}
+		dc.SetColor(colorSubtle)
+		dc.DrawStringAnchored(row.Label, 40, row.Y+layout.RowH/2, 0, 0.5)
+		dc.SetColor(statusColor(row.Status))
+		dc.DrawRoundedRectangle(row.X, row.Y+4, row.W, layout.RowH-8, 4)
+		dc.Fill()
+		dc.SetColor(colorStroke)
+		dc.DrawRoundedRectangle(row.X, row.Y+4, row.W, layout.RowH-8, 4)
+		dc.Stroke()
+	}
+
+	dc.SetColor(colorBlocked)
+	dc.SetLineWidth(2)
+	dc.DrawLine(layout.NowX, layout.ChartTop, layout.NowX, bottom)
+	dc.Stroke()
+
+	return dc.SavePNG(path)
+}
+
+func renderGanttSVGToWriter(w io.Writer, layout ganttLayout) error {
+	canvas := svg.New(w)
+	canvas.Start(layout.Width, layout.Height)
+	canvas.Rect(0, 0, layout.Width, layout.Height, fmt.Sprintf("fill:%s", css(colorBackdrop)))
+	canvas.Roundrect(16, 16, layout.Width-32, int(layout.Header-24), 10, 10, fmt.Sprintf("fill:%s", css(colorHeaderBG)))
+	canvas.Text(32, 44, layout.Title, fmt.Sprintf("fill:%s;font-size:16px;font-family:monospace;font-weight:bold", css(colorText)))
+	canvas.Text(32, 64, layout.Subtitle, fmt.Sprintf("fill:%s;font-size:13px;font-family:monospace", css(colorSubtle)))
+
+	bottom := layout.Height - 24
+	for _, tick := range layout.Ticks {
+		x := int(tick.X)
+		canvas.Line(x, int(layout.ChartTop), x, bottom, fmt.Sprintf("stroke:%s;stroke-width:1", css(colorLegendBG)))
+		canvas.Text(x, int(layout.ChartTop-layout.RowH/2)+4, tick.Label,
+			fmt.Sprintf("fill:%s;font-size:11px;font-family:monospace;text-anchor:middle", css(colorSubtle)))
+	}
+
+	rowH := int(layout.RowH)
+	for _, row := range layout.Rows {
+		y := int(row.Y)
+		if row.Section {
+			canvas.Rect(24, y, layout.Width-48, rowH, fmt.Sprintf("fill:%s", css(colorHeaderBG)))
+			canvas.Text(32, y+rowH/2+4, row.Label, fmt.Sprintf("fill:%s;font-size:13px;font-family:monospace;font-weight:bold", css(colorText)))
+			continue
+		}
+		canvas.Text(40, y+rowH/2+4, row.Label, fmt.Sprintf("fill:%s;font-size:12px;font-family:monospace", css(colorSubtle)))
+		canvas.Roundrect(int(row.X), y+4, int(math.Max(3, row.W)), rowH-8, 4, 4,
+			fmt.Sprintf("fill:%s;stroke:%s;stroke-width:1", css(statusColor(row.Status)), css(colorStroke)))
+	}
+
+	nowX := int(layout.NowX)
+	canvas.Line(nowX, int(layout.ChartTop), nowX, bottom, fmt.Sprintf("stroke:%s;stroke-width:2", css(colorBlocked)))
+
+	canvas.End()
+	return nil
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func ganttFixture() []model.Issue {
+	est := func(m int) *int { return &m }
+	closedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{ID: "E1", Title: "Payments epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Title: "Schema", Status: model.StatusInProgress, IssueType: model.TypeTask, Priority: 1, EstimatedMinutes: est(240), Labels: []string{"db"},
+			Dependencies: []*model.Dependency{{DependsOnID: "E1", Type: model.DepParentChild}}},
+		{ID: "B", Title: "API: endpoints", Status: model.StatusBlocked, IssueType: model.TypeTask, Priority: 1, EstimatedMinutes: est(480), Labels: []string{"api"},
+			Dependencies: []*model.Dependency{
+				{DependsOnID: "E1", Type: model.DepParentChild},
+				{DependsOnID: "A", Type: model.DepBlocks},
+			}},
+		{ID: "C", Title: "Docs", Status: model.StatusOpen, IssueType: model.TypeChore, Priority: 3, EstimatedMinutes: est(60)},
+		{ID: "D", Title: "Done already", Status: model.StatusClosed, IssueType: model.TypeTask, ClosedAt: &closedAt},
+	}
+}
+
+func TestBuildGanttSchedule_RespectsBlockers(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	schedule := BuildGanttSchedule(ganttFixture(), GanttOptions{Now: now})
+
+	if schedule.TaskCount != 3 {
+		t.Fatalf("expected 3 scheduled tasks (epic and closed excluded), got %d", schedule.TaskCount)
+	}
+
+	tasks := make(map[string]GanttTask)
+	for _, s := range schedule.Sections {
+		for _, task := range s.Tasks {
+			tasks[task.ID] = task
+		}
+	}
+	a, b := tasks["A"], tasks["B"]
+	if b.Start.Before(a.End) {
+		t.Errorf("B starts %v before its blocker A ends %v", b.Start, a.End)
+	}
+	if len(b.DependsOn) != 1 || b.DependsOn[0] != "A" {
+		t.Errorf("B.DependsOn = %v, want [A]", b.DependsOn)
+	}
+	if a.Track != b.Track {
+		t.Errorf("blocked task should inherit blocker's track: A=%s B=%s", a.Track, b.Track)
+	}
+	if !tasks["C"].Start.Equal(now) {
+		t.Errorf("independent task C should start now, got %v", tasks["C"].Start)
+	}
+	if schedule.End.Before(b.End) {
+		t.Errorf("schedule end %v before last task end %v", schedule.End, b.End)
+	}
+}
+
+func TestBuildGanttSchedule_Grouping(t *testing.T) {
+	issues := ganttFixture()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	byEpic := BuildGanttSchedule(issues, GanttOptions{Now: now, GroupBy: GanttGroupByEpic})
+	names := sectionNames(byEpic)
+	if !names["E1 Payments epic"] || !names["No epic"] {
+		t.Errorf("epic sections = %v", names)
+	}
+
+	byLabel := BuildGanttSchedule(issues, GanttOptions{Now: now, GroupBy: GanttGroupByLabel})
+	names = sectionNames(byLabel)
+	for _, want := range []string{"db", "api", "Unlabeled"} {
+		if !names[want] {
+			t.Errorf("missing label section %q in %v", want, names)
+		}
+	}
+}
+
+func sectionNames(s GanttSchedule) map[string]bool {
+	names := make(map[string]bool)
+	for _, sec := range s.Sections {
+		names[sec.Name] = true
+	}
+	return names
+}
+
+func TestBuildGanttSchedule_CycleDoesNotHang(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "X", Title: "X", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "Y", Type: model.DepBlocks}}},
+		{ID: "Y", Title: "Y", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "X", Type: model.DepBlocks}}},
+	}
+	schedule := BuildGanttSchedule(issues, GanttOptions{})
+	if schedule.TaskCount != 2 {
+		t.Fatalf("expected both cycle members scheduled, got %d", schedule.TaskCount)
+	}
+}
+
+func TestGenerateMermaidGantt(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	schedule := BuildGanttSchedule(ganttFixture(), GanttOptions{Now: now})
+	out := GenerateMermaidGantt(schedule, "Roadmap: Q1")
+
+	for _, want := range []string{
+		"gantt\n",
+		"title Roadmap - Q1",
+		"dateFormat YYYY-MM-DD HH:mm",
+		"section E1 Payments epic",
+		":active, A, 2025-03-01 09:00,",
+		"B API - endpoints :crit, B,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSaveGanttChart_Formats(t *testing.T) {
+	schedule := BuildGanttSchedule(ganttFixture(), GanttOptions{Now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)})
+	tmp := t.TempDir()
+
+	for _, name := range []string{"gantt.svg", "gantt.png", "gantt.mmd", "gantt.md"} {
+		t.Run(name, func(t *testing.T) {
+			out := filepath.Join(tmp, name)
+			if err := SaveGanttChart(GanttExportOptions{Path: out, Schedule: schedule}); err != nil {
+				t.Fatalf("SaveGanttChart(%s): %v", name, err)
+			}
+			data, err := os.ReadFile(out)
+			if err != nil || len(data) == 0 {
+				t.Fatalf("output missing or empty: %v", err)
+			}
+			if name == "gantt.md" && !strings.HasPrefix(string(data), "```mermaid\ngantt") {
+				t.Errorf("markdown output should be fenced, got %q", string(data[:20]))
+			}
+			if name == "gantt.svg" && !strings.Contains(string(data), "<svg") {
+				t.Errorf("svg output missing <svg> tag")
+			}
+		})
+	}
+}
+
+func TestSaveGanttChart_Errors(t *testing.T) {
+	if err := SaveGanttChart(GanttExportOptions{Path: "x.svg"}); err == nil {
+		t.Error("expected error for empty schedule")
+	}
+	schedule := BuildGanttSchedule(ganttFixture(), GanttOptions{})
+	if err := SaveGanttChart(GanttExportOptions{Path: filepath.Join(t.TempDir(), "x.bin"), Format: "gif", Schedule: schedule}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestParseGanttGroupBy(t *testing.T) {
+	if g, err := ParseGanttGroupBy(""); err != nil || g != GanttGroupByEpic {
+		t.Errorf("default = %q, %v", g, err)
+	}
+	if g, err := ParseGanttGroupBy("Label"); err != nil || g != GanttGroupByLabel {
+		t.Errorf("label = %q, %v", g, err)
+	}
+	if _, err := ParseGanttGroupBy("assignee"); err == nil {
+		t.Error("expected error for unknown grouping")
+	}
+}
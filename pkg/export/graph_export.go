@@ -26,6 +26,7 @@ type GraphExportConfig struct {
 	Label    string            // Filter to specific label
 	Root     string            // Subgraph from specific root
 	Depth    int               // Max depth for subgraph (0 = unlimited)
+	Reduced  bool              // Drop blocking edges implied by longer chains (transitive reduction)
 	DataHash string            // Hash of input data for provenance
 }
 
@@ -75,6 +76,9 @@ type AdjacencyEdge struct {
 func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphExportConfig) (*GraphExportResult, error) {
 	// Filter issues if needed
 	filteredIssues := filterIssues(issues, config)
+	if config.Reduced {
+		filteredIssues = analysis.ApplyTransitiveReduction(filteredIssues, analysis.ComputeTransitiveReduction(filteredIssues))
+	}
 
 	if len(filteredIssues) == 0 {
 		return &GraphExportResult{
@@ -115,6 +119,9 @@ func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphE
 	if config.Depth > 0 {
 		filtersApplied["depth"] = fmt.Sprintf("%d", config.Depth)
 	}
+	if config.Reduced {
+		filtersApplied["reduced"] = "true"
+	}
 
 	result := &GraphExportResult{
 		Format:         string(config.Format),
@@ -335,6 +335,44 @@ func TestExportGraph_SubgraphRoot(t *testing.T) {
 	}
 }
 
+func TestExportGraph_Reduced(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Base", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Middle", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{
+				{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks},
+			},
+		},
+		{ID: "bv-3", Title: "Top", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{
+				{IssueID: "bv-3", DependsOnID: "bv-2", Type: model.DepBlocks},
+				{IssueID: "bv-3", DependsOnID: "bv-1", Type: model.DepBlocks},
+			},
+		},
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	result, err := ExportGraph(issues, &stats, GraphExportConfig{Format: GraphFormatDOT, Reduced: true})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+
+	if result.Edges != 2 {
+		t.Errorf("Expected redundant bv-3 -> bv-1 edge dropped (2 edges), got %d", result.Edges)
+	}
+	if strings.Contains(result.Graph, `"bv-3" -> "bv-1"`) {
+		t.Error("Reduced DOT output should not contain the redundant edge")
+	}
+	if result.FiltersApplied["reduced"] != "true" {
+		t.Errorf("Expected reduced filter recorded, got %v", result.FiltersApplied)
+	}
+	if len(issues[2].Dependencies) != 2 {
+		t.Error("ExportGraph should not modify the caller's issues")
+	}
+}
+
 func TestExportGraph_EmptyResult(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "bv-1", Title: "Issue", Status: model.StatusOpen, Labels: []string{"api"}},
@@ -70,8 +70,19 @@ func sanitizeMermaidText(text string) string {
 	return result
 }
 
+// MarkdownOptions enables optional sections of the markdown report.
+type MarkdownOptions struct {
+	// Schedule adds a Forecast Schedule section (see BuildGanttSchedule); nil omits it.
+	Schedule *GanttSchedule
+}
+
 // GenerateMarkdown creates a comprehensive markdown report of all issues
 func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
+	return GenerateMarkdownWithOptions(issues, title, MarkdownOptions{})
+}
+
+// GenerateMarkdownWithOptions is GenerateMarkdown with optional sections.
+func GenerateMarkdownWithOptions(issues []model.Issue, title string, opts MarkdownOptions) (string, error) {
 	var sb strings.Builder
 
 	// Header
@@ -139,6 +150,15 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	sb.WriteString("```\n\n")
 	sb.WriteString("---\n\n")
 
+	// Forecast Schedule (Mermaid gantt) - only when requested and there is open work
+	if schedule := opts.Schedule; schedule != nil && schedule.TaskCount > 0 {
+		sb.WriteString("## Forecast Schedule\n\n")
+		sb.WriteString("```mermaid\n")
+		sb.WriteString(GenerateMermaidGantt(*schedule, ""))
+		sb.WriteString("```\n\n")
+		sb.WriteString("---\n\n")
+	}
+
 	// Individual Issues
 	for idx, i := range issues {
 		typeIcon := getTypeEmoji(string(i.IssueType))
@@ -313,6 +333,11 @@ func getPriorityLabel(priority int) string {
 
 // SaveMarkdownToFile writes the generated markdown to a file
 func SaveMarkdownToFile(issues []model.Issue, filename string) error {
+	return SaveMarkdownToFileWithOptions(issues, filename, MarkdownOptions{})
+}
+
+// SaveMarkdownToFileWithOptions is SaveMarkdownToFile with optional sections.
+func SaveMarkdownToFileWithOptions(issues []model.Issue, filename string, opts MarkdownOptions) error {
 	// Make a copy to avoid mutating the caller's slice
 	issuesCopy := make([]model.Issue, len(issues))
 	copy(issuesCopy, issues)
@@ -330,7 +355,7 @@ func SaveMarkdownToFile(issues []model.Issue, filename string) error {
 		return issuesCopy[i].CreatedAt.After(issuesCopy[j].CreatedAt)
 	})
 
-	content, err := GenerateMarkdown(issuesCopy, "Beads Export")
+	content, err := GenerateMarkdownWithOptions(issuesCopy, "Beads Export", opts)
 	if err != nil {
 		return err
 	}
@@ -723,6 +723,39 @@ func TestGenerateMarkdown_MermaidClassDefs(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdown_ForecastSchedule(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "open-1", Title: "Open work", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "closed-1", Title: "Done", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+	md, err := GenerateMarkdown(issues, "Schedule Test")
+	if err != nil {
+		t.Fatalf("GenerateMarkdown returned error: %v", err)
+	}
+	if strings.Contains(md, "## Forecast Schedule") {
+		t.Error("schedule section should be opt-in")
+	}
+
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	schedule := BuildGanttSchedule(issues, GanttOptions{Now: now})
+	md, err = GenerateMarkdownWithOptions(issues, "Schedule Test", MarkdownOptions{Schedule: &schedule})
+	if err != nil {
+		t.Fatalf("GenerateMarkdownWithOptions returned error: %v", err)
+	}
+	if !strings.Contains(md, "## Forecast Schedule") || !strings.Contains(md, "```mermaid\ngantt\n") || !strings.Contains(md, "2025-01-06") {
+		t.Error("expected a Mermaid gantt forecast schedule section starting at the given time")
+	}
+
+	closedOnly := BuildGanttSchedule(issues[1:], GanttOptions{Now: now})
+	md, err = GenerateMarkdownWithOptions(issues[1:], "All Closed", MarkdownOptions{Schedule: &closedOnly})
+	if err != nil {
+		t.Fatalf("GenerateMarkdownWithOptions returned error: %v", err)
+	}
+	if strings.Contains(md, "## Forecast Schedule") {
+		t.Error("schedule section should be omitted when nothing is open")
+	}
+}
+
 // ============================================================================
 // SaveMarkdownToFile tests
 // ============================================================================
@@ -31,6 +31,10 @@ type SQLiteExporter struct {
 	Triage  *analysis.TriageResult
 	Config  SQLiteExportConfig
 	gitHash string
+
+	// Transitions is the git-derived status history used for flow metrics.
+	// When empty, flow metrics fall back to snapshot timestamps.
+	Transitions []analysis.StatusTransition
 }
 
 // NewSQLiteExporter creates a new exporter with the given data.
@@ -163,6 +167,16 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 		return fmt.Errorf("write graph layout: %w", err)
 	}
 
+	// Write forecast schedule (Gantt) for the charts dashboard
+	if err := e.writeGanttSchedule(dataDir); err != nil {
+		return fmt.Errorf("write gantt schedule: %w", err)
+	}
+
+	// Write flow metrics (cumulative flow, lead/cycle time) for the charts dashboard
+	if err := e.writeFlowMetrics(dataDir); err != nil {
+		return fmt.Errorf("write flow metrics: %w", err)
+	}
+
 	// Chunk if needed
 	if err := e.chunkIfNeeded(outputDir, dbPath); err != nil {
 		return fmt.Errorf("chunk database: %w", err)
@@ -818,3 +832,47 @@ func (e *SQLiteExporter) writeGraphLayout(dataDir string) error {
 
 	return writeJSON(filepath.Join(dataDir, "graph_layout.json"), layout)
 }
+
+// writeGanttSchedule writes the forecast schedule as JSON, Mermaid and SVG.
+// Nothing is written when there is no open work to schedule.
+func (e *SQLiteExporter) writeGanttSchedule(dataDir string) error {
+	issues := make([]model.Issue, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	schedule := BuildGanttSchedule(issues, GanttOptions{Stats: e.Stats})
+	if schedule.TaskCount == 0 {
+		return nil
+	}
+
+	if err := writeJSON(filepath.Join(dataDir, "gantt.json"), schedule); err != nil {
+		return fmt.Errorf("write gantt.json: %w", err)
+	}
+	for _, name := range []string{"gantt.mmd", "gantt.svg"} {
+		opts := GanttExportOptions{
+			Path:     filepath.Join(dataDir, name),
+			Title:    e.Config.Title,
+			Schedule: schedule,
+		}
+		if err := SaveGanttChart(opts); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeFlowMetrics writes data/flow.json with project and per-label flow metrics.
+func (e *SQLiteExporter) writeFlowMetrics(dataDir string) error {
+	issues := make([]model.Issue, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	report := analysis.ComputeFlowReport(issues, e.Transitions, analysis.DefaultFlowConfig())
+	return writeJSON(filepath.Join(dataDir, "flow.json"), report)
+}
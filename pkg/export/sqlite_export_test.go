@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	_ "modernc.org/sqlite"
@@ -166,6 +167,26 @@ func TestExport_CreatesDataDirectory(t *testing.T) {
 	if meta.IssueCount != 1 {
 		t.Errorf("Expected issue_count 1, got %d", meta.IssueCount)
 	}
+
+	// Forecast schedule assets for the charts dashboard
+	for _, name := range []string{"gantt.json", "gantt.mmd", "gantt.svg"} {
+		if _, err := os.Stat(filepath.Join(dataDir, name)); err != nil {
+			t.Errorf("%s was not created: %v", name, err)
+		}
+	}
+
+	// Flow metrics for the cumulative flow chart
+	flowData, err := os.ReadFile(filepath.Join(dataDir, "flow.json"))
+	if err != nil {
+		t.Fatalf("flow.json was not created: %v", err)
+	}
+	var flow analysis.FlowReport
+	if err := json.Unmarshal(flowData, &flow); err != nil {
+		t.Fatalf("flow.json is not valid JSON: %v", err)
+	}
+	if len(flow.Project.CFD) != flow.Days {
+		t.Errorf("expected %d CFD points, got %d", flow.Days, len(flow.Project.CFD))
+	}
 }
 
 func TestExport_MaterializedView(t *testing.T) {
@@ -6,6 +6,7 @@
  * - Label dependency heatmap
  * - Priority distribution pie chart
  * - Type breakdown bar chart
+ * - Cumulative flow diagram with lead/cycle time (data/flow.json)
  *
  * Uses Chart.js for standard charts, custom canvas for heatmap.
  *
@@ -71,6 +72,8 @@ const chartsState = {
     burndownChart: null,
     priorityChart: null,
     typeChart: null,
+    flowChart: null,
+    flowReport: null,
     heatmapCanvas: null,
     issues: [],
     dependencies: [],
@@ -101,6 +104,7 @@ function initCharts(issues, dependencies) {
     initPriorityChart();
     initTypeChart();
     initHeatmap();
+    initFlowChart();
 
     console.log('[bv-charts] Dashboard initialized with', issues.length, 'issues');
 }
@@ -136,6 +140,10 @@ function destroyCharts() {
         chartsState.typeChart.destroy();
         chartsState.typeChart = null;
     }
+    if (chartsState.flowChart) {
+        chartsState.flowChart.destroy();
+        chartsState.flowChart = null;
+    }
     chartsState.initialized = false;
 }
 
@@ -700,6 +708,170 @@ function updateHeatmap() {
     renderHeatmap();
 }
 
+// ============================================================================
+// CUMULATIVE FLOW DIAGRAM
+// ============================================================================
+
+/**
+ * Initialize the cumulative flow chart from the precomputed flow report.
+ * The report is built at export time from git history (see --robot-flow);
+ * the panel stays empty when data/flow.json is absent.
+ */
+async function initFlowChart() {
+    const canvas = document.getElementById('flow-chart');
+    if (!canvas) return;
+
+    if (!chartsState.flowReport) {
+        try {
+            const response = await fetch('./data/flow.json');
+            if (!response.ok) return;
+            chartsState.flowReport = await response.json();
+        } catch (err) {
+            console.warn('[bv-charts] flow.json unavailable:', err);
+            return;
+        }
+    }
+    // destroyCharts() may have run while the report was loading
+    if (!chartsState.initialized || chartsState.flowChart) return;
+
+    const select = document.getElementById('flow-scope');
+    if (select && select.options.length <= 1) {
+        (chartsState.flowReport.labels || []).forEach(summary => {
+            const option = document.createElement('option');
+            option.value = summary.label;
+            option.textContent = summary.label;
+            select.appendChild(option);
+        });
+        select.addEventListener('change', () => updateFlowChart());
+    }
+
+    const data = computeFlowData();
+    const band = (label, values, color) => ({
+        label,
+        data: values,
+        borderColor: color,
+        backgroundColor: color + '99',
+        fill: true,
+        tension: 0.2,
+        pointRadius: 0,
+        pointHoverRadius: 4
+    });
+
+    chartsState.flowChart = new Chart(canvas.getContext('2d'), {
+        type: 'line',
+        data: {
+            labels: data.labels,
+            datasets: [
+                band('Closed', data.closed, CHART_THEME.status.closed),
+                band('Blocked', data.blocked, CHART_THEME.status.blocked),
+                band('In Progress', data.inProgress, CHART_THEME.status.in_progress),
+                band('Open', data.open, CHART_THEME.status.open)
+            ]
+        },
+        options: {
+            responsive: true,
+            maintainAspectRatio: false,
+            interaction: {
+                intersect: false,
+                mode: 'index'
+            },
+            plugins: {
+                legend: {
+                    position: 'top',
+                    labels: {
+                        usePointStyle: true,
+                        padding: 15
+                    }
+                },
+                tooltip: {
+                    backgroundColor: CHART_THEME.tooltipBg,
+                    titleColor: CHART_THEME.fg,
+                    bodyColor: CHART_THEME.fg,
+                    borderColor: CHART_THEME.borderColor,
+                    borderWidth: 1,
+                    padding: 12
+                }
+            },
+            scales: {
+                x: {
+                    grid: { color: CHART_THEME.gridColor },
+                    ticks: { maxTicksLimit: 10 }
+                },
+                y: {
+                    stacked: true,
+                    beginAtZero: true,
+                    grid: { color: CHART_THEME.gridColor },
+                    ticks: { precision: 0 }
+                }
+            }
+        }
+    });
+
+    renderFlowStats(data.summary);
+}
+
+/**
+ * Select the project or label summary chosen in the scope dropdown and
+ * convert its CFD points into chart series.
+ */
+function computeFlowData() {
+    const report = chartsState.flowReport;
+    const empty = { labels: [], open: [], inProgress: [], blocked: [], closed: [], summary: null };
+    if (!report) return empty;
+
+    const select = document.getElementById('flow-scope');
+    const scope = select ? select.value : '';
+    const summary = scope
+        ? (report.labels || []).find(s => s.label === scope)
+        : report.project;
+    if (!summary) return empty;
+
+    const cfd = summary.cfd || [];
+    return {
+        labels: cfd.map(p => formatDateLabel(p.date)),
+        open: cfd.map(p => p.open),
+        inProgress: cfd.map(p => p.in_progress),
+        blocked: cfd.map(p => p.blocked),
+        closed: cfd.map(p => p.closed),
+        summary
+    };
+}
+
+function updateFlowChart() {
+    if (!chartsState.flowChart) return;
+
+    const data = computeFlowData();
+    chartsState.flowChart.data.labels = data.labels;
+    chartsState.flowChart.data.datasets[0].data = data.closed;
+    chartsState.flowChart.data.datasets[1].data = data.blocked;
+    chartsState.flowChart.data.datasets[2].data = data.inProgress;
+    chartsState.flowChart.data.datasets[3].data = data.open;
+    chartsState.flowChart.update();
+    renderFlowStats(data.summary);
+}
+
+/**
+ * Render lead time, cycle time, throughput and aging WIP below the chart
+ */
+function renderFlowStats(summary) {
+    const el = document.getElementById('flow-stats');
+    if (!el) return;
+    if (!summary) {
+        el.textContent = '';
+        return;
+    }
+
+    const fmt = stats => stats && stats.count
+        ? `median ${stats.median_days.toFixed(1)}d, p85 ${stats.p85_days.toFixed(1)}d (n=${stats.count})`
+        : 'no samples';
+    const aging = (summary.wip_aging || []).filter(w => w.over_p85).length;
+    const source = chartsState.flowReport.source === 'git' ? '' : ` · source: ${chartsState.flowReport.source}`;
+
+    el.textContent = `Lead time: ${fmt(summary.lead_time)} · Cycle time: ${fmt(summary.cycle_time)}` +
+        ` · Throughput: ${summary.throughput.per_week.toFixed(1)}/week` +
+        ` · WIP: ${(summary.wip_aging || []).length} (${aging} aging past p85)${source}`;
+}
+
 // ============================================================================
 // UTILITIES
 // ============================================================================
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -387,3 +388,112 @@ func (g *GitLoader) HasBeadsAtRevision(revision string) (bool, error) {
 
 	return false, nil
 }
+
+// SprintSnapshot is the tracker state at one commit: the issues and the
+// sprints file as they were then.
+type SprintSnapshot struct {
+	Revision RevisionInfo  `json:"revision"`
+	Issues   []model.Issue `json:"-"` // nil when no beads file was readable at this commit
+	Sprint   *model.Sprint `json:"-"` // nil when the sprint was not defined at this commit
+}
+
+// SprintSnapshots replays the history of the beads and sprints files for a
+// sprint window, oldest first. The last commit before since is included so
+// callers know the state the window started from.
+func (g *GitLoader) SprintSnapshots(sprintID string, since, until time.Time) ([]SprintSnapshot, error) {
+	paths := []string{"--", filepath.ToSlash(filepath.Join(".beads", SprintsFileName))}
+	for _, name := range PreferredJSONLNames {
+		paths = append(paths, ".beads/"+name)
+	}
+
+	before, err := g.logRevisions(append([]string{"-n1", fmt.Sprintf("--until=%s", since.Format(time.RFC3339))}, paths...))
+	if err != nil {
+		return nil, err
+	}
+	window, err := g.logRevisions(append([]string{
+		fmt.Sprintf("--since=%s", since.Format(time.RFC3339)),
+		fmt.Sprintf("--until=%s", until.Format(time.RFC3339)),
+	}, paths...))
+	if err != nil {
+		return nil, err
+	}
+
+	// git log lists newest first; replay oldest first.
+	revisions := before
+	seen := make(map[string]bool, len(before))
+	for _, r := range before {
+		seen[r.SHA] = true
+	}
+	for i := len(window) - 1; i >= 0; i-- {
+		if !seen[window[i].SHA] {
+			revisions = append(revisions, window[i])
+		}
+	}
+
+	snapshots := make([]SprintSnapshot, 0, len(revisions))
+	for _, rev := range revisions {
+		snap := SprintSnapshot{Revision: rev}
+		if issues, err := g.LoadAt(rev.SHA); err == nil {
+			snap.Issues = issues
+		}
+		sprints, err := g.LoadSprintsAt(rev.SHA)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sprints {
+			if sprints[i].ID == sprintID {
+				snap.Sprint = &sprints[i]
+				break
+			}
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, nil
+}
+
+// LoadSprintsAt loads sprints from a specific git revision. A revision
+// without a sprints file has no sprints.
+func (g *GitLoader) LoadSprintsAt(revision string) ([]model.Sprint, error) {
+	sha, err := g.resolveRevision(revision)
+	if err != nil {
+		return nil, fmt.Errorf("resolving revision %q: %w", revision, err)
+	}
+
+	cmd := exec.Command("git", "show", fmt.Sprintf("%s:.beads/%s", sha, SprintsFileName))
+	cmd.Dir = g.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return []model.Sprint{}, nil
+	}
+	return ParseSprints(bytes.NewReader(out))
+}
+
+// logRevisions runs git log with the given arguments and parses the commits,
+// dated by committer time to match --since/--until filtering.
+func (g *GitLoader) logRevisions(args []string) ([]RevisionInfo, error) {
+	cmd := exec.Command("git", append([]string{"log", "--format=%H|%cI|%s"}, args...)...)
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing git history: %w", err)
+	}
+
+	var revisions []RevisionInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			continue // skip revisions with unparseable timestamps
+		}
+		revisions = append(revisions, RevisionInfo{SHA: parts[0], Timestamp: timestamp, Message: parts[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing git log output: %w", err)
+	}
+	return revisions, nil
+}
@@ -331,6 +331,44 @@ func TestGitLoader_ListRevisions(t *testing.T) {
 	}
 }
 
+func TestGitLoader_SprintSnapshots(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	sprint := `{"id":"s1","name":"Sprint 1","bead_ids":["ISSUE-1","ISSUE-3"]}` + "\n"
+	if err := os.WriteFile(filepath.Join(repoDir, ".beads", SprintsFileName), []byte(sprint), 0644); err != nil {
+		t.Fatalf("failed to write sprints: %v", err)
+	}
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-m", "Plan sprint")
+
+	loader := NewGitLoader(repoDir)
+	now := time.Now()
+	snapshots, err := loader.SprintSnapshots("s1", now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SprintSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(snapshots))
+	}
+	if len(snapshots[0].Issues) != 2 || snapshots[0].Sprint != nil {
+		t.Errorf("expected the oldest commit first without a sprint, got %+v", snapshots[0])
+	}
+	last := snapshots[2]
+	if last.Revision.Message != "Plan sprint" || last.Sprint == nil || len(last.Sprint.BeadIDs) != 2 || len(last.Issues) != 3 {
+		t.Errorf("unexpected latest snapshot %+v", last)
+	}
+
+	// A window after all commits still starts from the latest state.
+	snapshots, err = loader.SprintSnapshots("s1", now.Add(time.Hour), now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("SprintSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Revision.Message != "Plan sprint" {
+		t.Errorf("expected only the starting state, got %+v", snapshots)
+	}
+}
+
 func TestGitLoader_HasBeadsAtRevision(t *testing.T) {
 	repoDir, cleanup := setupTestGitRepo(t)
 	defer cleanup()
@@ -232,14 +232,15 @@ type Comment struct {
 
 // Sprint represents a time-boxed period of work
 type Sprint struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	StartDate      time.Time `json:"start_date,omitzero"`
-	EndDate        time.Time `json:"end_date,omitzero"`
-	BeadIDs        []string  `json:"bead_ids,omitempty"`
-	VelocityTarget float64   `json:"velocity_target,omitempty"`
-	CreatedAt      time.Time `json:"created_at,omitzero"`
-	UpdatedAt      time.Time `json:"updated_at,omitzero"`
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	StartDate       time.Time `json:"start_date,omitzero"`
+	EndDate         time.Time `json:"end_date,omitzero"`
+	BeadIDs         []string  `json:"bead_ids,omitempty"`
+	VelocityTarget  float64   `json:"velocity_target,omitempty"`
+	CapacityMinutes int       `json:"capacity_minutes,omitempty"` // Planned work in estimated minutes
+	CreatedAt       time.Time `json:"created_at,omitzero"`
+	UpdatedAt       time.Time `json:"updated_at,omitzero"`
 }
 
 // Validate checks if the sprint data is logically valid
@@ -0,0 +1,214 @@
+// Package notify delivers drift alerts to the sinks configured in
+// .bv/notify.yaml: HTTP webhooks (generic or Slack-shaped), shell commands,
+// append-only files and desktop notifications. Alerts already delivered to a
+// sink are suppressed there until their cooldown expires, so repeated CI runs
+// and live reloads do not spam. Nothing is sent unless the user asks for it
+// with --notify: the config comes from the repository and can run commands.
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"gopkg.in/yaml.v3"
+)
+
+// SinkType identifies how a sink delivers notifications
+type SinkType string
+
+const (
+	SinkWebhook SinkType = "webhook" // POST the notification JSON (or a template) to a URL
+	SinkSlack   SinkType = "slack"   // POST a Slack-compatible message to an incoming webhook
+	SinkCommand SinkType = "command" // Run a shell command with the notification JSON on stdin
+	SinkFile    SinkType = "file"    // Append the notification as one JSON line
+	SinkDesktop SinkType = "desktop" // notify-send (Linux)
+)
+
+// Default delivery settings
+const (
+	DefaultCooldown       = 24 * time.Hour
+	DefaultRetries        = 3
+	DefaultBackoff        = time.Second
+	DefaultWebhookTimeout = 10 * time.Second
+	DefaultCommandTimeout = 30 * time.Second
+)
+
+// Sink is one notification destination
+type Sink struct {
+	Name string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Type SinkType `yaml:"type" json:"type"`
+
+	// URL for webhook and slack sinks, used as written (the environment is not
+	// expanded, so a repository cannot send local secrets anywhere)
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Headers for webhook sinks, used as written like URL
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Template renders a custom webhook body from the Notification (text/template)
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+
+	// Command for command sinks, run with sh -c (cmd /C on Windows)
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Path for file sinks, relative to the project directory
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// MinSeverity overrides the global minimum for this sink
+	MinSeverity drift.Severity `yaml:"min_severity,omitempty" json:"min_severity,omitempty"`
+
+	// Retry policy for webhook and slack sinks (backoff doubles per attempt).
+	// Retries defaults to DefaultRetries when absent from notify.yaml; 0 sends once.
+	Retries int           `yaml:"retries" json:"retries,omitempty"`
+	Backoff time.Duration `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	tmpl *template.Template
+}
+
+// UnmarshalYAML defaults Retries only when the key is absent, so an explicit
+// retries: 0 disables retrying.
+func (s *Sink) UnmarshalYAML(node *yaml.Node) error {
+	type plain Sink
+	p := plain{Retries: DefaultRetries}
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*s = Sink(p)
+	return nil
+}
+
+// Config is the notification configuration from .bv/notify.yaml
+type Config struct {
+	// MinSeverity is the lowest severity delivered (default warning)
+	MinSeverity drift.Severity `yaml:"min_severity,omitempty" json:"min_severity,omitempty"`
+
+	// Cooldown suppresses an alert that was delivered more recently than this (default 24h)
+	Cooldown time.Duration `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+
+	Sinks []Sink `yaml:"sinks" json:"sinks"`
+}
+
+// ConfigFilename is the notification config filename inside .bv
+const ConfigFilename = "notify.yaml"
+
+// StateFilename records when each alert was last delivered
+const StateFilename = "notify-state.json"
+
+// ConfigPath returns the notification config path for a project
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// StatePath returns the delivery state path for a project
+func StatePath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", StateFilename)
+}
+
+// LoadConfig loads .bv/notify.yaml. A missing file yields a config with no sinks.
+func LoadConfig(projectDir string) (*Config, error) {
+	data, err := os.ReadFile(ConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("reading notify config: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing notify config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notify config: %w", err)
+	}
+	return &config, nil
+}
+
+// Validate applies defaults and checks each sink
+func (c *Config) Validate() error {
+	if c.MinSeverity == "" {
+		c.MinSeverity = drift.SeverityWarning
+	}
+	if severityRank(c.MinSeverity) == 0 {
+		return fmt.Errorf("min_severity must be critical, warning or info")
+	}
+	if c.Cooldown == 0 {
+		c.Cooldown = DefaultCooldown
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("cooldown must be positive")
+	}
+
+	for i := range c.Sinks {
+		s := &c.Sinks[i]
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("%s-%d", s.Type, i+1)
+		}
+		if s.MinSeverity != "" && severityRank(s.MinSeverity) == 0 {
+			return fmt.Errorf("sink %q: min_severity must be critical, warning or info", s.Name)
+		}
+		if s.Retries < 0 || s.Backoff < 0 || s.Timeout < 0 {
+			return fmt.Errorf("sink %q: retries, backoff and timeout must be non-negative", s.Name)
+		}
+		if s.Backoff == 0 {
+			s.Backoff = DefaultBackoff
+		}
+
+		switch s.Type {
+		case SinkWebhook, SinkSlack:
+			if strings.TrimSpace(s.URL) == "" {
+				return fmt.Errorf("sink %q: url is required", s.Name)
+			}
+			if s.Timeout == 0 {
+				s.Timeout = DefaultWebhookTimeout
+			}
+			if s.Template != "" {
+				if s.Type == SinkSlack {
+					return fmt.Errorf("sink %q: template is only supported by webhook sinks", s.Name)
+				}
+				tmpl, err := template.New(s.Name).Funcs(templateFuncs).Parse(s.Template)
+				if err != nil {
+					return fmt.Errorf("sink %q: template: %w", s.Name, err)
+				}
+				s.tmpl = tmpl
+			}
+		case SinkCommand:
+			if strings.TrimSpace(s.Command) == "" {
+				return fmt.Errorf("sink %q: command is required", s.Name)
+			}
+			if s.Timeout == 0 {
+				s.Timeout = DefaultCommandTimeout
+			}
+		case SinkFile:
+			if strings.TrimSpace(s.Path) == "" {
+				return fmt.Errorf("sink %q: path is required", s.Name)
+			}
+		case SinkDesktop:
+		default:
+			return fmt.Errorf("sink %q: unknown type %q (want webhook, slack, command, file or desktop)", s.Name, s.Type)
+		}
+	}
+	return nil
+}
+
+// HasSinks reports whether any sink is configured
+func (c *Config) HasSinks() bool {
+	return c != nil && len(c.Sinks) > 0
+}
+
+// severityRank orders severities; unknown severities rank 0
+func severityRank(s drift.Severity) int {
+	switch s {
+	case drift.SeverityCritical:
+		return 3
+	case drift.SeverityWarning:
+		return 2
+	case drift.SeverityInfo:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+func writeNotifyConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.HasSinks() {
+		t.Errorf("expected no sinks, got %+v", cfg.Sinks)
+	}
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	dir := t.TempDir()
+	writeNotifyConfig(t, dir, `cooldown: 90m
+sinks:
+  - type: webhook
+    url: https://example.com/hook
+    template: '{"text": {{json .Summary}}}'
+  - type: command
+    name: pager
+    command: ./page.sh
+    min_severity: critical
+  - type: file
+    path: .bv/alerts.jsonl
+  - type: desktop
+    retries: 0
+`)
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.MinSeverity != drift.SeverityWarning || cfg.Cooldown != 90*time.Minute {
+		t.Errorf("unexpected globals %+v", cfg)
+	}
+	hook := cfg.Sinks[0]
+	if hook.Name != "webhook-1" || hook.Retries != DefaultRetries || hook.Backoff != DefaultBackoff || hook.Timeout != DefaultWebhookTimeout || hook.tmpl == nil {
+		t.Errorf("unexpected webhook defaults %+v", hook)
+	}
+	if cfg.Sinks[1].Name != "pager" || cfg.Sinks[1].Timeout != DefaultCommandTimeout {
+		t.Errorf("unexpected command defaults %+v", cfg.Sinks[1])
+	}
+	if cfg.Sinks[3].Retries != 0 {
+		t.Errorf("expected an explicit retries: 0 to be kept, got %d", cfg.Sinks[3].Retries)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		yaml    string
+		wantErr string
+	}{
+		{"sinks:\n  - type: pager\n", "unknown type"},
+		{"sinks:\n  - type: webhook\n", "url is required"},
+		{"sinks:\n  - type: slack\n    url: x\n    template: y\n", "only supported by webhook"},
+		{"sinks:\n  - type: webhook\n    url: x\n    template: '{{.Nope'\n", "template"},
+		{"sinks:\n  - type: command\n", "command is required"},
+		{"sinks:\n  - type: file\n", "path is required"},
+		{"sinks:\n  - type: desktop\n    retries: -1\n", "non-negative"},
+		{"min_severity: loud\nsinks: []\n", "min_severity"},
+		{"cooldown: -1h\nsinks: []\n", "cooldown must be positive"},
+		{"sinks: [", "parsing notify config"},
+	} {
+		writeNotifyConfig(t, dir, tt.yaml)
+		if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.yaml, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+// Notification is the payload delivered to every sink. Webhook and command
+// sinks receive it as JSON; webhook templates render it.
+type Notification struct {
+	Source      string        `json:"source"`  // "check-drift", "robot-alerts" or "tui"
+	Project     string        `json:"project"` // Project directory name
+	GeneratedAt time.Time     `json:"generated_at"`
+	Summary     Summary       `json:"summary"`
+	Alerts      []drift.Alert `json:"alerts"`
+}
+
+// Summary counts the alerts in a notification by severity
+type Summary struct {
+	Critical int `json:"critical"`
+	Warning  int `json:"warning"`
+	Info     int `json:"info"`
+}
+
+// SinkResult records one sink's delivery attempt
+type SinkResult struct {
+	Sink     string   `json:"sink"`
+	Type     SinkType `json:"type"`
+	Alerts   int      `json:"alerts"`
+	Attempts int      `json:"attempts"`
+	Success  bool     `json:"success"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// Report summarizes one Notify call
+type Report struct {
+	Delivered  int          `json:"delivered"`  // Alerts delivered by at least one sink
+	Suppressed int          `json:"suppressed"` // Alerts skipped as duplicates or in cooldown
+	Results    []SinkResult `json:"results"`
+}
+
+// Failed reports whether any sink failed
+func (r *Report) Failed() bool {
+	for _, res := range r.Results {
+		if !res.Success {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders a one-line summary for stderr
+func (r *Report) String() string {
+	var failed []string
+	for _, res := range r.Results {
+		if !res.Success {
+			failed = append(failed, fmt.Sprintf("%s: %s", res.Sink, res.Error))
+		}
+	}
+	s := fmt.Sprintf("Notified %d alert(s) via %d sink(s); %d suppressed by dedup/cooldown",
+		r.Delivered, len(r.Results)-len(failed), r.Suppressed)
+	if len(failed) > 0 {
+		s += "; failed: " + strings.Join(failed, "; ")
+	}
+	return s
+}
+
+// Dispatcher delivers alerts to the configured sinks, tracking what was
+// delivered in .bv/notify-state.json for dedup and cooldown.
+type Dispatcher struct {
+	config     *Config
+	projectDir string
+	client     *http.Client
+	now        func() time.Time
+	sleep      func(time.Duration)
+}
+
+// NewDispatcher creates a dispatcher for a validated config
+func NewDispatcher(cfg *Config, projectDir string) *Dispatcher {
+	return &Dispatcher{
+		config:     cfg,
+		projectDir: projectDir,
+		client:     &http.Client{},
+		now:        time.Now,
+		sleep:      time.Sleep,
+	}
+}
+
+// Notify delivers the alerts that meet each sink's minimum severity and that
+// sink has not delivered within the cooldown. Delivery is tracked per sink, so
+// an alert one sink failed to deliver is retried there on the next call even
+// if another sink accepted it. The error covers reading or writing the
+// delivery state only; sink failures are in the report.
+func (d *Dispatcher) Notify(source string, alerts []drift.Alert) (*Report, error) {
+	report := &Report{}
+	if !d.config.HasSinks() {
+		return report, nil
+	}
+	now := d.now()
+	state := loadState(StatePath(d.projectDir))
+
+	var fresh []drift.Alert
+	var keys []string
+	seen := make(map[string]bool)
+	for _, a := range alerts {
+		if severityRank(a.Severity) < severityRank(d.config.MinSeverity) {
+			continue
+		}
+		key := AlertKey(a)
+		if seen[key] {
+			report.Suppressed++
+			continue
+		}
+		seen[key] = true
+		fresh = append(fresh, a)
+		keys = append(keys, key)
+	}
+	if len(fresh) == 0 {
+		return report, nil
+	}
+
+	sent := make(map[string]bool)      // alert keys handed to at least one sink
+	cooling := make(map[string]bool)   // alert keys some sink skipped for cooldown
+	delivered := make(map[string]bool) // alert keys at least one sink accepted
+	changed := false
+	for _, sink := range d.config.Sinks {
+		minSeverity := sink.MinSeverity
+		if minSeverity == "" {
+			minSeverity = d.config.MinSeverity
+		}
+		var batch []drift.Alert
+		var batchKeys []string
+		for i, a := range fresh {
+			if severityRank(a.Severity) < severityRank(minSeverity) {
+				continue
+			}
+			if last, ok := state[sinkStateKey(sink, keys[i])]; ok && now.Sub(last) < d.config.Cooldown {
+				cooling[keys[i]] = true
+				continue
+			}
+			batch = append(batch, a)
+			batchKeys = append(batchKeys, keys[i])
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		n := d.notification(source, batch, now)
+		attempts, err := d.deliver(sink, n)
+		result := SinkResult{Sink: sink.Name, Type: sink.Type, Alerts: len(batch), Attempts: attempts, Success: err == nil}
+		for _, k := range batchKeys {
+			sent[k] = true
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			for _, k := range batchKeys {
+				delivered[k] = true
+				state[sinkStateKey(sink, k)] = now
+			}
+			changed = true
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	report.Delivered = len(delivered)
+	for k := range cooling {
+		if !sent[k] {
+			report.Suppressed++
+		}
+	}
+	// Forget entries whose cooldown has passed
+	for k, last := range state {
+		if now.Sub(last) >= d.config.Cooldown {
+			delete(state, k)
+		}
+	}
+	if changed {
+		if err := saveState(StatePath(d.projectDir), state); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// sinkStateKey records an alert's delivery to one sink
+func sinkStateKey(sink Sink, alertKey string) string {
+	return sink.Name + "|" + alertKey
+}
+
+// notification builds the payload for a batch of alerts
+func (d *Dispatcher) notification(source string, alerts []drift.Alert, now time.Time) Notification {
+	n := Notification{
+		Source:      source,
+		Project:     filepath.Base(d.projectDir),
+		GeneratedAt: now.UTC(),
+		Alerts:      alerts,
+	}
+	for _, a := range alerts {
+		switch a.Severity {
+		case drift.SeverityCritical:
+			n.Summary.Critical++
+		case drift.SeverityWarning:
+			n.Summary.Warning++
+		default:
+			n.Summary.Info++
+		}
+	}
+	return n
+}
+
+// AlertKey identifies an alert across runs for dedup: its type, rule,
+// issue and label, plus the cycles for new_cycle alerts.
+func AlertKey(a drift.Alert) string {
+	parts := []string{string(a.Type), a.Rule, a.IssueID, a.Label}
+	if a.Type == drift.AlertNewCycle {
+		details := append([]string(nil), a.Details...)
+		sort.Strings(details)
+		parts = append(parts, details...)
+	}
+	return strings.Join(parts, "|")
+}
+
+// loadState reads the last delivery time per alert key. A missing or
+// unreadable file starts fresh.
+func loadState(path string) map[string]time.Time {
+	state := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	var file struct {
+		Delivered map[string]time.Time `json:"delivered"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil || file.Delivered == nil {
+		return state
+	}
+	return file.Delivered
+}
+
+// saveState writes the delivery state atomically (temp file + rename), so a
+// concurrent TUI and CI run never read a half-written file.
+func saveState(path string, state map[string]time.Time) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating notify state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(struct {
+		Delivered map[string]time.Time `json:"delivered"`
+	}{state}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding notify state: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("writing notify state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing notify state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing notify state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing notify state: %w", err)
+	}
+	return nil
+}
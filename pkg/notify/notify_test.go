@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+func fileSinkConfig(t *testing.T, cooldown time.Duration, sinks ...Sink) *Config {
+	t.Helper()
+	cfg := &Config{Cooldown: cooldown, Sinks: sinks}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	return cfg
+}
+
+func readNotifications(t *testing.T, path string) []Notification {
+	t.Helper()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []Notification
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var n Notification
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil {
+			t.Fatalf("bad line %q: %v", scanner.Text(), err)
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func TestNotify_DedupAndCooldown(t *testing.T) {
+	dir := t.TempDir()
+	cfg := fileSinkConfig(t, time.Hour, Sink{Type: SinkFile, Path: "alerts.jsonl"})
+	d := NewDispatcher(cfg, dir)
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	stale := drift.Alert{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, Message: "X is stale", IssueID: "X"}
+	alerts := []drift.Alert{
+		stale,
+		stale, // Duplicate in the same batch
+		{Type: drift.AlertCustomRule, Severity: drift.SeverityCritical, Message: "rule", Rule: "p0"},
+		{Type: drift.AlertNodeCountChange, Severity: drift.SeverityInfo, Message: "below the minimum"},
+	}
+	report, err := d.Notify("check-drift", alerts)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if report.Delivered != 2 || report.Suppressed != 1 || len(report.Results) != 1 || !report.Results[0].Success {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	got := readNotifications(t, filepath.Join(dir, "alerts.jsonl"))
+	if len(got) != 1 || got[0].Source != "check-drift" || got[0].Project != filepath.Base(dir) ||
+		got[0].Summary.Critical != 1 || got[0].Summary.Warning != 1 || len(got[0].Alerts) != 2 {
+		t.Fatalf("unexpected notification %+v", got)
+	}
+
+	// Within the cooldown nothing is sent again.
+	now = now.Add(30 * time.Minute)
+	report, _ = d.Notify("check-drift", alerts)
+	if report.Delivered != 0 || report.Suppressed != 3 || len(report.Results) != 0 {
+		t.Errorf("expected everything suppressed, got %+v", report)
+	}
+
+	// After it expires, the alerts are delivered again.
+	now = now.Add(time.Hour)
+	report, _ = d.Notify("check-drift", alerts[:1])
+	if report.Delivered != 1 || len(readNotifications(t, filepath.Join(dir, "alerts.jsonl"))) != 2 {
+		t.Errorf("expected a redelivery after the cooldown, got %+v", report)
+	}
+}
+
+func TestNotify_FailedSinksRetryNextTime(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := fileSinkConfig(t, time.Hour,
+		Sink{Type: SinkFile, Path: filepath.Join(blocker, "alerts.jsonl")}, // Cannot be created
+		Sink{Type: SinkFile, Path: "critical.jsonl", MinSeverity: drift.SeverityCritical},
+	)
+	d := NewDispatcher(cfg, dir)
+	alert := drift.Alert{Type: drift.AlertBlockedIncrease, Severity: drift.SeverityWarning, Message: "blocked"}
+
+	report, err := d.Notify("robot-alerts", []drift.Alert{alert})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if !report.Failed() || report.Delivered != 0 || len(report.Results) != 1 {
+		t.Fatalf("expected one failed sink and the critical-only sink skipped, got %+v", report)
+	}
+	report, _ = d.Notify("robot-alerts", []drift.Alert{alert})
+	if report.Suppressed != 0 || len(report.Results) != 1 {
+		t.Errorf("expected an undelivered alert to be retried, got %+v", report)
+	}
+}
+
+func TestNotify_CooldownIsPerSink(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := fileSinkConfig(t, time.Hour,
+		Sink{Name: "ok", Type: SinkFile, Path: "alerts.jsonl"},
+		Sink{Name: "broken", Type: SinkFile, Path: filepath.Join(blocker, "alerts.jsonl")},
+	)
+	d := NewDispatcher(cfg, dir)
+	alert := drift.Alert{Type: drift.AlertBlockedIncrease, Severity: drift.SeverityWarning, Message: "blocked"}
+
+	report, err := d.Notify("check-drift", []drift.Alert{alert})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if report.Delivered != 1 || len(report.Results) != 2 || !report.Failed() {
+		t.Fatalf("expected one sink to deliver and one to fail, got %+v", report)
+	}
+
+	// The working sink is in cooldown; the broken one is tried again.
+	report, _ = d.Notify("check-drift", []drift.Alert{alert})
+	if len(report.Results) != 1 || report.Results[0].Sink != "broken" || report.Suppressed != 0 {
+		t.Errorf("expected only the failed sink to retry, got %+v", report)
+	}
+	if got := readNotifications(t, filepath.Join(dir, "alerts.jsonl")); len(got) != 1 {
+		t.Errorf("expected one delivery on the working sink, got %d", len(got))
+	}
+
+	// The state is replaced atomically, leaving no temp files behind.
+	entries, err := os.ReadDir(filepath.Join(dir, ".bv"))
+	if err != nil || len(entries) != 1 || entries[0].Name() != StateFilename {
+		t.Errorf("expected only %s in .bv, got %v (%v)", StateFilename, entries, err)
+	}
+}
+
+func TestAlertKey(t *testing.T) {
+	a := drift.Alert{Type: drift.AlertNewCycle, Details: []string{"B → C → B", "A → B → A"}}
+	b := drift.Alert{Type: drift.AlertNewCycle, Details: []string{"A → B → A", "B → C → B"}, Message: "different wording"}
+	if AlertKey(a) != AlertKey(b) {
+		t.Errorf("expected cycle alerts with the same cycles to share a key")
+	}
+	c := drift.Alert{Type: drift.AlertNewCycle, Details: []string{"A → B → A"}}
+	if AlertKey(a) == AlertKey(c) {
+		t.Errorf("expected a different cycle set to change the key")
+	}
+	r1 := drift.Alert{Type: drift.AlertCustomRule, Rule: "one"}
+	r2 := drift.Alert{Type: drift.AlertCustomRule, Rule: "two"}
+	if AlertKey(r1) == AlertKey(r2) {
+		t.Errorf("expected rules to be keyed by name")
+	}
+}
@@ -0,0 +1,264 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+// desktopCommand is the desktop notifier binary (overridden in tests)
+var desktopCommand = "notify-send"
+
+// templateFuncs are available to webhook templates
+var templateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {"text": {{json .Summary}}}
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// deliver sends a notification to one sink and returns the attempts made
+func (d *Dispatcher) deliver(sink Sink, n Notification) (int, error) {
+	switch sink.Type {
+	case SinkWebhook:
+		body, err := webhookBody(sink, n)
+		if err != nil {
+			return 0, err
+		}
+		return d.post(sink, body)
+	case SinkSlack:
+		body, err := json.Marshal(slackPayload(n))
+		if err != nil {
+			return 0, err
+		}
+		return d.post(sink, body)
+	case SinkCommand:
+		return 1, d.runCommand(sink, n)
+	case SinkFile:
+		return 1, d.appendFile(sink, n)
+	case SinkDesktop:
+		return 1, sendDesktop(n)
+	}
+	return 0, fmt.Errorf("unknown sink type %q", sink.Type)
+}
+
+// webhookBody renders the sink's template, or the notification JSON
+func webhookBody(sink Sink, n Notification) ([]byte, error) {
+	tmpl := sink.tmpl
+	if tmpl == nil && sink.Template != "" {
+		var err error
+		if tmpl, err = template.New(sink.Name).Funcs(templateFuncs).Parse(sink.Template); err != nil {
+			return nil, fmt.Errorf("template: %w", err)
+		}
+	}
+	if tmpl == nil {
+		return json.Marshal(n)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends body to the sink URL, retrying network errors, 429 and 5xx
+// responses with exponential backoff
+func (d *Dispatcher) post(sink Sink, body []byte) (int, error) {
+	backoff := sink.Backoff
+	var lastErr error
+	attempt := 0
+	for attempt < sink.Retries+1 {
+		if attempt > 0 {
+			d.sleep(backoff)
+			backoff *= 2
+		}
+		attempt++
+
+		retry, err := d.postOnce(sink, body)
+		if err == nil {
+			return attempt, nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return attempt, lastErr
+}
+
+func (d *Dispatcher) postOnce(sink Sink, body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sink.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bv-notify")
+	for k, v := range sink.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook returned %s", resp.Status)
+}
+
+// slackMessage is the incoming-webhook payload shape used by Slack and
+// compatible services (Mattermost, Rocket.Chat, Discord's /slack endpoint)
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color    string `json:"color"`
+	Title    string `json:"title"`
+	Text     string `json:"text,omitempty"`
+	Fallback string `json:"fallback"`
+}
+
+func slackPayload(n Notification) slackMessage {
+	msg := slackMessage{Text: fmt.Sprintf("*bv* %s: %s", n.Project, summaryText(n.Summary))}
+	for _, a := range n.Alerts {
+		color := "#439FE0"
+		switch a.Severity {
+		case drift.SeverityCritical:
+			color = "danger"
+		case drift.SeverityWarning:
+			color = "warning"
+		}
+		title := fmt.Sprintf("[%s] %s", a.Type, a.Message)
+		msg.Attachments = append(msg.Attachments, slackAttachment{
+			Color:    color,
+			Title:    title,
+			Text:     strings.Join(a.Details, "\n"),
+			Fallback: title,
+		})
+	}
+	return msg
+}
+
+func summaryText(s Summary) string {
+	var parts []string
+	if s.Critical > 0 {
+		parts = append(parts, fmt.Sprintf("%d critical", s.Critical))
+	}
+	if s.Warning > 0 {
+		parts = append(parts, fmt.Sprintf("%d warning", s.Warning))
+	}
+	if s.Info > 0 {
+		parts = append(parts, fmt.Sprintf("%d info", s.Info))
+	}
+	return strings.Join(parts, ", ") + " alert(s)"
+}
+
+// runCommand runs the sink command with the notification JSON on stdin
+func (d *Dispatcher) runCommand(sink Sink, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sink.Timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, sink.Command)
+	cmd.Dir = d.projectDir
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("BV_NOTIFY_SOURCE=%s", n.Source),
+		fmt.Sprintf("BV_ALERT_COUNT=%d", len(n.Alerts)),
+		fmt.Sprintf("BV_ALERT_CRITICAL=%d", n.Summary.Critical),
+		fmt.Sprintf("BV_ALERT_WARNING=%d", n.Summary.Warning),
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timeout after %v", sink.Timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// appendFile appends the notification as one JSON line
+func (d *Dispatcher) appendFile(sink Sink, n Notification) error {
+	path := sink.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(d.projectDir, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	line, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sendDesktop shows a desktop notification via notify-send
+func sendDesktop(n Notification) error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("desktop notifications need notify-send on Linux")
+	}
+	bin, err := exec.LookPath(desktopCommand)
+	if err != nil {
+		return fmt.Errorf("%s not found: %w", desktopCommand, err)
+	}
+	urgency := "low"
+	if n.Summary.Critical > 0 {
+		urgency = "critical"
+	} else if n.Summary.Warning > 0 {
+		urgency = "normal"
+	}
+	var body []string
+	for _, a := range n.Alerts {
+		body = append(body, fmt.Sprintf("• %s", a.Message))
+	}
+	title := fmt.Sprintf("bv %s: %s", n.Project, summaryText(n.Summary))
+	out, err := exec.Command(bin, "-a", "bv", "-u", urgency, title, strings.Join(body, "\n")).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
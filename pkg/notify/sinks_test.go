@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+var sinkTestAlerts = []drift.Alert{
+	{Type: drift.AlertNewCycle, Severity: drift.SeverityCritical, Message: "1 new cycle(s) detected", Details: []string{"A → B → A"}},
+	{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, Message: "X is stale", IssueID: "X"},
+}
+
+// recordingServer answers with the given status codes in turn (then 200)
+// and records request bodies and headers.
+type recordingServer struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+	headers  []http.Header
+}
+
+func (s *recordingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, string(body))
+	s.headers = append(s.headers, r.Header.Clone())
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestDispatcher(t *testing.T, sinks ...Sink) (*Dispatcher, *[]time.Duration) {
+	t.Helper()
+	cfg := &Config{Sinks: sinks}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	d := NewDispatcher(cfg, t.TempDir())
+	var sleeps []time.Duration
+	d.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return d, &sleeps
+}
+
+func TestWebhookSink_RetryAndTemplate(t *testing.T) {
+	rec := &recordingServer{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+	t.Setenv("BV_TEST_TOKEN", "s3cret")
+
+	d, sleeps := newTestDispatcher(t, Sink{
+		Type:     SinkWebhook,
+		URL:      srv.URL,
+		Headers:  map[string]string{"Authorization": "Bearer ${BV_TEST_TOKEN}"},
+		Template: `{"count": {{len .Alerts}}, "first": {{json (index .Alerts 0).Message}}}`,
+		Retries:  DefaultRetries,
+		Backoff:  time.Second,
+	})
+	report, err := d.Notify("check-drift", sinkTestAlerts)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(report.Results) != 1 || !report.Results[0].Success || report.Results[0].Attempts != 3 {
+		t.Fatalf("expected success on the third attempt, got %+v", report.Results)
+	}
+	if len(*sleeps) != 2 || (*sleeps)[0] != time.Second || (*sleeps)[1] != 2*time.Second {
+		t.Errorf("expected doubling backoff, got %v", *sleeps)
+	}
+	if got := rec.bodies[2]; got != `{"count": 2, "first": "1 new cycle(s) detected"}` {
+		t.Errorf("unexpected templated body %s", got)
+	}
+	// Repository-supplied headers are sent as written, never filled from the environment.
+	if got := rec.headers[2].Get("Authorization"); got != "Bearer ${BV_TEST_TOKEN}" {
+		t.Errorf("expected the literal header, got %q", got)
+	}
+}
+
+func TestWebhookSink_DefaultPayloadAndClientErrors(t *testing.T) {
+	rec := &recordingServer{statuses: []int{http.StatusOK, http.StatusBadRequest}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	d, sleeps := newTestDispatcher(t, Sink{Type: SinkWebhook, URL: srv.URL})
+	if _, err := d.Notify("robot-alerts", sinkTestAlerts); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	var n Notification
+	if err := json.Unmarshal([]byte(rec.bodies[0]), &n); err != nil {
+		t.Fatalf("expected the notification JSON: %v\n%s", err, rec.bodies[0])
+	}
+	if n.Source != "robot-alerts" || len(n.Alerts) != 2 || n.Summary.Critical != 1 {
+		t.Errorf("unexpected payload %+v", n)
+	}
+	if ct := rec.headers[0].Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+
+	// 4xx responses are not retried.
+	report, _ := d.Notify("robot-alerts", []drift.Alert{{Type: drift.AlertBlockedIncrease, Severity: drift.SeverityWarning}})
+	if len(report.Results) != 1 || report.Results[0].Success || report.Results[0].Attempts != 1 || len(*sleeps) != 0 {
+		t.Errorf("expected one failed attempt, got %+v", report.Results)
+	}
+	if !strings.Contains(report.String(), "failed: webhook-1: webhook returned 400") {
+		t.Errorf("unexpected report summary %q", report.String())
+	}
+}
+
+func TestSlackSink_Payload(t *testing.T) {
+	rec := &recordingServer{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	d, _ := newTestDispatcher(t, Sink{Type: SinkSlack, URL: srv.URL})
+	if _, err := d.Notify("tui", sinkTestAlerts); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	var msg slackMessage
+	if err := json.Unmarshal([]byte(rec.bodies[0]), &msg); err != nil {
+		t.Fatalf("parse slack payload: %v", err)
+	}
+	if !strings.Contains(msg.Text, "1 critical, 1 warning alert(s)") || len(msg.Attachments) != 2 {
+		t.Fatalf("unexpected slack message %+v", msg)
+	}
+	if a := msg.Attachments[0]; a.Color != "danger" || a.Title != "[new_cycle] 1 new cycle(s) detected" || a.Text != "A → B → A" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+	if msg.Attachments[1].Color != "warning" {
+		t.Errorf("expected a warning color, got %+v", msg.Attachments[1])
+	}
+}
+
+func TestCommandSink_ReceivesJSONOnStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	d, _ := newTestDispatcher(t,
+		Sink{Type: SinkCommand, Command: `cat > received.json; echo "$BV_NOTIFY_SOURCE $BV_ALERT_COUNT $BV_ALERT_CRITICAL" > env.txt`},
+		Sink{Type: SinkCommand, Name: "broken", Command: "echo nope >&2; exit 3"},
+	)
+	report, err := d.Notify("check-drift", sinkTestAlerts)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if !report.Results[0].Success || report.Results[1].Success || !strings.Contains(report.Results[1].Error, "nope") {
+		t.Fatalf("unexpected results %+v", report.Results)
+	}
+	data, err := os.ReadFile(filepath.Join(d.projectDir, "received.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n Notification
+	if err := json.Unmarshal(data, &n); err != nil || len(n.Alerts) != 2 {
+		t.Errorf("expected the notification on stdin, got %s (%v)", data, err)
+	}
+	env, _ := os.ReadFile(filepath.Join(d.projectDir, "env.txt"))
+	if strings.TrimSpace(string(env)) != "check-drift 2 1" {
+		t.Errorf("unexpected environment %q", env)
+	}
+}
+
+func TestDesktopSink(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("notify-send is Linux only")
+	}
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args.txt")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\n"
+	fake := filepath.Join(binDir, "fake-notify-send")
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := desktopCommand
+	desktopCommand = fake
+	defer func() { desktopCommand = old }()
+
+	d, _ := newTestDispatcher(t, Sink{Type: SinkDesktop})
+	report, err := d.Notify("tui", sinkTestAlerts)
+	if err != nil || !report.Results[0].Success {
+		t.Fatalf("expected delivery, got %+v (%v)", report, err)
+	}
+	args, _ := os.ReadFile(argsFile)
+	for _, want := range []string{"-u\ncritical", "1 critical, 1 warning alert(s)", "• X is stale"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("expected %q in notify-send args:\n%s", want, args)
+		}
+	}
+
+	desktopCommand = filepath.Join(binDir, "missing")
+	report, _ = d.Notify("tui", []drift.Alert{{Type: drift.AlertBlockedIncrease, Severity: drift.SeverityWarning}})
+	if report.Results[0].Success || !strings.Contains(report.Results[0].Error, "not found") {
+		t.Errorf("expected a missing notifier error, got %+v", report.Results)
+	}
+}
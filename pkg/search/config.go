@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // EmbeddingConfigFromEnv reads semantic embedding configuration from environment variables.
@@ -13,24 +14,65 @@ import (
 // Supported variables:
 //   - BV_SEMANTIC_EMBEDDER: embedding provider (default: "hash")
 //   - BV_SEMANTIC_MODEL: model identifier (provider-specific, optional)
-//   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim)
+//   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim; detected for HTTP providers)
+//   - BV_SEMANTIC_URL: base URL of an OpenAI-compatible endpoint (HTTP providers)
+//   - BV_SEMANTIC_API_KEY: bearer token (falls back to OPENAI_API_KEY for "openai")
+//   - BV_SEMANTIC_BATCH: texts per request (default: DefaultHTTPBatchSize)
+//   - BV_SEMANTIC_TIMEOUT: per-request timeout, e.g. "30s" (default: DefaultHTTPTimeout)
 func EmbeddingConfigFromEnv() EmbeddingConfig {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvSemanticEmbedder)))
 	cfg := EmbeddingConfig{
 		Provider: Provider(provider),
 		Model:    strings.TrimSpace(os.Getenv(EnvSemanticModel)),
+		BaseURL:  strings.TrimSpace(os.Getenv(EnvSemanticURL)),
+		APIKey:   strings.TrimSpace(os.Getenv(EnvSemanticAPIKey)),
 	}
 	if dimStr := os.Getenv(EnvSemanticDim); dimStr != "" {
 		if dim, err := strconv.Atoi(dimStr); err == nil {
 			cfg.Dim = dim
 		}
 	}
+	if batchStr := os.Getenv(EnvSemanticBatch); batchStr != "" {
+		if batch, err := strconv.Atoi(batchStr); err == nil {
+			cfg.BatchSize = batch
+		}
+	}
+	if timeoutStr := os.Getenv(EnvSemanticTimeout); timeoutStr != "" {
+		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
+			cfg.Timeout = timeout
+		}
+	}
+	if cfg.APIKey == "" && cfg.Provider == ProviderOpenAI {
+		cfg.APIKey = strings.TrimSpace(os.Getenv("OPENAI_API_KEY"))
+	}
 	if cfg.Provider == "" {
 		cfg.Provider = ProviderHash
 	}
 	return cfg.Normalized()
 }
 
+// HNSWConfigFromEnv reads the vector index ANN knobs from environment variables.
+//
+// Supported variables (unset or invalid values keep the defaults):
+//   - BV_SEMANTIC_HNSW_M: links per node (default: DefaultHNSWM)
+//   - BV_SEMANTIC_HNSW_EF_CONSTRUCTION: build-time beam width (default: DefaultHNSWEfConstruction)
+//   - BV_SEMANTIC_HNSW_EF_SEARCH: query-time beam width (default: DefaultHNSWEfSearch)
+//   - BV_SEMANTIC_EXACT_THRESHOLD: index size below which search is exact; -1 always searches exactly
+func HNSWConfigFromEnv() HNSWConfig {
+	cfg := DefaultHNSWConfig()
+	for env, field := range map[string]*int{
+		EnvSemanticHNSWM:              &cfg.M,
+		EnvSemanticHNSWEfConstruction: &cfg.EfConstruction,
+		EnvSemanticHNSWEfSearch:       &cfg.EfSearch,
+		EnvSemanticExactThreshold:     &cfg.ExactThreshold,
+	} {
+		if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env))); err == nil {
+			*field = v
+		}
+	}
+	return cfg.Normalized()
+}
+
 // NewEmbedderFromConfig constructs an Embedder for the given configuration.
 func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 	cfg = cfg.Normalized()
@@ -39,10 +81,21 @@ func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 		return NewHashEmbedder(cfg.Dim), nil
 	case ProviderPythonSentenceTransformers:
 		return nil, fmt.Errorf("semantic embedder %q not implemented (mvp placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
-	case ProviderOpenAI:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+	case ProviderOpenAI, ProviderOllama:
+		embedder, err := NewHTTPEmbedder(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("semantic embedder %q: %w; set %s=%q for deterministic fallback", cfg.Provider, err, EnvSemanticEmbedder, ProviderHash)
+		}
+		if cfg.CacheDir == "" {
+			return embedder, nil
+		}
+		cached, err := NewCachedEmbedder(embedder, EmbeddingCachePath(cfg.CacheDir, embedder.Provider(), cfg.BaseURL, embedder.Model(), embedder.Dim()))
+		if err != nil {
+			return nil, err
+		}
+		return cached, nil
 	default:
-		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q", cfg.Provider, ProviderHash)
+		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q, %q or %q", cfg.Provider, ProviderHash, ProviderOpenAI, ProviderOllama)
 	}
 }
 
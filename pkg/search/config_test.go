@@ -342,10 +342,23 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 			errContains: "not implemented",
 		},
 		{
-			name:        "openai not implemented",
-			cfg:         EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
+			name:    "openai with configured dim skips detection",
+			cfg:     EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536, BaseURL: "http://127.0.0.1:1"},
+			wantErr: false,
+			checkEmbed: func(t *testing.T, e Embedder) {
+				if e.Provider() != ProviderOpenAI {
+					t.Errorf("Provider() = %q, want %q", e.Provider(), ProviderOpenAI)
+				}
+				if e.Dim() != 1536 {
+					t.Errorf("Dim() = %d, want 1536", e.Dim())
+				}
+			},
+		},
+		{
+			name:        "ollama unreachable for dim detection",
+			cfg:         EmbeddingConfig{Provider: ProviderOllama, BaseURL: "http://127.0.0.1:1", MaxRetries: -1},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: "detect embedding dimension",
 		},
 		{
 			name:        "unknown provider error",
@@ -355,7 +368,7 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 		},
 		{
 			name:        "error message suggests hash fallback",
-			cfg:         EmbeddingConfig{Provider: ProviderOpenAI},
+			cfg:         EmbeddingConfig{Provider: ProviderOllama, BaseURL: "http://127.0.0.1:1", MaxRetries: -1},
 			wantErr:     true,
 			errContains: ProviderHash.String(),
 		},
@@ -0,0 +1,103 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultDuplicateNeighbors is how many nearest neighbours are considered per issue.
+const DefaultDuplicateNeighbors = 5
+
+// DuplicateDocument returns the text embedded for duplicate detection: title,
+// labels and description. Unlike IssueDocument it omits the ID, which would
+// otherwise pull every pair apart.
+func DuplicateDocument(issue model.Issue) string {
+	var parts []string
+	if title := strings.TrimSpace(issue.Title); title != "" {
+		parts = append(parts, title, title)
+	}
+	if labels := strings.TrimSpace(strings.Join(issue.Labels, " ")); labels != "" {
+		parts = append(parts, labels)
+	}
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		parts = append(parts, desc)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// DuplicateDocuments maps issue IDs to their DuplicateDocument, skipping
+// tombstones and issues with no text.
+func DuplicateDocuments(issues []model.Issue) map[string]string {
+	docs := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" || issue.Status == model.StatusTombstone {
+			continue
+		}
+		if doc := DuplicateDocument(issue); doc != "" {
+			docs[issue.ID] = doc
+		}
+	}
+	return docs
+}
+
+// SemanticDuplicatePairs embeds issues into idx (incrementally; pass a fresh
+// index for a one-off run) and returns DuplicatePairsFromIndex. Callers that
+// already synced idx with DuplicateDocuments should call DuplicatePairsFromIndex
+// directly.
+func SemanticDuplicatePairs(ctx context.Context, idx *VectorIndex, embedder Embedder, issues []model.Issue, k int) ([]analysis.SemanticPair, error) {
+	if idx == nil {
+		if embedder == nil {
+			return nil, fmt.Errorf("embedder cannot be nil")
+		}
+		idx = NewVectorIndex(embedder.Dim())
+	}
+	if k <= 0 {
+		k = DefaultDuplicateNeighbors
+	}
+
+	if _, err := SyncVectorIndex(ctx, idx, embedder, DuplicateDocuments(issues), 64); err != nil {
+		return nil, err
+	}
+	return DuplicatePairsFromIndex(idx, k)
+}
+
+// DuplicatePairsFromIndex returns each indexed issue's k nearest neighbours with
+// their cosine similarity. Pairs are deduplicated; thresholds are applied by
+// analysis.FindDuplicateMatches.
+func DuplicatePairsFromIndex(idx *VectorIndex, k int) ([]analysis.SemanticPair, error) {
+	if k <= 0 {
+		k = DefaultDuplicateNeighbors
+	}
+	seen := make(map[[2]string]bool)
+	var pairs []analysis.SemanticPair
+	for _, id := range idx.sortedIDs() {
+		entry, ok := idx.Get(id)
+		if !ok {
+			continue
+		}
+		// +1 because the issue itself is always its own nearest neighbour.
+		neighbors, err := idx.SearchTopK(entry.Vector, k+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			if n.IssueID == id {
+				continue
+			}
+			key := [2]string{id, n.IssueID}
+			if n.IssueID < id {
+				key = [2]string{n.IssueID, id}
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			pairs = append(pairs, analysis.SemanticPair{Issue1: key[0], Issue2: key[1], Cosine: n.Score})
+		}
+	}
+	return pairs, nil
+}
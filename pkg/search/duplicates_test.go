@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDuplicateDocumentOmitsID(t *testing.T) {
+	doc := DuplicateDocument(model.Issue{ID: "bv-1", Title: "Fix login", Labels: []string{"auth"}, Description: "Broken"})
+	if doc != "Fix login\nFix login\nauth\nBroken" {
+		t.Errorf("unexpected duplicate document %q", doc)
+	}
+}
+
+func TestSemanticDuplicatePairsFeedsDuplicateMatches(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Login page crashes on submit", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Login page crashes on submit", Description: "seen on staging", Status: model.StatusOpen},
+		{ID: "bv-3", Title: "Refresh dashboard palette", Status: model.StatusOpen},
+		{ID: "bv-4", Title: "Login page crashes on submit", Status: model.StatusTombstone},
+	}
+	embedder := NewHashEmbedder(DefaultEmbeddingDim)
+	idx := NewVectorIndex(embedder.Dim())
+
+	pairs, err := SemanticDuplicatePairs(context.Background(), idx, embedder, issues, 2)
+	if err != nil {
+		t.Fatalf("SemanticDuplicatePairs: %v", err)
+	}
+	if idx.Size() != 3 {
+		t.Errorf("expected tombstones to be skipped, index has %d entries", idx.Size())
+	}
+	// A pre-synced index gives the same pairs without embedding again.
+	if again, err := DuplicatePairsFromIndex(idx, 2); err != nil || !reflect.DeepEqual(again, pairs) {
+		t.Errorf("DuplicatePairsFromIndex = %+v, %v; want %+v", again, err, pairs)
+	}
+	var best analysis.SemanticPair
+	for _, p := range pairs {
+		if p.Issue1 >= p.Issue2 {
+			t.Errorf("expected ordered pair, got %+v", p)
+		}
+		if p.Cosine > best.Cosine {
+			best = p
+		}
+	}
+	if best.Issue1 != "bv-1" || best.Issue2 != "bv-2" || best.Cosine < 0.85 {
+		t.Fatalf("expected bv-1/bv-2 as the closest pair, got %+v", best)
+	}
+
+	config := analysis.DefaultDuplicateConfig()
+	config.Semantic = pairs
+	matches := analysis.FindDuplicateMatches(issues, config)
+	// "seen on staging" keeps keyword overlap below the Jaccard threshold.
+	if len(matches) != 1 || matches[0].Method != analysis.DuplicateMethodSemantic || matches[0].LexicalScore >= config.JaccardThreshold {
+		t.Errorf("expected one semantic match, got %+v", matches)
+	}
+}
+
+func TestDuplicateDocumentsAndIndexPath(t *testing.T) {
+	docs := DuplicateDocuments([]model.Issue{
+		{ID: "a", Title: "Login crash"},
+		{ID: "b", Title: "Gone", Status: model.StatusTombstone},
+		{ID: "c"},
+	})
+	if len(docs) != 1 || docs["a"] != DuplicateDocument(model.Issue{ID: "a", Title: "Login crash"}) {
+		t.Errorf("unexpected docs %v", docs)
+	}
+
+	cfg := EmbeddingConfig{Provider: ProviderHash, Dim: 256}
+	got := DefaultDuplicateIndexPath("/p", cfg)
+	if want := filepath.Join("/p", ".bv", "semantic", "duplicates-index-hash-256.bvvi"); got != want {
+		t.Errorf("DefaultDuplicateIndexPath = %q, want %q", got, want)
+	}
+	if got == DefaultIndexPath("/p", cfg) {
+		t.Error("duplicate and search indexes must not share a file")
+	}
+}
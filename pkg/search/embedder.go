@@ -1,6 +1,9 @@
 package search
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Provider identifies an embedding backend.
 type Provider string
@@ -14,8 +17,12 @@ const (
 	// sentence-transformers to generate high-quality embeddings (MVP choice for bv-9gf).
 	ProviderPythonSentenceTransformers Provider = "python-sentence-transformers"
 
-	// ProviderOpenAI uses a hosted embedding API.
+	// ProviderOpenAI uses any OpenAI-compatible /v1/embeddings endpoint
+	// (OpenAI itself, a llama.cpp server, vLLM, ...).
 	ProviderOpenAI Provider = "openai"
+
+	// ProviderOllama is ProviderOpenAI with defaults for a local Ollama server.
+	ProviderOllama Provider = "ollama"
 )
 
 const DefaultEmbeddingDim = 384
@@ -24,6 +31,15 @@ const (
 	EnvSemanticEmbedder = "BV_SEMANTIC_EMBEDDER"
 	EnvSemanticModel    = "BV_SEMANTIC_MODEL"
 	EnvSemanticDim      = "BV_SEMANTIC_DIM"
+	EnvSemanticURL      = "BV_SEMANTIC_URL"
+	EnvSemanticAPIKey   = "BV_SEMANTIC_API_KEY"
+	EnvSemanticBatch    = "BV_SEMANTIC_BATCH"
+	EnvSemanticTimeout  = "BV_SEMANTIC_TIMEOUT"
+
+	EnvSemanticHNSWM              = "BV_SEMANTIC_HNSW_M"
+	EnvSemanticHNSWEfConstruction = "BV_SEMANTIC_HNSW_EF_CONSTRUCTION"
+	EnvSemanticHNSWEfSearch       = "BV_SEMANTIC_HNSW_EF_SEARCH"
+	EnvSemanticExactThreshold     = "BV_SEMANTIC_EXACT_THRESHOLD"
 )
 
 // EmbeddingConfig captures embedder selection/configuration.
@@ -32,15 +48,57 @@ type EmbeddingConfig struct {
 	Provider Provider
 	Model    string
 	Dim      int
+
+	// HTTP providers only. A zero Dim is detected from the endpoint.
+	BaseURL    string
+	APIKey     string
+	BatchSize  int
+	Timeout    time.Duration
+	MaxRetries int
+
+	// CacheDir, when set, keeps HTTP embeddings on disk keyed by content hash.
+	CacheDir string
 }
 
 func (c EmbeddingConfig) Normalized() EmbeddingConfig {
-	if c.Dim <= 0 {
-		c.Dim = DefaultEmbeddingDim
+	if !c.Provider.IsHTTP() {
+		if c.Dim <= 0 {
+			c.Dim = DefaultEmbeddingDim
+		}
+		return c
+	}
+	if c.Dim < 0 {
+		c.Dim = 0
+	}
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultOpenAIBaseURL
+		if c.Provider == ProviderOllama {
+			c.BaseURL = DefaultOllamaBaseURL
+		}
+	}
+	if c.Model == "" {
+		c.Model = DefaultOpenAIModel
+		if c.Provider == ProviderOllama {
+			c.Model = DefaultOllamaModel
+		}
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultHTTPBatchSize
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultHTTPTimeout
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultHTTPRetries // Negative disables retries
 	}
 	return c
 }
 
+// IsHTTP reports whether the provider calls an embedding endpoint over HTTP.
+func (p Provider) IsHTTP() bool {
+	return p == ProviderOpenAI || p == ProviderOllama
+}
+
 // Embedder produces fixed-size dense vectors for text inputs.
 type Embedder interface {
 	Provider() Provider
@@ -0,0 +1,161 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultEmbeddingCacheDir returns the embedding cache directory under the given project directory.
+func DefaultEmbeddingCacheDir(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "semantic", "cache")
+}
+
+// EmbeddingCachePath returns the cache file for one provider/endpoint/model/dim
+// combination, so vectors from different models, or from same-named models
+// served by different endpoints, never mix. The endpoint is a short hash of
+// baseURL.
+func EmbeddingCachePath(dir string, provider Provider, baseURL, model string, dim int) string {
+	safe := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_")
+	return filepath.Join(dir, fmt.Sprintf("%s-%s-%s-%d.jsonl",
+		safe.Replace(string(provider)), safe.Replace(model), endpointKey(baseURL), dim))
+}
+
+// endpointKey is a short, filename-safe hash of an embedding endpoint. A trailing
+// slash does not change it.
+func endpointKey(baseURL string) string {
+	sum := sha256.Sum256([]byte(strings.TrimRight(baseURL, "/")))
+	return hex.EncodeToString(sum[:4])
+}
+
+type embeddingCacheEntry struct {
+	Hash   string    `json:"hash"`
+	Vector []float32 `json:"vector"`
+}
+
+// CachedEmbedder wraps an Embedder with an append-only on-disk cache keyed by
+// ContentHash. Only texts that were never embedded before reach the inner
+// embedder, which keeps rebuilding an index (or --semantic-duplicates, which
+// embeds every issue on each run) cheap with a remote model.
+type CachedEmbedder struct {
+	inner Embedder
+	path  string
+
+	mu   sync.Mutex
+	vecs map[ContentHash][]float32
+}
+
+// NewCachedEmbedder loads the cache at path (if any) and wraps inner.
+// Unreadable lines and vectors of the wrong dimension are skipped.
+func NewCachedEmbedder(inner Embedder, path string) (*CachedEmbedder, error) {
+	c := &CachedEmbedder{inner: inner, path: path, vecs: make(map[ContentHash][]float32)}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("open embedding cache: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry embeddingCacheEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || len(entry.Vector) != inner.Dim() {
+			continue
+		}
+		hash, err := ParseContentHashHex(entry.Hash)
+		if err != nil {
+			continue
+		}
+		c.vecs[hash] = entry.Vector
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read embedding cache: %w", err)
+	}
+	return c, nil
+}
+
+func (c *CachedEmbedder) Provider() Provider { return c.inner.Provider() }
+func (c *CachedEmbedder) Dim() int           { return c.inner.Dim() }
+
+// Len returns the number of cached vectors.
+func (c *CachedEmbedder) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.vecs)
+}
+
+func (c *CachedEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	hashes := make([]ContentHash, len(texts))
+	var missing []string
+	var missingHashes []ContentHash
+	seen := make(map[ContentHash]bool)
+
+	c.mu.Lock()
+	for i, text := range texts {
+		hashes[i] = ComputeContentHash(text)
+		if _, ok := c.vecs[hashes[i]]; ok || seen[hashes[i]] {
+			continue
+		}
+		seen[hashes[i]] = true
+		missing = append(missing, text)
+		missingHashes = append(missingHashes, hashes[i])
+	}
+	c.mu.Unlock()
+
+	if len(missing) > 0 {
+		vecs, err := c.inner.Embed(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(vecs) != len(missing) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vecs), len(missing))
+		}
+		c.mu.Lock()
+		for i, vec := range vecs {
+			c.vecs[missingHashes[i]] = vec
+		}
+		c.mu.Unlock()
+		// The cache is an optimization; failing to persist it must not fail the search.
+		_ = c.append(missingHashes, vecs)
+	}
+
+	out := make([][]float32, len(texts))
+	c.mu.Lock()
+	for i, hash := range hashes {
+		out[i] = append([]float32(nil), c.vecs[hash]...)
+	}
+	c.mu.Unlock()
+	return out, nil
+}
+
+func (c *CachedEmbedder) append(hashes []ContentHash, vecs [][]float32) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i, hash := range hashes {
+		if err := enc.Encode(embeddingCacheEntry{Hash: hash.Hex(), Vector: vecs[i]}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,110 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// countingEmbedder records every text it is asked to embed.
+type countingEmbedder struct {
+	*HashEmbedder
+	seen []string
+}
+
+func (c *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	c.seen = append(c.seen, texts...)
+	return c.HashEmbedder.Embed(ctx, texts)
+}
+
+func TestCachedEmbedder_PersistsByContentHash(t *testing.T) {
+	dir := t.TempDir()
+	path := EmbeddingCachePath(dir, ProviderOllama, DefaultOllamaBaseURL, "nomic-embed-text:latest", 16)
+	base := filepath.Base(path)
+	if !strings.HasPrefix(base, "ollama-nomic-embed-text_latest-") || !strings.HasSuffix(base, "-16.jsonl") {
+		t.Errorf("unexpected cache file name %q", base)
+	}
+	if EmbeddingCachePath(dir, ProviderOllama, DefaultOllamaBaseURL+"/", "nomic-embed-text:latest", 16) != path {
+		t.Error("expected a trailing slash not to change the cache file")
+	}
+	if EmbeddingCachePath(dir, ProviderOllama, "http://gpu-box:11434", "nomic-embed-text:latest", 16) == path {
+		t.Error("expected different endpoints to use different cache files")
+	}
+
+	inner := &countingEmbedder{HashEmbedder: NewHashEmbedder(16)}
+	cached, err := NewCachedEmbedder(inner, path)
+	if err != nil {
+		t.Fatalf("NewCachedEmbedder: %v", err)
+	}
+	vecs, err := cached.Embed(context.Background(), []string{"alpha", "beta", "alpha"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs) != 3 || len(inner.seen) != 2 || dotFloat32(vecs[0], vecs[2]) < 0.999 {
+		t.Fatalf("expected duplicate texts to be embedded once, inner saw %v", inner.seen)
+	}
+
+	// A fresh process reuses the cache and only embeds new content.
+	inner = &countingEmbedder{HashEmbedder: NewHashEmbedder(16)}
+	cached, err = NewCachedEmbedder(inner, path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if cached.Len() != 2 {
+		t.Fatalf("expected 2 cached vectors, got %d", cached.Len())
+	}
+	again, err := cached.Embed(context.Background(), []string{"beta", "gamma"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(inner.seen) != 1 || inner.seen[0] != "gamma" {
+		t.Errorf("expected only the new text to be embedded, got %v", inner.seen)
+	}
+	if dotFloat32(again[0], vecs[1]) < 0.999 {
+		t.Errorf("expected the cached vector for beta")
+	}
+
+	// Returned vectors are copies; callers may mutate them.
+	again[0][0] = 42
+	if v, _ := cached.Embed(context.Background(), []string{"beta"}); v[0][0] == 42 {
+		t.Errorf("cache entry was mutated through a returned vector")
+	}
+}
+
+func TestCachedEmbedder_SkipsBadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+	good := `{"hash":"` + ComputeContentHash("a").Hex() + `","vector":[1,0,0,0]}`
+	wrongDim := `{"hash":"` + ComputeContentHash("b").Hex() + `","vector":[1,0]}`
+	if err := os.WriteFile(path, []byte(good+"\nnot json\n"+wrongDim+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := NewCachedEmbedder(NewHashEmbedder(4), path)
+	if err != nil {
+		t.Fatalf("NewCachedEmbedder: %v", err)
+	}
+	if cached.Len() != 1 {
+		t.Errorf("expected only the valid entry, got %d", cached.Len())
+	}
+}
+
+func TestNewEmbedderFromConfig_HTTPWithCache(t *testing.T) {
+	stub := &stubEmbeddingServer{dim: 6}
+	srv := newStubServer(t, stub)
+	dir := t.TempDir()
+
+	e, err := NewEmbedderFromConfig(EmbeddingConfig{Provider: ProviderOpenAI, BaseURL: srv + "/v1", Model: "test-model", CacheDir: dir})
+	if err != nil {
+		t.Fatalf("NewEmbedderFromConfig: %v", err)
+	}
+	if _, ok := e.(*CachedEmbedder); !ok || e.Dim() != 6 || e.Provider() != ProviderOpenAI {
+		t.Fatalf("expected a cached openai embedder with dim 6, got %T dim %d", e, e.Dim())
+	}
+	if _, err := e.Embed(context.Background(), []string{"one", "two"}); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if _, err := os.Stat(EmbeddingCachePath(dir, ProviderOpenAI, srv+"/v1", "test-model", 6)); err != nil {
+		t.Errorf("expected the cache file: %v", err)
+	}
+}
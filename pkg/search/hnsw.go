@@ -0,0 +1,530 @@
+package search
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// HNSW (Hierarchical Navigable Small World) approximate nearest-neighbour graph.
+// See Malkov & Yashunin, "Efficient and robust approximate nearest neighbor
+// search using Hierarchical Navigable Small World graphs" (2016).
+
+const (
+	DefaultHNSWM                = 16
+	DefaultHNSWEfConstruction   = 200
+	DefaultHNSWEfSearch         = 64
+	DefaultExactSearchThreshold = 4096
+
+	hnswMaxLevel = 16
+	hnswSeed     = 42
+)
+
+// HNSWConfig holds the recall/latency knobs of the vector index.
+//
+// M and EfConstruction shape the graph: more links and a wider build-time beam
+// raise recall at the cost of memory and build time. EfSearch is the query-time
+// beam width and can be changed freely. Indexes smaller than ExactThreshold are
+// searched exhaustively; a negative ExactThreshold disables the graph.
+type HNSWConfig struct {
+	M              int
+	EfConstruction int
+	EfSearch       int
+	ExactThreshold int
+}
+
+func DefaultHNSWConfig() HNSWConfig {
+	return HNSWConfig{
+		M:              DefaultHNSWM,
+		EfConstruction: DefaultHNSWEfConstruction,
+		EfSearch:       DefaultHNSWEfSearch,
+		ExactThreshold: DefaultExactSearchThreshold,
+	}
+}
+
+// Normalized fills zero fields with defaults.
+func (c HNSWConfig) Normalized() HNSWConfig {
+	if c.M < 2 {
+		c.M = DefaultHNSWM
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = DefaultHNSWEfConstruction
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = DefaultHNSWEfSearch
+	}
+	if c.ExactThreshold == 0 {
+		c.ExactThreshold = DefaultExactSearchThreshold
+	}
+	return c
+}
+
+type hnswNode struct {
+	id    string
+	vec   []float32
+	links [][]int32 // Outgoing links per layer; len(links) == level+1
+}
+
+type hnswCandidate struct {
+	slot int32
+	dist float64
+}
+
+// hnswGraph stores nodes in slots. Removed slots are nil and dangling links to
+// them are skipped until the graph is compacted.
+type hnswGraph struct {
+	m              int
+	efConstruction int
+	levelMult      float64
+	rng            *rand.Rand
+
+	nodes    []*hnswNode
+	slots    map[string]int32
+	entry    int32
+	maxLevel int
+	removed  int
+}
+
+func newHNSWGraph(m, efConstruction int) *hnswGraph {
+	return &hnswGraph{
+		m:              m,
+		efConstruction: efConstruction,
+		levelMult:      1 / math.Log(float64(m)),
+		rng:            rand.New(rand.NewSource(hnswSeed)),
+		slots:          make(map[string]int32),
+		entry:          -1,
+	}
+}
+
+func (g *hnswGraph) len() int { return len(g.slots) }
+
+// maxConn is the link budget per node: 2*M on the dense bottom layer, M above.
+func (g *hnswGraph) maxConn(layer int) int {
+	if layer == 0 {
+		return 2 * g.m
+	}
+	return g.m
+}
+
+func (g *hnswGraph) randomLevel() int {
+	level := int(math.Floor(-math.Log(1-g.rng.Float64()) * g.levelMult))
+	return min(level, hnswMaxLevel)
+}
+
+// distance is cosine distance for the L2-normalized vectors embedders produce.
+// It only steers graph navigation, so it accumulates in float32 (unrolled) for
+// speed; reported scores still come from dotFloat32.
+func distance(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 1
+	}
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return 1 - float64(s0+s1+s2+s3)
+}
+
+func (g *hnswGraph) insert(id string, vec []float32) {
+	if _, ok := g.slots[id]; ok {
+		g.remove(id)
+	}
+	level := g.randomLevel()
+	slot := int32(len(g.nodes))
+	node := &hnswNode{id: id, vec: vec, links: make([][]int32, level+1)}
+	g.nodes = append(g.nodes, node)
+	g.slots[id] = slot
+
+	if g.entry < 0 {
+		g.entry = slot
+		g.maxLevel = level
+		return
+	}
+
+	eps := []hnswCandidate{{g.entry, distance(vec, g.nodes[g.entry].vec)}}
+	for l := g.maxLevel; l > level; l-- {
+		eps = g.searchLayer(vec, eps, 1, l)[:1]
+	}
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		found := g.searchLayer(vec, eps, g.efConstruction, l)
+		node.links[l] = g.selectNeighbors(found, g.m)
+		for _, n := range node.links[l] {
+			g.connect(n, slot, l)
+		}
+		eps = found
+	}
+	if level > g.maxLevel {
+		g.maxLevel = level
+		g.entry = slot
+	}
+}
+
+// connect adds a back link from n to slot, pruning n's links when over budget.
+func (g *hnswGraph) connect(n, slot int32, layer int) {
+	node := g.nodes[n]
+	node.links[layer] = append(node.links[layer], slot)
+	if len(node.links[layer]) <= g.maxConn(layer) {
+		return
+	}
+	node.links[layer] = g.relink(node, node.links[layer], layer)
+}
+
+// relink picks the best links for node among the given slots.
+func (g *hnswGraph) relink(node *hnswNode, slots []int32, layer int) []int32 {
+	cands := make([]hnswCandidate, 0, len(slots))
+	for _, s := range slots {
+		if other := g.nodes[s]; other != nil && other != node {
+			cands = append(cands, hnswCandidate{s, distance(node.vec, other.vec)})
+		}
+	}
+	sortCandidates(cands)
+	return g.selectNeighbors(cands, g.maxConn(layer))
+}
+
+// selectNeighbors applies the diversity heuristic: a candidate is kept only if
+// it is closer to the base than to every neighbour already kept, which keeps
+// links spread across clusters. Pruned candidates fill any remaining budget.
+// cands must be sorted by distance.
+func (g *hnswGraph) selectNeighbors(cands []hnswCandidate, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var pruned []int32
+	for _, c := range cands {
+		if len(selected) >= m {
+			break
+		}
+		diverse := true
+		for _, s := range selected {
+			if distance(g.nodes[c.slot].vec, g.nodes[s].vec) < c.dist {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, c.slot)
+		} else {
+			pruned = append(pruned, c.slot)
+		}
+	}
+	for _, s := range pruned {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, s)
+	}
+	return selected
+}
+
+// remove deletes id and repairs the neighbourhoods it was part of by linking
+// its neighbours to each other.
+func (g *hnswGraph) remove(id string) {
+	slot, ok := g.slots[id]
+	if !ok {
+		return
+	}
+	node := g.nodes[slot]
+	g.nodes[slot] = nil
+	delete(g.slots, id)
+	g.removed++
+
+	for l, links := range node.links {
+		for _, n := range links {
+			neighbor := g.nodes[n]
+			if neighbor == nil || len(neighbor.links) <= l {
+				continue
+			}
+			merged := make([]int32, 0, len(neighbor.links[l])+len(links))
+			seen := make(map[int32]bool, cap(merged))
+			for _, s := range append(neighbor.links[l], links...) {
+				if s != slot && s != n && !seen[s] {
+					seen[s] = true
+					merged = append(merged, s)
+				}
+			}
+			neighbor.links[l] = g.relink(neighbor, merged, l)
+		}
+	}
+
+	if slot == g.entry {
+		g.entry, g.maxLevel = -1, 0
+		for s, n := range g.nodes {
+			if n != nil && (g.entry < 0 || len(n.links)-1 > g.maxLevel) {
+				g.entry, g.maxLevel = int32(s), len(n.links)-1
+			}
+		}
+	}
+	if g.removed > 64 && g.removed > len(g.nodes)/2 {
+		g.compact()
+	}
+}
+
+// compact renumbers the live nodes and drops dangling links.
+func (g *hnswGraph) compact() {
+	remap := make([]int32, len(g.nodes))
+	live := make([]*hnswNode, 0, len(g.slots))
+	for s, n := range g.nodes {
+		remap[s] = -1
+		if n != nil {
+			remap[s] = int32(len(live))
+			live = append(live, n)
+		}
+	}
+	for _, n := range live {
+		for l, links := range n.links {
+			kept := links[:0]
+			for _, s := range links {
+				if remap[s] >= 0 {
+					kept = append(kept, remap[s])
+				}
+			}
+			n.links[l] = kept
+		}
+		g.slots[n.id] = remap[g.slots[n.id]]
+	}
+	if g.entry >= 0 {
+		g.entry = remap[g.entry]
+	}
+	g.nodes = live
+	g.removed = 0
+}
+
+// search returns up to ef nearest candidates to query, sorted by distance.
+func (g *hnswGraph) search(query []float32, ef int) []hnswCandidate {
+	if g.entry < 0 {
+		return nil
+	}
+	eps := []hnswCandidate{{g.entry, distance(query, g.nodes[g.entry].vec)}}
+	for l := g.maxLevel; l > 0; l-- {
+		eps = g.searchLayer(query, eps, 1, l)[:1]
+	}
+	return g.searchLayer(query, eps, ef, 0)
+}
+
+// searchLayer is a best-first beam search of width ef on one layer.
+func (g *hnswGraph) searchLayer(query []float32, eps []hnswCandidate, ef, layer int) []hnswCandidate {
+	visited := make([]uint64, (len(g.nodes)+63)/64)
+	frontier := &candidateHeap{}
+	results := &candidateHeap{max: true}
+	for _, ep := range eps {
+		visited[ep.slot/64] |= 1 << (ep.slot % 64)
+		frontier.push(ep)
+		results.push(ep)
+	}
+	for len(results.items) > ef {
+		results.pop()
+	}
+
+	for len(frontier.items) > 0 {
+		c := frontier.pop()
+		if len(results.items) >= ef && c.dist > results.items[0].dist {
+			break
+		}
+		node := g.nodes[c.slot]
+		if node == nil || len(node.links) <= layer {
+			continue
+		}
+		for _, n := range node.links[layer] {
+			if visited[n/64]&(1<<(n%64)) != 0 {
+				continue
+			}
+			visited[n/64] |= 1 << (n % 64)
+			neighbor := g.nodes[n]
+			if neighbor == nil {
+				continue
+			}
+			d := distance(query, neighbor.vec)
+			if len(results.items) < ef || d < results.items[0].dist {
+				frontier.push(hnswCandidate{n, d})
+				results.push(hnswCandidate{n, d})
+				if len(results.items) > ef {
+					results.pop()
+				}
+			}
+		}
+	}
+
+	out := results.items
+	sortCandidates(out)
+	return out
+}
+
+func sortCandidates(cands []hnswCandidate) {
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].dist != cands[j].dist {
+			return cands[i].dist < cands[j].dist
+		}
+		return cands[i].slot < cands[j].slot
+	})
+}
+
+// candidateHeap is a min-heap by distance, or a max-heap when max is set.
+// It avoids container/heap, whose interface boxing dominates build time.
+type candidateHeap struct {
+	items []hnswCandidate
+	max   bool
+}
+
+func (h *candidateHeap) less(i, j int) bool {
+	if h.max {
+		return h.items[i].dist > h.items[j].dist
+	}
+	return h.items[i].dist < h.items[j].dist
+}
+
+func (h *candidateHeap) push(c hnswCandidate) {
+	h.items = append(h.items, c)
+	for i := len(h.items) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if !h.less(i, parent) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *candidateHeap) pop() hnswCandidate {
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	h.items = h.items[:last]
+	for i := 0; ; {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < last && h.less(left, smallest) {
+			smallest = left
+		}
+		if right < last && h.less(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+	return top
+}
+
+// writeHNSWGraph appends the graph section of a v2 index file. Links refer to
+// positions in the sorted id order the entries were written in.
+func writeHNSWGraph(w io.Writer, g *hnswGraph, ids []string) error {
+	positions := make(map[int32]uint32, len(ids))
+	if g != nil && g.len() == len(ids) {
+		for i, id := range ids {
+			slot, ok := g.slots[id]
+			if !ok {
+				break
+			}
+			positions[slot] = uint32(i)
+		}
+	}
+	if len(positions) != len(ids) || g == nil || g.m > math.MaxUint16 || g.efConstruction > math.MaxUint16 {
+		if err := binary.Write(w, binary.LittleEndian, uint8(0)); err != nil {
+			return fmt.Errorf("write graph flag: %w", err)
+		}
+		return nil
+	}
+
+	entry := int32(-1)
+	if g.entry >= 0 {
+		entry = int32(positions[g.entry])
+	}
+	header := []any{uint8(1), uint16(g.m), uint16(g.efConstruction), uint8(g.maxLevel), entry}
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("write graph header: %w", err)
+		}
+	}
+	for _, id := range ids {
+		node := g.nodes[g.slots[id]]
+		if err := binary.Write(w, binary.LittleEndian, uint8(len(node.links)-1)); err != nil {
+			return fmt.Errorf("write graph level: %w", err)
+		}
+		for _, links := range node.links {
+			live := make([]uint32, 0, len(links))
+			for _, s := range links {
+				if g.nodes[s] != nil {
+					live = append(live, positions[s])
+				}
+			}
+			if err := binary.Write(w, binary.LittleEndian, uint16(len(live))); err != nil {
+				return fmt.Errorf("write graph links: %w", err)
+			}
+			if err := binary.Write(w, binary.LittleEndian, live); err != nil {
+				return fmt.Errorf("write graph links: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// readHNSWGraph reads the graph section written by writeHNSWGraph. It returns
+// nil when the file has no graph.
+func readHNSWGraph(r io.Reader, idx *VectorIndex, ids []string) (*hnswGraph, error) {
+	var flag uint8
+	if err := binary.Read(r, binary.LittleEndian, &flag); err != nil {
+		return nil, fmt.Errorf("read graph flag: %w", err)
+	}
+	if flag == 0 {
+		return nil, nil
+	}
+	if len(idx.entries) != len(ids) {
+		return nil, fmt.Errorf("graph: duplicate issue ids")
+	}
+	var header struct {
+		M              uint16
+		EfConstruction uint16
+		MaxLevel       uint8
+		Entry          int32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("read graph header: %w", err)
+	}
+	if header.M < 2 || header.MaxLevel > hnswMaxLevel || header.Entry < -1 || int(header.Entry) >= len(ids) || (header.Entry < 0) != (len(ids) == 0) {
+		return nil, fmt.Errorf("invalid graph header")
+	}
+
+	g := newHNSWGraph(int(header.M), int(header.EfConstruction))
+	g.maxLevel = int(header.MaxLevel)
+	g.entry = header.Entry
+	g.nodes = make([]*hnswNode, len(ids))
+	for i, id := range ids {
+		var level uint8
+		if err := binary.Read(r, binary.LittleEndian, &level); err != nil {
+			return nil, fmt.Errorf("read graph level: %w", err)
+		}
+		if level > header.MaxLevel {
+			return nil, fmt.Errorf("graph level %d above max %d", level, header.MaxLevel)
+		}
+		node := &hnswNode{id: id, vec: idx.entries[id].Vector, links: make([][]int32, int(level)+1)}
+		for l := range node.links {
+			var count uint16
+			if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+				return nil, fmt.Errorf("read graph links: %w", err)
+			}
+			positions := make([]uint32, count)
+			if err := binary.Read(r, binary.LittleEndian, positions); err != nil {
+				return nil, fmt.Errorf("read graph links: %w", err)
+			}
+			node.links[l] = make([]int32, count)
+			for j, p := range positions {
+				if int(p) >= len(ids) {
+					return nil, fmt.Errorf("graph link %d out of range", p)
+				}
+				node.links[l][j] = int32(p)
+			}
+		}
+		g.nodes[i] = node
+		g.slots[id] = int32(i)
+	}
+	return g, nil
+}
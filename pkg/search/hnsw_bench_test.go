@@ -0,0 +1,43 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Brute force vs HNSW. Compare with:
+//
+//	go test ./pkg/search -run '^$' -bench 'VectorIndex' -benchmem
+func BenchmarkVectorIndexSearchTopK(b *testing.B) {
+	for _, size := range []int{1000, 10000, 25000} {
+		vecs := randomUnitVectors(size, 128, 1)
+		queries := randomUnitVectors(64, 128, 2)
+		for _, mode := range []struct {
+			name string
+			cfg  HNSWConfig
+		}{
+			{"exact", HNSWConfig{ExactThreshold: -1}},
+			{"hnsw", HNSWConfig{ExactThreshold: 1}},
+		} {
+			b.Run(fmt.Sprintf("%s-%d", mode.name, size), func(b *testing.B) {
+				idx := buildTestIndex(b, vecs, mode.cfg)
+				idx.ensureGraph()
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := idx.SearchTopK(queries[i%len(queries)], 10); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkVectorIndexBuildHNSW(b *testing.B) {
+	vecs := randomUnitVectors(10000, 128, 1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buildTestIndex(b, vecs, HNSWConfig{ExactThreshold: 1}).ensureGraph()
+	}
+}
@@ -0,0 +1,237 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// randomUnitVectors returns n L2-normalized vectors grouped around a few
+// centroids, which is closer to real embeddings than uniform noise.
+func randomUnitVectors(n, dim int, seed int64) [][]float32 {
+	rng := rand.New(rand.NewSource(seed))
+	centroids := make([][]float32, 16)
+	for i := range centroids {
+		centroids[i] = make([]float32, dim)
+		for j := range centroids[i] {
+			centroids[i][j] = float32(rng.NormFloat64())
+		}
+	}
+	out := make([][]float32, n)
+	for i := range out {
+		c := centroids[rng.Intn(len(centroids))]
+		vec := make([]float32, dim)
+		for j := range vec {
+			vec[j] = c[j] + float32(rng.NormFloat64())*0.8
+		}
+		normalizeL2(vec)
+		out[i] = vec
+	}
+	return out
+}
+
+func buildTestIndex(t testing.TB, vecs [][]float32, cfg HNSWConfig) *VectorIndex {
+	t.Helper()
+	idx := NewVectorIndex(len(vecs[0]))
+	idx.SetHNSWConfig(cfg)
+	for i, vec := range vecs {
+		id := fmt.Sprintf("doc-%05d", i)
+		if err := idx.Upsert(id, ComputeContentHash(id), vec); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+	return idx
+}
+
+// recallAtK compares approximate results with an exact scan of the same entries.
+func recallAtK(t *testing.T, idx *VectorIndex, queries [][]float32, k int) float64 {
+	t.Helper()
+	exact := NewVectorIndex(idx.Dim)
+	exact.SetHNSWConfig(HNSWConfig{ExactThreshold: -1})
+	for _, id := range idx.sortedIDs() {
+		entry, _ := idx.Get(id)
+		if err := exact.Upsert(id, entry.ContentHash, entry.Vector); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hits, total := 0, 0
+	for _, q := range queries {
+		want, err := exact.SearchTopK(q, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := idx.SearchTopK(q, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Tie-aware: any result scoring at least the k-th exact score is a hit.
+		if len(want) == 0 {
+			continue
+		}
+		kth := want[len(want)-1].Score
+		for _, r := range got {
+			if r.Score >= kth-1e-9 {
+				hits++
+			}
+		}
+		total += len(want)
+	}
+	return float64(hits) / float64(total)
+}
+
+func TestVectorIndex_HNSWRecall(t *testing.T) {
+	vecs := randomUnitVectors(3000, 32, 1)
+	idx := buildTestIndex(t, vecs, HNSWConfig{ExactThreshold: 1})
+	if !idx.Approximate() {
+		t.Fatal("expected the graph to be used above the threshold")
+	}
+	queries := randomUnitVectors(100, 32, 2)
+	if recall := recallAtK(t, idx, queries, 10); recall < 0.95 {
+		t.Errorf("recall@10 = %.3f, want >= 0.95", recall)
+	}
+
+	// A wider beam never hurts recall.
+	idx.SetHNSWConfig(HNSWConfig{ExactThreshold: 1, EfSearch: 256})
+	if recall := recallAtK(t, idx, queries, 10); recall < 0.99 {
+		t.Errorf("recall@10 with ef=256 = %.3f, want >= 0.99", recall)
+	}
+}
+
+func TestVectorIndex_ExactBelowThreshold(t *testing.T) {
+	vecs := randomUnitVectors(50, 8, 3)
+	idx := buildTestIndex(t, vecs, HNSWConfig{ExactThreshold: 100})
+	if idx.Approximate() {
+		t.Fatal("expected exact search below the threshold")
+	}
+	if _, err := idx.SearchTopK(vecs[0], 5); err != nil {
+		t.Fatal(err)
+	}
+	if idx.graph != nil {
+		t.Error("expected no graph to be built for a small index")
+	}
+}
+
+func TestVectorIndex_HNSWIncrementalSync(t *testing.T) {
+	embedder := NewHashEmbedder(64)
+	docs := make(map[string]string)
+	for i := 0; i < 600; i++ {
+		docs[fmt.Sprintf("issue-%03d", i)] = fmt.Sprintf("topic%d widget%d module%d", i%7, i%13, i)
+	}
+	idx := NewVectorIndex(64)
+	idx.SetHNSWConfig(HNSWConfig{ExactThreshold: 1})
+	if _, err := SyncVectorIndex(context.Background(), idx, embedder, docs, 64); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.SearchTopK(make([]float32, 64), 1); err != nil {
+		t.Fatal(err)
+	}
+	if idx.graph == nil || idx.graph.len() != 600 {
+		t.Fatalf("expected a graph over all 600 docs")
+	}
+
+	// Delete two thirds (forcing a compaction), edit some and add new ones.
+	for i := 0; i < 400; i++ {
+		delete(docs, fmt.Sprintf("issue-%03d", i))
+	}
+	for i := 400; i < 450; i++ {
+		docs[fmt.Sprintf("issue-%03d", i)] = fmt.Sprintf("edited topic%d", i%5)
+	}
+	for i := 600; i < 700; i++ {
+		docs[fmt.Sprintf("issue-%03d", i)] = fmt.Sprintf("topic%d gadget%d", i%7, i)
+	}
+	stats, err := SyncVectorIndex(context.Background(), idx, embedder, docs, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Removed != 400 || stats.Updated != 50 || stats.Added != 100 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if idx.graph.len() != 300 || len(idx.graph.nodes) >= 700 {
+		t.Fatalf("expected 300 live nodes after compaction, got %d in %d slots", idx.graph.len(), len(idx.graph.nodes))
+	}
+
+	queries, _ := embedder.Embed(context.Background(), []string{"topic3", "edited topic2", "gadget650", "widget5"})
+	for _, q := range queries {
+		results, err := idx.SearchTopK(q, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range results {
+			if _, ok := docs[r.IssueID]; !ok {
+				t.Fatalf("removed doc %s returned", r.IssueID)
+			}
+		}
+	}
+	if recall := recallAtK(t, idx, queries, 10); recall < 0.95 {
+		t.Errorf("recall@10 after churn = %.3f, want >= 0.95", recall)
+	}
+}
+
+func TestVectorIndex_HNSWSaveLoad(t *testing.T) {
+	vecs := randomUnitVectors(500, 16, 4)
+	idx := buildTestIndex(t, vecs, HNSWConfig{ExactThreshold: 1})
+	path := filepath.Join(t.TempDir(), "index.bvvi")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.graph == nil || loaded.graph.len() != 500 {
+		t.Fatal("expected the graph to be loaded, not rebuilt")
+	}
+	loaded.SetHNSWConfig(HNSWConfig{ExactThreshold: 1})
+	for _, q := range randomUnitVectors(20, 16, 5) {
+		want, _ := idx.SearchTopK(q, 5)
+		got, _ := loaded.SearchTopK(q, 5)
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Fatalf("loaded graph answers differently:\n%v\n%v", want, got)
+		}
+	}
+
+	// A different M invalidates the stored graph.
+	loaded.SetHNSWConfig(HNSWConfig{M: 8, ExactThreshold: 1})
+	if loaded.graph != nil {
+		t.Error("expected the graph to be dropped when M changes")
+	}
+
+	// Version 1 files (no graph section) still load.
+	exact := buildTestIndex(t, vecs[:10], HNSWConfig{ExactThreshold: -1})
+	v1Path := filepath.Join(t.TempDir(), "v1.bvvi")
+	if err := exact.Save(v1Path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(v1Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[len(data)-1] != 0 {
+		t.Fatalf("expected an empty graph flag")
+	}
+	data = data[:len(data)-1]
+	data[4], data[5] = 1, 0
+	if err := os.WriteFile(v1Path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v1, err := LoadVectorIndex(v1Path)
+	if err != nil || v1.Size() != 10 || v1.graph != nil {
+		t.Fatalf("expected the v1 file to load without a graph: %v", err)
+	}
+}
+
+func TestHNSWConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvSemanticHNSWM, "24")
+	t.Setenv(EnvSemanticHNSWEfConstruction, "")
+	t.Setenv(EnvSemanticHNSWEfSearch, "128")
+	t.Setenv(EnvSemanticExactThreshold, "-1")
+	cfg := HNSWConfigFromEnv()
+	want := HNSWConfig{M: 24, EfConstruction: DefaultHNSWEfConstruction, EfSearch: 128, ExactThreshold: -1}
+	if cfg != want {
+		t.Errorf("HNSWConfigFromEnv() = %+v, want %+v", cfg, want)
+	}
+}
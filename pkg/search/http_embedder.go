@@ -0,0 +1,216 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+	DefaultOllamaBaseURL = "http://localhost:11434/v1"
+	DefaultOpenAIModel   = "text-embedding-3-small"
+	DefaultOllamaModel   = "nomic-embed-text"
+	DefaultHTTPBatchSize = 64
+	DefaultHTTPTimeout   = 30 * time.Second
+	DefaultHTTPRetries   = 3
+)
+
+// dimProbeText is embedded once to learn the model's dimension when none is configured.
+const dimProbeText = "dimension probe"
+
+// HTTPEmbedder calls an OpenAI-compatible /embeddings endpoint.
+// Inputs are sent in batches; each request has its own timeout and is
+// retried with exponential backoff on network errors, 429 and 5xx.
+type HTTPEmbedder struct {
+	provider   Provider
+	baseURL    string
+	apiKey     string
+	model      string
+	dim        int
+	batchSize  int
+	timeout    time.Duration
+	maxRetries int
+	backoff    time.Duration
+	client     *http.Client
+}
+
+// NewHTTPEmbedder builds an embedder for cfg. When cfg.Dim is zero the
+// dimension is detected by embedding a probe text, so the endpoint must be
+// reachable.
+func NewHTTPEmbedder(cfg EmbeddingConfig) (*HTTPEmbedder, error) {
+	if !cfg.Provider.IsHTTP() {
+		return nil, fmt.Errorf("provider %q is not an HTTP embedder", cfg.Provider)
+	}
+	cfg = cfg.Normalized()
+	e := &HTTPEmbedder{
+		provider:   cfg.Provider,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+		dim:        cfg.Dim,
+		batchSize:  cfg.BatchSize,
+		timeout:    cfg.Timeout,
+		maxRetries: max(cfg.MaxRetries, 0),
+		backoff:    500 * time.Millisecond,
+		client:     &http.Client{},
+	}
+	if e.dim == 0 {
+		// A single attempt bounded by the request timeout, so an unreachable
+		// endpoint fails fast instead of waiting out every retry.
+		body, err := json.Marshal(embeddingRequest{Model: e.model, Input: []string{dimProbeText}})
+		if err != nil {
+			return nil, err
+		}
+		vecs, _, err := e.post(context.Background(), body, 1)
+		if err != nil {
+			return nil, fmt.Errorf("detect embedding dimension: %w", err)
+		}
+		if len(vecs[0]) == 0 {
+			return nil, fmt.Errorf("detect embedding dimension: endpoint returned an empty vector")
+		}
+		e.dim = len(vecs[0])
+	}
+	return e, nil
+}
+
+func (e *HTTPEmbedder) Provider() Provider { return e.provider }
+func (e *HTTPEmbedder) Dim() int           { return e.dim }
+func (e *HTTPEmbedder) Model() string      { return e.model }
+
+func (e *HTTPEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.batchSize {
+		end := min(start+e.batchSize, len(texts))
+		vecs, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, vec := range vecs {
+			if len(vec) != e.dim {
+				return nil, fmt.Errorf("embedding has dim %d, want %d (model %q)", len(vec), e.dim, e.model)
+			}
+			normalizeL2(vec)
+			out = append(out, vec)
+		}
+	}
+	return out, nil
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// embedBatch sends one request, retrying transient failures.
+func (e *HTTPEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		vecs, retry, err := e.post(ctx, body, len(texts))
+		if err == nil {
+			return vecs, nil
+		}
+		if !retry || attempt >= e.maxRetries {
+			if attempt > 0 {
+				return nil, fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+			}
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(e.backoff << attempt):
+		}
+	}
+}
+
+// post performs a single request and reports whether a failure is worth retrying.
+func (e *HTTPEmbedder) post(ctx context.Context, body []byte, n int) ([][]float32, bool, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		return nil, true, fmt.Errorf("embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("read embedding response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, fmt.Errorf("embedding endpoint returned %d: %s", resp.StatusCode, apiErrorMessage(data))
+	}
+
+	var parsed embeddingResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, false, fmt.Errorf("parse embedding response: %w", err)
+	}
+	if len(parsed.Data) != n {
+		return nil, false, fmt.Errorf("embedding endpoint returned %d vectors for %d inputs", len(parsed.Data), n)
+	}
+	vecs := make([][]float32, n)
+	for _, item := range parsed.Data {
+		if item.Index < 0 || item.Index >= n || vecs[item.Index] != nil {
+			return nil, false, fmt.Errorf("embedding response has invalid index %d", item.Index)
+		}
+		vecs[item.Index] = item.Embedding
+	}
+	return vecs, false, nil
+}
+
+// apiErrorMessage extracts {"error":{"message":...}} (or {"error":"..."}) from
+// an error body, falling back to the trimmed body.
+func apiErrorMessage(data []byte) string {
+	var structured struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &structured) == nil && structured.Error.Message != "" {
+		return structured.Error.Message
+	}
+	var plain struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &plain) == nil && plain.Error != "" {
+		return plain.Error
+	}
+	msg := strings.TrimSpace(string(data))
+	if len(msg) > 200 {
+		msg = msg[:200] + "..."
+	}
+	if msg == "" {
+		return "empty body"
+	}
+	return msg
+}
@@ -0,0 +1,249 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubEmbeddingServer is a minimal OpenAI-compatible /embeddings endpoint.
+// Each input becomes a dim-sized vector derived from its length; responses
+// list items in reverse order to check that index is honoured.
+type stubEmbeddingServer struct {
+	mu       sync.Mutex
+	dim      int
+	statuses []int
+	delay    time.Duration
+	requests []embeddingRequest
+	auth     []string
+}
+
+func (s *stubEmbeddingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/embeddings" || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+	var req embeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	delay := s.delay
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"error":{"message":"model not loaded"}}`))
+		return
+	}
+	var resp embeddingResponse
+	for i := len(req.Input) - 1; i >= 0; i-- {
+		vec := make([]float32, s.dim)
+		vec[0] = float32(len(req.Input[i]))
+		vec[1] = 1
+		resp.Data = append(resp.Data, struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}{i, vec})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *stubEmbeddingServer) inputs() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out [][]string
+	for _, req := range s.requests {
+		out = append(out, req.Input)
+	}
+	return out
+}
+
+// respond sets the statuses for the next requests (then 200) and the response delay.
+func (s *stubEmbeddingServer) respond(delay time.Duration, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = delay
+	s.statuses = statuses
+}
+
+func newStubServer(t *testing.T, stub *stubEmbeddingServer) string {
+	t.Helper()
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func newStubEmbedder(t *testing.T, stub *stubEmbeddingServer, cfg EmbeddingConfig) *HTTPEmbedder {
+	t.Helper()
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderOllama
+	}
+	cfg.BaseURL = newStubServer(t, stub) + "/v1/"
+	e, err := NewHTTPEmbedder(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPEmbedder: %v", err)
+	}
+	e.backoff = time.Millisecond
+	return e
+}
+
+func TestHTTPEmbedder_DetectsDimAndBatches(t *testing.T) {
+	stub := &stubEmbeddingServer{dim: 8}
+	e := newStubEmbedder(t, stub, EmbeddingConfig{BatchSize: 2})
+	if e.Dim() != 8 || e.Model() != DefaultOllamaModel {
+		t.Fatalf("expected a detected dim of 8 with the ollama model, got %d %q", e.Dim(), e.Model())
+	}
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+	vecs, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs) != len(texts) {
+		t.Fatalf("expected %d vectors, got %d", len(texts), len(vecs))
+	}
+	for i, vec := range vecs {
+		// Normalized [len, 1, 0, ...]
+		want := float32(len(texts[i])) / float32(math.Sqrt(float64(len(texts[i])*len(texts[i])+1)))
+		if math.Abs(float64(vec[0]-want)) > 1e-6 {
+			t.Errorf("vector %d out of order or not normalized: %v", i, vec)
+		}
+	}
+
+	inputs := stub.inputs()
+	if len(inputs) != 4 || inputs[0][0] != dimProbeText || len(inputs[1]) != 2 || len(inputs[3]) != 1 {
+		t.Errorf("expected a probe then batches of 2,2,1, got %v", inputs)
+	}
+	if stub.requests[1].Model != DefaultOllamaModel {
+		t.Errorf("expected the model in the request, got %q", stub.requests[1].Model)
+	}
+}
+
+func TestHTTPEmbedder_RetriesTransientErrors(t *testing.T) {
+	stub := &stubEmbeddingServer{dim: 4}
+	e := newStubEmbedder(t, stub, EmbeddingConfig{Provider: ProviderOpenAI, Dim: 4, APIKey: "sk-test"})
+
+	stub.respond(0, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("expected success after retries: %v", err)
+	}
+	if got := len(stub.inputs()); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if stub.auth[0] != "Bearer sk-test" {
+		t.Errorf("expected the bearer token, got %q", stub.auth[0])
+	}
+
+	stub.respond(0, http.StatusBadRequest)
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "returned 400: model not loaded") {
+		t.Fatalf("expected the API error message, got %v", err)
+	}
+	if got := len(stub.inputs()); got != 4 {
+		t.Errorf("4xx must not be retried, got %d requests", got)
+	}
+
+	stub.respond(0, 500, 500, 500, 500)
+	if _, err := e.Embed(context.Background(), []string{"x"}); err == nil || !strings.Contains(err.Error(), "after 4 attempts") {
+		t.Errorf("expected retries to give up, got %v", err)
+	}
+}
+
+func TestHTTPEmbedder_TimeoutAndDimMismatch(t *testing.T) {
+	stub := &stubEmbeddingServer{dim: 4, delay: time.Second}
+	e := newStubEmbedder(t, stub, EmbeddingConfig{Dim: 4, Timeout: 20 * time.Millisecond, MaxRetries: -1})
+	start := time.Now()
+	if _, err := e.Embed(context.Background(), []string{"x"}); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("request timeout was not applied")
+	}
+
+	stub.respond(0)
+	e.dim = 16
+	if _, err := e.Embed(context.Background(), []string{"x"}); err == nil || !strings.Contains(err.Error(), "want 16") {
+		t.Errorf("expected a dim mismatch error, got %v", err)
+	}
+}
+
+func TestNewHTTPEmbedder_ProbeIsSingleAttempt(t *testing.T) {
+	stub := &stubEmbeddingServer{dim: 4}
+	stub.respond(0, http.StatusServiceUnavailable)
+	_, err := NewHTTPEmbedder(EmbeddingConfig{Provider: ProviderOllama, BaseURL: newStubServer(t, stub) + "/v1/"})
+	if err == nil || !strings.Contains(err.Error(), "detect embedding dimension") {
+		t.Fatalf("expected the probe to fail, got %v", err)
+	}
+	if got := len(stub.inputs()); got != 1 {
+		t.Errorf("expected the probe not to be retried, got %d requests", got)
+	}
+
+	slow := &stubEmbeddingServer{dim: 4, delay: time.Second}
+	start := time.Now()
+	_, err = NewHTTPEmbedder(EmbeddingConfig{Provider: ProviderOllama, BaseURL: newStubServer(t, slow) + "/v1/", Timeout: 20 * time.Millisecond})
+	if err == nil || time.Since(start) > 500*time.Millisecond {
+		t.Errorf("expected the probe to time out quickly, got %v after %v", err, time.Since(start))
+	}
+}
+
+func TestEmbeddingConfig_HTTPDefaults(t *testing.T) {
+	cfg := EmbeddingConfig{Provider: ProviderOllama}.Normalized()
+	if cfg.Dim != 0 || cfg.BaseURL != DefaultOllamaBaseURL || cfg.Model != DefaultOllamaModel ||
+		cfg.BatchSize != DefaultHTTPBatchSize || cfg.Timeout != DefaultHTTPTimeout || cfg.MaxRetries != DefaultHTTPRetries {
+		t.Errorf("unexpected ollama defaults %+v", cfg)
+	}
+	cfg = EmbeddingConfig{Provider: ProviderOpenAI, MaxRetries: -1}.Normalized()
+	if cfg.BaseURL != DefaultOpenAIBaseURL || cfg.Model != DefaultOpenAIModel || cfg.MaxRetries != -1 {
+		t.Errorf("unexpected openai defaults %+v", cfg)
+	}
+
+	t.Setenv(EnvSemanticEmbedder, "openai")
+	t.Setenv(EnvSemanticURL, "http://localhost:8080/v1")
+	t.Setenv(EnvSemanticBatch, "16")
+	t.Setenv(EnvSemanticTimeout, "5s")
+	t.Setenv(EnvSemanticAPIKey, "")
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	cfg = EmbeddingConfigFromEnv()
+	if cfg.BaseURL != "http://localhost:8080/v1" || cfg.BatchSize != 16 || cfg.Timeout != 5*time.Second || cfg.APIKey != "sk-env" {
+		t.Errorf("unexpected env config %+v", cfg)
+	}
+}
+
+func TestDefaultIndexPath_HTTPIncludesModel(t *testing.T) {
+	got := DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderOllama, Model: "nomic-embed-text:latest", Dim: 768})
+	want := filepath.Join("/p", ".bv", "semantic", "index-ollama-nomic-embed-text_latest-"+endpointKey(DefaultOllamaBaseURL)+"-768.bvvi")
+	if got != want {
+		t.Errorf("DefaultIndexPath = %q, want %q", got, want)
+	}
+	// The same model served elsewhere gets its own index.
+	other := DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderOllama, BaseURL: "http://gpu-box:11434", Model: "nomic-embed-text:latest", Dim: 768})
+	if other == got {
+		t.Error("expected different endpoints to use different index files")
+	}
+	got = DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderHash, Model: "ignored"})
+	if want = filepath.Join("/p", ".bv", "semantic", "index-hash-384.bvvi"); got != want {
+		t.Errorf("DefaultIndexPath = %q, want %q", got, want)
+	}
+}
@@ -11,15 +11,29 @@ import (
 )
 
 // DefaultIndexPath returns the default semantic index path under the given project directory.
-// The filename is keyed by provider+dim to avoid mixing incompatible embeddings.
+// The filename is keyed by provider+dim (and model and a hash of the base URL for HTTP
+// providers) to avoid mixing incompatible embeddings. HTTP providers detect their dim,
+// so pass the embedder's Dim().
 func DefaultIndexPath(projectDir string, cfg EmbeddingConfig) string {
 	cfg = cfg.Normalized()
 	provider := cfg.Provider
 	if provider == "" {
 		provider = ProviderHash
 	}
-	safeProvider := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(string(provider))
-	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", safeProvider, cfg.Dim))
+	safe := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_")
+	name := safe.Replace(string(provider))
+	if provider.IsHTTP() {
+		name += "-" + safe.Replace(cfg.Model) + "-" + endpointKey(cfg.BaseURL)
+	}
+	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", name, cfg.Dim))
+}
+
+// DefaultDuplicateIndexPath returns the persisted index used for semantic duplicate
+// detection. It sits next to DefaultIndexPath but embeds DuplicateDocument text, so the
+// two never re-embed each other's entries.
+func DefaultDuplicateIndexPath(projectDir string, cfg EmbeddingConfig) string {
+	path := DefaultIndexPath(projectDir, cfg)
+	return filepath.Join(filepath.Dir(path), "duplicates-"+filepath.Base(path))
 }
 
 type IndexSyncStats struct {
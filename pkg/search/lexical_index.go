@@ -0,0 +1,730 @@
+package search
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const (
+	lexicalIndexMagic   = "BVLX"
+	lexicalIndexVersion = uint16(1)
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// lexicalPrefixExpansion caps how many dictionary terms a prefix like "a*" expands to.
+	lexicalPrefixExpansion = 64
+	lexicalMinPrefixLen    = 2
+)
+
+// LexicalField identifies a document field with its own BM25F weight and length norm.
+type LexicalField uint8
+
+const (
+	LexicalFieldID LexicalField = iota
+	LexicalFieldTitle
+	LexicalFieldLabels
+	LexicalFieldDescription
+	LexicalFieldComments
+	LexicalFieldCommits
+	LexicalFieldPaths
+	numLexicalFields
+)
+
+// lexicalFieldWeights: title > labels > description > commits > comments and paths. ID
+// tokens weigh most so "bv-12" finds its issue even when other issues mention it.
+var lexicalFieldWeights = [numLexicalFields]float64{
+	LexicalFieldID:          5,
+	LexicalFieldTitle:       3,
+	LexicalFieldLabels:      2,
+	LexicalFieldDescription: 1,
+	LexicalFieldComments:    0.5,
+	LexicalFieldCommits:     0.75,
+	LexicalFieldPaths:       0.5,
+}
+
+// Lucene's English stop words; they keep a position slot so phrases still line up.
+var lexicalStopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "but": {},
+	"by": {}, "for": {}, "if": {}, "in": {}, "into": {}, "is": {}, "it": {}, "no": {},
+	"not": {}, "of": {}, "on": {}, "or": {}, "such": {}, "that": {}, "the": {}, "their": {},
+	"then": {}, "there": {}, "these": {}, "they": {}, "this": {}, "to": {}, "was": {},
+	"will": {}, "with": {},
+}
+
+// LexicalDocument is the per-field text indexed for BM25 search.
+type LexicalDocument struct {
+	// Key identifies the document in results and defaults to ID. Documents that aren't
+	// a whole issue (a single comment or commit) set Key and leave ID empty.
+	Key string
+
+	ID          string
+	Title       string
+	Labels      []string
+	Description string
+	Comments    []string
+	Commits     []string // commit messages
+	Paths       []string // touched file paths
+}
+
+func (d LexicalDocument) key() string {
+	if d.Key != "" {
+		return d.Key
+	}
+	return d.ID
+}
+
+// LexicalDocumentFromIssue extracts the searchable fields of an issue.
+func LexicalDocumentFromIssue(issue model.Issue) LexicalDocument {
+	doc := LexicalDocument{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Labels:      issue.Labels,
+		Description: issue.Description,
+	}
+	for _, c := range issue.Comments {
+		if c != nil && c.Text != "" {
+			doc.Comments = append(doc.Comments, c.Text)
+		}
+	}
+	return doc
+}
+
+// LexicalDocumentsFromIssues builds lexical documents for all issues with an ID.
+func LexicalDocumentsFromIssues(issues []model.Issue) []LexicalDocument {
+	docs := make([]LexicalDocument, 0, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		docs = append(docs, LexicalDocumentFromIssue(issue))
+	}
+	return docs
+}
+
+func (d LexicalDocument) field(f LexicalField) string {
+	switch f {
+	case LexicalFieldID:
+		return d.ID
+	case LexicalFieldTitle:
+		return d.Title
+	case LexicalFieldLabels:
+		return strings.Join(d.Labels, "\n")
+	case LexicalFieldDescription:
+		return d.Description
+	case LexicalFieldComments:
+		return strings.Join(d.Comments, "\n")
+	case LexicalFieldCommits:
+		return strings.Join(d.Commits, "\n")
+	case LexicalFieldPaths:
+		return strings.Join(d.Paths, "\n")
+	}
+	return ""
+}
+
+// LexicalDataHash fingerprints the indexed text so a persisted index can be reused
+// only while the issues it was built from are unchanged.
+func LexicalDataHash(docs []LexicalDocument) string {
+	sorted := make([]LexicalDocument, len(docs))
+	copy(sorted, docs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key() < sorted[j].key() })
+
+	h := sha256.New()
+	for _, doc := range sorted {
+		h.Write([]byte(doc.key()))
+		h.Write([]byte{0})
+		for f := LexicalField(0); f < numLexicalFields; f++ {
+			h.Write([]byte(doc.field(f)))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{1})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type lexicalToken struct {
+	term string
+	pos  uint32
+}
+
+// analyzeLexical lowercases, splits on anything that isn't a letter or digit, drops stop
+// words and stems. Positions count every word, stop words included.
+func analyzeLexical(text string) []lexicalToken {
+	var out []lexicalToken
+	var pos uint32
+	for _, word := range splitLexicalWords(text) {
+		if _, stop := lexicalStopWords[word]; !stop {
+			out = append(out, lexicalToken{term: porterStem(word), pos: pos})
+		}
+		pos++
+	}
+	return out
+}
+
+func splitLexicalWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+type lexicalDoc struct {
+	id   string
+	lens [numLexicalFields]uint32
+}
+
+type lexicalPosting struct {
+	doc       uint32
+	field     LexicalField
+	positions []uint32
+}
+
+// LexicalIndex is an immutable inverted index over issue fields, scored with BM25F.
+// Build it with NewLexicalIndex or load it with LoadLexicalIndex; it is safe for
+// concurrent searches.
+type LexicalIndex struct {
+	dataHash string
+	docs     []lexicalDoc
+	avgLen   [numLexicalFields]float64
+	postings map[string][]lexicalPosting // sorted by doc, then field
+	terms    []string                    // sorted dictionary for prefix expansion
+}
+
+// NewLexicalIndex indexes docs. Documents without a key are skipped.
+func NewLexicalIndex(docs []LexicalDocument) *LexicalIndex {
+	sorted := make([]LexicalDocument, 0, len(docs))
+	for _, doc := range docs {
+		if doc.key() != "" {
+			sorted = append(sorted, doc)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key() < sorted[j].key() })
+
+	idx := &LexicalIndex{
+		dataHash: LexicalDataHash(sorted),
+		docs:     make([]lexicalDoc, len(sorted)),
+		postings: make(map[string][]lexicalPosting),
+	}
+	for i, doc := range sorted {
+		idx.docs[i].id = doc.key()
+		for f := LexicalField(0); f < numLexicalFields; f++ {
+			tokens := analyzeLexical(doc.field(f))
+			idx.docs[i].lens[f] = uint32(len(tokens))
+			byTerm := make(map[string][]uint32)
+			var order []string
+			for _, tok := range tokens {
+				if _, seen := byTerm[tok.term]; !seen {
+					order = append(order, tok.term)
+				}
+				byTerm[tok.term] = append(byTerm[tok.term], tok.pos)
+			}
+			for _, term := range order {
+				idx.postings[term] = append(idx.postings[term], lexicalPosting{
+					doc:       uint32(i),
+					field:     f,
+					positions: byTerm[term],
+				})
+			}
+		}
+	}
+	idx.finish()
+	return idx
+}
+
+// finish derives the dictionary and average field lengths.
+func (idx *LexicalIndex) finish() {
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+
+	// Averages only count documents that have the field, so sparse fields (comments,
+	// commit messages) aren't normalized against a mostly-empty corpus.
+	idx.avgLen = [numLexicalFields]float64{}
+	var counts [numLexicalFields]int
+	for _, doc := range idx.docs {
+		for f, l := range doc.lens {
+			if l > 0 {
+				idx.avgLen[f] += float64(l)
+				counts[f]++
+			}
+		}
+	}
+	for f := range idx.avgLen {
+		if counts[f] > 0 {
+			idx.avgLen[f] /= float64(counts[f])
+		}
+	}
+}
+
+// DataHash returns the LexicalDataHash of the indexed documents.
+func (idx *LexicalIndex) DataHash() string {
+	return idx.dataHash
+}
+
+// Size returns the number of indexed documents.
+func (idx *LexicalIndex) Size() int {
+	return len(idx.docs)
+}
+
+// lexicalClause is one query unit: a term, a prefix, or a phrase.
+type lexicalClause struct {
+	terms   []string
+	offsets []uint32 // phrase positions relative to the first term
+	prefix  bool
+}
+
+// parseLexicalQuery understands bare terms, "quoted phrases" and prefix* terms. Words
+// that split into several tokens ("bv-12", "snake_case") are matched as phrases. With
+// prefixLast the final bare word is treated as a prefix, for search-as-you-type.
+func parseLexicalQuery(query string, prefixLast bool) []lexicalClause {
+	var clauses []lexicalClause
+	rest := query
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			var phrase string
+			if end < 0 {
+				phrase, rest = rest[1:], ""
+			} else {
+				phrase, rest = rest[1:end+1], rest[end+2:]
+			}
+			if c, ok := phraseClause(analyzeLexical(phrase)); ok {
+				clauses = append(clauses, c)
+			}
+			continue
+		}
+
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		word := rest
+		if end >= 0 {
+			word, rest = rest[:end], rest[end:]
+		} else {
+			rest = ""
+		}
+		prefix := strings.HasSuffix(word, "*") || (prefixLast && rest == "")
+
+		words := splitLexicalWords(word)
+		if prefix && len(words) == 1 && len(words[0]) >= lexicalMinPrefixLen {
+			clauses = append(clauses, lexicalClause{terms: []string{words[0]}, prefix: true})
+			continue
+		}
+		if c, ok := phraseClause(analyzeLexical(word)); ok {
+			clauses = append(clauses, c)
+		}
+	}
+	return clauses
+}
+
+func phraseClause(tokens []lexicalToken) (lexicalClause, bool) {
+	if len(tokens) == 0 {
+		return lexicalClause{}, false
+	}
+	c := lexicalClause{}
+	for _, tok := range tokens {
+		c.terms = append(c.terms, tok.term)
+		c.offsets = append(c.offsets, tok.pos-tokens[0].pos)
+	}
+	return c, true
+}
+
+// Search ranks documents for query with BM25F, best first. limit <= 0 returns every match.
+func (idx *LexicalIndex) Search(query string, limit int) []SearchResult {
+	return idx.search(parseLexicalQuery(query, false), limit)
+}
+
+// SearchPrefix is Search with the last word treated as a prefix, so partially typed
+// queries already match ("auth" finds "authentication").
+func (idx *LexicalIndex) SearchPrefix(query string, limit int) []SearchResult {
+	return idx.search(parseLexicalQuery(query, true), limit)
+}
+
+func (idx *LexicalIndex) search(clauses []lexicalClause, limit int) []SearchResult {
+	if idx == nil || len(idx.docs) == 0 || len(clauses) == 0 {
+		return nil
+	}
+	scores := make(map[uint32]float64)
+	for _, c := range clauses {
+		switch {
+		case c.prefix:
+			best := make(map[uint32]float64)
+			for _, term := range idx.expandPrefix(c.terms[0]) {
+				for doc, s := range idx.scoreFreqs(idx.termFreqs(term), idx.idf(len(idx.docFreq(term)))) {
+					if s > best[doc] {
+						best[doc] = s
+					}
+				}
+			}
+			for doc, s := range best {
+				scores[doc] += s
+			}
+		case len(c.terms) == 1:
+			for doc, s := range idx.scoreFreqs(idx.termFreqs(c.terms[0]), idx.idf(len(idx.docFreq(c.terms[0])))) {
+				scores[doc] += s
+			}
+		default:
+			var idf float64
+			for _, term := range c.terms {
+				idf += idx.idf(len(idx.docFreq(term)))
+			}
+			for doc, s := range idx.scoreFreqs(idx.phraseFreqs(c), idf) {
+				scores[doc] += s
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for doc, score := range scores {
+		results = append(results, SearchResult{IssueID: idx.docs[doc].id, Score: score})
+	}
+	sortSearchResults(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+type fieldFreqs map[uint32]*[numLexicalFields]uint32
+
+func (idx *LexicalIndex) termFreqs(term string) fieldFreqs {
+	freqs := make(fieldFreqs)
+	for _, p := range idx.postings[term] {
+		tf := freqs[p.doc]
+		if tf == nil {
+			tf = new([numLexicalFields]uint32)
+			freqs[p.doc] = tf
+		}
+		tf[p.field] = uint32(len(p.positions))
+	}
+	return freqs
+}
+
+// phraseFreqs counts, per doc and field, how often the clause terms occur in order.
+func (idx *LexicalIndex) phraseFreqs(c lexicalClause) fieldFreqs {
+	type key struct {
+		doc   uint32
+		field LexicalField
+	}
+	rest := make([]map[key]map[uint32]struct{}, len(c.terms)-1)
+	for i, term := range c.terms[1:] {
+		rest[i] = make(map[key]map[uint32]struct{})
+		for _, p := range idx.postings[term] {
+			set := make(map[uint32]struct{}, len(p.positions))
+			for _, pos := range p.positions {
+				set[pos] = struct{}{}
+			}
+			rest[i][key{p.doc, p.field}] = set
+		}
+	}
+
+	freqs := make(fieldFreqs)
+	for _, p := range idx.postings[c.terms[0]] {
+		k := key{p.doc, p.field}
+		var n uint32
+	positions:
+		for _, start := range p.positions {
+			for i := range rest {
+				if _, ok := rest[i][k][start+c.offsets[i+1]]; !ok {
+					continue positions
+				}
+			}
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		tf := freqs[p.doc]
+		if tf == nil {
+			tf = new([numLexicalFields]uint32)
+			freqs[p.doc] = tf
+		}
+		tf[p.field] = n
+	}
+	return freqs
+}
+
+// scoreFreqs applies BM25F: field frequencies are length-normalized and weighted before
+// a single saturation step, so a term repeated across fields doesn't score twice.
+func (idx *LexicalIndex) scoreFreqs(freqs fieldFreqs, idf float64) map[uint32]float64 {
+	out := make(map[uint32]float64, len(freqs))
+	for doc, tf := range freqs {
+		var weighted float64
+		for f, n := range tf {
+			if n == 0 {
+				continue
+			}
+			norm := 1.0
+			if idx.avgLen[f] > 0 {
+				norm = 1 - bm25B + bm25B*float64(idx.docs[doc].lens[f])/idx.avgLen[f]
+			}
+			weighted += lexicalFieldWeights[f] * float64(n) / norm
+		}
+		out[doc] = idf * weighted / (bm25K1 + weighted)
+	}
+	return out
+}
+
+func (idx *LexicalIndex) docFreq(term string) map[uint32]struct{} {
+	docs := make(map[uint32]struct{})
+	for _, p := range idx.postings[term] {
+		docs[p.doc] = struct{}{}
+	}
+	return docs
+}
+
+func (idx *LexicalIndex) idf(df int) float64 {
+	n := float64(len(idx.docs))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+// expandPrefix returns dictionary terms starting with prefix or with its stem, so a fully
+// typed word still matches ("authenticated" -> "authent"). Capped to the most frequent.
+func (idx *LexicalIndex) expandPrefix(prefix string) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, p := range []string{prefix, porterStem(prefix)} {
+		for i := sort.SearchStrings(idx.terms, p); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], p); i++ {
+			if _, ok := seen[idx.terms[i]]; !ok {
+				seen[idx.terms[i]] = struct{}{}
+				out = append(out, idx.terms[i])
+			}
+		}
+	}
+	if len(out) > lexicalPrefixExpansion {
+		sort.SliceStable(out, func(i, j int) bool { return len(idx.postings[out[i]]) > len(idx.postings[out[j]]) })
+		out = out[:lexicalPrefixExpansion]
+	}
+	return out
+}
+
+func sortSearchResults(results []SearchResult) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].IssueID < results[j].IssueID
+		}
+		return results[i].Score > results[j].Score
+	})
+}
+
+// DefaultLexicalIndexPath returns where the BM25 index is persisted for a project.
+func DefaultLexicalIndexPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "semantic", "lexical.bvlx")
+}
+
+// LoadOrBuildLexicalIndex reuses the index at path when it was built from the same data
+// (by LexicalDataHash), otherwise builds a fresh one and saves it. The index is always
+// returned; a non-nil error only reports a failed save.
+func LoadOrBuildLexicalIndex(path string, docs []LexicalDocument) (*LexicalIndex, bool, error) {
+	if loaded, err := LoadLexicalIndex(path); err == nil && loaded.dataHash == LexicalDataHash(docs) {
+		return loaded, true, nil
+	}
+	idx := NewLexicalIndex(docs)
+	return idx, false, idx.Save(path)
+}
+
+// LoadLexicalIndex reads an index written by Save.
+func LoadLexicalIndex(path string) (*LexicalIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	r := bufio.NewReader(f)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if string(magic[:]) != lexicalIndexMagic {
+		return nil, fmt.Errorf("invalid magic %q", string(magic[:]))
+	}
+	var version uint16
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("read version: %w", err)
+	}
+	if version != lexicalIndexVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+
+	lr := &lexicalReader{r: r}
+	idx := &LexicalIndex{postings: make(map[string][]lexicalPosting)}
+	idx.dataHash = lr.string()
+	if fields := lr.uvarint(); fields != uint64(numLexicalFields) && lr.err == nil {
+		return nil, fmt.Errorf("unsupported field count %d", fields)
+	}
+	nDocs := lr.uvarint()
+	for i := uint64(0); i < nDocs && lr.err == nil; i++ {
+		doc := lexicalDoc{id: lr.string()}
+		for f := range doc.lens {
+			doc.lens[f] = uint32(lr.uvarint())
+		}
+		idx.docs = append(idx.docs, doc)
+	}
+	nTerms := lr.uvarint()
+	for i := uint64(0); i < nTerms && lr.err == nil; i++ {
+		term := lr.string()
+		nPostings := lr.uvarint()
+		// Counts come from the file: bound them before allocating so a corrupt index
+		// is rejected (and rebuilt) rather than exhausting memory.
+		if lr.err == nil && nPostings > uint64(len(idx.docs))*uint64(numLexicalFields) {
+			return nil, fmt.Errorf("corrupt posting count %d for %q", nPostings, term)
+		}
+		postings := make([]lexicalPosting, 0, nPostings)
+		for j := uint64(0); j < nPostings && lr.err == nil; j++ {
+			p := lexicalPosting{doc: uint32(lr.uvarint()), field: LexicalField(lr.uvarint())}
+			if int(p.doc) >= len(idx.docs) || p.field >= numLexicalFields {
+				return nil, fmt.Errorf("corrupt posting for %q", term)
+			}
+			nPos := lr.uvarint()
+			if lr.err == nil && nPos > uint64(idx.docs[p.doc].lens[p.field]) {
+				return nil, fmt.Errorf("corrupt position count %d for %q", nPos, term)
+			}
+			var pos uint32
+			for k := uint64(0); k < nPos && lr.err == nil; k++ {
+				pos += uint32(lr.uvarint())
+				p.positions = append(p.positions, pos)
+			}
+			postings = append(postings, p)
+		}
+		idx.postings[term] = postings
+	}
+	if lr.err != nil {
+		return nil, fmt.Errorf("read lexical index: %w", lr.err)
+	}
+	idx.finish()
+	return idx, nil
+}
+
+// Save writes the index atomically (temp file + rename).
+func (idx *LexicalIndex) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "bvlx-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	w := bufio.NewWriter(tmp)
+	lw := &lexicalWriter{w: w}
+	lw.bytes([]byte(lexicalIndexMagic))
+	lw.bytes(binary.LittleEndian.AppendUint16(nil, lexicalIndexVersion))
+	lw.string(idx.dataHash)
+	lw.uvarint(uint64(numLexicalFields))
+	lw.uvarint(uint64(len(idx.docs)))
+	for _, doc := range idx.docs {
+		lw.string(doc.id)
+		for _, l := range doc.lens {
+			lw.uvarint(uint64(l))
+		}
+	}
+	lw.uvarint(uint64(len(idx.terms)))
+	for _, term := range idx.terms {
+		postings := idx.postings[term]
+		lw.string(term)
+		lw.uvarint(uint64(len(postings)))
+		for _, p := range postings {
+			lw.uvarint(uint64(p.doc))
+			lw.uvarint(uint64(p.field))
+			lw.uvarint(uint64(len(p.positions)))
+			var prev uint32
+			for _, pos := range p.positions {
+				lw.uvarint(uint64(pos - prev))
+				prev = pos
+			}
+		}
+	}
+	if lw.err != nil {
+		return fmt.Errorf("write lexical index: %w", lw.err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		// Windows can't rename over an existing file; the index is rebuildable, so replace it.
+		if runtime.GOOS == "windows" {
+			if rmErr := os.Remove(path); rmErr == nil {
+				if err2 := os.Rename(tmpPath, path); err2 == nil {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("rename: %w", err)
+	}
+	return nil
+}
+
+type lexicalWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (lw *lexicalWriter) bytes(b []byte) {
+	if lw.err == nil {
+		_, lw.err = lw.w.Write(b)
+	}
+}
+
+func (lw *lexicalWriter) uvarint(v uint64) {
+	lw.bytes(lw.buf[:binary.PutUvarint(lw.buf[:], v)])
+}
+
+func (lw *lexicalWriter) string(s string) {
+	lw.uvarint(uint64(len(s)))
+	lw.bytes([]byte(s))
+}
+
+type lexicalReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (lr *lexicalReader) uvarint() uint64 {
+	if lr.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(lr.r)
+	lr.err = err
+	return v
+}
+
+func (lr *lexicalReader) string() string {
+	n := lr.uvarint()
+	if lr.err != nil {
+		return ""
+	}
+	if n > math.MaxUint16*16 {
+		lr.err = fmt.Errorf("string length %d too large", n)
+		return ""
+	}
+	b := make([]byte, n)
+	_, lr.err = io.ReadFull(lr.r, b)
+	return string(b)
+}
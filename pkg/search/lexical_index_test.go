@@ -0,0 +1,179 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func lexicalTestDocs() []LexicalDocument {
+	return []LexicalDocument{
+		{ID: "bv-1", Title: "Authentication fails on login", Description: "Users see a 500 after entering credentials."},
+		{ID: "bv-2", Title: "Update changelog", Description: "Mention the authentication fix in the release notes."},
+		{ID: "bv-3", Title: "Dark mode", Labels: []string{"auth", "ui"}, Description: "Theme toggle."},
+		{ID: "bv-4", Title: "Flaky test", Comments: []string{"Probably the login retry loop again."}},
+		{ID: "bv-5", Title: "Login page redesign", Description: "Fails silently on slow networks, then login retries."},
+	}
+}
+
+func resultIDs(results []SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.IssueID
+	}
+	return ids
+}
+
+func TestLexicalIndex_FieldWeightsAndStemming(t *testing.T) {
+	idx := NewLexicalIndex(lexicalTestDocs())
+
+	// "authenticated" stems to the same term; the title hit outranks the description hit.
+	got := resultIDs(idx.Search("authenticated", 0))
+	if want := []string{"bv-1", "bv-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Search(authenticated) = %v, want %v", got, want)
+	}
+
+	// Title > description > comments for the same term.
+	got = resultIDs(idx.Search("login", 0))
+	if len(got) != 3 || got[len(got)-1] != "bv-4" {
+		t.Fatalf("expected the comment-only match last, got %v", got)
+	}
+
+	if results := idx.Search("the", 0); len(results) != 0 {
+		t.Errorf("stop words should not match, got %v", resultIDs(results))
+	}
+}
+
+func TestLexicalIndex_PhraseAndPrefix(t *testing.T) {
+	idx := NewLexicalIndex(lexicalTestDocs())
+
+	// Both bv-1 and bv-5 contain "fails" and "login", only bv-1 has the phrase (stop word gap included).
+	if got := resultIDs(idx.Search(`"fails on login"`, 0)); !reflect.DeepEqual(got, []string{"bv-1"}) {
+		t.Errorf("phrase search = %v, want [bv-1]", got)
+	}
+	if got := resultIDs(idx.Search(`"login fails"`, 0)); len(got) != 0 {
+		t.Errorf("out-of-order phrase should not match, got %v", got)
+	}
+
+	// Without the star "auth" only hits the label; as a prefix it also reaches "authentication".
+	if got := resultIDs(idx.Search("auth", 0)); !reflect.DeepEqual(got, []string{"bv-3"}) {
+		t.Errorf("Search(auth) = %v, want [bv-3]", got)
+	}
+	if got := idx.Search("auth*", 0); len(got) != 3 {
+		t.Errorf("Search(auth*) = %v, want 3 matches", resultIDs(got))
+	}
+	if got := idx.SearchPrefix("dark mo", 0); len(got) != 1 || got[0].IssueID != "bv-3" {
+		t.Errorf("SearchPrefix(dark mo) = %v, want [bv-3]", resultIDs(got))
+	}
+	// A fully typed last word is longer than its stem and must still match.
+	if got := idx.SearchPrefix("authenticated", 0); len(got) != 2 {
+		t.Errorf("SearchPrefix(authenticated) = %v, want 2 matches", resultIDs(got))
+	}
+
+	// IDs split into tokens and match as a phrase.
+	if got := idx.Search("bv-4", 1); len(got) != 1 || got[0].IssueID != "bv-4" {
+		t.Errorf("Search(bv-4) = %v", resultIDs(got))
+	}
+}
+
+func TestLexicalIndex_SaveLoadKeyedByDataHash(t *testing.T) {
+	docs := lexicalTestDocs()
+	path := filepath.Join(t.TempDir(), "lexical.bvlx")
+
+	idx, loaded, err := LoadOrBuildLexicalIndex(path, docs)
+	if err != nil || loaded {
+		t.Fatalf("expected a fresh build, loaded=%v err=%v", loaded, err)
+	}
+	again, loaded, err := LoadOrBuildLexicalIndex(path, docs)
+	if err != nil || !loaded {
+		t.Fatalf("expected the saved index to be reused, loaded=%v err=%v", loaded, err)
+	}
+	for _, q := range []string{"login", `"fails on login"`, "auth*", "changelog release"} {
+		if want, got := idx.Search(q, 0), again.Search(q, 0); !reflect.DeepEqual(want, got) {
+			t.Errorf("loaded index answers %q differently:\n%v\n%v", q, want, got)
+		}
+	}
+
+	// Editing a comment changes the hash and forces a rebuild.
+	docs[3].Comments = []string{"Turned out to be a timezone bug."}
+	rebuilt, loaded, err := LoadOrBuildLexicalIndex(path, docs)
+	if err != nil || loaded {
+		t.Fatalf("expected a rebuild after an edit, loaded=%v err=%v", loaded, err)
+	}
+	if got := resultIDs(rebuilt.Search("timezone", 0)); !reflect.DeepEqual(got, []string{"bv-4"}) {
+		t.Errorf("rebuilt index missed the edit: %v", got)
+	}
+
+	// A corrupt file is rebuilt too.
+	if err := os.WriteFile(path, []byte("BVLX\x01\x00garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, loaded, err := LoadOrBuildLexicalIndex(path, docs); err != nil || loaded {
+		t.Fatalf("expected a rebuild over a corrupt file, loaded=%v err=%v", loaded, err)
+	}
+}
+
+func TestLoadLexicalIndex_RejectsOversizedCounts(t *testing.T) {
+	docs := lexicalTestDocs()
+	path := filepath.Join(t.TempDir(), "lexical.bvlx")
+	write := func(nPostings, nPos uint64) {
+		t.Helper()
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		lw := &lexicalWriter{w: w}
+		lw.bytes([]byte(lexicalIndexMagic))
+		lw.bytes(binary.LittleEndian.AppendUint16(nil, lexicalIndexVersion))
+		lw.string(LexicalDataHash(docs))
+		lw.uvarint(uint64(numLexicalFields))
+		lw.uvarint(1)
+		lw.string("bv-1")
+		for f := LexicalField(0); f < numLexicalFields; f++ {
+			lw.uvarint(4)
+		}
+		lw.uvarint(1)
+		lw.string("login")
+		lw.uvarint(nPostings)
+		lw.uvarint(0)
+		lw.uvarint(uint64(LexicalFieldTitle))
+		lw.uvarint(nPos)
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct{ nPostings, nPos uint64 }{{1 << 62, 1}, {1, 1 << 62}} {
+		write(c.nPostings, c.nPos)
+		if _, err := LoadLexicalIndex(path); err == nil {
+			t.Errorf("expected counts %+v to be rejected", c)
+		}
+		idx, loaded, err := LoadOrBuildLexicalIndex(path, docs)
+		if err != nil || loaded || idx.Size() != len(docs) {
+			t.Fatalf("expected a rebuild over counts %+v, loaded=%v err=%v", c, loaded, err)
+		}
+	}
+}
+
+func TestLexicalDocumentFromIssue(t *testing.T) {
+	issue := model.Issue{
+		ID:       "bv-9",
+		Title:    "Title",
+		Labels:   []string{"a", "b"},
+		Comments: []*model.Comment{{Text: "first"}, nil, {Text: ""}, {Text: "second"}},
+	}
+	doc := LexicalDocumentFromIssue(issue)
+	if !reflect.DeepEqual(doc.Comments, []string{"first", "second"}) {
+		t.Errorf("unexpected comments %v", doc.Comments)
+	}
+	if docs := LexicalDocumentsFromIssues([]model.Issue{issue, {Title: "no id"}}); len(docs) != 1 {
+		t.Errorf("expected issues without an ID to be skipped, got %d docs", len(docs))
+	}
+}
@@ -9,7 +9,6 @@ import (
 const (
 	shortQueryTokenLimit        = 2
 	shortQueryRuneLimit         = 12
-	shortQueryMinTextWeight     = 0.55
 	hybridCandidateMin          = 200
 	hybridCandidateMinShort     = 300
 	hybridCandidateDefaultLimit = 10
@@ -39,38 +38,6 @@ func IsShortQuery(query string) bool {
 	return AnalyzeQuery(query).IsShort
 }
 
-// AdjustWeightsForQuery boosts text relevance for short queries to prevent
-// unrelated high-impact items from dominating hybrid ranking.
-func AdjustWeightsForQuery(weights Weights, query string) Weights {
-	if !IsShortQuery(query) {
-		return weights
-	}
-	if weights.TextRelevance >= shortQueryMinTextWeight {
-		return weights
-	}
-
-	target := shortQueryMinTextWeight
-	if target >= 1.0 {
-		return Weights{TextRelevance: 1.0}
-	}
-
-	remaining := weights.sum() - weights.TextRelevance
-	if remaining <= 0 {
-		return Weights{TextRelevance: 1.0}
-	}
-
-	scale := (1.0 - target) / remaining
-	adjusted := Weights{
-		TextRelevance: target,
-		PageRank:      weights.PageRank * scale,
-		Status:        weights.Status * scale,
-		Impact:        weights.Impact * scale,
-		Priority:      weights.Priority * scale,
-		Recency:       weights.Recency * scale,
-	}
-	return adjusted.Normalize()
-}
-
 // HybridCandidateLimit returns the number of candidates to consider for hybrid re-ranking.
 // It widens the candidate pool for short queries to improve recall of literal matches.
 func HybridCandidateLimit(limit int, total int, query string) int {
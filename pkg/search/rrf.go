@@ -0,0 +1,101 @@
+package search
+
+// DefaultRRFK is the reciprocal rank fusion constant from Cormack et al.; larger values
+// flatten the advantage of the very top ranks.
+const DefaultRRFK = 60
+
+// RankedList is one input to ReciprocalRankFusion, best result first.
+type RankedList struct {
+	Weight  float64
+	Results []SearchResult
+}
+
+// ReciprocalRankFusion merges rankings by summing weight/(k+rank) for every list an issue
+// appears in. Only ranks matter, so BM25 scores, cosine similarities and graph scores can
+// be combined without calibrating them against each other.
+func ReciprocalRankFusion(k int, lists ...RankedList) []SearchResult {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+	fused := make(map[string]float64)
+	for _, list := range lists {
+		if list.Weight <= 0 {
+			continue
+		}
+		for rank, r := range list.Results {
+			fused[r.IssueID] += list.Weight / float64(k+rank+1)
+		}
+	}
+	out := make([]SearchResult, 0, len(fused))
+	for id, score := range fused {
+		out = append(out, SearchResult{IssueID: id, Score: score})
+	}
+	sortSearchResults(out)
+	return out
+}
+
+// FuseTextResults combines the BM25 and vector rankings with equal weight.
+func FuseTextResults(lexical, semantic []SearchResult) []SearchResult {
+	return ReciprocalRankFusion(DefaultRRFK,
+		RankedList{Weight: 1, Results: lexical},
+		RankedList{Weight: 1, Results: semantic},
+	)
+}
+
+// FuseHybridResults fuses the BM25 and vector rankings with a graph ranking of the same
+// candidates. The graph ranking orders candidates by the scorer's non-text signals
+// (PageRank, status, impact, priority, recency); the text lists are each weighted by
+// TextRelevance and the graph list by the remainder, so presets still steer the result.
+// Component scores report the raw "bm25" and "semantic" scores next to the graph signals.
+func FuseHybridResults(lexical, semantic []SearchResult, scorer HybridScorer) ([]HybridScore, error) {
+	weights := scorer.GetWeights().Normalize()
+
+	bm25 := make(map[string]float64, len(lexical))
+	for _, r := range lexical {
+		bm25[r.IssueID] = r.Score
+	}
+	cosine := make(map[string]float64, len(semantic))
+	for _, r := range semantic {
+		cosine[r.IssueID] = r.Score
+	}
+
+	graph := make(map[string]HybridScore, len(lexical)+len(semantic))
+	var graphRanking []SearchResult
+	for _, list := range [][]SearchResult{lexical, semantic} {
+		for _, r := range list {
+			if _, ok := graph[r.IssueID]; ok {
+				continue
+			}
+			scored, err := scorer.Score(r.IssueID, 0)
+			if err != nil {
+				return nil, err
+			}
+			graph[r.IssueID] = scored
+			graphRanking = append(graphRanking, SearchResult{IssueID: r.IssueID, Score: scored.FinalScore})
+		}
+	}
+	sortSearchResults(graphRanking)
+
+	fused := ReciprocalRankFusion(DefaultRRFK,
+		RankedList{Weight: weights.TextRelevance, Results: lexical},
+		RankedList{Weight: weights.TextRelevance, Results: semantic},
+		RankedList{Weight: 1 - weights.TextRelevance, Results: graphRanking},
+	)
+
+	out := make([]HybridScore, 0, len(fused))
+	for _, r := range fused {
+		components := make(map[string]float64, len(graph[r.IssueID].ComponentScores)+2)
+		for name, v := range graph[r.IssueID].ComponentScores {
+			components[name] = v
+		}
+		components["bm25"] = bm25[r.IssueID]
+		components["semantic"] = cosine[r.IssueID]
+		out = append(out, HybridScore{
+			IssueID:         r.IssueID,
+			FinalScore:      r.Score,
+			TextScore:       cosine[r.IssueID],
+			ComponentScores: components,
+		})
+	}
+	return out, nil
+}
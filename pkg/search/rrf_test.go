@@ -0,0 +1,91 @@
+package search
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestReciprocalRankFusion(t *testing.T) {
+	a := []SearchResult{{IssueID: "x", Score: 9}, {IssueID: "y", Score: 8}}
+	b := []SearchResult{{IssueID: "y", Score: 0.9}, {IssueID: "z", Score: 0.1}}
+	fused := ReciprocalRankFusion(0, RankedList{Weight: 1, Results: a}, RankedList{Weight: 1, Results: b})
+
+	if got := resultIDs(fused); !reflect.DeepEqual(got, []string{"y", "x", "z"}) {
+		t.Fatalf("fused order = %v, want [y x z]", got)
+	}
+	if want := 1.0/62 + 1.0/61; math.Abs(fused[0].Score-want) > 1e-12 {
+		t.Errorf("y score = %v, want %v", fused[0].Score, want)
+	}
+
+	// Zero-weight lists are ignored entirely.
+	fused = ReciprocalRankFusion(DefaultRRFK, RankedList{Weight: 1, Results: a}, RankedList{Weight: 0, Results: b})
+	if got := resultIDs(fused); !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Errorf("fused order with a muted list = %v, want [x y]", got)
+	}
+}
+
+// The graph-heavy impact-first preset must not bury the only literal match under
+// high-PageRank issues that merely look similar to the hash embedder.
+func TestFuseHybridResults_ShortQueryKeepsLexicalMatch(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "bench-1", Title: "Performance benchmarks for graph rendering", Status: model.StatusOpen, Priority: 2, UpdatedAt: now.Add(-24 * time.Hour)},
+		{ID: "core-1", Title: "Core engine refactor", Status: model.StatusOpen, Priority: 0, UpdatedAt: now},
+	}
+	for _, id := range []string{"dep-1", "dep-2", "dep-3"} {
+		issues = append(issues, model.Issue{
+			ID: id, Title: "Feature depends on core", Status: model.StatusOpen, Priority: 1, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: "core-1", Type: model.DepBlocks}},
+		})
+	}
+
+	embedder := NewHashEmbedder(DefaultEmbeddingDim)
+	vecIdx := NewVectorIndex(embedder.Dim())
+	if _, err := SyncVectorIndex(context.Background(), vecIdx, embedder, DocumentsFromIssues(issues), 64); err != nil {
+		t.Fatal(err)
+	}
+	qvecs, _ := embedder.Embed(context.Background(), []string{"benchmarks"})
+	semantic, err := vecIdx.SearchTopK(qvecs[0], len(issues))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lexical := NewLexicalIndex(LexicalDocumentsFromIssues(issues)).Search("benchmarks", 0)
+
+	cache := NewMetricsCache(NewAnalyzerMetricsLoader(issues))
+	if err := cache.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	weights, _ := GetPreset(PresetImpactFirst)
+	fused, err := FuseHybridResults(lexical, semantic, NewHybridScorer(weights, cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fused) != len(issues) || fused[0].IssueID != "bench-1" {
+		t.Fatalf("expected bench-1 first among %d results, got %+v", len(issues), fused)
+	}
+	top := fused[0].ComponentScores
+	if top["bm25"] <= 0 || top["semantic"] <= 0 {
+		t.Errorf("expected bm25 and semantic components, got %v", top)
+	}
+	if _, ok := top["pagerank"]; !ok {
+		t.Errorf("expected graph components, got %v", top)
+	}
+
+	// With the text-only preset the graph list carries no weight.
+	textOnly, _ := GetPreset(PresetTextOnly)
+	fused, err = FuseHybridResults(lexical, semantic, NewHybridScorer(textOnly, cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FuseTextResults(lexical, semantic)
+	for i := range want {
+		if fused[i].IssueID != want[i].IssueID {
+			t.Fatalf("text-only hybrid order differs from text fusion at %d: %s vs %s", i, fused[i].IssueID, want[i].IssueID)
+		}
+	}
+}
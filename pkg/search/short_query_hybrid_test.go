@@ -106,7 +106,7 @@ func TestHybridShortQueryPrefersLexicalMatch(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SearchTopK: %v", err)
 	}
-	results = ApplyShortQueryLexicalBoost(results, "benchmarks", docs)
+	lexical := NewLexicalIndex(LexicalDocumentsFromIssues(issues)).Search("benchmarks", len(issues))
 
 	cache := NewMetricsCache(NewAnalyzerMetricsLoader(issues))
 	if err := cache.Refresh(); err != nil {
@@ -117,10 +117,9 @@ func TestHybridShortQueryPrefersLexicalMatch(t *testing.T) {
 	if err != nil {
 		t.Fatalf("preset: %v", err)
 	}
-	weights = AdjustWeightsForQuery(weights.Normalize(), "benchmarks")
 
 	scorer := NewHybridScorer(weights, cache)
-	hybridResults, err := scoreHybridResults(results, scorer)
+	hybridResults, err := FuseHybridResults(lexical, results, scorer)
 	if err != nil {
 		t.Fatalf("score hybrid: %v", err)
 	}
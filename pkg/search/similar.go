@@ -0,0 +1,292 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Similarity signals, used as keys in SimilarIssue.Components.
+const (
+	SimilarEmbedding = "embedding"
+	SimilarLabels    = "labels"
+	SimilarGraph     = "graph"
+	SimilarCoChange  = "cochange"
+)
+
+const (
+	// similarMaxHops is the furthest dependency distance that counts as proximity.
+	similarMaxHops = 3
+	// similarTextReason is the cosine above which similar text is given as a reason.
+	similarTextReason = 0.3
+	// similarMaxSharedFiles caps the shared files listed per suggestion.
+	similarMaxSharedFiles = 3
+)
+
+// SimilarWeights weights the similarity signals. They are normalized to sum to 1.
+type SimilarWeights struct {
+	Embedding float64 `json:"embedding"`
+	Labels    float64 `json:"labels"`
+	Graph     float64 `json:"graph"`
+	CoChange  float64 `json:"cochange"`
+}
+
+// DefaultSimilarWeights favours text similarity; the structural signals mostly explain
+// and break ties between textually similar issues.
+func DefaultSimilarWeights() SimilarWeights {
+	return SimilarWeights{Embedding: 0.5, Labels: 0.2, Graph: 0.15, CoChange: 0.15}
+}
+
+func (w SimilarWeights) normalize() SimilarWeights {
+	sum := w.Embedding + w.Labels + w.Graph + w.CoChange
+	if sum <= 0 {
+		return DefaultSimilarWeights()
+	}
+	return SimilarWeights{Embedding: w.Embedding / sum, Labels: w.Labels / sum, Graph: w.Graph / sum, CoChange: w.CoChange / sum}
+}
+
+// SimilarOptions configures FindSimilarIssues.
+type SimilarOptions struct {
+	Limit         int     // max suggestions (0 = all)
+	MinScore      float64 // drop weaker suggestions (every suggestion needs a reason regardless)
+	IncludeClosed bool
+	Weights       SimilarWeights
+	// Vectors holds issue embeddings (see NewSimilarityIndex); nil skips text similarity.
+	Vectors *VectorIndex
+	// History supplies files touched by correlated commits; nil skips co-change.
+	History *correlation.HistoryReport
+}
+
+// DefaultSimilarOptions returns sensible defaults.
+func DefaultSimilarOptions() SimilarOptions {
+	return SimilarOptions{Limit: 10, Weights: DefaultSimilarWeights()}
+}
+
+// SimilarIssue is a suggested related issue with the evidence behind it.
+type SimilarIssue struct {
+	IssueID      string             `json:"issue_id"`
+	Title        string             `json:"title"`
+	Status       string             `json:"status"`
+	Score        float64            `json:"score"`
+	Components   map[string]float64 `json:"components"`
+	Reasons      []string           `json:"reasons"`
+	SharedLabels []string           `json:"shared_labels,omitempty"`
+	SharedFiles  []string           `json:"shared_files,omitempty"`
+	Path         []string           `json:"dependency_path,omitempty"` // target ... issue
+}
+
+// Explanation joins the reasons into one line.
+func (s SimilarIssue) Explanation() string {
+	return strings.Join(s.Reasons, "; ")
+}
+
+// NewSimilarityIndex embeds title, labels and description of every issue (see
+// DuplicateDocument) into a fresh in-memory index.
+func NewSimilarityIndex(ctx context.Context, embedder Embedder, issues []model.Issue) (*VectorIndex, error) {
+	if embedder == nil {
+		return nil, fmt.Errorf("embedder cannot be nil")
+	}
+	docs := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" || issue.Status == model.StatusTombstone {
+			continue
+		}
+		if doc := DuplicateDocument(issue); doc != "" {
+			docs[issue.ID] = doc
+		}
+	}
+	idx := NewVectorIndex(embedder.Dim())
+	if _, err := SyncVectorIndex(ctx, idx, embedder, docs, 64); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// FindSimilarIssues suggests issues related to targetID by combining embedding
+// similarity, shared labels, dependency-graph proximity and files co-changed by their
+// commits. Issues already linked to the target by a dependency are left out, since the
+// point is to find links that are missing. It returns an error if the target is unknown.
+func FindSimilarIssues(targetID string, issues []model.Issue, opts SimilarOptions) ([]SimilarIssue, error) {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	target, ok := byID[targetID]
+	if !ok {
+		return nil, fmt.Errorf("issue not found: %s", targetID)
+	}
+	w := opts.Weights.normalize()
+
+	var targetVec []float32
+	if opts.Vectors != nil {
+		if e, ok := opts.Vectors.Get(targetID); ok {
+			targetVec = e.Vector
+		}
+	}
+	paths := dependencyPaths(targetID, issues, similarMaxHops)
+	files := touchedFiles(opts.History)
+
+	var out []SimilarIssue
+	for i := range issues {
+		issue := &issues[i]
+		if issue.ID == "" || issue.ID == targetID || issue.Status == model.StatusTombstone {
+			continue
+		}
+		if issue.Status.IsClosed() && !opts.IncludeClosed {
+			continue
+		}
+		path := paths[issue.ID]
+		if len(path) == 2 {
+			continue // already linked
+		}
+
+		s := SimilarIssue{IssueID: issue.ID, Title: issue.Title, Status: string(issue.Status), Components: map[string]float64{}}
+
+		if targetVec != nil {
+			if e, ok := opts.Vectors.Get(issue.ID); ok && len(e.Vector) == len(targetVec) {
+				cos := max(dotFloat32(targetVec, e.Vector), 0)
+				s.Components[SimilarEmbedding] = cos
+				if cos >= similarTextReason {
+					s.Reasons = append(s.Reasons, fmt.Sprintf("similar text (%.0f%%)", cos*100))
+				}
+			}
+		}
+
+		if shared, jaccard := overlap(target.Labels, issue.Labels); len(shared) > 0 {
+			s.Components[SimilarLabels] = jaccard
+			s.SharedLabels = shared
+			s.Reasons = append(s.Reasons, "shares "+pluralize(len(shared), "label", "labels")+" "+strings.Join(shared, ", "))
+		}
+
+		if len(path) > 2 {
+			hops := len(path) - 1
+			s.Components[SimilarGraph] = 1 / float64(hops-1)
+			s.Path = path
+			s.Reasons = append(s.Reasons, fmt.Sprintf("%d hops away via %s", hops, strings.Join(path[1:len(path)-1], " → ")))
+		}
+
+		if shared, jaccard := overlap(files[targetID], files[issue.ID]); len(shared) > 0 {
+			s.Components[SimilarCoChange] = jaccard
+			s.SharedFiles = shared
+			listed := strings.Join(shared[:min(len(shared), similarMaxSharedFiles)], ", ")
+			if extra := len(shared) - similarMaxSharedFiles; extra > 0 {
+				listed += fmt.Sprintf(" (+%d more)", extra)
+			}
+			s.Reasons = append(s.Reasons, "both changed "+listed)
+		}
+
+		s.Score = w.Embedding*s.Components[SimilarEmbedding] +
+			w.Labels*s.Components[SimilarLabels] +
+			w.Graph*s.Components[SimilarGraph] +
+			w.CoChange*s.Components[SimilarCoChange]
+		if len(s.Reasons) == 0 || s.Score < opts.MinScore {
+			continue
+		}
+		out = append(out, s)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score == out[j].Score {
+			return out[i].IssueID < out[j].IssueID
+		}
+		return out[i].Score > out[j].Score
+	})
+	if opts.Limit > 0 && len(out) > opts.Limit {
+		out = out[:opts.Limit]
+	}
+	return out, nil
+}
+
+// dependencyPaths returns the shortest undirected dependency path from targetID to each
+// issue within maxHops, target first.
+func dependencyPaths(targetID string, issues []model.Issue, maxHops int) map[string][]string {
+	adj := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.DependsOnID == "" || dep.DependsOnID == issue.ID {
+				continue
+			}
+			adj[issue.ID] = append(adj[issue.ID], dep.DependsOnID)
+			adj[dep.DependsOnID] = append(adj[dep.DependsOnID], issue.ID)
+		}
+	}
+	for id := range adj {
+		sort.Strings(adj[id]) // deterministic "via" when several paths tie
+	}
+
+	paths := map[string][]string{targetID: {targetID}}
+	frontier := []string{targetID}
+	for hop := 0; hop < maxHops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			for _, n := range adj[id] {
+				if _, seen := paths[n]; seen {
+					continue
+				}
+				path := append(append([]string(nil), paths[id]...), n)
+				paths[n] = path
+				next = append(next, n)
+			}
+		}
+		frontier = next
+	}
+	return paths
+}
+
+// touchedFiles maps each bead to the files its correlated commits changed.
+func touchedFiles(report *correlation.HistoryReport) map[string][]string {
+	files := make(map[string][]string)
+	if report == nil {
+		return files
+	}
+	for id, h := range report.Histories {
+		seen := make(map[string]bool)
+		for _, c := range h.Commits {
+			for _, f := range c.Files {
+				if f.Path != "" && !seen[f.Path] {
+					seen[f.Path] = true
+					files[id] = append(files[id], f.Path)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// overlap returns the sorted values in both a and b and their Jaccard similarity.
+func overlap(a, b []string) ([]string, float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, 0
+	}
+	inA := make(map[string]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+	}
+	union := len(inA)
+	seen := make(map[string]bool, len(b))
+	var shared []string
+	for _, v := range b {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		if inA[v] {
+			shared = append(shared, v)
+		} else {
+			union++
+		}
+	}
+	sort.Strings(shared)
+	return shared, float64(len(shared)) / float64(union)
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
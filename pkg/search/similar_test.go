@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func similarTestIssues() []model.Issue {
+	dep := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "A", Title: "Login retry loop never backs off", Labels: []string{"auth", "backend"}, Status: model.StatusOpen},
+		{ID: "B", Title: "Retry loop on login hammers the server", Labels: []string{"auth"}, Status: model.StatusOpen},
+		{ID: "C", Title: "Session store migration", Status: model.StatusOpen, Dependencies: dep("C", "A")},
+		{ID: "D", Title: "Dashboard colours", Status: model.StatusOpen, Dependencies: dep("D", "C")},
+		{ID: "E", Title: "Unrelated changelog chore", Status: model.StatusOpen},
+		{ID: "F", Title: "Old login retry bug", Labels: []string{"auth"}, Status: model.StatusClosed},
+	}
+}
+
+func TestFindSimilarIssues_CombinesSignals(t *testing.T) {
+	issues := similarTestIssues()
+	vectors, err := NewSimilarityIndex(context.Background(), NewHashEmbedder(DefaultEmbeddingDim), issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"A": {Commits: []correlation.CorrelatedCommit{{SHA: "1", Files: []correlation.FileChange{{Path: "auth/retry.go"}, {Path: "auth/client.go"}}}}},
+		"E": {Commits: []correlation.CorrelatedCommit{{SHA: "2", Files: []correlation.FileChange{{Path: "auth/retry.go"}, {Path: "CHANGELOG.md"}}}}},
+	}}
+	opts := DefaultSimilarOptions()
+	opts.Vectors = vectors
+	opts.History = history
+
+	got, err := FindSimilarIssues("A", issues, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[string]SimilarIssue)
+	var ids []string
+	for _, s := range got {
+		byID[s.IssueID] = s
+		ids = append(ids, s.IssueID)
+	}
+
+	// B shares text and a label; C is directly linked and left out; F is closed.
+	if len(got) == 0 || got[0].IssueID != "B" {
+		t.Fatalf("expected B first, got %v", ids)
+	}
+	if _, ok := byID["C"]; ok {
+		t.Errorf("already-linked C should not be suggested: %v", ids)
+	}
+	if _, ok := byID["F"]; ok {
+		t.Errorf("closed F should be excluded by default: %v", ids)
+	}
+	b := byID["B"]
+	if b.Components[SimilarEmbedding] <= 0 || !reflect.DeepEqual(b.SharedLabels, []string{"auth"}) {
+		t.Errorf("unexpected evidence for B: %+v", b)
+	}
+	if !strings.Contains(b.Explanation(), "similar text") || !strings.Contains(b.Explanation(), "shares label auth") {
+		t.Errorf("unexpected explanation %q", b.Explanation())
+	}
+
+	// D is two hops away through C.
+	d, ok := byID["D"]
+	if !ok || !reflect.DeepEqual(d.Path, []string{"A", "C", "D"}) || d.Components[SimilarGraph] != 1 {
+		t.Fatalf("expected D via C, got %+v (ok=%v)", d, ok)
+	}
+	if !strings.Contains(d.Explanation(), "2 hops away via C") {
+		t.Errorf("unexpected explanation %q", d.Explanation())
+	}
+
+	// E only shares a touched file.
+	e, ok := byID["E"]
+	if !ok || !reflect.DeepEqual(e.SharedFiles, []string{"auth/retry.go"}) {
+		t.Fatalf("expected E via a co-changed file, got %+v (ok=%v)", e, ok)
+	}
+	if want := 1.0 / 3; e.Components[SimilarCoChange] != want {
+		t.Errorf("co-change jaccard = %v, want %v", e.Components[SimilarCoChange], want)
+	}
+
+	opts.IncludeClosed = true
+	got, _ = FindSimilarIssues("A", issues, opts)
+	found := false
+	for _, s := range got {
+		found = found || s.IssueID == "F"
+	}
+	if !found {
+		t.Errorf("expected closed F when included, got %+v", got)
+	}
+	opts.Limit = 1
+	if got, _ := FindSimilarIssues("A", issues, opts); len(got) != 1 {
+		t.Errorf("expected the limit to apply, got %d", len(got))
+	}
+}
+
+func TestFindSimilarIssues_WithoutOptionalSignals(t *testing.T) {
+	issues := similarTestIssues()
+	got, err := FindSimilarIssues("A", issues, DefaultSimilarOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range got {
+		if _, ok := s.Components[SimilarEmbedding]; ok {
+			t.Errorf("no vectors were given, got an embedding component for %s", s.IssueID)
+		}
+	}
+	if len(got) != 2 || got[0].IssueID != "D" || got[1].IssueID != "B" {
+		t.Errorf("expected graph and label matches [D B], got %+v", got)
+	}
+
+	if _, err := FindSimilarIssues("missing", issues, DefaultSimilarOptions()); err == nil {
+		t.Error("expected an error for an unknown issue")
+	}
+}
@@ -0,0 +1,414 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SearchSource names where a lexical match was found.
+type SearchSource string
+
+const (
+	SourceIssue   SearchSource = "issue"   // title, labels and description
+	SourceComment SearchSource = "comment" // one document per comment
+	SourceCommit  SearchSource = "commit"  // messages of correlated commits
+	SourceFile    SearchSource = "file"    // paths touched by correlated commits
+)
+
+// AllSearchSources lists every source in display order.
+var AllSearchSources = []SearchSource{SourceIssue, SourceComment, SourceCommit, SourceFile}
+
+// DefaultSearchSources are the sources available without reading git history.
+var DefaultSearchSources = []SearchSource{SourceIssue, SourceComment}
+
+// ParseSearchSources parses a comma-separated source list; "all" selects every source.
+func ParseSearchSources(s string) ([]SearchSource, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return DefaultSearchSources, nil
+	}
+	if s == "all" {
+		return AllSearchSources, nil
+	}
+	seen := make(map[SearchSource]bool)
+	var out []SearchSource
+	for _, part := range strings.Split(s, ",") {
+		src := SearchSource(strings.TrimSuffix(strings.TrimSpace(part), "s"))
+		switch src {
+		case SourceIssue, SourceComment, SourceCommit, SourceFile:
+		default:
+			return nil, fmt.Errorf("invalid search source %q (expected issue, comment, commit, file or all)", strings.TrimSpace(part))
+		}
+		if !seen[src] {
+			seen[src] = true
+			out = append(out, src)
+		}
+	}
+	return out, nil
+}
+
+// SourceIndexPath returns where the BM25 index over sources is persisted. Each
+// source combination has its own file, so switching --search-sources back and forth
+// doesn't rebuild the index each time. The default sources use DefaultLexicalIndexPath.
+func SourceIndexPath(projectDir string, sources []SearchSource) string {
+	path := DefaultLexicalIndexPath(projectDir)
+	key := sourcesKey(sources)
+	if key == sourcesKey(DefaultSearchSources) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(path), "lexical-"+key+".bvlx")
+}
+
+// sourcesKey names a source set independent of order and duplicates.
+func sourcesKey(sources []SearchSource) string {
+	selected := make(map[SearchSource]bool, len(sources))
+	for _, src := range sources {
+		selected[src] = true
+	}
+	var names []string
+	for _, src := range AllSearchSources {
+		if selected[src] {
+			names = append(names, string(src))
+		}
+	}
+	return strings.Join(names, "-")
+}
+
+// NeedsHistory reports whether sources include anything derived from git history.
+func NeedsHistory(sources []SearchSource) bool {
+	for _, src := range sources {
+		if src == SourceCommit || src == SourceFile {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceDocument is one searchable piece of text owned by an issue.
+type SourceDocument struct {
+	IssueID string
+	Source  SearchSource
+	Ref     string // comment author, short commit SHA or file path
+
+	// Title and Labels are only set for SourceIssue.
+	Title  string
+	Labels []string
+	// Text is the description for SourceIssue, otherwise the comment, commit message or path.
+	Text string
+}
+
+// SourceDocumentsFromIssues returns an issue document and one document per comment for
+// every issue with an ID.
+func SourceDocumentsFromIssues(issues []model.Issue) []SourceDocument {
+	var docs []SourceDocument
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		docs = append(docs, SourceDocument{
+			IssueID: issue.ID,
+			Source:  SourceIssue,
+			Title:   issue.Title,
+			Labels:  issue.Labels,
+			Text:    issue.Description,
+		})
+		for _, c := range issue.Comments {
+			if c == nil || strings.TrimSpace(c.Text) == "" {
+				continue
+			}
+			docs = append(docs, SourceDocument{IssueID: issue.ID, Source: SourceComment, Ref: c.Author, Text: c.Text})
+		}
+	}
+	return docs
+}
+
+// SourceDocumentsFromHistory returns a document per correlated commit message and per
+// distinct file path those commits touched, attributed to the bead they correlate with.
+func SourceDocumentsFromHistory(report *correlation.HistoryReport) []SourceDocument {
+	if report == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(report.Histories))
+	for id := range report.Histories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var docs []SourceDocument
+	for _, id := range ids {
+		seenCommit := make(map[string]bool)
+		seenPath := make(map[string]bool)
+		for _, c := range report.Histories[id].Commits {
+			if seenCommit[c.SHA] {
+				continue
+			}
+			seenCommit[c.SHA] = true
+			ref := c.ShortSHA
+			if ref == "" && len(c.SHA) > 7 {
+				ref = c.SHA[:7]
+			}
+			if strings.TrimSpace(c.Message) != "" {
+				docs = append(docs, SourceDocument{IssueID: id, Source: SourceCommit, Ref: ref, Text: c.Message})
+			}
+			for _, f := range c.Files {
+				if f.Path == "" || seenPath[f.Path] {
+					continue
+				}
+				seenPath[f.Path] = true
+				docs = append(docs, SourceDocument{IssueID: id, Source: SourceFile, Ref: f.Path, Text: f.Path})
+			}
+		}
+	}
+	return docs
+}
+
+// FilterSourceDocuments keeps the documents whose source is listed.
+func FilterSourceDocuments(docs []SourceDocument, sources []SearchSource) []SourceDocument {
+	keep := make(map[SearchSource]bool, len(sources))
+	for _, src := range sources {
+		keep[src] = true
+	}
+	out := make([]SourceDocument, 0, len(docs))
+	for _, doc := range docs {
+		if keep[doc.Source] {
+			out = append(out, doc)
+		}
+	}
+	return out
+}
+
+func (d SourceDocument) lexical(key string) LexicalDocument {
+	doc := LexicalDocument{Key: key}
+	switch d.Source {
+	case SourceIssue:
+		doc.ID, doc.Title, doc.Labels, doc.Description = d.IssueID, d.Title, d.Labels, d.Text
+	case SourceComment:
+		doc.Comments = []string{d.Text}
+	case SourceCommit:
+		doc.Commits = []string{d.Text}
+	case SourceFile:
+		doc.Paths = []string{d.Text}
+	}
+	return doc
+}
+
+// SourceMatch is one document that matched a query.
+type SourceMatch struct {
+	Source  SearchSource `json:"source"`
+	Ref     string       `json:"ref,omitempty"`
+	Snippet string       `json:"snippet,omitempty"`
+	Score   float64      `json:"score"`
+}
+
+// Describe returns a short label such as "matched in commit abc123".
+func (m SourceMatch) Describe() string {
+	switch {
+	case m.Source == SourceIssue:
+		return "matched in issue"
+	case m.Source == SourceComment && m.Ref != "":
+		return "matched in comment by " + m.Ref
+	case m.Ref != "":
+		return fmt.Sprintf("matched in %s %s", m.Source, m.Ref)
+	default:
+		return "matched in " + string(m.Source)
+	}
+}
+
+// SourceResult is an issue with the documents that matched it, best first.
+type SourceResult struct {
+	IssueID string
+	Score   float64
+	Matches []SourceMatch
+}
+
+// SourceSearchResults drops the match details for rank fusion.
+func SourceSearchResults(results []SourceResult) []SearchResult {
+	out := make([]SearchResult, len(results))
+	for i, r := range results {
+		out[i] = SearchResult{IssueID: r.IssueID, Score: r.Score}
+	}
+	return out
+}
+
+// SourceIndex is a BM25 index over source documents whose hits are grouped back to the
+// owning issue.
+type SourceIndex struct {
+	lexical *LexicalIndex
+	docs    map[string]SourceDocument // lexical key -> document
+}
+
+// sourceLexicalDocuments keys each document by issue, source and ordinal so keys are
+// stable for unchanged data.
+func sourceLexicalDocuments(docs []SourceDocument) ([]LexicalDocument, map[string]SourceDocument) {
+	lexical := make([]LexicalDocument, 0, len(docs))
+	byKey := make(map[string]SourceDocument, len(docs))
+	ordinal := make(map[string]int)
+	for _, doc := range docs {
+		if doc.IssueID == "" {
+			continue
+		}
+		group := doc.IssueID + "\x1f" + string(doc.Source)
+		key := group + "\x1f" + strconv.Itoa(ordinal[group])
+		ordinal[group]++
+		lexical = append(lexical, doc.lexical(key))
+		byKey[key] = doc
+	}
+	return lexical, byKey
+}
+
+// NewSourceIndex indexes docs. Documents without an issue ID are skipped.
+func NewSourceIndex(docs []SourceDocument) *SourceIndex {
+	lexical, byKey := sourceLexicalDocuments(docs)
+	return &SourceIndex{lexical: NewLexicalIndex(lexical), docs: byKey}
+}
+
+// LoadOrBuildSourceIndex is LoadOrBuildLexicalIndex for source documents.
+func LoadOrBuildSourceIndex(path string, docs []SourceDocument) (*SourceIndex, bool, error) {
+	lexical, byKey := sourceLexicalDocuments(docs)
+	idx, loaded, err := LoadOrBuildLexicalIndex(path, lexical)
+	return &SourceIndex{lexical: idx, docs: byKey}, loaded, err
+}
+
+// SourceDataHash identifies the indexed text of docs, like LexicalDataHash.
+func SourceDataHash(docs []SourceDocument) string {
+	lexical, _ := sourceLexicalDocuments(docs)
+	return LexicalDataHash(lexical)
+}
+
+// Lexical returns the underlying BM25 index.
+func (s *SourceIndex) Lexical() *LexicalIndex {
+	return s.lexical
+}
+
+// Size returns the number of indexed documents.
+func (s *SourceIndex) Size() int {
+	return s.lexical.Size()
+}
+
+// Search ranks issues by their matching documents, best first. limit <= 0 returns every
+// matching issue.
+func (s *SourceIndex) Search(query string, limit int) []SourceResult {
+	return s.group(query, false, limit)
+}
+
+// SearchPrefix is Search with the last word treated as a prefix.
+func (s *SourceIndex) SearchPrefix(query string, limit int) []SourceResult {
+	return s.group(query, true, limit)
+}
+
+// group folds document hits into their issues. The best document counts fully and each
+// further one half as much as the previous, so an issue discussed in several places
+// beats one with a single mention without letting long threads dominate.
+func (s *SourceIndex) group(query string, prefixLast bool, limit int) []SourceResult {
+	if s == nil {
+		return nil
+	}
+	clauses := parseLexicalQuery(query, prefixLast)
+	hits := s.lexical.search(clauses, 0)
+
+	byIssue := make(map[string]*SourceResult)
+	var order []string
+	for _, hit := range hits {
+		doc, ok := s.docs[hit.IssueID]
+		if !ok {
+			continue
+		}
+		r := byIssue[doc.IssueID]
+		if r == nil {
+			r = &SourceResult{IssueID: doc.IssueID}
+			byIssue[doc.IssueID] = r
+			order = append(order, doc.IssueID)
+		}
+		r.Score += math.Ldexp(hit.Score, -len(r.Matches)) // hits arrive best first
+		r.Matches = append(r.Matches, SourceMatch{
+			Source:  doc.Source,
+			Ref:     doc.Ref,
+			Snippet: matchSnippet(doc, clauses),
+			Score:   hit.Score,
+		})
+	}
+
+	results := make([]SourceResult, 0, len(order))
+	for _, id := range order {
+		results = append(results, *byIssue[id])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].IssueID < results[j].IssueID
+		}
+		return results[i].Score > results[j].Score
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+const (
+	snippetBefore = 5
+	snippetAfter  = 10
+)
+
+// matchSnippet returns a few words around the first query match in the document, or the
+// start of its text if no single word matches (an ID phrase, for example).
+func matchSnippet(doc SourceDocument, clauses []lexicalClause) string {
+	texts := []string{doc.Text}
+	if doc.Source == SourceIssue {
+		texts = []string{doc.Title, doc.Text, strings.Join(doc.Labels, " ")}
+	}
+	for _, text := range texts {
+		words := strings.Fields(text)
+		for i, word := range words {
+			if wordMatches(word, clauses) {
+				return snippetWindow(words, i)
+			}
+		}
+	}
+	for _, text := range texts {
+		if words := strings.Fields(text); len(words) > 0 {
+			return snippetWindow(words, 0)
+		}
+	}
+	return ""
+}
+
+func wordMatches(word string, clauses []lexicalClause) bool {
+	for _, w := range splitLexicalWords(word) {
+		stem := porterStem(w)
+		for _, c := range clauses {
+			if c.prefix {
+				p := c.terms[0]
+				if strings.HasPrefix(w, p) || strings.HasPrefix(stem, porterStem(p)) {
+					return true
+				}
+				continue
+			}
+			for _, term := range c.terms {
+				if stem == term {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func snippetWindow(words []string, at int) string {
+	start := max(at-snippetBefore, 0)
+	end := min(at+snippetAfter+1, len(words))
+	snippet := strings.Join(words[start:end], " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -0,0 +1,157 @@
+package search
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func sourceTestDocs() []SourceDocument {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Flaky sync", Description: "Sync sometimes stalls.", Comments: []*model.Comment{
+			{Author: "sam", Text: "I think the retry bug is back: the backoff never resets after a timeout, so every later request waits for the maximum delay."},
+			{Author: "kim", Text: "Agreed, retry counts look wrong."},
+		}},
+		{ID: "bv-2", Title: "Retry button styling", Description: "Make the button blue."},
+		{ID: "bv-3", Title: "Export speedup"},
+	}
+	report := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"bv-3": {Commits: []correlation.CorrelatedCommit{
+			{SHA: "abc1234def", ShortSHA: "abc1234", Message: "Stream the exporter instead of buffering", Files: []correlation.FileChange{
+				{Path: "pkg/export/stream_writer.go"}, {Path: "pkg/export/stream_writer_test.go"},
+			}},
+		}},
+	}}
+	return append(SourceDocumentsFromIssues(issues), SourceDocumentsFromHistory(report)...)
+}
+
+func sourceResultIDs(results []SourceResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.IssueID
+	}
+	return ids
+}
+
+func TestSourceIndex_GroupsMatchesByIssue(t *testing.T) {
+	idx := NewSourceIndex(sourceTestDocs())
+
+	// Two comments mention the retry bug; they fold into one result with both matches.
+	results := idx.Search("retry", 0)
+	if got := sourceResultIDs(results); !reflect.DeepEqual(got, []string{"bv-2", "bv-1"}) {
+		t.Fatalf("Search(retry) = %v, want [bv-2 bv-1]", got)
+	}
+	if m := results[0].Matches; len(m) != 1 || m[0].Source != SourceIssue {
+		t.Errorf("expected a single issue match for bv-2, got %+v", m)
+	}
+	comments := results[1].Matches
+	if len(comments) != 2 || comments[0].Source != SourceComment || comments[1].Source != SourceComment {
+		t.Fatalf("expected two comment matches for bv-1, got %+v", comments)
+	}
+	if want := comments[0].Score + comments[1].Score/2; results[1].Score != want {
+		t.Errorf("grouped score = %v, want %v", results[1].Score, want)
+	}
+
+	// A phrase only found in one comment reports that comment and a snippet around it.
+	results = idx.Search(`"retry bug"`, 0)
+	if len(results) != 1 || results[0].IssueID != "bv-1" {
+		t.Fatalf(`Search("retry bug") = %v`, sourceResultIDs(results))
+	}
+	m := results[0].Matches[0]
+	if m.Describe() != "matched in comment by sam" || !strings.Contains(m.Snippet, "retry bug") {
+		t.Errorf("unexpected match %q / %q", m.Describe(), m.Snippet)
+	}
+	if !strings.HasSuffix(m.Snippet, "…") {
+		t.Errorf("expected a truncated snippet, got %q", m.Snippet)
+	}
+}
+
+func TestSourceIndex_CommitsAndPaths(t *testing.T) {
+	idx := NewSourceIndex(sourceTestDocs())
+
+	results := idx.Search("buffering", 0)
+	if len(results) != 1 || results[0].IssueID != "bv-3" {
+		t.Fatalf("Search(buffering) = %v, want [bv-3]", sourceResultIDs(results))
+	}
+	if got := results[0].Matches[0].Describe(); got != "matched in commit abc1234" {
+		t.Errorf("Describe() = %q", got)
+	}
+
+	// Path segments are searchable, prefixes included; the path is the reference.
+	results = idx.SearchPrefix("export writ", 0)
+	if len(results) != 1 || results[0].IssueID != "bv-3" {
+		t.Fatalf("SearchPrefix(export writ) = %v, want [bv-3]", sourceResultIDs(results))
+	}
+	var files []string
+	for _, m := range results[0].Matches {
+		if m.Source == SourceFile {
+			files = append(files, m.Ref)
+		}
+	}
+	if len(files) != 2 {
+		t.Errorf("expected both touched files to match, got %+v", results[0].Matches)
+	}
+
+	// Leaving the history sources out drops those matches.
+	only := NewSourceIndex(FilterSourceDocuments(sourceTestDocs(), DefaultSearchSources))
+	if got := only.Search("buffering", 0); len(got) != 0 {
+		t.Errorf("expected no commit matches without the commit source, got %v", sourceResultIDs(got))
+	}
+}
+
+func TestLoadOrBuildSourceIndex(t *testing.T) {
+	docs := sourceTestDocs()
+	path := filepath.Join(t.TempDir(), "lexical.bvlx")
+	idx, loaded, err := LoadOrBuildSourceIndex(path, docs)
+	if err != nil || loaded {
+		t.Fatalf("expected a fresh build, loaded=%v err=%v", loaded, err)
+	}
+	again, loaded, err := LoadOrBuildSourceIndex(path, docs)
+	if err != nil || !loaded {
+		t.Fatalf("expected the saved index to be reused, loaded=%v err=%v", loaded, err)
+	}
+	if want, got := idx.Search("retry", 0), again.Search("retry", 0); !reflect.DeepEqual(want, got) {
+		t.Errorf("loaded index answers differently:\n%+v\n%+v", want, got)
+	}
+
+	// Switching sources changes the indexed documents and forces a rebuild.
+	if _, loaded, _ := LoadOrBuildSourceIndex(path, FilterSourceDocuments(docs, DefaultSearchSources)); loaded {
+		t.Error("expected a rebuild for a different source selection")
+	}
+}
+
+func TestParseSearchSources(t *testing.T) {
+	if got, err := ParseSearchSources(""); err != nil || !reflect.DeepEqual(got, DefaultSearchSources) {
+		t.Errorf("empty = %v, %v", got, err)
+	}
+	if got, err := ParseSearchSources("all"); err != nil || !NeedsHistory(got) || len(got) != 4 {
+		t.Errorf("all = %v, %v", got, err)
+	}
+	got, err := ParseSearchSources("Commits, issue,commit")
+	if err != nil || !reflect.DeepEqual(got, []SearchSource{SourceCommit, SourceIssue}) {
+		t.Errorf("list = %v, %v", got, err)
+	}
+	if _, err := ParseSearchSources("wiki"); err == nil {
+		t.Error("expected an error for an unknown source")
+	}
+}
+
+func TestSourceIndexPath(t *testing.T) {
+	if got := SourceIndexPath("/p", DefaultSearchSources); got != DefaultLexicalIndexPath("/p") {
+		t.Errorf("default sources = %q", got)
+	}
+	if got := SourceIndexPath("/p", []SearchSource{SourceComment, SourceIssue}); got != DefaultLexicalIndexPath("/p") {
+		t.Errorf("reordered default sources = %q", got)
+	}
+	want := filepath.Join("/p", ".bv", "semantic", "lexical-issue-commit.bvlx")
+	if got := SourceIndexPath("/p", []SearchSource{SourceCommit, SourceIssue}); got != want {
+		t.Errorf("SourceIndexPath = %q, want %q", got, want)
+	}
+	if SourceIndexPath("/p", AllSearchSources) == want {
+		t.Error("expected each source combination to get its own file")
+	}
+}
@@ -0,0 +1,208 @@
+package search
+
+// porterStem reduces a lowercase ASCII word to its Porter stem (M.F. Porter, 1980),
+// so "authentication", "authenticate" and "authenticated" share the term "authent".
+// Short words and words with non a-z bytes (IDs, numbers, non-English) are returned as-is.
+func porterStem(word string) string {
+	if len(word) < 3 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	p := &porterWord{b: []byte(word)}
+	p.step1a()
+	p.step1b()
+	p.step1c()
+	p.step2()
+	p.step3()
+	p.step4()
+	p.step5()
+	return string(p.b)
+}
+
+type porterWord struct {
+	b []byte
+}
+
+func (p *porterWord) isCons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.isCons(i-1)
+	}
+	return true
+}
+
+// measure counts the VC sequences in b[:n].
+func (p *porterWord) measure(n int) int {
+	m, i := 0, 0
+	for i < n && p.isCons(i) {
+		i++
+	}
+	for i < n {
+		for i < n && !p.isCons(i) {
+			i++
+		}
+		if i >= n {
+			break
+		}
+		for i < n && p.isCons(i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func (p *porterWord) hasVowel(n int) bool {
+	for i := 0; i < n; i++ {
+		if !p.isCons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *porterWord) doubleCons(n int) bool {
+	return n >= 2 && p.b[n-1] == p.b[n-2] && p.isCons(n-1)
+}
+
+// cvc reports whether b[:n] ends consonant-vowel-consonant, the last not w, x or y.
+func (p *porterWord) cvc(n int) bool {
+	if n < 3 || !p.isCons(n-3) || p.isCons(n-2) || !p.isCons(n-1) {
+		return false
+	}
+	switch p.b[n-1] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+func (p *porterWord) hasSuffix(s string) bool {
+	return len(p.b) >= len(s) && string(p.b[len(p.b)-len(s):]) == s
+}
+
+func (p *porterWord) setSuffix(n int, repl string) {
+	p.b = append(p.b[:n], repl...)
+}
+
+type porterRule struct {
+	suffix, repl string
+}
+
+// applyRules replaces the first (longest) matching suffix when the remaining stem
+// has a measure above minMeasure. Only one rule is considered, as in the reference
+// implementation.
+func (p *porterWord) applyRules(rules []porterRule, minMeasure int) {
+	for _, r := range rules {
+		if !p.hasSuffix(r.suffix) {
+			continue
+		}
+		n := len(p.b) - len(r.suffix)
+		if p.measure(n) > minMeasure {
+			p.setSuffix(n, r.repl)
+		}
+		return
+	}
+}
+
+func (p *porterWord) step1a() {
+	switch {
+	case p.hasSuffix("sses"), p.hasSuffix("ies"):
+		p.b = p.b[:len(p.b)-2]
+	case p.hasSuffix("ss"):
+	case p.hasSuffix("s"):
+		p.b = p.b[:len(p.b)-1]
+	}
+}
+
+func (p *porterWord) step1b() {
+	if p.hasSuffix("eed") {
+		if n := len(p.b) - 3; p.measure(n) > 0 {
+			p.b = p.b[:len(p.b)-1]
+		}
+		return
+	}
+	var n int
+	switch {
+	case p.hasSuffix("ed") && p.hasVowel(len(p.b)-2):
+		n = len(p.b) - 2
+	case p.hasSuffix("ing") && p.hasVowel(len(p.b)-3):
+		n = len(p.b) - 3
+	default:
+		return
+	}
+	p.b = p.b[:n]
+	switch {
+	case p.hasSuffix("at"), p.hasSuffix("bl"), p.hasSuffix("iz"):
+		p.b = append(p.b, 'e')
+	case p.doubleCons(n):
+		switch p.b[n-1] {
+		case 'l', 's', 'z':
+		default:
+			p.b = p.b[:n-1]
+		}
+	case p.measure(n) == 1 && p.cvc(n):
+		p.b = append(p.b, 'e')
+	}
+}
+
+func (p *porterWord) step1c() {
+	if p.hasSuffix("y") && p.hasVowel(len(p.b)-1) {
+		p.b[len(p.b)-1] = 'i'
+	}
+}
+
+var porterStep2 = []porterRule{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"abli", "able"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+}
+
+var porterStep3 = []porterRule{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var porterStep4 = []porterRule{
+	{"al", ""}, {"ance", ""}, {"ence", ""}, {"er", ""}, {"ic", ""},
+	{"able", ""}, {"ible", ""}, {"ant", ""}, {"ement", ""}, {"ment", ""},
+	{"ent", ""}, {"ou", ""}, {"ism", ""}, {"ate", ""}, {"iti", ""},
+	{"ous", ""}, {"ive", ""}, {"ize", ""},
+}
+
+func (p *porterWord) step2() { p.applyRules(porterStep2, 0) }
+func (p *porterWord) step3() { p.applyRules(porterStep3, 0) }
+
+func (p *porterWord) step4() {
+	// "-ion" only goes after s or t, so handle it ahead of the table.
+	if p.hasSuffix("ion") {
+		n := len(p.b) - 3
+		if n > 0 && (p.b[n-1] == 's' || p.b[n-1] == 't') {
+			if p.measure(n) > 1 {
+				p.b = p.b[:n]
+			}
+			return
+		}
+	}
+	p.applyRules(porterStep4, 1)
+}
+
+func (p *porterWord) step5() {
+	if p.hasSuffix("e") {
+		n := len(p.b) - 1
+		if m := p.measure(n); m > 1 || (m == 1 && !p.cvc(n)) {
+			p.b = p.b[:n]
+		}
+	}
+	if n := len(p.b); p.measure(n) > 1 && p.doubleCons(n) && p.b[n-1] == 'l' {
+		p.b = p.b[:n-1]
+	}
+}
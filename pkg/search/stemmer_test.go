@@ -0,0 +1,46 @@
+package search
+
+import "testing"
+
+func TestPorterStem(t *testing.T) {
+	// Expected stems from Porter's reference vocabulary.
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"troubled":       "troubl",
+		"sized":          "size",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"rational":       "ration",
+		"generalization": "gener",
+		"hopeful":        "hope",
+		"goodness":       "good",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controll":       "control",
+		"rate":           "rate",
+		"authentication": "authent",
+		"authenticated":  "authent",
+		"running":        "run",
+		// Left alone: too short, digits, non-ASCII.
+		"is":    "is",
+		"v2":    "v2",
+		"naïve": "naïve",
+	}
+	for word, want := range tests {
+		if got := porterStem(word); got != want {
+			t.Errorf("porterStem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
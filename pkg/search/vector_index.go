@@ -19,7 +19,7 @@ import (
 
 const (
 	vectorIndexMagic   = "BVVI"
-	vectorIndexVersion = uint16(1)
+	vectorIndexVersion = uint16(2) // v2 appends the optional HNSW graph; v1 files still load
 )
 
 type ContentHash [32]byte
@@ -57,6 +57,9 @@ type VectorIndex struct {
 	entries  map[string]VectorEntry
 	idsCache []string
 	idsDirty bool
+
+	hnswCfg HNSWConfig
+	graph   *hnswGraph // Built once the index reaches hnswCfg.ExactThreshold
 }
 
 func NewVectorIndex(dim int) *VectorIndex {
@@ -67,9 +70,63 @@ func NewVectorIndex(dim int) *VectorIndex {
 		Dim:      dim,
 		entries:  make(map[string]VectorEntry),
 		idsDirty: true,
+		hnswCfg:  DefaultHNSWConfig(),
+	}
+}
+
+// SetHNSWConfig changes the ANN knobs. A graph built with a different M or
+// EfConstruction is discarded and rebuilt on the next search or save.
+func (idx *VectorIndex) SetHNSWConfig(cfg HNSWConfig) {
+	cfg = cfg.Normalized()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.hnswCfg = cfg
+	if idx.graph != nil && (cfg.ExactThreshold < 0 || idx.graph.m != cfg.M || idx.graph.efConstruction != cfg.EfConstruction) {
+		idx.graph = nil
 	}
 }
 
+func (idx *VectorIndex) HNSWConfig() HNSWConfig {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.hnswCfg
+}
+
+// Approximate reports whether SearchTopK uses the HNSW graph rather than an exact scan.
+func (idx *VectorIndex) Approximate() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.wantsGraphLocked()
+}
+
+func (idx *VectorIndex) wantsGraphLocked() bool {
+	return idx.hnswCfg.ExactThreshold >= 0 && len(idx.entries) >= idx.hnswCfg.ExactThreshold
+}
+
+// ensureGraph builds the HNSW graph from the current entries when it is wanted but missing.
+func (idx *VectorIndex) ensureGraph() {
+	idx.mu.RLock()
+	ready := idx.graph != nil || !idx.wantsGraphLocked()
+	idx.mu.RUnlock()
+	if ready {
+		return
+	}
+
+	ids := idx.sortedIDs()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.graph != nil || !idx.wantsGraphLocked() {
+		return
+	}
+	graph := newHNSWGraph(idx.hnswCfg.M, idx.hnswCfg.EfConstruction)
+	for _, id := range ids {
+		if entry, ok := idx.entries[id]; ok {
+			graph.insert(id, entry.Vector)
+		}
+	}
+	idx.graph = graph
+}
+
 func LoadVectorIndex(path string) (*VectorIndex, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -91,7 +148,7 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
 		return nil, fmt.Errorf("read version: %w", err)
 	}
-	if version != vectorIndexVersion {
+	if version != 1 && version != vectorIndexVersion {
 		return nil, fmt.Errorf("unsupported version %d", version)
 	}
 
@@ -114,6 +171,7 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 	}
 
 	idx := NewVectorIndex(int(dimU32))
+	ids := make([]string, 0, count)
 	for i := uint32(0); i < count; i++ {
 		var idLen uint16
 		if err := binary.Read(r, binary.LittleEndian, &idLen); err != nil {
@@ -146,12 +204,24 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 		if err := idx.Upsert(issueID, ch, vec); err != nil {
 			return nil, err
 		}
+		ids = append(ids, issueID)
+	}
+
+	if version >= 2 {
+		graph, err := readHNSWGraph(r, idx, ids)
+		if err != nil {
+			return nil, err
+		}
+		idx.graph = graph
 	}
 
 	return idx, nil
 }
 
 func (idx *VectorIndex) Save(path string) error {
+	// Build the graph first so the next process can load it instead of rebuilding.
+	idx.ensureGraph()
+
 	// Acquire sorted IDs before locking to avoid deadlock (sortedIDs needs Write lock if dirty)
 	ids := idx.sortedIDs()
 
@@ -220,6 +290,10 @@ func (idx *VectorIndex) Save(path string) error {
 		}
 	}
 
+	if err := writeHNSWGraph(w, idx.graph, ids); err != nil {
+		return err
+	}
+
 	if err := w.Flush(); err != nil {
 		return fmt.Errorf("flush: %w", err)
 	}
@@ -268,6 +342,9 @@ func (idx *VectorIndex) Upsert(issueID string, hash ContentHash, vec []float32)
 	if !exists {
 		idx.idsDirty = true
 	}
+	if idx.graph != nil {
+		idx.graph.insert(issueID, cp)
+	}
 	return nil
 }
 
@@ -280,6 +357,9 @@ func (idx *VectorIndex) Remove(issueID string) {
 	}
 	delete(idx.entries, issueID)
 	idx.idsDirty = true
+	if idx.graph != nil {
+		idx.graph.remove(issueID)
+	}
 }
 
 func (idx *VectorIndex) Get(issueID string) (VectorEntry, bool) {
@@ -334,6 +414,22 @@ func (idx *VectorIndex) SearchTopK(query []float32, k int) ([]SearchResult, erro
 		return nil, fmt.Errorf("query dim mismatch: %d != %d", len(query), idx.Dim)
 	}
 
+	idx.ensureGraph()
+	idx.mu.RLock()
+	if idx.graph != nil && idx.wantsGraphLocked() {
+		defer idx.mu.RUnlock()
+		collector := topk.New[SearchResult](k, func(a, b SearchResult) bool {
+			return a.IssueID < b.IssueID
+		})
+		for _, c := range idx.graph.search(query, max(idx.hnswCfg.EfSearch, k)) {
+			node := idx.graph.nodes[c.slot]
+			score := dotFloat32(query, node.vec)
+			collector.Add(SearchResult{IssueID: node.id, Score: score}, score)
+		}
+		return collector.Results(), nil
+	}
+	idx.mu.RUnlock()
+
 	// sortedIDs now handles its own locking safely
 	ids := idx.sortedIDs()
 
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notify"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AlertsNotifiedMsg reports a background delivery of alerts to the sinks in
+// .bv/notify.yaml
+type AlertsNotifiedMsg struct {
+	Report *notify.Report
+	Err    error
+}
+
+// NotifyAlertsCmd delivers alerts to the sinks in .bv/notify.yaml, so live
+// reloads notify like CI runs do. Dedup and cooldown state is shared with
+// --check-drift --notify.
+func NotifyAlertsCmd(projectDir string, alerts []drift.Alert) tea.Cmd {
+	if len(alerts) == 0 {
+		return nil
+	}
+	alerts = append([]drift.Alert(nil), alerts...)
+	return func() tea.Msg {
+		cfg, err := notify.LoadConfig(projectDir)
+		if err != nil {
+			return AlertsNotifiedMsg{Err: err}
+		}
+		if !cfg.HasSinks() {
+			return nil
+		}
+		report, err := notify.NewDispatcher(cfg, projectDir).Notify("tui", alerts)
+		return AlertsNotifiedMsg{Report: report, Err: err}
+	}
+}
+
+// EnableAlertNotifications lets the TUI deliver alerts to the sinks in
+// .bv/notify.yaml. It is off unless the user passes --notify, since the
+// config ships with the repository and may run commands.
+func (m *Model) EnableAlertNotifications() {
+	m.notifyAlerts = true
+}
+
+// notifyAlertsCmd notifies about the current alerts from the working directory,
+// which is where computeAlerts reads .bv/drift.yaml
+func (m Model) notifyAlertsCmd() tea.Cmd {
+	if !m.notifyAlerts {
+		return nil
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	return NotifyAlertsCmd(projectDir, m.alerts)
+}
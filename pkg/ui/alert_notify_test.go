@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notify"
+)
+
+func TestNotifyAlertsCmd(t *testing.T) {
+	dir := t.TempDir()
+	alerts := []drift.Alert{{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, Message: "X is stale", IssueID: "X"}}
+
+	// No config: nothing to do.
+	if msg := NotifyAlertsCmd(dir, alerts)(); msg != nil {
+		t.Fatalf("expected no message without a config, got %#v", msg)
+	}
+	if NotifyAlertsCmd(dir, nil) != nil {
+		t.Fatal("expected no command without alerts")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(content string) {
+		if err := os.WriteFile(notify.ConfigPath(dir), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig("sinks:\n  - type: file\n    path: alerts.jsonl\n")
+	msg, ok := NotifyAlertsCmd(dir, alerts)().(AlertsNotifiedMsg)
+	if !ok || msg.Err != nil || msg.Report.Delivered != 1 {
+		t.Fatalf("expected one delivered alert, got %#v", msg)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "alerts.jsonl"))
+	if err != nil || !strings.Contains(string(data), `"source":"tui"`) {
+		t.Fatalf("expected a tui notification line, got %s (%v)", data, err)
+	}
+
+	m := NewModel([]model.Issue{{ID: "X", Title: "X", Status: model.StatusOpen}}, nil, "")
+	// The repository's config alone never makes the TUI deliver; --notify does.
+	m.alerts = alerts
+	if m.notifyAlertsCmd() != nil {
+		t.Error("expected no delivery without --notify")
+	}
+	m.EnableAlertNotifications()
+	if m.notifyAlertsCmd() == nil {
+		t.Error("expected delivery with --notify")
+	}
+	updated, _ := m.Update(msg)
+	if got := updated.(Model).statusMsg; !strings.HasPrefix(got, "Notified 1 alert(s)") {
+		t.Errorf("expected a delivery status, got %q", got)
+	}
+
+	// The same alert again is suppressed by the cooldown.
+	msg = NotifyAlertsCmd(dir, alerts)().(AlertsNotifiedMsg)
+	if msg.Report.Delivered != 0 || msg.Report.Suppressed != 1 {
+		t.Errorf("expected the repeat to be suppressed, got %+v", msg.Report)
+	}
+
+	writeConfig("sinks:\n  - type: pager\n")
+	msg = NotifyAlertsCmd(dir, alerts)().(AlertsNotifiedMsg)
+	updated, _ = m.Update(msg)
+	if got := updated.(Model); !got.statusIsError || !strings.Contains(got.statusMsg, "unknown type") {
+		t.Errorf("expected a config error status, got %q", got.statusMsg)
+	}
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// AssigneeDashboardModel lists every assignee ranked by attention score and
+// shows workload, blockers, stale claims and flow trends for the selection.
+type AssigneeDashboardModel struct {
+	report *analysis.AssigneeHealthReport
+	cursor int
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewAssigneeDashboardModel creates an empty assignee dashboard.
+func NewAssigneeDashboardModel(theme Theme) AssigneeDashboardModel {
+	return AssigneeDashboardModel{theme: theme}
+}
+
+// SetData recomputes assignee health. Status transitions come from the git
+// history report when it has been loaded; otherwise snapshot timestamps are used.
+func (m *AssigneeDashboardModel) SetData(issues []model.Issue, history *correlation.HistoryReport) {
+	var transitions []analysis.StatusTransition
+	if history != nil {
+		for _, h := range history.Histories {
+			transitions = append(transitions, analysis.TransitionsFromEvents(h.Events)...)
+		}
+	}
+	report := analysis.ComputeAssigneeHealth(issues, transitions, analysis.DefaultAssigneeHealthConfig())
+	m.report = &report
+	if m.cursor >= len(report.Assignees) {
+		m.cursor = 0
+	}
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *AssigneeDashboardModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveDown selects the next assignee.
+func (m *AssigneeDashboardModel) MoveDown() {
+	if m.report != nil && m.cursor < len(m.report.Assignees)-1 {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous assignee.
+func (m *AssigneeDashboardModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// SelectedAssignee returns the assignee under the cursor.
+func (m *AssigneeDashboardModel) SelectedAssignee() *analysis.AssigneeHealth {
+	if m.report == nil || m.cursor < 0 || m.cursor >= len(m.report.Assignees) {
+		return nil
+	}
+	return &m.report.Assignees[m.cursor]
+}
+
+// FocusIssueID returns the selected assignee's most pressing item: the
+// first stale claim, else the first item waiting on someone else.
+func (m *AssigneeDashboardModel) FocusIssueID() string {
+	h := m.SelectedAssignee()
+	if h == nil {
+		return ""
+	}
+	if len(h.StaleClaims) > 0 {
+		return h.StaleClaims[0]
+	}
+	for _, item := range h.Blocked {
+		for _, b := range item.Blockers {
+			if b.External {
+				return item.IssueID
+			}
+		}
+	}
+	if len(h.Blocked) > 0 {
+		return h.Blocked[0].IssueID
+	}
+	return ""
+}
+
+// View renders the dashboard.
+func (m *AssigneeDashboardModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selectedStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	if m.report == nil || len(m.report.Assignees) == 0 {
+		return titleStyle.Render("Assignee Workload") + "\n\n" + dimStyle.Render("  No assigned issues found")
+	}
+	r := m.report
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Assignee Workload"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d assignees • team median %.1f open • %d unassigned open",
+		len(r.Assignees), r.TeamMedianLoad, r.UnassignedOpen)))
+	sb.WriteString("\n\n")
+
+	listRows := height/2 - 4
+	if listRows < 3 {
+		listRows = 3
+	}
+	start := 0
+	if m.cursor >= listRows {
+		start = m.cursor - listRows + 1
+	}
+	end := start + listRows
+	if end > len(r.Assignees) {
+		end = len(r.Assignees)
+	}
+
+	nameWidth := width - 62
+	if nameWidth < 10 {
+		nameWidth = 10
+	}
+	if nameWidth > 24 {
+		nameWidth = 24
+	}
+	sb.WriteString(labelStyle.Render(fmt.Sprintf("  %-*s %4s %4s %7s %5s %6s  %-10s %s",
+		nameWidth, "Assignee", "Open", "WIP", "Blocked", "Stale", "Load", "Trend", "Attention")))
+	sb.WriteString("\n")
+
+	barWidth := 10
+	for i := start; i < end; i++ {
+		h := r.Assignees[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		load := fmt.Sprintf("%.1fx", h.LoadRatio)
+		if h.Overloaded {
+			load += "!"
+		}
+		line := fmt.Sprintf("%s%-*s %4d %4d %7d %5d %6s  %-10s %s %3d",
+			marker, nameWidth, truncateRunesHelper(h.Assignee, nameWidth, "…"),
+			h.OpenCount, h.WIP, h.BlockedCount, h.StaleClaimCount, load,
+			h.Throughput.Trend,
+			RenderMiniBar(float64(h.AttentionScore)/100, barWidth, t), h.AttentionScore)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if end < len(r.Assignees) {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more", len(r.Assignees)-end)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	h := r.Assignees[m.cursor]
+	sb.WriteString(labelStyle.Render(h.Assignee))
+	sb.WriteString("\n")
+
+	weekly := make([]int, len(h.Throughput.Weekly))
+	maxWeek := 0
+	for i, w := range h.Throughput.Weekly {
+		// Weekly buckets are newest first; sparkline reads left to right.
+		weekly[len(weekly)-1-i] = w.Closed
+		if w.Closed > maxWeek {
+			maxWeek = w.Closed
+		}
+	}
+	sparkStyle := t.Renderer.NewStyle().Foreground(t.Closed)
+	sb.WriteString(fmt.Sprintf("  Throughput %d closed (prev %d) • %.1f/week • %s ",
+		h.Throughput.Recent, h.Throughput.Prior, h.Throughput.PerWeek, h.Throughput.Trend))
+	sb.WriteString(sparkStyle.Render(buildSparkline(weekly, maxWeek)))
+	sb.WriteString("\n")
+
+	cycle := "no cycle time data"
+	if h.CycleTime.Overall.Count > 0 {
+		cycle = fmt.Sprintf("median %.1fd over %d items", h.CycleTime.Overall.MedianDays, h.CycleTime.Overall.Count)
+		if h.CycleTime.Trend != "insufficient_data" {
+			cycle += fmt.Sprintf(" • %.1fd → %.1fd (%s)", h.CycleTime.PriorDays, h.CycleTime.RecentDays, h.CycleTime.Trend)
+		}
+	}
+	sb.WriteString("  Cycle time " + cycle + "\n")
+
+	if len(h.StaleClaims) > 0 {
+		sb.WriteString("  Stale      " + warnStyle.Render(strings.Join(h.StaleClaims, ", ")) + "\n")
+	}
+	for _, reason := range h.Reasons {
+		sb.WriteString("  ⚠ " + reason + "\n")
+	}
+
+	if len(h.Blocked) > 0 {
+		sb.WriteString(fmt.Sprintf("  Blocked    %d items, %d waiting on others\n", h.BlockedCount, h.BlockedByOthers))
+		maxRows := height - (end - start) - 18 - len(h.Reasons)
+		if maxRows < 1 {
+			maxRows = 1
+		}
+		for i, item := range h.Blocked {
+			if i >= maxRows {
+				sb.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(h.Blocked)-maxRows)))
+				sb.WriteString("\n")
+				break
+			}
+			var blockers []string
+			for _, b := range item.Blockers {
+				owner := "unassigned"
+				if b.Assignee != "" {
+					owner = "@" + b.Assignee
+				}
+				blockers = append(blockers, b.IssueID+" "+owner)
+			}
+			line := fmt.Sprintf("    %-12s %s ← %s", item.IssueID, item.Title, strings.Join(blockers, ", "))
+			sb.WriteString(truncateRunesHelper(line, max(20, width-2), "…"))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: select | enter: open most pressing item | W/esc: back"))
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func assigneeDashboardTestIssues(now time.Time) []model.Issue {
+	old := now.AddDate(0, 0, -20)
+	return []model.Issue{
+		{ID: "A1", Title: "Old claim", Status: model.StatusInProgress, Assignee: "alice", CreatedAt: old, UpdatedAt: old},
+		{ID: "A2", Title: "Waiting", Status: model.StatusOpen, Assignee: "alice", CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{DependsOnID: "B1", Type: model.DepBlocks}}},
+		{ID: "A3", Title: "Queued", Status: model.StatusOpen, Assignee: "alice", CreatedAt: now, UpdatedAt: now},
+		{ID: "B1", Title: "API", Status: model.StatusInProgress, Assignee: "bob", CreatedAt: now, UpdatedAt: now},
+		{ID: "B2", Title: "Done", Status: model.StatusClosed, Assignee: "bob", CreatedAt: now, ClosedAt: timePtr(now.Add(-time.Hour))},
+	}
+}
+
+func TestAssigneeDashboardViewEmpty(t *testing.T) {
+	m := NewAssigneeDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	if out := m.View(); !strings.Contains(out, "No assigned issues found") {
+		t.Errorf("expected empty-state message, got:\n%s", out)
+	}
+}
+
+func TestAssigneeDashboardViewAndNavigation(t *testing.T) {
+	m := NewAssigneeDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(assigneeDashboardTestIssues(time.Now().UTC()), nil)
+	m.SetSize(120, 40)
+
+	out := m.View()
+	for _, want := range []string{"Assignee Workload", "2 assignees", "alice", "bob", "Stale      A1", "B1 @bob", "Throughput"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+
+	if h := m.SelectedAssignee(); h == nil || h.Assignee != "alice" {
+		t.Fatalf("expected alice to rank first, got %+v", h)
+	}
+	if id := m.FocusIssueID(); id != "A1" {
+		t.Errorf("expected stale claim A1 as focus item, got %q", id)
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if h := m.SelectedAssignee(); h.Assignee != "bob" {
+		t.Errorf("expected cursor to stop at bob, got %q", h.Assignee)
+	}
+	if id := m.FocusIssueID(); id != "" {
+		t.Errorf("expected no focus item for bob, got %q", id)
+	}
+}
+
+func TestAssigneeDashboardKeyOpensAndCloses(t *testing.T) {
+	m := NewModel(assigneeDashboardTestIssues(time.Now().UTC()), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	m = updated.(Model)
+	if m.FocusState() != "assignee_dashboard" {
+		t.Fatalf("expected assignee_dashboard focus after W, got %q", m.FocusState())
+	}
+	if m.CurrentContext() != ContextAssigneeDashboard {
+		t.Errorf("expected assignee dashboard context, got %q", m.CurrentContext())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.FocusState() != "detail" {
+		t.Errorf("expected enter to open the focus item, got %q", m.FocusState())
+	}
+}
@@ -1398,6 +1398,7 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	var snapshot *DataSnapshot
 	analyzeStart := time.Now()
 	analyzeErr := w.safeCompute("analyze_phase1", func() error {
+		reloadTUITriageOptions() // picks up .bv/triage.yaml edits with the reload
 		builder := NewSnapshotBuilder(issues).
 			WithRecipe(currentRecipe).
 			WithBuildConfig(snapshotBuildConfigForTier(tier))
@@ -25,6 +25,12 @@ const (
 	// Views
 	ContextInsights       Context = "insights"
 	ContextFlowMatrix     Context = "flow-matrix"
+	ContextFlowMetrics    Context = "flow-metrics"
+	ContextEpicDashboard  Context = "epic-dashboard"
+	ContextAssigneeDashboard Context = "assignee-dashboard"
+	ContextWorkspaceDashboard Context = "workspace-dashboard"
+	ContextSprintPlan Context = "sprint-plan"
+	ContextSimilarPanel Context = "similar-panel"
 	ContextGraph          Context = "graph"
 	ContextBoard          Context = "board"
 	ContextActionable     Context = "actionable"
@@ -127,6 +133,26 @@ func (m Model) CurrentContext() Context {
 		return ContextFlowMatrix
 	}
 
+	// Flow metrics panel
+	if m.focused == focusFlowMetrics {
+		return ContextFlowMetrics
+	}
+	if m.focused == focusEpicDashboard {
+		return ContextEpicDashboard
+	}
+	if m.focused == focusAssigneeDashboard {
+		return ContextAssigneeDashboard
+	}
+	if m.focused == focusWorkspaceDashboard {
+		return ContextWorkspaceDashboard
+	}
+	if m.focused == focusSprintPlan {
+		return ContextSprintPlan
+	}
+	if m.focused == focusSimilarPanel {
+		return ContextSimilarPanel
+	}
+
 	// Label dashboard
 	if m.focused == focusLabelDashboard {
 		return ContextLabelDashboard
@@ -203,11 +229,17 @@ func (c Context) Description() string {
 		ContextCassSession:        "Cass session preview",
 		ContextInsights:           "Insights panel",
 		ContextFlowMatrix:         "Flow matrix",
+		ContextFlowMetrics:        "Flow metrics",
+		ContextEpicDashboard:      "Epic progress",
+		ContextAssigneeDashboard:  "Assignee workload",
+		ContextWorkspaceDashboard: "Workspace health",
 		ContextGraph:              "Dependency graph",
 		ContextBoard:              "Kanban board",
 		ContextActionable:         "Actionable view",
 		ContextHistory:            "History view",
 		ContextSprint:             "Sprint view",
+		ContextSprintPlan:         "Sprint planner",
+		ContextSimilarPanel:       "More like this",
 		ContextLabelDashboard:     "Label dashboard",
 		ContextAttention:          "Attention view",
 		ContextSplit:              "Split view",
@@ -237,8 +269,8 @@ func (c Context) IsOverlay() bool {
 // IsView returns true if the context is a full view (not overlay or default list)
 func (c Context) IsView() bool {
 	switch c {
-	case ContextInsights, ContextFlowMatrix, ContextGraph, ContextBoard,
-		ContextActionable, ContextHistory, ContextSprint, ContextLabelDashboard,
+	case ContextInsights, ContextFlowMatrix, ContextFlowMetrics, ContextEpicDashboard, ContextAssigneeDashboard, ContextWorkspaceDashboard, ContextGraph, ContextBoard,
+		ContextActionable, ContextHistory, ContextSprint, ContextSprintPlan, ContextSimilarPanel, ContextLabelDashboard,
 		ContextAttention, ContextSplit, ContextDetail, ContextTimeTravel:
 		return true
 	}
@@ -262,8 +294,14 @@ func (c Context) TutorialPages() []int {
 		ContextTimeTravel:         {10},          // Time-Travel
 		ContextLabelDashboard:     {11},          // Labels
 		ContextFlowMatrix:         {11, 12},      // Labels, Advanced
+		ContextFlowMetrics:        {8, 12},       // History, Advanced
+		ContextEpicDashboard:      {7, 12},       // Insights, Advanced
+		ContextAssigneeDashboard:  {7, 12},       // Insights, Advanced
+		ContextWorkspaceDashboard: {7, 12},       // Insights, Advanced
 		ContextHelp:               {13},          // Keyboard Reference
 		ContextSprint:             {14},          // Sprints
+		ContextSprintPlan:         {14},          // Sprints
+		ContextSimilarPanel:       {7, 12},       // Insights, Advanced
 		ContextAttention:          {7},           // Insights (attention is part of insights)
 		ContextAlerts:             {15},          // Alerts
 		ContextLabelPicker:        {11, 3},       // Labels, Filtering
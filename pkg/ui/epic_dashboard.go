@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EpicDashboardModel lists every epic with its rolled-up progress and shows
+// blockers, critical path, velocity and forecast for the selected epic.
+type EpicDashboardModel struct {
+	rollups []analysis.EpicRollup
+	cursor  int
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewEpicDashboardModel creates an empty epic progress dashboard.
+func NewEpicDashboardModel(theme Theme) EpicDashboardModel {
+	return EpicDashboardModel{theme: theme}
+}
+
+// SetData recomputes the epic rollups.
+func (m *EpicDashboardModel) SetData(issues []model.Issue) {
+	m.rollups = analysis.ComputeEpicRollups(issues, analysis.DefaultEpicRollupConfig())
+	if m.cursor >= len(m.rollups) {
+		m.cursor = 0
+	}
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *EpicDashboardModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveDown selects the next epic.
+func (m *EpicDashboardModel) MoveDown() {
+	if m.cursor < len(m.rollups)-1 {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous epic.
+func (m *EpicDashboardModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// SelectedEpicID returns the ID of the epic under the cursor.
+func (m *EpicDashboardModel) SelectedEpicID() string {
+	if m.cursor < 0 || m.cursor >= len(m.rollups) {
+		return ""
+	}
+	return m.rollups[m.cursor].EpicID
+}
+
+// View renders the dashboard.
+func (m *EpicDashboardModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selectedStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+
+	if len(m.rollups) == 0 {
+		return titleStyle.Render("Epic Progress") + "\n\n" + dimStyle.Render("  No epics found")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Epic Progress"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d epics", len(m.rollups))))
+	sb.WriteString("\n\n")
+
+	// Epic list: leave roughly half the screen for the detail section.
+	listRows := height/2 - 3
+	if listRows < 3 {
+		listRows = 3
+	}
+	start := 0
+	if m.cursor >= listRows {
+		start = m.cursor - listRows + 1
+	}
+	end := start + listRows
+	if end > len(m.rollups) {
+		end = len(m.rollups)
+	}
+
+	barWidth := 12
+	for i := start; i < end; i++ {
+		r := m.rollups[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		indent := strings.Repeat("  ", r.Depth)
+		titleWidth := width - barWidth - len(indent) - 48
+		if titleWidth < 10 {
+			titleWidth = 10
+		}
+		title := truncateRunesHelper(r.Title, titleWidth, "…")
+		line := fmt.Sprintf("%s%s%-12s %-*s %s %5.1f%% %s",
+			marker, indent, r.EpicID, titleWidth, title,
+			RenderMiniBar(r.PercentByCount/100, barWidth, t),
+			r.PercentByCount,
+			formatEpicForecast(r.Forecast))
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if end < len(m.rollups) {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more", len(m.rollups)-end)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	r := m.rollups[m.cursor]
+	sb.WriteString(labelStyle.Render(fmt.Sprintf("%s %s", r.EpicID, r.Title)))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("  Items      %d/%d closed (%.0f%%) • %s of %s estimated (%.0f%%)\n",
+		r.ClosedIssues, r.TotalIssues, r.PercentByCount,
+		formatMinutes(r.ClosedMinutes), formatMinutes(r.TotalMinutes), r.PercentByMinutes))
+
+	weekly := make([]int, len(r.Velocity.Weekly))
+	maxWeek := 0
+	for i, w := range r.Velocity.Weekly {
+		// Weekly buckets are newest first; sparkline reads left to right.
+		weekly[len(weekly)-1-i] = w.Closed
+		if w.Closed > maxWeek {
+			maxWeek = w.Closed
+		}
+	}
+	sparkStyle := t.Renderer.NewStyle().Foreground(t.Closed)
+	sb.WriteString(fmt.Sprintf("  Velocity   %.1f items/week • %s/week ",
+		r.Velocity.IssuesPerWeek, formatMinutes(int(r.Velocity.MinutesPerWeek))))
+	sb.WriteString(sparkStyle.Render(buildSparkline(weekly, maxWeek)))
+	sb.WriteString("\n")
+	sb.WriteString("  Forecast   " + formatEpicForecast(r.Forecast) + "\n")
+
+	path := "none"
+	if len(r.CriticalPath.Path) > 0 {
+		path = strings.Join(r.CriticalPath.Path, " → ") + " (" + formatMinutes(r.CriticalPath.Minutes) + ")"
+		if r.CriticalPath.HasCycle {
+			path += " ⚠ cycle"
+		}
+	}
+	sb.WriteString("  Critical   " + truncateRunesHelper(path, max(20, width-14), "…") + "\n")
+
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+	if len(r.ExternalBlockers) == 0 {
+		sb.WriteString("  Blockers   none outside this epic\n")
+	} else {
+		sb.WriteString("  Blockers   " + warnStyle.Render(fmt.Sprintf("%d outside this epic", len(r.ExternalBlockers))) + "\n")
+		maxRows := height - (end - start) - 16
+		if maxRows < 1 {
+			maxRows = 1
+		}
+		for i, b := range r.ExternalBlockers {
+			if i >= maxRows {
+				sb.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(r.ExternalBlockers)-maxRows)))
+				sb.WriteString("\n")
+				break
+			}
+			owner := ""
+			if b.Assignee != "" {
+				owner = " @" + b.Assignee
+			}
+			line := fmt.Sprintf("    %-12s %s%s → blocks %s", b.IssueID, b.Title, owner, strings.Join(b.Blocks, ", "))
+			sb.WriteString(truncateRunesHelper(line, max(20, width-2), "…"))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: select | enter: open epic | e/esc: back"))
+	return sb.String()
+}
+
+func formatEpicForecast(f analysis.EpicForecast) string {
+	switch f.Status {
+	case analysis.EpicForecastComplete:
+		return "done"
+	case analysis.EpicForecastNoItems:
+		return "no child work"
+	case analysis.EpicForecastNoVelocity:
+		return "no recent progress"
+	}
+	if f.ETADate == nil {
+		return f.Status
+	}
+	return fmt.Sprintf("ETA %s (~%.1fw)", f.ETADate.Format("2006-01-02"), f.RemainingWeeks)
+}
+
+func formatMinutes(minutes int) string {
+	d := time.Duration(minutes) * time.Minute
+	switch {
+	case d >= 8*time.Hour*5:
+		return fmt.Sprintf("%.1fw", d.Hours()/40)
+	case d >= 8*time.Hour:
+		return fmt.Sprintf("%.1fd", d.Hours()/8)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func epicDashboardTestIssues(now time.Time) []model.Issue {
+	parent := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepParentChild}}
+	}
+	return []model.Issue{
+		{ID: "E1", Title: "Platform", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 1, CreatedAt: now},
+		{ID: "E2", Title: "Storage", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 2, CreatedAt: now, Dependencies: parent("E1")},
+		{ID: "A", Title: "Schema", IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, ClosedAt: timePtr(now.Add(-24 * time.Hour)), Dependencies: parent("E1")},
+		{ID: "B", Title: "Disk layout", IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: append(parent("E2"), &model.Dependency{DependsOnID: "X", Type: model.DepBlocks})},
+		{ID: "X", Title: "Auth service", IssueType: model.TypeTask, Status: model.StatusInProgress, Assignee: "alice", CreatedAt: now},
+	}
+}
+
+func TestEpicDashboardViewEmpty(t *testing.T) {
+	m := NewEpicDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	if out := m.View(); !strings.Contains(out, "No epics found") {
+		t.Errorf("expected empty-state message, got:\n%s", out)
+	}
+}
+
+func TestEpicDashboardViewRendersRollups(t *testing.T) {
+	m := NewEpicDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(epicDashboardTestIssues(time.Now().UTC()))
+	m.SetSize(120, 40)
+
+	out := m.View()
+	for _, want := range []string{"Epic Progress", "2 epics", "E1", "E2", "1/2 closed", "Velocity", "Forecast", "1 outside this epic", "@alice"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestEpicDashboardNavigation(t *testing.T) {
+	m := NewEpicDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(epicDashboardTestIssues(time.Now().UTC()))
+
+	if m.SelectedEpicID() != "E1" {
+		t.Fatalf("expected E1 first, got %q", m.SelectedEpicID())
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if m.SelectedEpicID() != "E2" {
+		t.Errorf("expected cursor to stop at E2, got %q", m.SelectedEpicID())
+	}
+	m.MoveUp()
+	if m.SelectedEpicID() != "E1" {
+		t.Errorf("expected E1 after moving up, got %q", m.SelectedEpicID())
+	}
+}
+
+func TestEpicDashboardKeyOpensAndCloses(t *testing.T) {
+	m := NewModel(epicDashboardTestIssues(time.Now().UTC()), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = updated.(Model)
+	if m.FocusState() != "epic_dashboard" {
+		t.Fatalf("expected epic_dashboard focus after e, got %q", m.FocusState())
+	}
+	if m.CurrentContext() != ContextEpicDashboard {
+		t.Errorf("expected epic dashboard context, got %q", m.CurrentContext())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.FocusState() != "list" {
+		t.Errorf("expected list focus after esc, got %q", m.FocusState())
+	}
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FlowMetricsModel renders the cumulative flow diagram together with lead
+// time, cycle time, throughput and WIP aging for the project or one label.
+type FlowMetricsModel struct {
+	report *analysis.FlowReport
+	cursor int // 0 = project, n = report.Labels[n-1]
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewFlowMetricsModel creates an empty flow metrics panel.
+func NewFlowMetricsModel(theme Theme) FlowMetricsModel {
+	return FlowMetricsModel{theme: theme}
+}
+
+// SetData computes the flow report. Status transitions come from the git
+// history report when it has been loaded; otherwise snapshot timestamps are used.
+func (m *FlowMetricsModel) SetData(issues []model.Issue, history *correlation.HistoryReport) {
+	var transitions []analysis.StatusTransition
+	if history != nil {
+		for _, h := range history.Histories {
+			transitions = append(transitions, analysis.TransitionsFromEvents(h.Events)...)
+		}
+	}
+	report := analysis.ComputeFlowReport(issues, transitions, analysis.DefaultFlowConfig())
+	m.report = &report
+	if m.cursor > len(report.Labels) {
+		m.cursor = 0
+	}
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *FlowMetricsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveDown selects the next scope (project, then each label).
+func (m *FlowMetricsModel) MoveDown() {
+	if m.report != nil && m.cursor < len(m.report.Labels) {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous scope.
+func (m *FlowMetricsModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// SelectedLabel returns the label in focus, or "" for the project scope.
+func (m *FlowMetricsModel) SelectedLabel() string {
+	if m.report == nil || m.cursor == 0 || m.cursor > len(m.report.Labels) {
+		return ""
+	}
+	return m.report.Labels[m.cursor-1].Label
+}
+
+func (m *FlowMetricsModel) selectedSummary() *analysis.FlowSummary {
+	if m.report == nil {
+		return nil
+	}
+	if m.cursor == 0 || m.cursor > len(m.report.Labels) {
+		return &m.report.Project
+	}
+	return &m.report.Labels[m.cursor-1]
+}
+
+// View renders the panel.
+func (m *FlowMetricsModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	summary := m.selectedSummary()
+	if summary == nil {
+		return titleStyle.Render("Flow Metrics") + "\n\n" + dimStyle.Render("  No flow data available")
+	}
+
+	var sb strings.Builder
+	scope := "project"
+	if summary.Label != "" {
+		scope = "label: " + summary.Label
+	}
+	sb.WriteString(titleStyle.Render("Flow Metrics"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %s (%d/%d) • last %d days • source: %s",
+		scope, m.cursor+1, len(m.report.Labels)+1, m.report.Days, m.report.Source)))
+	sb.WriteString("\n\n")
+
+	// Reserve rows for legend, stats, throughput, WIP header/footer.
+	chartHeight := height - 16
+	if chartHeight > 12 {
+		chartHeight = 12
+	}
+	if chartHeight < 4 {
+		chartHeight = 4
+	}
+	sb.WriteString(labelStyle.Render("Cumulative flow"))
+	sb.WriteString("\n")
+	sb.WriteString(m.renderCFD(summary.CFD, width-2, chartHeight))
+	sb.WriteString(m.renderLegend(summary.CFD))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Lead time  "))
+	sb.WriteString(formatDurationStats(summary.LeadTime))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Cycle time "))
+	sb.WriteString(formatDurationStats(summary.CycleTime))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Throughput "))
+	daily := summary.Throughput.Daily
+	if len(daily) > width-40 && width > 40 {
+		daily = daily[len(daily)-(width-40):]
+	}
+	maxDaily := 0
+	for _, v := range daily {
+		if v > maxDaily {
+			maxDaily = v
+		}
+	}
+	sparkStyle := t.Renderer.NewStyle().Foreground(t.Closed)
+	sb.WriteString(fmt.Sprintf("%d closed, %.1f/week ", summary.Throughput.Total, summary.Throughput.PerWeek))
+	sb.WriteString(sparkStyle.Render(buildSparkline(daily, maxDaily)))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render(fmt.Sprintf("WIP aging (%d in progress)", len(summary.WIPAging))))
+	sb.WriteString("\n")
+	if len(summary.WIPAging) == 0 {
+		sb.WriteString(dimStyle.Render("  Nothing in progress"))
+		sb.WriteString("\n")
+	}
+	maxRows := height - chartHeight - 14
+	if maxRows < 1 {
+		maxRows = 1
+	}
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+	for i, item := range summary.WIPAging {
+		if i >= maxRows {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more", len(summary.WIPAging)-maxRows)))
+			sb.WriteString("\n")
+			break
+		}
+		age := fmt.Sprintf("%5.1fd", item.AgeDays)
+		if item.OverP85 {
+			age = warnStyle.Render(age + " ⚠")
+		} else {
+			age += "  "
+		}
+		title := truncateRunesHelper(item.Title, max(10, width-30), "…")
+		sb.WriteString(fmt.Sprintf("  %s  %-12s %s\n", age, item.IssueID, title))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: scope | enter: filter by label | F/esc: back"))
+	return sb.String()
+}
+
+// renderCFD draws a stacked column chart: closed at the bottom, then blocked,
+// in progress and open on top. When the window is wider than the terminal the
+// most recent days are shown.
+func (m *FlowMetricsModel) renderCFD(points []analysis.CFDPoint, width, height int) string {
+	if len(points) == 0 {
+		return "\n"
+	}
+	if width < 10 {
+		width = 10
+	}
+	if len(points) > width {
+		points = points[len(points)-width:]
+	}
+
+	maxTotal := 0
+	for _, p := range points {
+		if total := p.Open + p.InProgress + p.Blocked + p.Closed; total > maxTotal {
+			maxTotal = total
+		}
+	}
+	if maxTotal == 0 {
+		return m.theme.Renderer.NewStyle().Foreground(m.theme.Secondary).Italic(true).Render("  No issues in window") + "\n"
+	}
+
+	t := m.theme
+	styles := []lipgloss.Style{
+		t.Renderer.NewStyle().Foreground(t.Closed),
+		t.Renderer.NewStyle().Foreground(t.Blocked),
+		t.Renderer.NewStyle().Foreground(t.InProgress),
+		t.Renderer.NewStyle().Foreground(t.Open),
+	}
+
+	// Column heights per band, in rows, bottom-up.
+	columns := make([][4]int, len(points))
+	for i, p := range points {
+		counts := [4]int{p.Closed, p.Blocked, p.InProgress, p.Open}
+		cum := 0
+		prevRows := 0
+		for b, c := range counts {
+			cum += c
+			rows := (cum*height + maxTotal/2) / maxTotal
+			columns[i][b] = rows - prevRows
+			prevRows = rows
+		}
+	}
+
+	var sb strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		sb.WriteString("  ")
+		for i := range points {
+			band := -1
+			level := 0
+			for b := 0; b < 4; b++ {
+				level += columns[i][b]
+				if row < level {
+					band = b
+					break
+				}
+			}
+			if band < 0 {
+				sb.WriteString(" ")
+				continue
+			}
+			sb.WriteString(styles[band].Render("█"))
+		}
+		sb.WriteString("\n")
+	}
+	first := points[0].Date.Format("01-02")
+	last := points[len(points)-1].Date.Format("01-02")
+	gap := len(points) - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	sb.WriteString("  " + first + strings.Repeat(" ", gap) + last + "\n")
+	return sb.String()
+}
+
+func (m *FlowMetricsModel) renderLegend(points []analysis.CFDPoint) string {
+	t := m.theme
+	var latest analysis.CFDPoint
+	if len(points) > 0 {
+		latest = points[len(points)-1]
+	}
+	entries := []struct {
+		color lipgloss.AdaptiveColor
+		name  string
+		count int
+	}{
+		{t.Open, "open", latest.Open},
+		{t.InProgress, "in progress", latest.InProgress},
+		{t.Blocked, "blocked", latest.Blocked},
+		{t.Closed, "closed", latest.Closed},
+	}
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		swatch := t.Renderer.NewStyle().Foreground(e.color).Render("█")
+		parts = append(parts, fmt.Sprintf("%s %s %d", swatch, e.name, e.count))
+	}
+	return "  " + strings.Join(parts, "   ")
+}
+
+func formatDurationStats(s analysis.DurationStats) string {
+	if s.Count == 0 {
+		return "no samples"
+	}
+	return fmt.Sprintf("median %.1fd • p85 %.1fd • p95 %.1fd • n=%d", s.MedianDays, s.P85Days, s.P95Days, s.Count)
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func flowMetricsTestIssues(now time.Time) []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Shipped", Status: model.StatusClosed, CreatedAt: now.Add(-96 * time.Hour), ClosedAt: timePtr(now.Add(-24 * time.Hour)), Labels: []string{"api"}},
+		{ID: "B", Title: "Underway", Status: model.StatusInProgress, CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now.Add(-48 * time.Hour), Labels: []string{"api"}},
+		{ID: "C", Title: "Queued", Status: model.StatusOpen, CreatedAt: now.Add(-24 * time.Hour)},
+	}
+}
+
+func TestFlowMetricsViewEmpty(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	out := m.View()
+	if !strings.Contains(out, "No flow data") {
+		t.Errorf("expected empty-state message, got:\n%s", out)
+	}
+}
+
+func TestFlowMetricsViewRendersSections(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(flowMetricsTestIssues(time.Now().UTC()), nil)
+	m.SetSize(100, 40)
+
+	out := m.View()
+	for _, want := range []string{"Cumulative flow", "Lead time", "Cycle time", "Throughput", "WIP aging (1 in progress)", "source: snapshot", "Underway"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFlowMetricsScopeNavigation(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(flowMetricsTestIssues(time.Now().UTC()), nil)
+
+	if m.SelectedLabel() != "" {
+		t.Fatalf("expected project scope first, got %q", m.SelectedLabel())
+	}
+	m.MoveDown()
+	if m.SelectedLabel() != "api" {
+		t.Fatalf("expected api scope, got %q", m.SelectedLabel())
+	}
+	m.MoveDown() // only one label; stays put
+	if m.SelectedLabel() != "api" {
+		t.Errorf("cursor moved past last label: %q", m.SelectedLabel())
+	}
+	m.MoveUp()
+	m.MoveUp()
+	if m.SelectedLabel() != "" {
+		t.Errorf("expected project scope after moving up, got %q", m.SelectedLabel())
+	}
+}
+
+func TestFlowMetricsUsesHistoryEvents(t *testing.T) {
+	now := time.Now().UTC()
+	issues := flowMetricsTestIssues(now)
+	history := &correlation.HistoryReport{
+		Histories: map[string]correlation.BeadHistory{
+			"A": {BeadID: "A", Events: []correlation.BeadEvent{
+				{BeadID: "A", EventType: correlation.EventClaimed, Timestamp: now.Add(-72 * time.Hour), Status: "in_progress"},
+				{BeadID: "A", EventType: correlation.EventClosed, Timestamp: now.Add(-24 * time.Hour), Status: "closed"},
+			}},
+		},
+	}
+
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(issues, history)
+
+	if m.report.Source != "mixed" {
+		t.Errorf("expected mixed source, got %s", m.report.Source)
+	}
+	if m.report.Project.CycleTime.Count != 1 {
+		t.Errorf("expected cycle time sample from git history, got %+v", m.report.Project.CycleTime)
+	}
+}
+
+func TestFlowMetricsKeyOpensAndCloses(t *testing.T) {
+	m := NewModel(flowMetricsTestIssues(time.Now().UTC()), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if m.FocusState() != "flow_metrics" {
+		t.Fatalf("expected flow_metrics focus after F, got %q", m.FocusState())
+	}
+	if m.CurrentContext() != ContextFlowMetrics {
+		t.Errorf("expected flow metrics context, got %q", m.CurrentContext())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.FocusState() != "list" {
+		t.Errorf("expected list focus after esc, got %q", m.FocusState())
+	}
+}
@@ -38,6 +38,11 @@ type GraphModel struct {
 	rankCriticalPath map[string]int
 	rankInDegree     map[string]int
 	rankOutDegree    map[string]int
+
+	// Color-by-cluster mode: community detection over the shown issues
+	clusterMode bool
+	clusterOf   map[string]int // issue ID -> cluster ID (0 = unclustered)
+	clusters    []analysis.IssueCluster
 }
 
 // NewGraphModel creates a new graph view from issues
@@ -109,6 +114,7 @@ func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
 	if g.selectedIdx >= len(g.sortedIDs) {
 		g.selectedIdx = 0
 	}
+	g.refreshClusters()
 }
 
 // SetIssues updates the graph data preserving the selected issue if possible
@@ -122,6 +128,7 @@ func (g *GraphModel) SetIssues(issues []model.Issue, insights *analysis.Insights
 	g.issues = issues
 	g.insights = insights
 	g.rebuildGraph()
+	g.refreshClusters()
 
 	// Restore selection
 	if selectedID != "" {
@@ -190,6 +197,52 @@ func (g *GraphModel) rebuildGraph() {
 	}
 }
 
+// ToggleClusterColors switches node coloring between status and detected cluster.
+func (g *GraphModel) ToggleClusterColors() {
+	g.clusterMode = !g.clusterMode
+	g.refreshClusters()
+}
+
+// ClusterMode reports whether nodes are colored by cluster.
+func (g *GraphModel) ClusterMode() bool {
+	return g.clusterMode
+}
+
+// ClusterCount returns the number of detected clusters (0 when the mode is off).
+func (g *GraphModel) ClusterCount() int {
+	return len(g.clusters)
+}
+
+// refreshClusters recomputes communities when color-by-cluster is enabled.
+func (g *GraphModel) refreshClusters() {
+	g.clusterOf = nil
+	g.clusters = nil
+	if !g.clusterMode {
+		return
+	}
+	cfg := analysis.DefaultClusterConfig()
+	cfg.IncludeClosed = true
+	cfg.MinClusterSize = 2
+	report := analysis.ComputeClusters(g.issues, cfg)
+	g.clusters = report.Clusters
+	g.clusterOf = make(map[string]int, len(g.issues))
+	for _, c := range report.Clusters {
+		for _, id := range c.IssueIDs {
+			g.clusterOf[id] = c.ID
+		}
+	}
+}
+
+// clusterColor returns the display color for an issue's cluster.
+func (g *GraphModel) clusterColor(id string, t Theme) lipgloss.AdaptiveColor {
+	c := g.clusterOf[id]
+	if c == 0 {
+		return t.Muted
+	}
+	palette := []lipgloss.AdaptiveColor{t.Primary, t.Feature, t.Open, t.Epic, t.InProgress, t.Bug, t.Task, t.Chore, t.Blocked}
+	return palette[(c-1)%len(palette)]
+}
+
 // computeRankings precomputes rankings for all metrics
 func (g *GraphModel) computeRankings() {
 	g.rankPageRank = nil
@@ -343,7 +396,11 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 		Bold(true).
 		Foreground(t.Primary).
 		Width(width)
-	lines = append(lines, headerStyle.Render(fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))))
+	header := fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))
+	if g.clusterMode {
+		header = fmt.Sprintf("📊 Nodes (%d) • %d clusters", len(g.sortedIDs), len(g.clusters))
+	}
+	lines = append(lines, headerStyle.Render(header))
 	lines = append(lines, strings.Repeat("─", width))
 
 	visibleItems := height - 4
@@ -385,8 +442,12 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 				Background(t.Highlight).
 				Width(width)
 		} else {
+			color := getStatusColor(issue.Status, t)
+			if g.clusterMode {
+				color = g.clusterColor(id, t)
+			}
 			style = t.Renderer.NewStyle().
-				Foreground(getStatusColor(issue.Status, t)).
+				Foreground(color).
 				Width(width)
 		}
 		lines = append(lines, style.Render(line))
@@ -425,6 +486,9 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	// EGO NODE (selected issue) - prominent center box
 	// ═══════════════════════════════════════════════════════════════════════
 	sections = append(sections, g.renderEgoNode(id, issue, width, t))
+	if g.clusterMode {
+		sections = append(sections, g.renderClusterLine(id, width, t))
+	}
 
 	// ═══════════════════════════════════════════════════════════════════════
 	// DEPENDENTS SECTION (what depends on this issue)
@@ -452,6 +516,21 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	return strings.Join(sections, "\n")
 }
 
+// renderClusterLine describes the ego node's cluster in cluster color mode.
+func (g *GraphModel) renderClusterLine(id string, width int, t Theme) string {
+	style := t.Renderer.NewStyle().
+		Foreground(g.clusterColor(id, t)).
+		Width(width).
+		Align(lipgloss.Center)
+	c := g.clusterOf[id]
+	if c == 0 || c > len(g.clusters) {
+		return style.Render("◆ unclustered")
+	}
+	cluster := g.clusters[c-1]
+	return style.Render(fmt.Sprintf("◆ cluster %d: %s (%d issues, cohesion %.0f%%)",
+		cluster.ID, truncateRunesHelper(cluster.Name, max(10, width-40), "…"), cluster.Size, cluster.Cohesion*100))
+}
+
 // renderBlockersVisual renders blocker nodes as boxes
 func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Theme) string {
 	headerStyle := t.Renderer.NewStyle().
@@ -565,6 +644,9 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 	if issue != nil {
 		statusIcon = getStatusIcon(issue.Status)
 		statusColor = getStatusColor(issue.Status, t)
+		if g.clusterMode {
+			statusColor = g.clusterColor(id, t)
+		}
 		displayID = smartTruncateID(id, boxWidth-4)
 		if issue.Title != "" {
 			title = truncateRunesHelper(issue.Title, boxWidth-4, "…")
@@ -2,6 +2,7 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -1081,3 +1082,42 @@ func TestGraphModelSelectByIDWithDependencies(t *testing.T) {
 		t.Errorf("Expected 'root' selected, got %v", sel)
 	}
 }
+
+// TestGraphModelClusterColors verifies color-by-cluster mode detects communities
+func TestGraphModelClusterColors(t *testing.T) {
+	theme := createTheme()
+	blockedBy := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A1", Title: "Auth login"},
+		{ID: "A2", Title: "Auth logout", Dependencies: blockedBy("A1")},
+		{ID: "A3", Title: "Auth tokens", Dependencies: blockedBy("A2")},
+		{ID: "B1", Title: "Billing"},
+		{ID: "B2", Title: "Billing export", Dependencies: blockedBy("B1")},
+		{ID: "B3", Title: "Billing invoices", Dependencies: blockedBy("B2")},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+
+	if g.ClusterMode() || g.ClusterCount() != 0 {
+		t.Fatal("cluster mode should be off by default")
+	}
+	g.ToggleClusterColors()
+	if !g.ClusterMode() || g.ClusterCount() != 2 {
+		t.Fatalf("expected 2 clusters after toggle, got %d", g.ClusterCount())
+	}
+	if out := g.View(120, 40); !strings.Contains(out, "2 clusters") || !strings.Contains(out, "◆ cluster") {
+		t.Errorf("expected cluster header and ego cluster line, got:\n%s", out)
+	}
+
+	// Clusters follow data updates while the mode is on.
+	g.SetIssues(issues[:3], nil)
+	if g.ClusterCount() != 1 {
+		t.Errorf("expected 1 cluster after SetIssues, got %d", g.ClusterCount())
+	}
+
+	g.ToggleClusterColors()
+	if g.ClusterMode() || g.ClusterCount() != 0 {
+		t.Error("expected cluster mode off after second toggle")
+	}
+}
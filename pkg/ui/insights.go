@@ -24,6 +24,7 @@ const (
 	PanelArticulation
 	PanelSlack
 	PanelCycles
+	PanelGates    // Dominator-tree gates
 	PanelPriority // Agent-first priority recommendations
 	PanelCount    // Sentinel for wrapping
 )
@@ -121,6 +122,15 @@ var metricDescriptions = map[MetricPanel]MetricInfo{
 		HowToUse:    "**Break cycles** by removing or reversing a dependency. Refactor to decouple.",
 		FormulaHint: "Detected via Tarjan's SCC algorithm",
 	},
+	PanelGates: {
+		Icon:        "🚪",
+		Title:       "Gates",
+		ShortDesc:   "Dominated Set Size",
+		WhatIs:      "Beads that **every path** to other work goes through (dominator tree over blocking and parent-child deps).",
+		WhyUseful:   "A gate is a *single point of no progress*: nothing it dominates can start until it is done.",
+		HowToUse:    "**Staff gates first** and keep them small; split a gate to open parallel routes.",
+		FormulaHint: "`Y dom X` ⇔ every path start→X passes Y; value = |{X : Y dom X}|",
+	},
 	PanelPriority: {
 		Icon:        "🎯",
 		Title:       "Priority",
@@ -141,6 +151,10 @@ type InsightsModel struct {
 	labelAttention []analysis.LabelAttentionScore
 	labelFlow      *analysis.CrossLabelFlow
 
+	// Dominator-tree gates, recomputed with the issue map
+	gates     *analysis.GateAnalysis
+	gateItems []analysis.InsightItem
+
 	// Priority triage data (bv-91)
 	topPicks []analysis.TopPick
 
@@ -192,9 +206,8 @@ func NewInsightsModel(ins analysis.Insights, issueMap map[string]*model.Issue, t
 		BorderForeground(theme.Primary).
 		Padding(0, 1)
 
-	return InsightsModel{
+	m := InsightsModel{
 		insights:         ins,
-		issueMap:         issueMap,
 		theme:            theme,
 		showExplanations: true,  // Visible by default
 		showCalculation:  true,  // Always show calculation details
@@ -202,6 +215,24 @@ func NewInsightsModel(ins analysis.Insights, issueMap map[string]*model.Issue, t
 		mdRenderer:       mdRenderer,
 		detailVP:         vp,
 	}
+	m.SetIssueMap(issueMap)
+	return m
+}
+
+// SetIssueMap replaces the issue lookup and recomputes the gates panel
+func (m *InsightsModel) SetIssueMap(issueMap map[string]*model.Issue) {
+	m.issueMap = issueMap
+	issues := make([]model.Issue, 0, len(issueMap))
+	for _, issue := range issueMap {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	m.gates = analysis.ComputeGates(issues)
+	m.gateItems = nil
+	for _, gate := range m.gates.TopGates(0) {
+		m.gateItems = append(m.gateItems, analysis.InsightItem{ID: gate.IssueID, Value: float64(gate.Dominates)})
+	}
 }
 
 func (m *InsightsModel) SetSize(w, h int) {
@@ -567,6 +598,8 @@ func (m *InsightsModel) currentPanelItemCount() int {
 		return len(m.insights.Slack)
 	case PanelCycles:
 		return len(m.insights.Cycles)
+	case PanelGates:
+		return len(m.gateItems)
 	case PanelPriority:
 		return len(m.topPicks)
 	default:
@@ -597,6 +630,8 @@ func (m *InsightsModel) getPanelItems(panel MetricPanel) []analysis.InsightItem
 		return items
 	case PanelSlack:
 		return m.insights.Slack
+	case PanelGates:
+		return m.gateItems
 	default:
 		return nil
 	}
@@ -699,14 +734,17 @@ func (m *InsightsModel) View() string {
 	row1 := lipgloss.JoinHorizontal(lipgloss.Top, panels[0], panels[1], panels[2])
 	row2 := lipgloss.JoinHorizontal(lipgloss.Top, panels[3], panels[4], panels[5])
 	row3 := lipgloss.JoinHorizontal(lipgloss.Top, panels[6], panels[7], panels[8])
-	// Priority panel spans full width for prominence (bv-91)
+	// Priority panel takes the rest of the row next to gates (bv-91)
 	// Toggle between priority list and heatmap view (bv-95)
-	var row4 string
+	gatesPanel := m.renderMetricPanel(PanelGates, colWidth, rowHeight, t)
+	priorityWidth := mainWidth - 2 - lipgloss.Width(gatesPanel)
+	var priorityPanel string
 	if m.showHeatmap {
-		row4 = m.renderHeatmapPanel(mainWidth-2, rowHeight, t)
+		priorityPanel = m.renderHeatmapPanel(priorityWidth, rowHeight, t)
 	} else {
-		row4 = m.renderPriorityPanel(mainWidth-2, rowHeight, t)
+		priorityPanel = m.renderPriorityPanel(priorityWidth, rowHeight, t)
 	}
+	row4 := lipgloss.JoinHorizontal(lipgloss.Top, gatesPanel, priorityPanel)
 
 	mainContent := lipgloss.JoinVertical(lipgloss.Left, row1, row2, row3, row4)
 
@@ -1917,6 +1955,45 @@ func (m *InsightsModel) renderCalculationProofMD(selectedID string) string {
 			sb.WriteString("> These beads form a circular dependency. *Break the cycle* by removing or reversing one edge.\n\n")
 		}
 
+	case PanelGates:
+		if m.gates == nil {
+			break
+		}
+		report := m.gates.Report(selectedID)
+		if report == nil {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("**Dominates:** `%d` beads that cannot start before this one\n\n", report.Dominates))
+		if len(report.GatingChain) > 0 {
+			sb.WriteString("**Gated by (outermost first):**\n")
+			for _, gate := range report.GatingChain {
+				sb.WriteString(fmt.Sprintf("- ⊳ %s\n", m.getBeadTitle(gate.IssueID, 40)))
+			}
+			sb.WriteString("\n")
+		}
+		if len(report.Dominated) > 0 {
+			sb.WriteString("**Waits on this bead:**\n")
+			for i, id := range report.Dominated {
+				if i >= 5 {
+					sb.WriteString(fmt.Sprintf("- _...+%d more_\n", len(report.Dominated)-5))
+					break
+				}
+				sb.WriteString(fmt.Sprintf("- ↓ %s\n", m.getBeadTitle(id, 40)))
+			}
+			sb.WriteString("\n")
+		}
+		for _, epic := range report.Epics {
+			sb.WriteString(fmt.Sprintf("**Feeds epic** %s", m.getBeadTitle(epic.EpicID, 30)))
+			if len(epic.Chain) > 0 {
+				ids := make([]string, 0, len(epic.Chain))
+				for _, gate := range epic.Chain {
+					ids = append(ids, gate.IssueID)
+				}
+				sb.WriteString(fmt.Sprintf(" via `%s`", strings.Join(ids, " → ")))
+			}
+			sb.WriteString("\n\n")
+		}
+
 	default:
 		// For other panels, show generic info
 		sb.WriteString(fmt.Sprintf("> %s\n\n", info.HowToUse))
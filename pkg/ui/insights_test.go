@@ -1,6 +1,7 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -546,6 +547,43 @@ func TestInsightsModelLongCycleChain(t *testing.T) {
 	_ = m.View()
 }
 
+// TestInsightsModelGatesPanel verifies the dominator-tree gates panel
+func TestInsightsModelGatesPanel(t *testing.T) {
+	theme := createTheme()
+	issueMap := map[string]*model.Issue{
+		"schema": {ID: "schema", Title: "Schema", Status: model.StatusOpen},
+		"model":  {ID: "model", Title: "Model", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "schema", Type: model.DepBlocks}}},
+		"api":    {ID: "api", Title: "API", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "model", Type: model.DepBlocks}}},
+		"docs":   {ID: "docs", Title: "Docs", Status: model.StatusOpen},
+	}
+
+	m := ui.NewInsightsModel(analysis.Insights{}, issueMap, theme)
+	m.SetSize(180, 50)
+
+	// Navigate to gates panel (after cycles)
+	for i := 0; i < 9; i++ {
+		m.NextPanel()
+	}
+	if id := m.SelectedIssueID(); id != "schema" {
+		t.Errorf("Expected schema (dominates 2) first, got %s", id)
+	}
+	m.MoveDown()
+	if id := m.SelectedIssueID(); id != "model" {
+		t.Errorf("Expected model second, got %s", id)
+	}
+	if view := m.View(); !strings.Contains(view, "Gates (2)") {
+		t.Error("Expected the gates panel header with two gates")
+	}
+
+	// Closing the gate drops it from the panel
+	issueMap["schema"].Status = model.StatusClosed
+	m.SetIssueMap(issueMap)
+	m.MoveUp()
+	if id := m.SelectedIssueID(); id != "model" {
+		t.Errorf("Expected model to remain the only gate, got %s", id)
+	}
+}
+
 // TestInsightsModelScrolling verifies scrolling behavior with many items
 func TestInsightsModelScrolling(t *testing.T) {
 	theme := createTheme()
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// LexicalFilter ranks the list filter with a BM25 index instead of fuzzy subsequence
+// scores. Items only the fuzzy matcher finds (status, assignee, abbreviations) follow the
+// BM25 hits, so filtering never loses a match it used to show.
+//
+// Comments, correlated commit messages and touched file paths are indexed as separate
+// documents and grouped back to their issue; the best match per issue is kept so the
+// detail pane can say where the term was found.
+//
+// The index is built lazily on the first query and kept until the indexed text changes.
+type LexicalFilter struct {
+	mu          sync.Mutex
+	issueDocs   []search.SourceDocument
+	historyDocs []search.SourceDocument
+	dataHash    string
+	index       *search.SourceIndex
+	positions   map[string]int // issue ID -> list index
+
+	matchTerm string
+	matches   map[string]search.SourceMatch // issue ID -> best match for matchTerm
+}
+
+func NewLexicalFilter() *LexicalFilter {
+	return &LexicalFilter{}
+}
+
+// SetIssues updates the issues shown in the list, in list order.
+func (f *LexicalFilter) SetIssues(issues []model.Issue) {
+	docs := search.SourceDocumentsFromIssues(issues)
+	positions := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		positions[issue.ID] = i
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issueDocs = docs
+	f.positions = positions
+	f.invalidate()
+}
+
+// SetHistory adds commit messages and file paths from a correlation report.
+func (f *LexicalFilter) SetHistory(report *correlation.HistoryReport) {
+	docs := search.SourceDocumentsFromHistory(report)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.historyDocs = docs
+	f.invalidate()
+}
+
+// invalidate drops the index if the indexed text changed. Callers hold f.mu.
+func (f *LexicalFilter) invalidate() {
+	hash := search.SourceDataHash(f.allDocs())
+	if hash != f.dataHash {
+		f.dataHash = hash
+		f.index = nil
+		f.matchTerm, f.matches = "", nil
+	}
+}
+
+func (f *LexicalFilter) allDocs() []search.SourceDocument {
+	docs := make([]search.SourceDocument, 0, len(f.issueDocs)+len(f.historyDocs))
+	return append(append(docs, f.issueDocs...), f.historyDocs...)
+}
+
+// Index returns the BM25 index for the current issues, building it if needed.
+func (f *LexicalFilter) Index() *search.SourceIndex {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.index == nil && len(f.issueDocs) > 0 {
+		f.index = search.NewSourceIndex(f.allDocs())
+	}
+	return f.index
+}
+
+func (f *LexicalFilter) snapshot() (*search.SourceIndex, map[string]int) {
+	idx := f.Index()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return idx, f.positions
+}
+
+// Match returns where the last filter for term matched issueID.
+func (f *LexicalFilter) Match(term, issueID string) (search.SourceMatch, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if term == "" || term != f.matchTerm {
+		return search.SourceMatch{}, false
+	}
+	m, ok := f.matches[issueID]
+	return m, ok
+}
+
+// Filter implements list.FilterFunc.
+func (f *LexicalFilter) Filter(term string, targets []string) []list.Rank {
+	fuzzy := list.DefaultFilter(term, targets)
+	if term == "" {
+		return fuzzy
+	}
+	idx, positions := f.snapshot()
+	if idx == nil || len(positions) != len(targets) {
+		return fuzzy
+	}
+
+	byIndex := make(map[int]list.Rank, len(fuzzy))
+	for _, r := range fuzzy {
+		byIndex[r.Index] = r
+	}
+	hits := idx.SearchPrefix(term, 0)
+	// Equal scores keep the list's own order (priority, sort mode) rather than ID order.
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return positions[hits[a].IssueID] < positions[hits[b].IssueID]
+	})
+	ranks := make([]list.Rank, 0, len(fuzzy))
+	seen := make(map[int]bool)
+	matches := make(map[string]search.SourceMatch, len(hits))
+	for _, hit := range hits {
+		i, ok := positions[hit.IssueID]
+		if !ok {
+			continue
+		}
+		rank := byIndex[i] // keeps fuzzy match highlighting where there is one
+		rank.Index = i
+		ranks = append(ranks, rank)
+		seen[i] = true
+		if len(hit.Matches) > 0 {
+			matches[hit.IssueID] = hit.Matches[0]
+		}
+	}
+	for _, r := range fuzzy {
+		if !seen[r.Index] {
+			ranks = append(ranks, r)
+		}
+	}
+
+	f.mu.Lock()
+	f.matchTerm, f.matches = term, matches
+	f.mu.Unlock()
+	return ranks
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func lexicalFilterFixture() ([]model.Issue, []string) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Update changelog", Description: "Mention the login fix.", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Login fails after logout", Status: model.StatusOpen},
+		{ID: "bv-3", Title: "Dark mode", Status: model.StatusBlocked},
+	}
+	targets := make([]string, len(issues))
+	for i, issue := range issues {
+		targets[i] = IssueItem{Issue: issue}.FilterValue()
+	}
+	return issues, targets
+}
+
+func rankIndexes(t *testing.T, f *LexicalFilter, term string, targets []string) []int {
+	t.Helper()
+	var out []int
+	for _, r := range f.Filter(term, targets) {
+		out = append(out, r.Index)
+	}
+	return out
+}
+
+func TestLexicalFilter_RanksWithBM25(t *testing.T) {
+	issues, targets := lexicalFilterFixture()
+	f := NewLexicalFilter()
+	f.SetIssues(issues)
+
+	// The title match beats the description match, whatever the list order.
+	got := rankIndexes(t, f, "login", targets)
+	if len(got) < 2 || got[0] != 1 || got[1] != 0 {
+		t.Fatalf("expected [1 0 ...], got %v", got)
+	}
+
+	// A partially typed word matches as a prefix.
+	if got := rankIndexes(t, f, "chang", targets); len(got) == 0 || got[0] != 0 {
+		t.Fatalf("expected the changelog issue first for a prefix, got %v", got)
+	}
+
+	// Fields the index doesn't cover still match through the fuzzy fallback.
+	if got := rankIndexes(t, f, "blocked", targets); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected the blocked issue via fuzzy matching, got %v", got)
+	}
+}
+
+func TestLexicalFilter_RebuildsOnlyWhenTextChanges(t *testing.T) {
+	issues, targets := lexicalFilterFixture()
+	f := NewLexicalFilter()
+	f.SetIssues(issues)
+	first := f.Index()
+
+	// Reordering or filtering the list keeps the index when the text is unchanged.
+	f.SetIssues([]model.Issue{issues[2], issues[1], issues[0]})
+	if f.Index() != first {
+		t.Error("expected the index to be reused for the same issues")
+	}
+	reversed := []string{targets[2], targets[1], targets[0]}
+	if got := rankIndexes(t, f, "login", reversed); len(got) < 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("expected positions to follow the new list order, got %v", got)
+	}
+
+	issues[2].Title = "Dark mode login toggle"
+	f.SetIssues(issues)
+	if f.Index() == first {
+		t.Error("expected an edit to rebuild the index")
+	}
+
+	// Without a matching ID mapping it falls back to fuzzy filtering.
+	if got := f.Filter("login", targets[:2]); len(got) == 0 {
+		t.Error("expected fuzzy results when targets don't match the issues")
+	}
+}
+
+func TestLexicalFilter_RecordsMatchSource(t *testing.T) {
+	issues, targets := lexicalFilterFixture()
+	issues[2].Comments = []*model.Comment{{Author: "kim", Text: "Contrast is off in the sidebar."}}
+	f := NewLexicalFilter()
+	f.SetIssues(issues)
+
+	if got := rankIndexes(t, f, "sidebar", targets); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected the comment match, got %v", got)
+	}
+	m, ok := f.Match("sidebar", "bv-3")
+	if !ok || m.Describe() != "matched in comment by kim" || !strings.Contains(m.Snippet, "sidebar") {
+		t.Fatalf("unexpected match %+v (ok=%v)", m, ok)
+	}
+	if _, ok := f.Match("side", "bv-3"); ok {
+		t.Error("expected no match for a term that wasn't filtered")
+	}
+
+	// Correlated commits become searchable once history arrives.
+	if got := rankIndexes(t, f, "scrollbar", targets); len(got) != 0 {
+		t.Fatalf("expected no matches before history loads, got %v", got)
+	}
+	f.SetHistory(&correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"bv-1": {Commits: []correlation.CorrelatedCommit{{SHA: "abc1234ff", ShortSHA: "abc1234", Message: "Fix scrollbar jitter"}}},
+	}})
+	if got := rankIndexes(t, f, "scrollbar", targets); len(got) != 1 || got[0] != 0 {
+		t.Fatalf("expected the commit match, got %v", got)
+	}
+	if m, _ := f.Match("scrollbar", "bv-1"); m.Describe() != "matched in commit abc1234" {
+		t.Errorf("unexpected match %+v", m)
+	}
+}
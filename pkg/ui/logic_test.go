@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -181,3 +183,31 @@ func TestFormatTimeRel(t *testing.T) {
 		}
 	}
 }
+
+func TestReloadTUITriageOptions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { _ = reloadTUITriageOptions() })
+	if err := os.MkdirAll(".bv", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(".bv", "triage.yaml"), []byte("formula: pagerank +\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloadTUITriageOptions(); err == nil {
+		t.Fatal("expected an error for an invalid formula")
+	}
+	if tuiTriageStatus() == "" || tuiTriageOptions().Formula != nil {
+		t.Errorf("expected built-in scoring and a status message, got %q", tuiTriageStatus())
+	}
+
+	if err := os.WriteFile(filepath.Join(".bv", "triage.yaml"), []byte("formula: pagerank + betweenness\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloadTUITriageOptions(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if tuiTriageStatus() != "" || tuiTriageOptions().Formula == nil {
+		t.Errorf("expected the cached formula, status %q", tuiTriageStatus())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
@@ -61,12 +62,18 @@ const (
 	focusHistory
 	focusAttention
 	focusLabelPicker
-	focusSprint      // Sprint dashboard view (bv-161)
-	focusAgentPrompt // AGENTS.md integration prompt (bv-i8dk)
-	focusFlowMatrix  // Cross-label flow matrix view
-	focusTutorial    // Interactive tutorial (bv-8y31)
-	focusCassModal   // Cass session preview modal (bv-5bqh)
-	focusUpdateModal // Self-update modal (bv-182)
+	focusSprint             // Sprint dashboard view (bv-161)
+	focusAgentPrompt        // AGENTS.md integration prompt (bv-i8dk)
+	focusFlowMatrix         // Cross-label flow matrix view
+	focusTutorial           // Interactive tutorial (bv-8y31)
+	focusCassModal          // Cass session preview modal (bv-5bqh)
+	focusUpdateModal        // Self-update modal (bv-182)
+	focusFlowMetrics        // Cumulative flow, lead/cycle time, WIP aging
+	focusEpicDashboard      // Epic progress rollups
+	focusAssigneeDashboard  // Per-assignee workload and health
+	focusWorkspaceDashboard // Cross-repo workspace health
+	focusSprintPlan         // Next-sprint planner
+	focusSimilarPanel       // "More like this" suggestions
 )
 
 // SortMode represents the current list sorting mode (bv-3ita)
@@ -260,33 +267,30 @@ func CheckAgentFileCmd(workDir string) tea.Cmd {
 	}
 }
 
-// LoadHistoryCmd returns a command that loads history data in the background
-func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
-	return func() tea.Msg {
-		var repoPath string
-		var err error
-
-		if beadsPath != "" {
-			// If beadsPath is provided (single-repo mode), derive repo root from it.
-			// Try to resolve absolute path first.
-			if absPath, e := filepath.Abs(beadsPath); e == nil {
-				dir := filepath.Dir(absPath)
-				// Standard layout: <repo_root>/.beads/<file.jsonl>
-				if filepath.Base(dir) == ".beads" {
-					repoPath = filepath.Dir(dir)
-				} else {
-					// Legacy/Flat layout: <repo_root>/<file.jsonl>
-					repoPath = dir
-				}
+// repoPathForBeads derives the repo root from the beads file path, falling
+// back to the working directory when beadsPath is empty (workspace mode).
+func repoPathForBeads(beadsPath string) (string, error) {
+	if beadsPath != "" {
+		// Try to resolve absolute path first.
+		if absPath, e := filepath.Abs(beadsPath); e == nil {
+			dir := filepath.Dir(absPath)
+			// Standard layout: <repo_root>/.beads/<file.jsonl>
+			if filepath.Base(dir) == ".beads" {
+				return filepath.Dir(dir), nil
 			}
+			// Legacy/Flat layout: <repo_root>/<file.jsonl>
+			return dir, nil
 		}
+	}
+	return os.Getwd()
+}
 
-		// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
-		if repoPath == "" {
-			repoPath, err = os.Getwd()
-			if err != nil {
-				return HistoryLoadedMsg{Error: err}
-			}
+// LoadHistoryCmd returns a command that loads history data in the background
+func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := repoPathForBeads(beadsPath)
+		if err != nil {
+			return HistoryLoadedMsg{Error: err}
 		}
 
 		// Convert model.Issue to correlation.BeadInfo
@@ -355,7 +359,15 @@ type Model struct {
 	graphView          GraphModel
 	tree               TreeModel // Hierarchical tree view (bv-gllx)
 	insightsPanel      InsightsModel
-	flowMatrix         FlowMatrixModel // Cross-label flow matrix
+	flowMatrix         FlowMatrixModel         // Cross-label flow matrix
+	flowMetrics        FlowMetricsModel        // Cumulative flow and cycle time
+	epicDashboard      EpicDashboardModel      // Epic progress rollups
+	assigneeDashboard  AssigneeDashboardModel  // Per-assignee workload and health
+	workspaceDashboard WorkspaceDashboardModel // Cross-repo workspace health
+	sprintPlan         SprintPlanModel         // Next-sprint planner
+	similarPanel       SimilarPanelModel       // "More like this" suggestions
+	similarVectors     *search.VectorIndex     // Offline embeddings for the similar panel
+	similarVectorsHash string                  // Issue data hash similarVectors was (or is being) built for
 	theme              Theme
 
 	// Update State
@@ -408,6 +420,7 @@ type Model struct {
 	semanticSearchEnabled  bool
 	semanticIndexBuilding  bool
 	semanticSearch         *SemanticSearch
+	lexicalFilter          *LexicalFilter // BM25 ranking for keyword filtering
 	semanticHybridEnabled  bool
 	semanticHybridPreset   search.PresetName
 	semanticHybridBuilding bool
@@ -462,10 +475,11 @@ type Model struct {
 	statusIsError bool
 
 	// Workspace mode state
-	workspaceMode    bool            // True when viewing multiple repos
-	availableRepos   []string        // List of repo prefixes available
-	activeRepos      map[string]bool // Which repos are currently shown (nil = all)
-	workspaceSummary string          // Summary text for footer (e.g., "3 repos")
+	workspaceMode    bool                     // True when viewing multiple repos
+	availableRepos   []string                 // List of repo prefixes available
+	activeRepos      map[string]bool          // Which repos are currently shown (nil = all)
+	workspaceSummary string                   // Summary text for footer (e.g., "3 repos")
+	workspaceRepos   []analysis.WorkspaceRepo // Configured repos for workspace health
 
 	// Alerts panel (bv-168)
 	alerts          []drift.Alert
@@ -475,12 +489,14 @@ type Model struct {
 	showAlertsPanel bool
 	alertsCursor    int
 	dismissedAlerts map[string]bool
+	notifyAlerts    bool // Deliver alerts to .bv/notify.yaml sinks (--notify)
 
 	// Sprint view (bv-161)
 	sprints        []model.Sprint
 	selectedSprint *model.Sprint
 	isSprintView   bool
 	sprintViewText string
+	sprintView     SprintViewModel
 
 	// AGENTS.md integration (bv-i8dk)
 	showAgentPrompt  bool
@@ -594,9 +610,19 @@ type WorkspaceInfo struct {
 	FailedCount  int
 	TotalIssues  int
 	RepoPrefixes []string
+	Repos        []analysis.WorkspaceRepo // Every configured repo, including failed ones
 }
 
 func (m *Model) updateSemanticIDs(items []list.Item) {
+	if m.lexicalFilter != nil {
+		issues := make([]model.Issue, 0, len(items))
+		for _, it := range items {
+			if issueItem, ok := it.(IssueItem); ok {
+				issues = append(issues, issueItem.Issue)
+			}
+		}
+		m.lexicalFilter.SetIssues(issues)
+	}
 	if m.semanticSearch == nil {
 		return
 	}
@@ -613,6 +639,14 @@ func (m *Model) updateSemanticIDs(items []list.Item) {
 	m.semanticSearch.SetDocs(docs)
 }
 
+// keywordFilter is the list filter outside semantic mode.
+func (m *Model) keywordFilter() list.FilterFunc {
+	if m.lexicalFilter == nil {
+		return list.DefaultFilter
+	}
+	return m.lexicalFilter.Filter
+}
+
 func (m *Model) shouldShowSearchScores() bool {
 	if !m.semanticSearchEnabled || !m.semanticHybridEnabled || m.semanticSearch == nil {
 		return false
@@ -851,7 +885,8 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	priorityHints := make(map[string]*analysis.PriorityRecommendation)
 
 	// Compute triage insights (bv-151) - reuse existing analyzer/stats (bv-runn.12)
-	triageResult := analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
+	reloadTUITriageOptions()
+	triageResult := analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, tuiTriageOptions(), time.Now())
 	triageScores := make(map[string]float64, len(triageResult.Recommendations))
 	triageReasons := make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
 	quickWinSet := make(map[string]bool, len(triageResult.QuickWins))
@@ -964,13 +999,21 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	// Semantic search (bv-9gf.3): initialized lazily on first toggle.
 	semanticSearch := NewSemanticSearch()
 	semanticIDs := make([]string, 0, len(items))
+	listedIssues := make([]model.Issue, 0, len(items))
 	for _, it := range items {
 		if issueItem, ok := it.(IssueItem); ok {
 			semanticIDs = append(semanticIDs, issueItem.Issue.ID)
+			listedIssues = append(listedIssues, issueItem.Issue)
 		}
 	}
 	semanticSearch.SetIDs(semanticIDs)
 
+	// Keyword filtering ranks with BM25; semantic mode fuses its vectors with the same index.
+	lexicalFilter := NewLexicalFilter()
+	lexicalFilter.SetIssues(listedIssues)
+	semanticSearch.SetLexicalFilter(lexicalFilter)
+	l.Filter = lexicalFilter.Filter
+
 	// Build initial status message if watcher failed
 	var initialStatus string
 	var initialStatusErr bool
@@ -984,6 +1027,10 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		initialStatus = fmt.Sprintf("Live reload unavailable: %v", watcherErr)
 		initialStatusErr = true
 	}
+	if status := tuiTriageStatus(); status != "" {
+		initialStatus = status
+		initialStatusErr = true
+	}
 
 	// Precompute drift/health alerts (bv-168)
 	alerts, alertsCritical, alertsWarning, alertsInfo := computeAlerts(issues, graphStats, analyzer)
@@ -1026,6 +1073,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		theme:                  theme,
 		currentFilter:          "all",
 		semanticSearch:         semanticSearch,
+		lexicalFilter:          lexicalFilter,
 		semanticHybridEnabled:  false,
 		semanticHybridPreset:   search.PresetDefault,
 		semanticHybridBuilding: false,
@@ -1154,7 +1202,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Error != nil {
 			// If indexing fails, revert to fuzzy mode for predictable behavior.
 			m.semanticSearchEnabled = false
-			m.list.Filter = list.DefaultFilter
+			m.list.Filter = m.keywordFilter()
 			m.statusMsg = fmt.Sprintf("Semantic search unavailable: %v", msg.Error)
 			m.statusIsError = true
 			break
@@ -1266,7 +1314,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.snapshot.Insights = ins
 		}
 		m.insightsPanel.SetInsights(ins)
-		m.insightsPanel.issueMap = m.issueMap
+		m.insightsPanel.SetIssueMap(m.issueMap)
 		bodyHeight := m.height - 1
 		if bodyHeight < 5 {
 			bodyHeight = 5
@@ -1282,7 +1330,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Generate triage for priority panel (bv-91) - reuse existing analyzer/stats (bv-runn.12)
-		triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
+		triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, tuiTriageOptions(), time.Now())
 		m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
 
 		// Set full recommendations with breakdown for priority radar (bv-93)
@@ -1298,6 +1346,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Refresh alerts now that full Phase 2 metrics (cycles, etc.) are available
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
+		if cmd := m.notifyAlertsCmd(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 
 		// Invalidate label health cache since we have new graph metrics (criticality)
 		m.labelHealthCached = false
@@ -1384,6 +1435,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case SprintBurndownLoadedMsg:
+		m.sprintView.SetBurndown(msg.SprintID, msg.Burndown, msg.Err)
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Sprint burndown replay failed: %v", msg.Err)
+			m.statusIsError = true
+		}
+		if m.isSprintView {
+			m.sprintViewText = m.renderSprintDashboard()
+		}
+
+	case AlertsNotifiedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Alert notifications failed: %v", msg.Err)
+			m.statusIsError = true
+		} else if msg.Report != nil && (msg.Report.Delivered > 0 || msg.Report.Failed()) {
+			m.statusMsg = msg.Report.String()
+			m.statusIsError = msg.Report.Failed()
+		}
+
+	case SimilarVectorsReadyMsg:
+		m.applySimilarVectors(msg)
+
+	case RelatedDependencyAddedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Linking %s ↔ %s failed: %v", msg.FromID, msg.ToID, msg.Err)
+			m.statusIsError = true
+		} else {
+			m.similarPanel.MarkLinked(msg.ToID)
+			m.statusMsg = fmt.Sprintf("✅ Linked %s ↔ %s as related", msg.FromID, msg.ToID)
+			m.statusIsError = false
+		}
+
 	case HistoryLoadedMsg:
 		// Background history loading completed
 		m.historyLoading = false
@@ -1392,6 +1475,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = fmt.Sprintf("History load failed: %v", msg.Error)
 			m.statusIsError = true
 		} else if msg.Report != nil {
+			if m.lexicalFilter != nil {
+				m.lexicalFilter.SetHistory(msg.Report)
+			}
 			m.historyView = NewHistoryModel(msg.Report, m.theme)
 			m.historyView.SetSize(m.width, m.height-1)
 			// Refresh detail pane if visible
@@ -1508,7 +1594,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Regenerate sub-views (Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
 		m.insightsPanel.SetInsights(m.snapshot.Insights)
-		m.insightsPanel.issueMap = m.issueMap
+		m.insightsPanel.SetIssueMap(m.issueMap)
 		bodyHeight := m.height - 1
 		if bodyHeight < 5 {
 			bodyHeight = 5
@@ -1716,6 +1802,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues", len(m.issues))
 		}
 		m.statusIsError = false
+		if status := tuiTriageStatus(); status != "" {
+			m.statusMsg = status
+			m.statusIsError = true
+		}
 
 		// Wait for Phase 2 if not ready
 		if msg.Snapshot.Analysis != nil {
@@ -1794,6 +1884,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, tea.Batch(cmds...)
 		}
+		reloadTUITriageOptions()
 
 		// Store selected issue ID to restore position after reload
 		var selectedID string
@@ -1958,6 +2049,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg += fmt.Sprintf(" (%d warnings)", len(reloadWarnings))
 		}
 		m.statusIsError = false
+		if status := tuiTriageStatus(); status != "" {
+			m.statusMsg = status
+			m.statusIsError = true
+		}
 		// Invalidate label-derived caches
 		m.labelHealthCached = false
 		m.labelDrilldownCache = make(map[string][]model.Issue)
@@ -2402,7 +2497,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				} else {
 					m.semanticSearchEnabled = false
-					m.list.Filter = list.DefaultFilter
+					m.list.Filter = m.keywordFilter()
 					m.statusMsg = "Semantic search unavailable"
 					m.statusIsError = true
 				}
@@ -2411,7 +2506,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, BuildHybridMetricsCmd(m.issuesForAsync()))
 				}
 			} else {
-				m.list.Filter = list.DefaultFilter
+				m.list.Filter = m.keywordFilter()
 				m.statusMsg = "Fuzzy search enabled"
 				m.clearSemanticScores()
 			}
@@ -2484,6 +2579,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusFlowMetrics {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusEpicDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusAssigneeDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusWorkspaceDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusSprintPlan {
+					m.closeSprintPlan()
+					return m, nil
+				}
+				if m.focused == focusSimilarPanel {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isSprintView {
+					m.isSprintView = false
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2515,6 +2639,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusFlowMetrics {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusEpicDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusAssigneeDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusWorkspaceDashboard {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.focused == focusSprintPlan {
+					m.closeSprintPlan()
+					return m, nil
+				}
+				if m.focused == focusSimilarPanel {
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isSprintView {
+					m.isSprintView = false
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2655,7 +2808,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if hasInsights {
 						m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
 						// Include priority triage (bv-91) - reuse existing analyzer/stats (bv-runn.12)
-						triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
+						triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, tuiTriageOptions(), time.Now())
 						m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
 						// Set full recommendations with breakdown for priority radar (bv-93)
 						dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
@@ -2818,10 +2971,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case "x":
+				// In the sprint dashboard, export the git-replayed burndown
+				if m.focused == focusSprint {
+					m.exportSprintBurndownCSV()
+					return m, nil
+				}
 				// Export to Markdown file
 				m.exportToMarkdown()
 				return m, nil
 
+			case "P":
+				// Open the sprint dashboard from the list; inside it, P closes
+				// via handleSprintKeys (bv-161)
+				if m.focused == focusList {
+					return m, m.openSprintView()
+				}
+
 			case "l":
 				// Open label picker for quick filter (bv-126)
 				if len(m.issues) == 0 {
@@ -2902,12 +3067,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case focusSprint:
 				m = m.handleSprintKeys(msg)
+				if m.isSprintView {
+					cmds = append(cmds, m.sprintBurndownCmd())
+				}
 
 			case focusFlowMatrix:
 				m = m.handleFlowMatrixKeys(msg)
 
+			case focusFlowMetrics:
+				m = m.handleFlowMetricsKeys(msg)
+
+			case focusEpicDashboard:
+				m = m.handleEpicDashboardKeys(msg)
+
+			case focusAssigneeDashboard:
+				m = m.handleAssigneeDashboardKeys(msg)
+
+			case focusWorkspaceDashboard:
+				m = m.handleWorkspaceDashboardKeys(msg)
+
+			case focusSprintPlan:
+				m, cmd = m.handleSprintPlanKeys(msg)
+				cmds = append(cmds, cmd)
+
+			case focusSimilarPanel:
+				m, cmd = m.handleSimilarPanelKeys(msg)
+				cmds = append(cmds, cmd)
+
 			case focusList:
 				m = m.handleListKeys(msg)
+				if m.focused == focusSimilarPanel {
+					cmds = append(cmds, m.similarVectorsCmd())
+				}
 
 			case focusDetail:
 				m.viewport, cmd = m.viewport.Update(msg)
@@ -2945,6 +3136,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historyView.MoveUp()
 			case focusFlowMatrix:
 				m.flowMatrix.MoveUp()
+			case focusFlowMetrics:
+				m.flowMetrics.MoveUp()
+			case focusEpicDashboard:
+				m.epicDashboard.MoveUp()
+			case focusAssigneeDashboard:
+				m.assigneeDashboard.MoveUp()
+			case focusWorkspaceDashboard:
+				m.workspaceDashboard.MoveUp()
+			case focusSprintPlan:
+				m.sprintPlan.MoveUp()
+			case focusSimilarPanel:
+				m.similarPanel.MoveUp()
 			}
 			return m, nil
 		case tea.MouseButtonWheelDown:
@@ -2974,6 +3177,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historyView.MoveDown()
 			case focusFlowMatrix:
 				m.flowMatrix.MoveDown()
+			case focusFlowMetrics:
+				m.flowMetrics.MoveDown()
+			case focusEpicDashboard:
+				m.epicDashboard.MoveDown()
+			case focusAssigneeDashboard:
+				m.assigneeDashboard.MoveDown()
+			case focusWorkspaceDashboard:
+				m.workspaceDashboard.MoveDown()
+			case focusSprintPlan:
+				m.sprintPlan.MoveDown()
+			case focusSimilarPanel:
+				m.similarPanel.MoveDown()
 			}
 			return m, nil
 		}
@@ -3290,6 +3505,15 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "c":
+		// Toggle color-by-cluster (community detection)
+		m.graphView.ToggleClusterColors()
+		if m.graphView.ClusterMode() {
+			m.statusMsg = fmt.Sprintf("Coloring by cluster (%d clusters)", m.graphView.ClusterCount())
+		} else {
+			m.statusMsg = "Coloring by status"
+		}
+		m.statusIsError = false
 	case "enter":
 		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -3783,6 +4007,175 @@ func (m Model) handleFlowMatrixKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// openFlowMetrics computes flow metrics (using git history when loaded) and
+// switches to the flow metrics panel
+func (m *Model) openFlowMetrics() {
+	var history *correlation.HistoryReport
+	if !m.historyLoading && !m.historyLoadFailed {
+		history = m.historyView.report
+	}
+	m.flowMetrics = NewFlowMetricsModel(m.theme)
+	m.flowMetrics.SetData(m.issues, history)
+	m.flowMetrics.SetSize(m.width, m.height-1)
+	m.focused = focusFlowMetrics
+	if history == nil {
+		m.statusMsg = "Flow metrics from snapshot timestamps (git history not loaded)"
+	} else {
+		m.statusMsg = "Flow metrics from git history"
+	}
+	m.statusIsError = false
+}
+
+// handleFlowMetricsKeys handles keyboard input when the flow metrics panel is focused
+func (m Model) handleFlowMetricsKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "F", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.flowMetrics.MoveDown()
+	case "k", "up":
+		m.flowMetrics.MoveUp()
+	case "enter":
+		if label := m.flowMetrics.SelectedLabel(); label != "" {
+			m.currentFilter = "label:" + label
+			m.applyFilter()
+		}
+		m.focused = focusList
+	}
+	return m
+}
+
+// openEpicDashboard computes epic rollups and switches to the epic dashboard
+func (m *Model) openEpicDashboard() {
+	m.epicDashboard = NewEpicDashboardModel(m.theme)
+	m.epicDashboard.SetData(m.issues)
+	m.epicDashboard.SetSize(m.width, m.height-1)
+	m.focused = focusEpicDashboard
+}
+
+// handleEpicDashboardKeys handles keyboard input when the epic dashboard is focused
+func (m Model) handleEpicDashboardKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "e", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.epicDashboard.MoveDown()
+	case "k", "up":
+		m.epicDashboard.MoveUp()
+	case "enter":
+		selectedID := m.epicDashboard.SelectedEpicID()
+		if selectedID == "" {
+			return m
+		}
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+			m.focused = focusDetail
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	}
+	return m
+}
+
+// openAssigneeDashboard computes assignee health (using git history when
+// loaded) and switches to the assignee dashboard
+func (m *Model) openAssigneeDashboard() {
+	var history *correlation.HistoryReport
+	if !m.historyLoading && !m.historyLoadFailed {
+		history = m.historyView.report
+	}
+	m.assigneeDashboard = NewAssigneeDashboardModel(m.theme)
+	m.assigneeDashboard.SetData(m.issues, history)
+	m.assigneeDashboard.SetSize(m.width, m.height-1)
+	m.focused = focusAssigneeDashboard
+}
+
+// openWorkspaceDashboard computes cross-repo health and switches to the
+// workspace dashboard. Only meaningful in workspace mode.
+func (m *Model) openWorkspaceDashboard() {
+	if !m.workspaceMode {
+		m.statusMsg = "Workspace dashboard available only in workspace mode"
+		m.statusIsError = false
+		return
+	}
+	m.workspaceDashboard = NewWorkspaceDashboardModel(m.theme)
+	m.workspaceDashboard.SetData(m.issues, m.workspaceRepos)
+	m.workspaceDashboard.SetSize(m.width, m.height-1)
+	m.focused = focusWorkspaceDashboard
+}
+
+// handleWorkspaceDashboardKeys handles keyboard input when the workspace dashboard is focused
+func (m Model) handleWorkspaceDashboardKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "R", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.workspaceDashboard.MoveDown()
+	case "k", "up":
+		m.workspaceDashboard.MoveUp()
+	case "enter":
+		selectedID := m.workspaceDashboard.FocusIssueID()
+		if selectedID == "" {
+			return m
+		}
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+			m.focused = focusDetail
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	}
+	return m
+}
+
+// handleAssigneeDashboardKeys handles keyboard input when the assignee dashboard is focused
+func (m Model) handleAssigneeDashboardKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "W", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.assigneeDashboard.MoveDown()
+	case "k", "up":
+		m.assigneeDashboard.MoveUp()
+	case "enter":
+		selectedID := m.assigneeDashboard.FocusIssueID()
+		if selectedID == "" {
+			return m
+		}
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+			m.focused = focusDetail
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	}
+	return m
+}
+
 // handleRecipePickerKeys handles keyboard input when recipe picker is focused
 func (m Model) handleRecipePickerKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -4001,6 +4394,21 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		if !m.isHistoryView {
 			m.enterHistoryView()
 		}
+	case "F":
+		// Flow metrics: cumulative flow, lead/cycle time, throughput, WIP aging
+		m.openFlowMetrics()
+	case "e":
+		// Epic progress dashboard: rollups across child epics
+		m.openEpicDashboard()
+	case "W":
+		// Assignee workload dashboard: WIP, blockers, stale claims, trends
+		m.openAssigneeDashboard()
+	case "R":
+		// Workspace health dashboard: per-repo health and cross-repo blockers
+		m.openWorkspaceDashboard()
+	case "M":
+		// "More like this": similar issues with the evidence for each
+		m.openSimilarPanel()
 	case "S":
 		// Apply triage recipe - sort by triage score (bv-151)
 		if r := m.recipeLoader.Get("triage"); r != nil {
@@ -4080,6 +4488,24 @@ func (m Model) restoreFocusFromHelp() focus {
 	if m.focusBeforeHelp == focusFlowMatrix {
 		return focusFlowMatrix
 	}
+	if m.focusBeforeHelp == focusFlowMetrics {
+		return focusFlowMetrics
+	}
+	if m.focusBeforeHelp == focusEpicDashboard {
+		return focusEpicDashboard
+	}
+	if m.focusBeforeHelp == focusAssigneeDashboard {
+		return focusAssigneeDashboard
+	}
+	if m.focusBeforeHelp == focusWorkspaceDashboard {
+		return focusWorkspaceDashboard
+	}
+	if m.focusBeforeHelp == focusSprintPlan {
+		return focusSprintPlan
+	}
+	if m.focusBeforeHelp == focusSimilarPanel {
+		return focusSimilarPanel
+	}
 	if m.focusBeforeHelp == focusAttention {
 		return focusAttention
 	}
@@ -4205,6 +4631,24 @@ func (m Model) View() string {
 	} else if m.focused == focusFlowMatrix {
 		m.flowMatrix.SetSize(m.width, m.height-1)
 		body = m.flowMatrix.View()
+	} else if m.focused == focusFlowMetrics {
+		m.flowMetrics.SetSize(m.width, m.height-1)
+		body = m.flowMetrics.View()
+	} else if m.focused == focusEpicDashboard {
+		m.epicDashboard.SetSize(m.width, m.height-1)
+		body = m.epicDashboard.View()
+	} else if m.focused == focusAssigneeDashboard {
+		m.assigneeDashboard.SetSize(m.width, m.height-1)
+		body = m.assigneeDashboard.View()
+	} else if m.focused == focusWorkspaceDashboard {
+		m.workspaceDashboard.SetSize(m.width, m.height-1)
+		body = m.workspaceDashboard.View()
+	} else if m.focused == focusSprintPlan {
+		m.sprintPlan.SetSize(m.width, m.height-1)
+		body = m.sprintPlan.View()
+	} else if m.focused == focusSimilarPanel {
+		m.similarPanel.SetSize(m.width, m.height-1)
+		body = m.similarPanel.View()
 	} else if m.focused == focusTree {
 		// Hierarchical tree view (bv-gllx)
 		m.tree.SetSize(m.width, m.height-1)
@@ -4544,6 +4988,12 @@ func (m *Model) renderHelpOverlay() string {
 		{"h", "History view"},
 		{"a", "Actionable"},
 		{"f", "Flow matrix"},
+		{"F", "Flow metrics (CFD)"},
+		{"e", "Epic progress"},
+		{"W", "Assignee workload"},
+		{"R", "Workspace health"},
+		{"M", "More like this"},
+		{"P", "Sprint dashboard"},
 		{"[", "Label dashboard"},
 		{"]", "Attention view"},
 	}
@@ -4576,6 +5026,7 @@ func (m *Model) renderHelpOverlay() string {
 		{"H/L", "Scroll left/right"},
 		{"PgUp/Dn", "Scroll up/down"},
 		{"Enter", "Jump to issue"},
+		{"c", "Color by cluster"},
 	}
 
 	insightsSection := []struct{ key, desc string }{
@@ -5639,8 +6090,22 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, keyStyle.Render("A")+" attention", keyStyle.Render("F")+" flow")
 	} else if m.focused == focusFlowMatrix {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" panel", keyStyle.Render("⏎")+" drill", keyStyle.Render("esc")+" back", keyStyle.Render("f")+" close")
+	} else if m.focused == focusFlowMetrics {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" scope", keyStyle.Render("⏎")+" filter", keyStyle.Render("esc")+" back", keyStyle.Render("F")+" close")
+	} else if m.focused == focusEpicDashboard {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" open", keyStyle.Render("esc")+" back", keyStyle.Render("e")+" close")
+	} else if m.focused == focusAssigneeDashboard {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" open", keyStyle.Render("esc")+" back", keyStyle.Render("W")+" close")
+	} else if m.focused == focusWorkspaceDashboard {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" repo", keyStyle.Render("⏎")+" open", keyStyle.Render("esc")+" back", keyStyle.Render("R")+" close")
+	} else if m.focused == focusSprintPlan {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("+/-")+" capacity", keyStyle.Render("s")+" save", keyStyle.Render("⏎")+" open", keyStyle.Render("esc")+" back")
+	} else if m.focused == focusSimilarPanel {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("r")+" link", keyStyle.Render("⏎")+" open", keyStyle.Render("esc")+" back", keyStyle.Render("M")+" close")
+	} else if m.isSprintView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" sprint", keyStyle.Render("x")+" csv", keyStyle.Render("esc")+" back", keyStyle.Render("P")+" close")
 	} else if m.isGraphView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
+		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("c")+" cluster", keyStyle.Render("g")+" list")
 	} else if m.isBoardView {
 		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("G")+" bottom", keyStyle.Render("⏎")+" view", keyStyle.Render("b")+" list")
 	} else if m.isActionableView {
@@ -6287,6 +6752,13 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString(fmt.Sprintf("**Labels:** %s\n\n", strings.Join(item.Labels, ", ")))
 	}
 
+	// Where the keyword filter matched (comment, commit or touched file)
+	if m.lexicalFilter != nil && m.list.FilterState() != list.Unfiltered {
+		if match, ok := m.lexicalFilter.Match(m.list.FilterValue(), item.ID); ok {
+			sb.WriteString(fmt.Sprintf("**🔎 %s:** %s\n\n", match.Describe(), match.Snippet))
+		}
+	}
+
 	// Triage Insights (bv-151)
 	if issueItem.TriageScore > 0 || issueItem.TriageReason != "" || issueItem.UnblocksCount > 0 || issueItem.IsQuickWin || issueItem.IsBlocker {
 		sb.WriteString("### 🎯 Triage Insights\n")
@@ -6552,6 +7024,13 @@ func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	m.workspaceMode = info.Enabled
 	m.availableRepos = normalizeRepoPrefixes(info.RepoPrefixes)
 	m.activeRepos = nil // nil means all repos are active
+	m.workspaceRepos = info.Repos
+	if len(m.workspaceRepos) == 0 {
+		for _, prefix := range info.RepoPrefixes {
+			name := strings.TrimRight(prefix, "-_:")
+			m.workspaceRepos = append(m.workspaceRepos, analysis.WorkspaceRepo{Name: name, Prefix: prefix})
+		}
+	}
 
 	if info.RepoCount > 0 {
 		if info.FailedCount > 0 {
@@ -6758,6 +7237,18 @@ func (m Model) FocusState() string {
 		return "agent_prompt"
 	case focusFlowMatrix:
 		return "flow_matrix"
+	case focusFlowMetrics:
+		return "flow_metrics"
+	case focusEpicDashboard:
+		return "epic_dashboard"
+	case focusAssigneeDashboard:
+		return "assignee_dashboard"
+	case focusWorkspaceDashboard:
+		return "workspace_dashboard"
+	case focusSprintPlan:
+		return "sprint_plan"
+	case focusSimilarPanel:
+		return "similar_panel"
 	case focusTutorial:
 		return "tutorial"
 	case focusCassModal:
@@ -7439,6 +7930,51 @@ func (m *Model) clearAttentionOverlay() {
 	}
 }
 
+// ════════════════════════════════════════════════════════════════════════════
+// TRIAGE SCORING
+// ════════════════════════════════════════════════════════════════════════════
+
+// tuiTriage caches the compiled .bv/triage.yaml so triage recomputes don't
+// re-read it. It is loaded at startup and on every data reload.
+var tuiTriage struct {
+	sync.RWMutex
+	opts analysis.TriageOptions
+	err  error
+}
+
+// reloadTUITriageOptions re-reads .bv/triage.yaml. An invalid file falls back
+// to built-in scoring; the error is returned for the status bar.
+func reloadTUITriageOptions() error {
+	projectDir, _ := os.Getwd()
+	formula, err := analysis.LoadTriageFormula(projectDir)
+
+	tuiTriage.Lock()
+	defer tuiTriage.Unlock()
+	tuiTriage.opts = analysis.TriageOptions{}
+	if err == nil {
+		tuiTriage.opts.Formula = formula
+	}
+	tuiTriage.err = err
+	return err
+}
+
+// tuiTriageOptions returns the cached triage scoring options.
+func tuiTriageOptions() analysis.TriageOptions {
+	tuiTriage.RLock()
+	defer tuiTriage.RUnlock()
+	return tuiTriage.opts
+}
+
+// tuiTriageStatus describes a .bv/triage.yaml load error for the status bar, or "".
+func tuiTriageStatus() string {
+	tuiTriage.RLock()
+	defer tuiTriage.RUnlock()
+	if tuiTriage.err == nil {
+		return ""
+	}
+	return fmt.Sprintf("Triage formula ignored, using built-in scoring: %v", tuiTriage.err)
+}
+
 // ════════════════════════════════════════════════════════════════════════════
 // ALERTS PANEL (bv-168)
 // ════════════════════════════════════════════════════════════════════════════
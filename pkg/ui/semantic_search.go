@@ -59,6 +59,7 @@ type SemanticSearch struct {
 	scores       atomic.Value // *semanticScoreCache
 	hybridConfig atomic.Value // semanticHybridConfig
 	metricsCache atomic.Value // *metricsCacheHolder
+	lexical      atomic.Value // *LexicalFilter
 }
 
 func NewSemanticSearch() *SemanticSearch {
@@ -164,6 +165,19 @@ func (s *SemanticSearch) SetMetricsCache(cache search.MetricsCache) {
 	s.metricsCache.Store(&metricsCacheHolder{cache: cache})
 }
 
+// SetLexicalFilter shares the list's BM25 index, which is fused with the vector ranking.
+func (s *SemanticSearch) SetLexicalFilter(f *LexicalFilter) {
+	s.lexical.Store(f)
+}
+
+func (s *SemanticSearch) getLexicalIndex() *search.SourceIndex {
+	f, _ := s.lexical.Load().(*LexicalFilter)
+	if f == nil {
+		return nil
+	}
+	return f.Index()
+}
+
 // ResetCache clears cached semantic results and scores.
 func (s *SemanticSearch) ResetCache() {
 	s.cache.Store(&semanticResultCache{results: make(map[string][]list.Rank)})
@@ -263,11 +277,11 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 
 	snap := s.Snapshot()
 	if !snap.Ready || snap.Index == nil || snap.Embedder == nil {
-		return list.DefaultFilter(term, targets)
+		return s.fallbackFilter(term, targets)
 	}
 	if len(snap.IDs) != len(targets) {
-		// If we don't have a stable ID mapping, fall back to fuzzy filtering.
-		return list.DefaultFilter(term, targets)
+		// If we don't have a stable ID mapping, fall back to keyword filtering.
+		return s.fallbackFilter(term, targets)
 	}
 
 	// Check cache first - return immediately if we have cached results
@@ -285,7 +299,15 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	}
 	s.cache.Store(newCache)
 
-	// Return fuzzy results immediately so UI stays responsive
+	// Return keyword results immediately so UI stays responsive
+	return s.fallbackFilter(term, targets)
+}
+
+// fallbackFilter answers with the BM25 list filter when one is attached, fuzzy otherwise.
+func (s *SemanticSearch) fallbackFilter(term string, targets []string) []list.Rank {
+	if f, _ := s.lexical.Load().(*LexicalFilter); f != nil {
+		return f.Filter(term, targets)
+	}
 	return list.DefaultFilter(term, targets)
 }
 
@@ -310,110 +332,87 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 	var scorer search.HybridScorer
 	if hybridConfig.Enabled {
 		if cache := s.getMetricsCache(); cache != nil {
-			weights := search.AdjustWeightsForQuery(hybridConfig.Weights, term)
-			scorer = search.NewHybridScorer(weights, cache)
+			scorer = search.NewHybridScorer(hybridConfig.Weights, cache)
 		}
 	}
 
-	type scored struct {
-		index     int
-		id        string
-		score     float64
-		textScore float64
-		hasVector bool
-	}
-
-	scoredItems := make([]scored, len(snap.IDs))
-	scoreMap := make(map[string]SemanticScore, len(snap.IDs))
+	// Vector ranking over every listed issue that has been embedded.
+	positions := make(map[string]int, len(snap.IDs))
+	semantic := make([]search.SearchResult, 0, len(snap.IDs))
 	for i, id := range snap.IDs {
-		entry, ok := snap.Index.Get(id)
-		textScore := 0.0
-		score := 0.0
-		if !ok {
-			// Item not in index (e.g. new issue before re-indexing).
-			// Assign lowest possible score to keep it in the list but at the bottom.
-			score = -2.0
-			textScore = score
-		} else {
-			textScore = dotFloat32(q, entry.Vector)
-			if doc, ok := snap.Docs[id]; ok {
-				textScore += search.ShortQueryLexicalBoost(term, doc)
-			}
-			score = textScore
+		positions[id] = i
+		if entry, ok := snap.Index.Get(id); ok {
+			semantic = append(semantic, search.SearchResult{IssueID: id, Score: dotFloat32(q, entry.Vector)})
 		}
-		scoredItems[i] = scored{
-			index:     i,
-			id:        id,
-			score:     score,
-			textScore: textScore,
-			hasVector: ok,
+	}
+	sort.Slice(semantic, func(i, j int) bool {
+		if semantic[i].Score == semantic[j].Score {
+			return semantic[i].IssueID < semantic[j].IssueID
 		}
-		scoreMap[id] = SemanticScore{
-			Score:     score,
-			TextScore: textScore,
+		return semantic[i].Score > semantic[j].Score
+	})
+
+	// BM25 ranking restricted to the listed issues.
+	var lexical []search.SearchResult
+	if lexicalIdx := s.getLexicalIndex(); lexicalIdx != nil {
+		for _, r := range search.SourceSearchResults(lexicalIdx.SearchPrefix(term, 0)) {
+			if _, ok := positions[r.IssueID]; ok {
+				lexical = append(lexical, r)
+			}
 		}
 	}
 
 	limit := 75
+	scoreMap := make(map[string]SemanticScore, len(snap.IDs))
+	var ordered []string
 	if scorer != nil {
-		candidateLimit := search.HybridCandidateLimit(limit, len(scoredItems), term)
-		var candidateIDs map[string]struct{}
-		if candidateLimit < len(scoredItems) {
-			candidates := make([]scored, len(scoredItems))
-			copy(candidates, scoredItems)
-			sort.Slice(candidates, func(i, j int) bool {
-				if candidates[i].textScore == candidates[j].textScore {
-					return candidates[i].id < candidates[j].id
-				}
-				return candidates[i].textScore > candidates[j].textScore
-			})
-			if candidateLimit < len(candidates) {
-				candidates = candidates[:candidateLimit]
-			}
-			candidateIDs = make(map[string]struct{}, len(candidates))
-			for _, item := range candidates {
-				if item.hasVector {
-					candidateIDs[item.id] = struct{}{}
-				}
-			}
+		candidates := semantic
+		if n := search.HybridCandidateLimit(limit, len(semantic), term); n < len(candidates) {
+			candidates = candidates[:n]
 		}
-
-		for i := range scoredItems {
-			item := &scoredItems[i]
-			if !item.hasVector {
-				continue
-			}
-			if candidateIDs != nil {
-				if _, ok := candidateIDs[item.id]; !ok {
-					continue
+		if fused, err := search.FuseHybridResults(lexical, candidates, scorer); err == nil {
+			for _, r := range fused {
+				ordered = append(ordered, r.IssueID)
+				scoreMap[r.IssueID] = SemanticScore{
+					Score:      r.FinalScore,
+					TextScore:  r.TextScore,
+					Components: r.ComponentScores,
 				}
 			}
-			hybridScore, err := scorer.Score(item.id, item.textScore)
-			if err != nil {
-				continue
-			}
-			item.score = hybridScore.FinalScore
-			scoreMap[item.id] = SemanticScore{
-				Score:      hybridScore.FinalScore,
-				TextScore:  hybridScore.TextScore,
-				Components: hybridScore.ComponentScores,
-			}
+		}
+	}
+	if ordered == nil {
+		cosine := make(map[string]float64, len(semantic))
+		for _, r := range semantic {
+			cosine[r.IssueID] = r.Score
+		}
+		for _, r := range search.FuseTextResults(lexical, semantic) {
+			ordered = append(ordered, r.IssueID)
+			scoreMap[r.IssueID] = SemanticScore{Score: r.Score, TextScore: cosine[r.IssueID]}
 		}
 	}
 
-	sort.Slice(scoredItems, func(i, j int) bool {
-		if scoredItems[i].score == scoredItems[j].score {
-			return scoredItems[i].id < scoredItems[j].id
+	// Anything left (outside the hybrid candidates, or not embedded yet, e.g. a new issue
+	// before re-indexing) keeps its place at the bottom rather than disappearing.
+	for _, r := range semantic {
+		if _, ok := scoreMap[r.IssueID]; !ok {
+			ordered = append(ordered, r.IssueID)
+			scoreMap[r.IssueID] = SemanticScore{Score: r.Score, TextScore: r.Score}
 		}
-		return scoredItems[i].score > scoredItems[j].score
-	})
+	}
+	for _, id := range snap.IDs {
+		if _, ok := scoreMap[id]; !ok {
+			ordered = append(ordered, id)
+			scoreMap[id] = SemanticScore{Score: -2.0, TextScore: -2.0}
+		}
+	}
 
-	if len(scoredItems) > limit {
-		scoredItems = scoredItems[:limit]
+	if len(ordered) > limit {
+		ordered = ordered[:limit]
 	}
-	out := make([]list.Rank, 0, len(scoredItems))
-	for _, it := range scoredItems {
-		out = append(out, list.Rank{Index: it.index})
+	out := make([]list.Rank, 0, len(ordered))
+	for _, id := range ordered {
+		out = append(out, list.Rank{Index: positions[id]})
 	}
 	s.SetScores(term, scoreMap)
 	return out
@@ -479,22 +478,25 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 // BuildSemanticIndexCmd builds or updates the semantic index for the given issues.
 func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
 	return func() tea.Msg {
-		cfg := search.EmbeddingConfigFromEnv()
-		embedder, err := search.NewEmbedderFromConfig(cfg)
+		projectDir, err := os.Getwd()
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
 
-		projectDir, err := os.Getwd()
+		cfg := search.EmbeddingConfigFromEnv()
+		cfg.CacheDir = search.DefaultEmbeddingCacheDir(projectDir)
+		embedder, err := search.NewEmbedderFromConfig(cfg)
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
+		cfg.Dim = embedder.Dim()
 
 		indexPath := search.DefaultIndexPath(projectDir, cfg)
 		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
+		idx.SetHNSWConfig(search.HNSWConfigFromEnv())
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -769,3 +770,36 @@ func BenchmarkDotFloat32(b *testing.B) {
 		_ = dotFloat32(a, bVec)
 	}
 }
+
+func TestSemanticSearchFusesLexicalRanking(t *testing.T) {
+	ss := NewSemanticSearch()
+	idx := search.NewVectorIndex(3)
+	ss.SetIndex(idx, &mockEmbedder{
+		dim: 3,
+		embedFunc: func(ctx context.Context, texts []string) ([][]float32, error) {
+			return [][]float32{{1, 0, 0}}, nil
+		},
+	})
+	// The vectors prefer id-1, but only id-3 literally mentions the query.
+	idx.Upsert("id-1", search.ContentHash{}, []float32{1, 0, 0})
+	idx.Upsert("id-2", search.ContentHash{}, []float32{0.6, 0.8, 0})
+	idx.Upsert("id-3", search.ContentHash{}, []float32{0, 1, 0})
+	ss.SetIDs([]string{"id-1", "id-2", "id-3"})
+
+	lexical := NewLexicalFilter()
+	lexical.SetIssues([]model.Issue{
+		{ID: "id-1", Title: "Theme colors"},
+		{ID: "id-2", Title: "Keyboard shortcuts"},
+		{ID: "id-3", Title: "Kraken integration"},
+	})
+	ss.SetLexicalFilter(lexical)
+
+	ranks := ss.ComputeSemanticResults("kraken")
+	if len(ranks) != 3 || ranks[0].Index != 2 {
+		t.Fatalf("expected the literal match first after fusion, got %+v", ranks)
+	}
+	scores, ok := ss.Scores("kraken")
+	if !ok || scores["id-3"].TextScore != 0 || scores["id-3"].Score <= scores["id-1"].Score {
+		t.Errorf("unexpected fused scores %+v", scores)
+	}
+}
@@ -121,6 +121,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"H/L", "Scroll ←/→"},
 				{"PgUp/Dn", "Scroll ↑/↓"},
 				{"Enter", "Jump to issue"},
+				{"c", "Color by cluster"},
 			},
 		},
 		{
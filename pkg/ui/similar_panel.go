@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bdExecutable runs dependency edits; bv itself never writes issues.
+var bdExecutable = "bd"
+
+// RelatedDependencyAddedMsg reports the result of linking two issues as related.
+type RelatedDependencyAddedMsg struct {
+	FromID string
+	ToID   string
+	Err    error
+}
+
+// addRelatedDependencyCmd runs `bd dep add <from> <to> --type related` in dir. The file
+// watcher picks up the change once bd has written it.
+func addRelatedDependencyCmd(dir, fromID, toID string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := exec.Command(bdExecutable, "dep", "add", fromID, toID, "--type", string(model.DepRelated))
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		return RelatedDependencyAddedMsg{FromID: fromID, ToID: toID, Err: err}
+	}
+}
+
+// SimilarVectorsReadyMsg carries the offline embeddings built for the similar panel
+// when the semantic index is not ready.
+type SimilarVectorsReadyMsg struct {
+	DataHash string // issue data the vectors were built from
+	Index    *search.VectorIndex
+	Err      error
+}
+
+// SimilarPanelModel lists "more like this" suggestions for one issue, each with the
+// evidence behind it, and tracks which ones were linked from the panel.
+type SimilarPanelModel struct {
+	targetID    string
+	targetTitle string
+	items       []search.SimilarIssue
+	linked      map[string]bool
+	err         error
+	loading     bool // waiting for embeddings of the issues in dataHash
+	dataHash    string
+	cursor      int
+	width       int
+	height      int
+	theme       Theme
+}
+
+// NewSimilarPanelModel creates an empty panel.
+func NewSimilarPanelModel(theme Theme) SimilarPanelModel {
+	return SimilarPanelModel{theme: theme}
+}
+
+// SetData ranks issues similar to targetID.
+func (m *SimilarPanelModel) SetData(targetID string, issues []model.Issue, opts search.SimilarOptions) {
+	m.setTarget(targetID, issues)
+	m.items, m.err = search.FindSimilarIssues(targetID, issues, opts)
+	m.loading = false
+}
+
+// SetLoading shows targetID while the issues in dataHash are being embedded.
+func (m *SimilarPanelModel) SetLoading(targetID string, issues []model.Issue, dataHash string) {
+	m.setTarget(targetID, issues)
+	m.items, m.err = nil, nil
+	m.loading, m.dataHash = true, dataHash
+}
+
+func (m *SimilarPanelModel) setTarget(targetID string, issues []model.Issue) {
+	m.targetID, m.targetTitle = targetID, ""
+	for _, issue := range issues {
+		if issue.ID == targetID {
+			m.targetTitle = issue.Title
+			break
+		}
+	}
+	m.linked = make(map[string]bool)
+	m.cursor = 0
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *SimilarPanelModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveDown selects the next suggestion.
+func (m *SimilarPanelModel) MoveDown() {
+	if m.cursor < len(m.items)-1 {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous suggestion.
+func (m *SimilarPanelModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// TargetID returns the issue suggestions are for.
+func (m *SimilarPanelModel) TargetID() string {
+	return m.targetID
+}
+
+// Selected returns the suggestion under the cursor.
+func (m *SimilarPanelModel) Selected() *search.SimilarIssue {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		return nil
+	}
+	return &m.items[m.cursor]
+}
+
+// MarkLinked records that a suggestion now has a related dependency.
+func (m *SimilarPanelModel) MarkLinked(issueID string) {
+	if m.linked == nil {
+		m.linked = make(map[string]bool)
+	}
+	m.linked[issueID] = true
+}
+
+// IsLinked reports whether a suggestion was linked from the panel.
+func (m *SimilarPanelModel) IsLinked(issueID string) bool {
+	return m.linked[issueID]
+}
+
+// View renders the panel.
+func (m *SimilarPanelModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selectedStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	linkedStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("More Like This"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %s %s", m.targetID, truncateRunesHelper(m.targetTitle, max(20, width-30), "…"))))
+	sb.WriteString("\n\n")
+
+	if m.loading {
+		sb.WriteString(dimStyle.Render("  Embedding issues to find similar ones…"))
+		return sb.String()
+	}
+	if m.err != nil {
+		sb.WriteString(warnStyle.Render("  " + m.err.Error()))
+		return sb.String()
+	}
+	if len(m.items) == 0 {
+		sb.WriteString(dimStyle.Render("  No similar issues: nothing shares text, labels, nearby dependencies or files"))
+		return sb.String()
+	}
+
+	// Two lines per suggestion; keep the cursor in view.
+	rows := max((height-5)/2, 1)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(start+rows, len(m.items))
+	for i := start; i < end; i++ {
+		s := m.items[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		line := fmt.Sprintf("%s%-12s %-*s %-11s",
+			marker, s.IssueID, max(20, width-50), truncateRunesHelper(s.Title, max(20, width-50), "…"), s.Status)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		sb.WriteString(line + " " + RenderMiniBar(s.Score, 10, t) + fmt.Sprintf(" %.2f", s.Score))
+		if m.linked[s.IssueID] {
+			sb.WriteString(linkedStyle.Render("  ✓ related"))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(dimStyle.Render("    " + truncateRunesHelper(s.Explanation(), max(20, width-6), "…")))
+		sb.WriteString("\n")
+	}
+	if end < len(m.items) {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(m.items)-end)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: select | r: link as related (bd dep add) | enter: open | M/esc: back"))
+	return sb.String()
+}
+
+// similarOptions gathers the signals available to the TUI: the semantic index when it
+// has been built, otherwise the offline hash embeddings cached for dataHash, and the
+// background history. Vectors is nil when neither is available yet.
+func (m *Model) similarOptions(dataHash string) search.SimilarOptions {
+	opts := search.DefaultSimilarOptions()
+	opts.IncludeClosed = true // old fixes are useful context; linking to them is still valid
+	if m.semanticSearch != nil {
+		if snap := m.semanticSearch.Snapshot(); snap.Ready && snap.Index != nil {
+			opts.Vectors = snap.Index
+		}
+	}
+	if opts.Vectors == nil && m.similarVectors != nil && m.similarVectorsHash == dataHash {
+		opts.Vectors = m.similarVectors
+	}
+	var history *correlation.HistoryReport
+	if !m.historyLoading && !m.historyLoadFailed {
+		history = m.historyView.report
+	}
+	opts.History = history
+	return opts
+}
+
+// openSimilarPanel shows suggestions for the selected issue. Without usable vectors the
+// panel waits for similarVectorsCmd to embed the issues.
+func (m *Model) openSimilarPanel() {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	dataHash := analysis.ComputeDataHash(m.issues)
+	m.similarPanel = NewSimilarPanelModel(m.theme)
+	if opts := m.similarOptions(dataHash); opts.Vectors != nil {
+		m.similarPanel.SetData(item.Issue.ID, m.issues, opts)
+	} else {
+		m.similarPanel.SetLoading(item.Issue.ID, m.issues, dataHash)
+	}
+	m.similarPanel.SetSize(m.width, m.height-1)
+	m.focused = focusSimilarPanel
+}
+
+// similarVectorsCmd embeds the issues with the offline hash embedder in the background
+// when the panel is waiting for them. It returns nil when nothing is waiting or the
+// same data is already being embedded.
+func (m *Model) similarVectorsCmd() tea.Cmd {
+	if !m.similarPanel.loading || m.similarVectorsHash == m.similarPanel.dataHash {
+		return nil
+	}
+	dataHash, issues := m.similarPanel.dataHash, m.issues
+	m.similarVectors, m.similarVectorsHash = nil, dataHash
+	return func() tea.Msg {
+		idx, err := search.NewSimilarityIndex(context.Background(), search.NewHashEmbedder(search.DefaultEmbeddingDim), issues)
+		return SimilarVectorsReadyMsg{DataHash: dataHash, Index: idx, Err: err}
+	}
+}
+
+// applySimilarVectors caches embeddings that match the latest request and fills the
+// panel if it is still waiting for them.
+func (m *Model) applySimilarVectors(msg SimilarVectorsReadyMsg) {
+	if msg.DataHash != m.similarVectorsHash {
+		return
+	}
+	if msg.Err != nil {
+		m.similarVectorsHash = "" // retry on the next open
+	} else {
+		m.similarVectors = msg.Index
+	}
+	if m.similarPanel.loading && m.similarPanel.dataHash == msg.DataHash {
+		// Without vectors the other signals still rank suggestions.
+		m.similarPanel.SetData(m.similarPanel.TargetID(), m.issues, m.similarOptions(msg.DataHash))
+	}
+}
+
+// handleSimilarPanelKeys handles keyboard input when the similar-issues panel is focused
+func (m Model) handleSimilarPanelKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "M", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.similarPanel.MoveDown()
+	case "k", "up":
+		m.similarPanel.MoveUp()
+	case "r":
+		s := m.similarPanel.Selected()
+		if s == nil || m.similarPanel.IsLinked(s.IssueID) {
+			return m, nil
+		}
+		if m.timeTravelMode || m.beadsPath == "" {
+			m.statusMsg = "Linking issues needs the live beads file"
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Linking %s ↔ %s as related…", m.similarPanel.TargetID(), s.IssueID)
+		m.statusIsError = false
+		return m, addRelatedDependencyCmd(m.workDir, m.similarPanel.TargetID(), s.IssueID)
+	case "enter":
+		s := m.similarPanel.Selected()
+		if s == nil {
+			return m, nil
+		}
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == s.IssueID {
+				m.list.Select(i)
+				break
+			}
+		}
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+			m.focused = focusDetail
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	}
+	return m, nil
+}
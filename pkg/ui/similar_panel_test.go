@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func similarPanelTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Login retry loop never backs off", Labels: []string{"auth"}, Status: model.StatusOpen},
+		{ID: "B", Title: "Retry loop on login hammers the server", Labels: []string{"auth"}, Status: model.StatusOpen},
+		{ID: "C", Title: "Dashboard colours", Status: model.StatusOpen},
+	}
+}
+
+func TestSimilarPanelModelViewAndNavigation(t *testing.T) {
+	m := NewSimilarPanelModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData("A", similarPanelTestIssues(), search.DefaultSimilarOptions())
+	m.SetSize(120, 40)
+
+	out := m.View()
+	for _, want := range []string{"More Like This", "A Login retry", "B ", "shares label auth"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Dashboard colours") {
+		t.Errorf("C shares nothing with A and should not be suggested:\n%s", out)
+	}
+	if s := m.Selected(); s == nil || s.IssueID != "B" {
+		t.Fatalf("expected B selected, got %+v", s)
+	}
+	m.MoveDown()
+	m.MoveUp()
+	if s := m.Selected(); s == nil || s.IssueID != "B" {
+		t.Fatalf("expected the cursor to stay within the list, got %+v", s)
+	}
+
+	m.MarkLinked("B")
+	if !strings.Contains(m.View(), "✓ related") {
+		t.Error("expected the linked marker")
+	}
+
+	empty := NewSimilarPanelModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	empty.SetData("C", similarPanelTestIssues(), search.DefaultSimilarOptions())
+	if !strings.Contains(empty.View(), "No similar issues") {
+		t.Errorf("expected the empty state, got:\n%s", empty.View())
+	}
+}
+
+func TestSimilarPanelLinksAsRelated(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script in place of bd")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	bd := filepath.Join(dir, "bd")
+	if err := os.WriteFile(bd, []byte("#!/bin/sh\necho \"$@\" > "+argsFile+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prev := bdExecutable
+	bdExecutable = bd
+	t.Cleanup(func() { bdExecutable = prev })
+
+	issues := similarPanelTestIssues()
+	items := make([]list.Item, len(issues))
+	for i, issue := range issues {
+		items[i] = IssueItem{Issue: issue}
+	}
+	l := list.New(items, list.NewDefaultDelegate(), 80, 20)
+	m := Model{theme: DefaultTheme(lipgloss.NewRenderer(nil)), width: 100, height: 40, list: l, issues: issues,
+		beadsPath: filepath.Join(dir, ".beads", "beads.jsonl"), workDir: dir, focused: focusList, historyLoading: true}
+
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if m.FocusState() != "similar_panel" || m.CurrentContext() != ContextSimilarPanel {
+		t.Fatalf("expected the similar panel, got %q", m.FocusState())
+	}
+	// Without a semantic index the issues are embedded in the background.
+	if !strings.Contains(m.similarPanel.View(), "Embedding issues") {
+		t.Errorf("expected a loading panel, got:\n%s", m.similarPanel.View())
+	}
+	embed := m.similarVectorsCmd()
+	if embed == nil {
+		t.Fatal("expected an embedding command")
+	}
+	if m.similarVectorsCmd() != nil {
+		t.Error("expected no second command while embedding is in flight")
+	}
+	updated, _ := m.Update(embed())
+	m = updated.(Model)
+	if m.similarPanel.Selected() == nil || m.similarVectors == nil {
+		t.Fatalf("expected suggestions once the vectors arrive, got:\n%s", m.similarPanel.View())
+	}
+
+	m, cmd := m.handleSimilarPanelKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatalf("expected a bd command, status=%q", m.statusMsg)
+	}
+	msg, ok := cmd().(RelatedDependencyAddedMsg)
+	if !ok || msg.Err != nil || msg.FromID != "A" || msg.ToID != "B" {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil || strings.TrimSpace(string(args)) != "dep add A B --type related" {
+		t.Fatalf("unexpected bd invocation %q (%v)", args, err)
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if !m.similarPanel.IsLinked("B") || !strings.Contains(m.statusMsg, "Linked A ↔ B") {
+		t.Errorf("expected B marked as linked, status=%q", m.statusMsg)
+	}
+	if _, cmd := m.handleSimilarPanelKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd != nil {
+		t.Error("linking the same issue twice should be a no-op")
+	}
+
+	m, _ = m.handleSimilarPanelKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.focused != focusList {
+		t.Errorf("expected esc to return to the list, got %q", m.FocusState())
+	}
+
+	// Reopening with unchanged issues reuses the cached vectors.
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if m.similarPanel.loading || m.similarVectorsCmd() != nil {
+		t.Error("expected the cached vectors to be reused")
+	}
+}
@@ -347,6 +347,9 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 			cfg.ComputeCriticalPath = false
 			cfg.ComputeCycles = false
 			graphStats = b.analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
+		} else if b.prevSnapshot != nil && b.prevSnapshot.Analyzer != nil && b.prevSnapshot.Analysis != nil {
+			// Live reload: patch the previous Phase 2 metrics when the edit is small.
+			graphStats = b.analyzer.AnalyzeIncremental(context.Background(), b.prevSnapshot.Analyzer, b.prevSnapshot.Analysis, b.diff)
 		} else {
 			graphStats = b.analyzer.AnalyzeAsync(context.Background())
 		}
@@ -425,7 +428,7 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 
 	// Compute triage insights (may be skipped for large/huge datasets; bv-9thm).
 	if b.cfg.PrecomputeTriage {
-		triageResult := analysis.ComputeTriageFromAnalyzer(b.analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
+		triageResult := analysis.ComputeTriageFromAnalyzer(b.analyzer, graphStats, issues, tuiTriageOptions(), time.Now())
 		triageScores = make(map[string]float64, len(triageResult.Recommendations))
 		triageReasons = make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
 		quickWinSet = make(map[string]bool, len(triageResult.QuickWins))
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -596,6 +597,50 @@ func TestSnapshotBuilder_IncrementalListMatchesFull(t *testing.T) {
 	}
 }
 
+func TestSnapshotBuilder_IncrementalGraphMetrics(t *testing.T) {
+	now := time.Now()
+	issues := make([]model.Issue, 0, 30)
+	for i := 0; i < 30; i++ {
+		issue := model.Issue{
+			ID:        fmt.Sprintf("G-%02d", i),
+			Title:     fmt.Sprintf("Issue %d", i),
+			Status:    model.StatusOpen,
+			IssueType: model.TypeTask,
+			CreatedAt: now.Add(-time.Duration(i) * time.Hour),
+		}
+		if i > 0 {
+			issue.Dependencies = []*model.Dependency{{DependsOnID: fmt.Sprintf("G-%02d", i/2), Type: model.DepBlocks}}
+		}
+		issues = append(issues, issue)
+	}
+
+	prev := NewSnapshotBuilder(copyIssues(issues)).Build()
+	prev.Analysis.WaitForPhase2()
+
+	updated := copyIssues(issues)
+	updated[29].Dependencies = append(updated[29].Dependencies, &model.Dependency{DependsOnID: "G-03", Type: model.DepBlocks})
+	diffValue := analysis.ComputeIssueDiff(prev.Issues, updated)
+
+	next := NewSnapshotBuilder(copyIssues(updated)).
+		WithPreviousSnapshot(prev, &diffValue).
+		Build()
+	next.Analysis.WaitForPhase2()
+	if next.Analysis.Incremental() == nil {
+		t.Fatal("expected graph metrics to be patched from the previous snapshot")
+	}
+
+	full := NewSnapshotBuilder(copyIssues(updated)).Build()
+	full.Analysis.WaitForPhase2()
+	for _, issue := range updated {
+		if got, want := next.Analysis.GetCriticalPathScore(issue.ID), full.Analysis.GetCriticalPathScore(issue.ID); got != want {
+			t.Errorf("%s critical path = %v, want %v", issue.ID, got, want)
+		}
+		if got, want := next.Analysis.GetBetweennessScore(issue.ID), full.Analysis.GetBetweennessScore(issue.ID); math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s betweenness = %v, want %v", issue.ID, got, want)
+		}
+	}
+}
+
 func TestSortIssuesByRecipe_PriorityAsc(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "A", Priority: 2},
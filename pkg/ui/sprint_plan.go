@@ -0,0 +1,287 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sprintPlanCapacityStep is how much +/- changes the planning capacity
+const sprintPlanCapacityStep = 4 * 60
+
+// SprintPlanModel plans the next sprint: the issues chosen to fill the
+// capacity by triage impact, in execution order, and what was left out.
+type SprintPlanModel struct {
+	plan     analysis.SprintPlan
+	issues   []model.Issue
+	sprints  []model.Sprint
+	capacity int
+	cursor   int
+	width    int
+	height   int
+	theme    Theme
+}
+
+// NewSprintPlanModel creates an empty sprint planner.
+func NewSprintPlanModel(theme Theme) SprintPlanModel {
+	return SprintPlanModel{theme: theme}
+}
+
+// SetData replans for the given issues and existing sprints. A zero
+// capacity uses the capacity recorded on the latest sprint.
+func (m *SprintPlanModel) SetData(issues []model.Issue, sprints []model.Sprint, capacity int) {
+	if capacity <= 0 {
+		capacity = analysis.SprintCapacityMinutes(sprints)
+	}
+	m.issues, m.sprints, m.capacity = issues, sprints, capacity
+	m.plan = analysis.PlanSprint(issues, analysis.SprintPlanOptions{CapacityMinutes: capacity, Sprints: sprints, Triage: tuiTriageOptions()})
+	if m.cursor >= m.rows() {
+		m.cursor = max(m.rows()-1, 0)
+	}
+}
+
+// AdjustCapacity changes the capacity by delta minutes (at least one step)
+// and replans.
+func (m *SprintPlanModel) AdjustCapacity(delta int) {
+	m.SetData(m.issues, m.sprints, max(m.capacity+delta, sprintPlanCapacityStep))
+}
+
+// Plan returns the current plan.
+func (m *SprintPlanModel) Plan() analysis.SprintPlan {
+	return m.plan
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *SprintPlanModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *SprintPlanModel) rows() int {
+	return len(m.plan.Items) + len(m.plan.Excluded)
+}
+
+// MoveDown selects the next row.
+func (m *SprintPlanModel) MoveDown() {
+	if m.cursor < m.rows()-1 {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous row.
+func (m *SprintPlanModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// FocusIssueID returns the issue under the cursor, planned or excluded.
+func (m *SprintPlanModel) FocusIssueID() string {
+	if m.cursor < len(m.plan.Items) {
+		return m.plan.Items[m.cursor].IssueID
+	}
+	if i := m.cursor - len(m.plan.Items); i < len(m.plan.Excluded) {
+		return m.plan.Excluded[i].IssueID
+	}
+	return ""
+}
+
+// View renders the planner.
+func (m *SprintPlanModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+	p := m.plan
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selectedStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Feature)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Sprint Planner"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d planned • %s of %s • score %.2f",
+		len(p.Items), formatMinutes(p.PlannedMinutes), formatMinutes(p.CapacityMinutes), p.TotalScore)))
+	sb.WriteString("\n\n")
+
+	barWidth := min(max(width-20, 10), 50)
+	filled := 0
+	if p.CapacityMinutes > 0 {
+		filled = min(p.PlannedMinutes*barWidth/p.CapacityMinutes, barWidth)
+	}
+	sb.WriteString(labelStyle.Render("  Capacity "))
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Open).Render(strings.Repeat("█", filled)))
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Render(strings.Repeat("░", barWidth-filled)))
+	sb.WriteString("\n\n")
+
+	// Rows are windowed around the cursor so long backlogs stay navigable.
+	visible := max(height-12-len(p.Epics), 5)
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	titleWidth := max(width-40, 20)
+	row := func(i int, line string, style func(...string) string) {
+		if i < start || i >= start+visible {
+			return
+		}
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+			style = selectedStyle.Render
+		}
+		sb.WriteString(style(marker + line))
+		sb.WriteString("\n")
+	}
+
+	if start < len(p.Items) {
+		sb.WriteString(labelStyle.Render("  Planned (in order)"))
+		sb.WriteString("\n")
+	}
+	if len(p.Items) == 0 {
+		sb.WriteString(dimStyle.Render("    Nothing fits the capacity"))
+		sb.WriteString("\n")
+	}
+	for i, item := range p.Items {
+		line := fmt.Sprintf("%-10s %-*s %6s %5.2f", item.IssueID, titleWidth, truncateRunesHelper(item.Title, titleWidth, "…"),
+			formatMinutes(item.Minutes), item.Score)
+		if len(item.PulledInFor) > 0 {
+			line += " ⤷ " + strings.Join(item.PulledInFor, ",")
+		}
+		row(i, line, t.Renderer.NewStyle().Render)
+	}
+
+	if len(p.Excluded) > 0 && start+visible > len(p.Items) {
+		sb.WriteString("\n")
+		sb.WriteString(labelStyle.Render("  Left out"))
+		sb.WriteString("\n")
+	}
+	for i, ex := range p.Excluded {
+		line := fmt.Sprintf("%-10s %-*s %6s %s", ex.IssueID, titleWidth, truncateRunesHelper(ex.Title, titleWidth, "…"),
+			formatMinutes(ex.Minutes), ex.Reason)
+		if i+len(p.Items) == m.cursor && ex.Detail != "" {
+			line += ": " + ex.Detail
+		}
+		row(len(p.Items)+i, line, dimStyle.Render)
+	}
+
+	if len(p.Epics) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(labelStyle.Render("  Epics"))
+		sb.WriteString("\n")
+		for _, e := range p.Epics {
+			line := fmt.Sprintf("    %-10s %s %d/%d children", e.EpicID, truncateRunesHelper(e.Title, titleWidth, "…"), e.Planned, e.Open)
+			if e.Complete {
+				sb.WriteString(t.Renderer.NewStyle().Foreground(t.Open).Render(line + " ✓"))
+			} else {
+				sb.WriteString(warnStyle.Render(line + " (split)"))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// openSprintPlan switches to the sprint planner
+func (m *Model) openSprintPlan() {
+	m.sprintPlan = NewSprintPlanModel(m.theme)
+	m.sprintPlan.SetData(m.issues, m.sprints, 0)
+	m.sprintPlan.SetSize(m.width, m.height-1)
+	m.focused = focusSprintPlan
+}
+
+// closeSprintPlan returns to the sprint dashboard when it is open, else the list
+func (m *Model) closeSprintPlan() {
+	if m.isSprintView {
+		m.focused = focusSprint
+		m.sprintViewText = m.renderSprintDashboard()
+		return
+	}
+	m.focused = focusList
+}
+
+// saveSprintPlan appends the plan to sprints.jsonl as the next sprint and
+// shows it in the sprint dashboard
+func (m *Model) saveSprintPlan() tea.Cmd {
+	plan := m.sprintPlan.Plan()
+	if len(plan.Items) == 0 {
+		m.statusMsg = "Nothing planned to save"
+		m.statusIsError = true
+		return nil
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "Saving sprints needs a beads directory"
+		m.statusIsError = true
+		return nil
+	}
+	id := analysis.NextSprintID(m.sprints)
+	name := "Sprint " + strings.TrimPrefix(id, "sprint-")
+	sprint := plan.NewSprint(id, name, 14, m.sprints, time.Now())
+	sprints := append(append([]model.Sprint(nil), m.sprints...), sprint)
+	path := filepath.Join(filepath.Dir(m.beadsPath), loader.SprintsFileName)
+	if err := loader.SaveSprintsToFile(path, sprints); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Saving sprint failed: %v", err)
+		m.statusIsError = true
+		return nil
+	}
+	m.sprints = sprints
+	m.selectedSprint = &m.sprints[len(m.sprints)-1]
+	m.statusMsg = fmt.Sprintf("✅ Saved %s with %d beads (%s)", id, len(sprint.BeadIDs), formatMinutes(plan.PlannedMinutes))
+	m.statusIsError = false
+	m.isSprintView = true
+	m.focused = focusSprint
+	cmd := m.sprintBurndownCmd()
+	m.sprintViewText = m.renderSprintDashboard()
+	return cmd
+}
+
+// handleSprintPlanKeys handles keyboard input when the sprint planner is focused
+func (m Model) handleSprintPlanKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "n", "q", "esc":
+		m.closeSprintPlan()
+	case "j", "down":
+		m.sprintPlan.MoveDown()
+	case "k", "up":
+		m.sprintPlan.MoveUp()
+	case "+", "=":
+		m.sprintPlan.AdjustCapacity(sprintPlanCapacityStep)
+	case "-":
+		m.sprintPlan.AdjustCapacity(-sprintPlanCapacityStep)
+	case "s":
+		return m, m.saveSprintPlan()
+	case "enter":
+		selectedID := m.sprintPlan.FocusIssueID()
+		if selectedID == "" {
+			return m, nil
+		}
+		m.isSprintView = false
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+			m.focused = focusDetail
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	}
+	return m, nil
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func sprintPlanTestIssues() []model.Issue {
+	est := func(m int) *int { return &m }
+	return []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, EstimatedMinutes: est(120)},
+		{ID: "B", Title: "API on schema", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, EstimatedMinutes: est(120),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Huge rewrite", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, EstimatedMinutes: est(6000)},
+	}
+}
+
+func TestSprintPlanModelViewAndCapacity(t *testing.T) {
+	m := NewSprintPlanModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(sprintPlanTestIssues(), nil, 0)
+	m.SetSize(120, 40)
+
+	out := m.View()
+	for _, want := range []string{"Sprint Planner", "2 planned", "Planned (in order)", "⤷ B", "Left out", "too_large"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+	if id := m.FocusIssueID(); id != "A" {
+		t.Errorf("expected the blocker first, got %q", id)
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if id := m.FocusIssueID(); id != "C" {
+		t.Errorf("expected the excluded issue after the plan, got %q", id)
+	}
+
+	m.AdjustCapacity(-10000)
+	if p := m.Plan(); p.CapacityMinutes != sprintPlanCapacityStep || len(p.Items) != 2 {
+		t.Errorf("expected capacity clamped to one step, still fitting A and B, got %dm %v", p.CapacityMinutes, p.BeadIDs())
+	}
+}
+
+func TestSprintPlanSaveCreatesSprint(t *testing.T) {
+	dir := t.TempDir()
+	beadsPath := filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{theme: DefaultTheme(lipgloss.NewRenderer(nil)), width: 100, height: 40, beadsPath: beadsPath,
+		issues: sprintPlanTestIssues(), focused: focusList}
+	m.openSprintPlan()
+	if m.FocusState() != "sprint_plan" || m.CurrentContext() != ContextSprintPlan {
+		t.Fatalf("expected the planner, got %q", m.FocusState())
+	}
+
+	m, _ = m.handleSprintPlanKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if !m.isSprintView || m.focused != focusSprint || m.selectedSprint == nil || m.selectedSprint.ID != "sprint-1" {
+		t.Fatalf("expected the saved sprint in the dashboard, got view=%v sprint=%v status=%q", m.isSprintView, m.selectedSprint, m.statusMsg)
+	}
+	saved, err := loader.LoadSprintsFromFile(filepath.Join(dir, ".beads", loader.SprintsFileName))
+	if err != nil || len(saved) != 1 || strings.Join(saved[0].BeadIDs, ",") != "A,B" {
+		t.Fatalf("expected sprint-1 with A,B on disk, got %+v (%v)", saved, err)
+	}
+}
@@ -2,23 +2,97 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// renderSprintDashboard renders the sprint view with progress, burndown, and at-risk items (bv-161)
+// SprintViewModel renders the sprint dashboard: progress, burndown, scope
+// changes, carryover and at-risk items (bv-161). Burndowns replayed from git
+// history are cached per sprint and replace the snapshot chart once loaded.
+type SprintViewModel struct {
+	sprint *model.Sprint
+	issues []model.Issue
+	width  int
+	height int
+	theme  Theme
+
+	burndowns map[string]*analysis.SprintBurndown
+	errs      map[string]error
+	requested map[string]bool
+}
+
+// NewSprintViewModel creates an empty sprint dashboard
+func NewSprintViewModel(theme Theme) SprintViewModel {
+	return SprintViewModel{theme: theme}
+}
+
+// SetSprint sets the sprint to render and the current issue snapshot
+func (v *SprintViewModel) SetSprint(sprint *model.Sprint, issues []model.Issue) {
+	v.sprint = sprint
+	v.issues = issues
+}
+
+// SetSize sets the available width and height
+func (v *SprintViewModel) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// SetBurndown stores the git-replayed burndown (or the error replaying it)
+// for a sprint
+func (v *SprintViewModel) SetBurndown(sprintID string, b *analysis.SprintBurndown, err error) {
+	if v.burndowns == nil {
+		v.burndowns = make(map[string]*analysis.SprintBurndown)
+		v.errs = make(map[string]error)
+	}
+	v.burndowns[sprintID] = b
+	v.errs[sprintID] = err
+}
+
+// Burndown returns the git-replayed burndown for a sprint, if loaded
+func (v SprintViewModel) Burndown(sprintID string) *analysis.SprintBurndown {
+	return v.burndowns[sprintID]
+}
+
+// markRequested records that a burndown load was started for a sprint and
+// reports whether it was already requested
+func (v *SprintViewModel) markRequested(sprintID string) bool {
+	if v.requested[sprintID] {
+		return true
+	}
+	if v.requested == nil {
+		v.requested = make(map[string]bool)
+	}
+	v.requested[sprintID] = true
+	return false
+}
+
+// renderSprintDashboard renders the sprint view for the selected sprint
 func (m Model) renderSprintDashboard() string {
-	t := m.theme
-	if m.selectedSprint == nil {
+	v := m.sprintView
+	v.theme = m.theme
+	v.SetSize(m.width, m.height)
+	v.SetSprint(m.selectedSprint, m.issues)
+	return v.View()
+}
+
+// View renders the sprint dashboard
+func (v SprintViewModel) View() string {
+	t := v.theme
+	if v.sprint == nil {
 		return "No sprint selected"
 	}
-	sprint := m.selectedSprint
+	sprint := v.sprint
 
-	innerWidth := m.width - 6
+	innerWidth := v.width - 6
 	if innerWidth < 40 {
 		innerWidth = 40
 	}
@@ -82,7 +156,7 @@ func (m Model) renderSprintDashboard() string {
 	for _, id := range sprint.BeadIDs {
 		beadIDSet[id] = true
 	}
-	for _, iss := range m.issues {
+	for _, iss := range v.issues {
 		if beadIDSet[iss.ID] {
 			totalBeads++
 			sprintIssues = append(sprintIssues, iss)
@@ -128,10 +202,14 @@ func (m Model) renderSprintDashboard() string {
 	sb.WriteString(valStyle.Render(fmt.Sprintf("○%d", openBeads-inProgressBeads-blockedBeads)))
 	sb.WriteString("\n\n")
 
-	// Simple burndown chart (ASCII)
-	sb.WriteString(labelStyle.Render("Burndown:"))
-	sb.WriteString("\n")
-	if sprintDuration > 0 && totalBeads > 0 {
+	// Burndown replayed from git when available, else a simple ASCII chart
+	// from the current snapshot
+	if b := v.burndowns[sprint.ID]; b != nil && len(b.Days) > 0 {
+		sb.WriteString(v.renderGitBurndown(b, innerWidth))
+	} else if sprintDuration > 0 && totalBeads > 0 {
+		sb.WriteString(labelStyle.Render("Burndown:"))
+		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(v.burndownNote(sprint.ID)))
+		sb.WriteString("\n")
 		// Ideal line: from totalBeads to 0 over sprintDuration days
 		// Current: totalBeads - closedBeads remaining on day daysPassed
 		chartHeight := 5
@@ -167,6 +245,8 @@ func (m Model) renderSprintDashboard() string {
 		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render("  · ideal  ● actual"))
 		sb.WriteString("\n\n")
 	} else {
+		sb.WriteString(labelStyle.Render("Burndown:"))
+		sb.WriteString("\n")
 		sb.WriteString(valStyle.Render("  (insufficient data)"))
 		sb.WriteString("\n\n")
 	}
@@ -232,25 +312,238 @@ func (m Model) renderSprintDashboard() string {
 	// Footer
 	sb.WriteString("\n")
 	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
-		"P: close sprint view • j/k: navigate sprints"))
+		"P: close sprint view • j/k: navigate sprints • x: export burndown CSV • n: plan next sprint"))
 
 	// Wrap in a box
 	boxStyle := t.Renderer.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(t.Primary).
 		Padding(1, 2).
-		Width(min(80, m.width-4)).
-		MaxHeight(m.height - 2)
+		Width(min(80, v.width-4)).
+		MaxHeight(v.height - 2)
 
 	return lipgloss.Place(
-		m.width,
-		m.height-1,
+		v.width,
+		v.height-1,
 		lipgloss.Center,
 		lipgloss.Top,
 		boxStyle.Render(sb.String()),
 	)
 }
 
+// burndownNote describes why the snapshot chart is shown instead of the
+// git-replayed one
+func (v SprintViewModel) burndownNote(sprintID string) string {
+	if v.errs[sprintID] != nil {
+		return " (git history unavailable, current snapshot)"
+	}
+	if _, done := v.burndowns[sprintID]; done {
+		return " (no git history, current snapshot)"
+	}
+	if v.requested[sprintID] {
+		return " (replaying git history…)"
+	}
+	return ""
+}
+
+// renderGitBurndown renders the git-replayed burndown: one bar per day of
+// remaining work against the ideal line, then the scope-change log and the
+// carryover.
+func (v SprintViewModel) renderGitBurndown(b *analysis.SprintBurndown, innerWidth int) string {
+	t := v.theme
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	valStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	var sb strings.Builder
+
+	sb.WriteString(labelStyle.Render("Burndown:"))
+	sb.WriteString(mutedStyle.Italic(true).Render(fmt.Sprintf(" (git, %d commits)", b.Revisions)))
+	sb.WriteString("\n")
+
+	maxScope := b.InitialScope
+	for _, d := range b.Days {
+		maxScope = max(maxScope, d.Scope)
+	}
+	barWidth := min(max(innerWidth-44, 10), 30)
+	days := b.Days
+	if len(days) > 14 {
+		days = days[len(days)-14:]
+	}
+	for _, d := range days {
+		filled, ideal := 0, -1
+		if maxScope > 0 {
+			filled = min(d.Remaining*barWidth/maxScope, barWidth)
+			ideal = min(int(d.IdealRemaining*float64(barWidth)/float64(maxScope)+0.5), barWidth-1)
+		}
+		var bar strings.Builder
+		for i := 0; i < barWidth; i++ {
+			switch {
+			case i == ideal:
+				bar.WriteString(t.Renderer.NewStyle().Foreground(t.Secondary).Render("│"))
+			case i < filled:
+				bar.WriteString(t.Renderer.NewStyle().Foreground(t.Primary).Render("█"))
+			default:
+				bar.WriteString(mutedStyle.Render("░"))
+			}
+		}
+		line := fmt.Sprintf("  %s %s %3d left %5s", d.Date.Format("Jan 02"), bar.String(), d.Remaining, formatMinutes(d.RemainingMinutes))
+		if d.Added > 0 || d.Removed > 0 {
+			line += t.Renderer.NewStyle().Foreground(t.Feature).Render(fmt.Sprintf("  +%d -%d", d.Added, d.Removed))
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(mutedStyle.Italic(true).Render(fmt.Sprintf("  █ remaining  │ ideal  • start %d (%s)", b.InitialScope, formatMinutes(b.InitialMinutes))))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Scope Changes:"))
+	sb.WriteString("\n")
+	if len(b.ScopeChanges) == 0 {
+		sb.WriteString(valStyle.Render("  (none since start)"))
+		sb.WriteString("\n")
+	}
+	changes := b.ScopeChanges
+	if len(changes) > 5 {
+		sb.WriteString(valStyle.Render(fmt.Sprintf("  … %d earlier", len(changes)-5)))
+		sb.WriteString("\n")
+		changes = changes[len(changes)-5:]
+	}
+	for _, c := range changes {
+		sign, style := "+", t.Renderer.NewStyle().Foreground(t.Feature)
+		if c.Action == analysis.ScopeRemoved {
+			sign, style = "-", t.Renderer.NewStyle().Foreground(t.Open)
+		}
+		sb.WriteString(style.Render(fmt.Sprintf("  %s %s - %s", sign, c.IssueID, truncateStrSprint(c.Title, 24))))
+		sb.WriteString(mutedStyle.Render(fmt.Sprintf(" (%s) %s %s", formatMinutes(c.Minutes), shortSHA(c.CommitSHA), c.Date.Format("Jan 02"))))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	label := "Carryover:"
+	if !b.Final {
+		label = "Carryover (if ended now):"
+	}
+	sb.WriteString(labelStyle.Render(label))
+	if len(b.Carryover) == 0 {
+		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Open).Render(" ✓ none"))
+		sb.WriteString("\n\n")
+		return sb.String()
+	}
+	sb.WriteString(valStyle.Render(fmt.Sprintf(" %d beads, %s", len(b.Carryover), formatMinutes(b.CarryoverMinutes))))
+	sb.WriteString("\n")
+	for i, c := range b.Carryover {
+		if i >= 5 {
+			sb.WriteString(valStyle.Render(fmt.Sprintf("  … +%d more", len(b.Carryover)-5)))
+			sb.WriteString("\n")
+			break
+		}
+		marker := " "
+		if c.AddedMidSprint {
+			marker = "+"
+		}
+		sb.WriteString(valStyle.Render(fmt.Sprintf("  %s %s - %s [%s]", marker, c.IssueID, truncateStrSprint(c.Title, 30), c.Status)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// SprintBurndownLoadedMsg carries a burndown replayed from git history
+type SprintBurndownLoadedMsg struct {
+	SprintID string
+	Burndown *analysis.SprintBurndown // nil when the beads files have no history
+	Err      error
+}
+
+// LoadSprintBurndownCmd replays a sprint's burndown from the git history of
+// the repo containing beadsPath in the background
+func LoadSprintBurndownCmd(beadsPath string, sprint model.Sprint) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := repoPathForBeads(beadsPath)
+		if err != nil {
+			return SprintBurndownLoadedMsg{SprintID: sprint.ID, Err: err}
+		}
+		b, err := analysis.LoadSprintBurndown(repoPath, sprint, time.Now())
+		return SprintBurndownLoadedMsg{SprintID: sprint.ID, Burndown: b, Err: err}
+	}
+}
+
+// sprintBurndownCmd starts replaying the selected sprint's burndown unless
+// it was already requested
+func (m *Model) sprintBurndownCmd() tea.Cmd {
+	if m.selectedSprint == nil || m.sprintView.markRequested(m.selectedSprint.ID) {
+		return nil
+	}
+	return LoadSprintBurndownCmd(m.beadsPath, *m.selectedSprint)
+}
+
+// openSprintView switches to the sprint dashboard, selecting the active
+// sprint (or the first one), and starts the git burndown replay
+func (m *Model) openSprintView() tea.Cmd {
+	if len(m.sprints) == 0 {
+		// Nothing to show yet: plan the first sprint instead
+		m.openSprintPlan()
+		m.statusMsg = "No sprints defined in .beads/" + loader.SprintsFileName + " - planning the first one"
+		m.statusIsError = false
+		return nil
+	}
+	if m.selectedSprint == nil {
+		m.selectedSprint = &m.sprints[0]
+		for i := range m.sprints {
+			if m.sprints[i].IsActive() {
+				m.selectedSprint = &m.sprints[i]
+				break
+			}
+		}
+	}
+	m.clearAttentionOverlay()
+	m.isGraphView = false
+	m.isBoardView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	m.isSprintView = true
+	m.focused = focusSprint
+	cmd := m.sprintBurndownCmd()
+	m.sprintViewText = m.renderSprintDashboard()
+	return cmd
+}
+
+// exportSprintBurndownCSV writes the selected sprint's git-replayed
+// burndown to burndown_<sprint>_YYYY-MM-DD.csv in the working directory
+func (m *Model) exportSprintBurndownCSV() {
+	if m.selectedSprint == nil {
+		return
+	}
+	b := m.sprintView.Burndown(m.selectedSprint.ID)
+	if b == nil {
+		m.statusMsg = "Burndown export needs git history (still loading or unavailable)"
+		m.statusIsError = true
+		return
+	}
+	filename := fmt.Sprintf("burndown_%s_%s.csv", m.selectedSprint.ID, time.Now().Format("2006-01-02"))
+	f, err := os.Create(filepath.Clean(filename))
+	if err == nil {
+		err = b.WriteCSV(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Export failed: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✅ Exported %d burndown days to %s", len(b.Days), filename)
+	m.statusIsError = false
+}
+
 // truncateStrSprint truncates a string to maxLen runes, adding ellipsis if needed.
 // Uses rune-based counting to safely handle UTF-8 multi-byte characters.
 func truncateStrSprint(s string, maxLen int) string {
@@ -271,6 +564,9 @@ func (m Model) handleSprintKeys(msg tea.KeyMsg) Model {
 		// Exit sprint view
 		m.isSprintView = false
 		m.focused = focusList
+	case "n":
+		// Plan the next sprint
+		m.openSprintPlan()
 	case "j", "down":
 		// Next sprint
 		if len(m.sprints) > 1 && m.selectedSprint != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -556,3 +557,66 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRenderSprintDashboard_GitBurndown(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sprint := model.Sprint{ID: "s1", Name: "Sprint 1", StartDate: start, EndDate: start.AddDate(0, 0, 3), BeadIDs: []string{"A", "C"}}
+	burndown := &analysis.SprintBurndown{
+		SprintID:     "s1",
+		Revisions:    3,
+		InitialScope: 2,
+		Days: []analysis.SprintBurndownDay{
+			{Date: start, Scope: 2, Completed: 1, Remaining: 1, RemainingMinutes: 60, IdealRemaining: 1.5},
+			{Date: start.AddDate(0, 0, 1), Scope: 2, Completed: 1, Remaining: 1, RemainingMinutes: 30, IdealRemaining: 1, Added: 1, Removed: 1},
+		},
+		ScopeChanges: []analysis.SprintScopeChange{
+			{Date: start.AddDate(0, 0, 1), CommitSHA: "abcdef1234567", IssueID: "C", Title: "Issue C", Action: analysis.ScopeAdded, Minutes: 30},
+		},
+		Carryover:        []analysis.SprintCarryover{{IssueID: "C", Title: "Issue C", Status: model.StatusOpen, Minutes: 30, AddedMidSprint: true}},
+		CarryoverMinutes: 30,
+		Final:            true,
+	}
+
+	m := Model{
+		theme:          DefaultTheme(lipgloss.NewRenderer(nil)),
+		width:          100,
+		height:         60,
+		selectedSprint: &sprint,
+		issues:         []model.Issue{{ID: "A", Title: "Issue A", Status: model.StatusClosed}, {ID: "C", Title: "Issue C", Status: model.StatusOpen}},
+	}
+	if out := m.renderSprintDashboard(); containsStr(out, "(git, 3 commits)") {
+		t.Fatal("expected the snapshot chart before the replay is loaded")
+	}
+
+	m.sprintView.SetBurndown("s1", burndown, nil)
+	out := m.renderSprintDashboard()
+	for _, want := range []string{"(git, 3 commits)", "Scope Changes", "+ C", "abcdef1", "Carryover", "1 beads, 30m"} {
+		if !containsStr(out, want) {
+			t.Errorf("expected %q in sprint dashboard:\n%s", want, out)
+		}
+	}
+}
+
+func TestOpenSprintView(t *testing.T) {
+	now := time.Now().UTC()
+	m := Model{theme: DefaultTheme(lipgloss.NewRenderer(nil)), width: 100, height: 40, focused: focusList}
+	if cmd := m.openSprintView(); cmd != nil || m.isSprintView || m.focused != focusSprintPlan {
+		t.Fatalf("expected the planner without sprints, got view=%v focus=%v", m.isSprintView, m.focused)
+	}
+	m.focused = focusList
+
+	m.sprints = []model.Sprint{
+		{ID: "old", Name: "Old", StartDate: now.AddDate(0, 0, -20), EndDate: now.AddDate(0, 0, -10)},
+		{ID: "cur", Name: "Current", StartDate: now.AddDate(0, 0, -2), EndDate: now.AddDate(0, 0, 5)},
+	}
+	cmd := m.openSprintView()
+	if !m.isSprintView || m.focused != focusSprint || m.selectedSprint == nil || m.selectedSprint.ID != "cur" {
+		t.Fatalf("expected the active sprint to open, got view=%v sprint=%v", m.isSprintView, m.selectedSprint)
+	}
+	if cmd == nil {
+		t.Fatal("expected a burndown load command")
+	}
+	if again := m.sprintBurndownCmd(); again != nil {
+		t.Error("expected the burndown to be requested only once per sprint")
+	}
+}
@@ -193,6 +193,9 @@ type TreeModel struct {
 
 	// Persistence state (bv-19vz)
 	beadsDir string // Directory containing .beads (for tree-state.json)
+
+	// Epic progress cache: closed/total leaf descendants per node
+	progress map[*IssueTreeNode][2]int
 }
 
 // NewTreeModel creates an empty tree model
@@ -292,6 +295,7 @@ func (t *TreeModel) Build(issues []model.Issue) {
 	t.flatList = nil
 	t.issueMap = make(map[string]*IssueTreeNode)
 	t.cursor = 0
+	t.progress = make(map[*IssueTreeNode][2]int)
 
 	if len(issues) == 0 {
 		t.built = true
@@ -340,6 +344,7 @@ func (t *TreeModel) BuildFromSnapshot(snapshot *DataSnapshot) {
 	// Reset view state, but keep dimensions/theme/beadsDir.
 	t.roots = snapshot.TreeRoots
 	t.issueMap = snapshot.TreeNodeMap
+	t.progress = make(map[*IssueTreeNode][2]int)
 
 	// If the snapshot didn't include tree data, fall back to building it now.
 	if len(t.roots) == 0 || t.issueMap == nil {
@@ -599,6 +604,13 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 	title := issue.Title
 	// Use lipgloss.Width for proper display width (handles ANSI codes + Unicode)
 	maxTitleLen := t.width - lipgloss.Width(prefix) - 25 // Account for prefix, indicator, icon, priority, ID
+	closed, total := 0, 0
+	if issue.IssueType == model.TypeEpic {
+		closed, total = t.epicProgress(node)
+		if total > 0 {
+			maxTitleLen -= treeProgressBarWidth + 6
+		}
+	}
 	if maxTitleLen < 20 {
 		maxTitleLen = 20
 	}
@@ -607,6 +619,15 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 	// Title uses base style foreground
 	sb.WriteString(title)
 
+	// Epic progress bar (closed leaf descendants / all leaf descendants)
+	if total > 0 {
+		frac := float64(closed) / float64(total)
+		sb.WriteString(" ")
+		sb.WriteString(RenderMiniBar(frac, treeProgressBarWidth, t.theme))
+		pctStyle := r.NewStyle().Foreground(t.theme.Muted)
+		sb.WriteString(pctStyle.Render(fmt.Sprintf(" %3.0f%%", frac*100)))
+	}
+
 	// Status indicator (colored dot at end)
 	statusColor := t.theme.GetStatusColor(string(issue.Status))
 	statusDot := " " + GetStatusIcon(string(issue.Status))
@@ -616,6 +637,37 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 	return sb.String()
 }
 
+// treeProgressBarWidth is the width of the progress bar shown on epic rows.
+const treeProgressBarWidth = 8
+
+// epicProgress returns the closed and total counts of leaf descendants under
+// node, recursing through child epics. Results are cached until the next build.
+func (t *TreeModel) epicProgress(node *IssueTreeNode) (closed, total int) {
+	if t.progress == nil {
+		t.progress = make(map[*IssueTreeNode][2]int)
+	}
+	if cached, ok := t.progress[node]; ok {
+		return cached[0], cached[1]
+	}
+	for _, child := range node.Children {
+		if child == nil || child.Issue == nil || child.Issue.Status == model.StatusTombstone {
+			continue
+		}
+		if len(child.Children) == 0 {
+			total++
+			if child.Issue.Status == model.StatusClosed {
+				closed++
+			}
+			continue
+		}
+		c, n := t.epicProgress(child)
+		closed += c
+		total += n
+	}
+	t.progress[node] = [2]int{closed, total}
+	return closed, total
+}
+
 // buildTreePrefix builds the indentation and branch characters for a node.
 func (t *TreeModel) buildTreePrefix(node *IssueTreeNode) string {
 	if node.Depth == 0 {
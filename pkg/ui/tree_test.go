@@ -441,6 +441,46 @@ func TestTreeViewRendering(t *testing.T) {
 	}
 }
 
+// TestTreeEpicProgress verifies epic rows roll up leaf descendants through child epics
+func TestTreeEpicProgress(t *testing.T) {
+	now := time.Now()
+	child := func(id, parent string, typ model.IssueType, status model.Status) model.Issue {
+		return model.Issue{
+			ID: id, Title: id, Priority: 2, IssueType: typ, Status: status, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}},
+		}
+	}
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic Issue", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		child("epic-2", "epic-1", model.TypeEpic, model.StatusOpen),
+		child("task-1", "epic-1", model.TypeTask, model.StatusClosed),
+		child("task-2", "epic-2", model.TypeTask, model.StatusClosed),
+		child("task-3", "epic-2", model.TypeTask, model.StatusOpen),
+		child("task-4", "epic-2", model.TypeTask, model.StatusInProgress),
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.Build(issues)
+	tree.SetSize(120, 30)
+
+	if closed, total := tree.epicProgress(tree.issueMap["epic-1"]); closed != 2 || total != 4 {
+		t.Errorf("epic-1 progress = %d/%d, want 2/4", closed, total)
+	}
+	if closed, total := tree.epicProgress(tree.issueMap["epic-2"]); closed != 1 || total != 3 {
+		t.Errorf("epic-2 progress = %d/%d, want 1/3", closed, total)
+	}
+
+	view := tree.View()
+	if !strings.Contains(view, " 50%") || !strings.Contains(view, " 33%") {
+		t.Errorf("expected epic progress percentages in view, got:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "task-1") && strings.Contains(line, "%") {
+			t.Errorf("non-epic row should not show progress: %q", line)
+		}
+	}
+}
+
 // TestTreeViewIndicators verifies expand/collapse indicators
 func TestTreeViewIndicators(t *testing.T) {
 	tree := NewTreeModel(newTreeTestTheme())
@@ -75,7 +75,11 @@ func TestInsightsCurrentPanelItemCount(t *testing.T) {
 		Cycles:       [][]string{{"X", "Y"}},
 		Stats:        analysis.NewGraphStatsForTest(nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil),
 	}
-	m := NewInsightsModel(ins, map[string]*model.Issue{}, DefaultTheme(nil))
+	issueMap := map[string]*model.Issue{
+		"G": {ID: "G", Status: model.StatusOpen},
+		"W": {ID: "W", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "G", Type: model.DepBlocks}}},
+	}
+	m := NewInsightsModel(ins, issueMap, DefaultTheme(nil))
 	m.SetTopPicks([]analysis.TopPick{{ID: "P1", Score: 1.0}})
 	counts := []int{m.currentPanelItemCount()}
 	for i := 0; i < int(PanelCount)-1; i++ {
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// WorkspaceDashboardModel shows per-repo health, the repo-to-repo blocking
+// matrix, cross-repo blockers and cross-repo cycles in workspace mode.
+type WorkspaceDashboardModel struct {
+	report *analysis.WorkspaceHealthReport
+	cursor int
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewWorkspaceDashboardModel creates an empty workspace dashboard.
+func NewWorkspaceDashboardModel(theme Theme) WorkspaceDashboardModel {
+	return WorkspaceDashboardModel{theme: theme}
+}
+
+// SetData recomputes workspace health for the given repos.
+func (m *WorkspaceDashboardModel) SetData(issues []model.Issue, repos []analysis.WorkspaceRepo) {
+	report := analysis.ComputeWorkspaceHealth(issues, repos, analysis.DefaultWorkspaceHealthConfig())
+	m.report = &report
+	if m.cursor >= len(report.Repos) {
+		m.cursor = 0
+	}
+}
+
+// SetSize sets the available rendering dimensions.
+func (m *WorkspaceDashboardModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveDown selects the next repo.
+func (m *WorkspaceDashboardModel) MoveDown() {
+	if m.report != nil && m.cursor < len(m.report.Repos)-1 {
+		m.cursor++
+	}
+}
+
+// MoveUp selects the previous repo.
+func (m *WorkspaceDashboardModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// SelectedRepo returns the repo under the cursor.
+func (m *WorkspaceDashboardModel) SelectedRepo() *analysis.RepoHealth {
+	if m.report == nil || m.cursor < 0 || m.cursor >= len(m.report.Repos) {
+		return nil
+	}
+	return &m.report.Repos[m.cursor]
+}
+
+// FocusIssueID returns the most impactful cross-repo blocker owned by the
+// selected repo, else the one holding up the selected repo the most.
+func (m *WorkspaceDashboardModel) FocusIssueID() string {
+	repo := m.SelectedRepo()
+	if repo == nil {
+		return ""
+	}
+	for _, b := range m.report.CrossRepoBlockers {
+		if b.Repo == repo.Repo {
+			return b.IssueID
+		}
+	}
+	for _, b := range m.report.CrossRepoBlockers {
+		for _, r := range b.BlockedRepos {
+			if r == repo.Repo {
+				return b.IssueID
+			}
+		}
+	}
+	return ""
+}
+
+// View renders the dashboard.
+func (m *WorkspaceDashboardModel) View() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selectedStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	if m.report == nil || len(m.report.Repos) == 0 {
+		return titleStyle.Render("Workspace Health") + "\n\n" + dimStyle.Render("  No workspace repos loaded")
+	}
+	r := m.report
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Workspace Health"))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d repos • %d cross-repo deps • %d cross-repo blockers",
+		len(r.Repos), r.Flow.TotalCrossRepoDeps, len(r.CrossRepoBlockers))))
+	sb.WriteString("\n\n")
+
+	nameWidth := 14
+	for _, h := range r.Repos {
+		if l := len([]rune(h.Repo)); l > nameWidth && l <= 24 {
+			nameWidth = l
+		}
+	}
+
+	// Repo table
+	sb.WriteString(labelStyle.Render(fmt.Sprintf("  %-*s %5s %7s %7s %7s  %s",
+		nameWidth, "Repo", "Open", "Blocked", "Waits", "Holds", "Health")))
+	sb.WriteString("\n")
+	for i, h := range r.Repos {
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		health := fmt.Sprintf("%s %3d %s", RenderMiniBar(float64(h.Health)/100, 10, t), h.Health, h.HealthLevel)
+		if h.LoadError != "" {
+			health = warnStyle.Render("load failed")
+		}
+		line := fmt.Sprintf("%s%-*s %5d %7d %7d %7d  ",
+			marker, nameWidth, truncateRunesHelper(h.Repo, nameWidth, "…"),
+			h.OpenCount, h.BlockedCount, h.BlockedByOtherRepos, h.BlockingOtherRepos)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		sb.WriteString(line + health)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(dimStyle.Render("  Waits: open issues blocked by other repos • Holds: issues in other repos blocked by this one"))
+	sb.WriteString("\n\n")
+
+	// Flow matrix: rows block columns
+	if r.Flow.TotalCrossRepoDeps > 0 {
+		cell := 6
+		sb.WriteString(labelStyle.Render(fmt.Sprintf("  %-*s", nameWidth, "blocks →")))
+		for _, name := range r.Flow.Repos {
+			sb.WriteString(labelStyle.Render(fmt.Sprintf("%*s", cell, truncateRunesHelper(name, cell-1, "…"))))
+		}
+		sb.WriteString("\n")
+		for i, row := range r.Flow.FlowMatrix {
+			line := fmt.Sprintf("  %-*s", nameWidth, truncateRunesHelper(r.Flow.Repos[i], nameWidth, "…"))
+			for j, count := range row {
+				switch {
+				case i == j:
+					line += fmt.Sprintf("%*s", cell, "·")
+				case count == 0:
+					line += fmt.Sprintf("%*s", cell, "-")
+				default:
+					line += fmt.Sprintf("%*d", cell, count)
+				}
+			}
+			if i == m.cursor {
+				line = selectedStyle.Render(line)
+			}
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, c := range r.Cycles {
+		if c.Kind == analysis.CrossRepoCycleIssues {
+			sb.WriteString(warnStyle.Render(fmt.Sprintf("  ⚠ Blocking cycle across %s: %s",
+				strings.Join(c.Repos, ", "), strings.Join(c.IssueIDs, " → "))))
+		} else {
+			sb.WriteString(warnStyle.Render(fmt.Sprintf("  ⚠ Repos block each other: %s", strings.Join(c.Repos, " ⇄ "))))
+		}
+		sb.WriteString("\n")
+	}
+	if len(r.Cycles) > 0 {
+		sb.WriteString("\n")
+	}
+
+	// Cross-repo blockers, ranked by downstream impact
+	if len(r.CrossRepoBlockers) > 0 {
+		sb.WriteString(labelStyle.Render("Cross-repo blockers"))
+		sb.WriteString("\n")
+		used := strings.Count(sb.String(), "\n")
+		maxRows := height - used - 3
+		if maxRows < 3 {
+			maxRows = 3
+		}
+		selected := r.Repos[m.cursor].Repo
+		for i, b := range r.CrossRepoBlockers {
+			if i >= maxRows {
+				sb.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(r.CrossRepoBlockers)-maxRows)))
+				sb.WriteString("\n")
+				break
+			}
+			line := fmt.Sprintf("    %-14s %-*s %3d downstream → %s  %s",
+				b.IssueID, nameWidth, truncateRunesHelper(b.Repo, nameWidth, "…"), b.DownstreamImpact,
+				strings.Join(b.BlockedRepos, ","), b.Title)
+			line = truncateRunesHelper(line, max(20, width-2), "…")
+			if b.Repo == selected {
+				line = selectedStyle.Render(line)
+			}
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	} else {
+		sb.WriteString(dimStyle.Render("  No open work is blocked across repos"))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: select repo | enter: open top cross-repo blocker | R/esc: back"))
+	return sb.String()
+}
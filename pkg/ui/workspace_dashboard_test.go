@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func workspaceDashboardTestData(now time.Time) ([]model.Issue, []analysis.WorkspaceRepo) {
+	issues := []model.Issue{
+		{ID: "api-1", Title: "Auth endpoint", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "web-1", Title: "Login page", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "web-1", DependsOnID: "api-1", Type: model.DepBlocks}}},
+		{ID: "web-2", Title: "Styles", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now},
+	}
+	repos := []analysis.WorkspaceRepo{
+		{Name: "api", Prefix: "api-"},
+		{Name: "web", Prefix: "web-"},
+	}
+	return issues, repos
+}
+
+func TestWorkspaceDashboardViewEmpty(t *testing.T) {
+	m := NewWorkspaceDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	if out := m.View(); !strings.Contains(out, "No workspace repos loaded") {
+		t.Errorf("expected empty-state message, got:\n%s", out)
+	}
+}
+
+func TestWorkspaceDashboardViewAndNavigation(t *testing.T) {
+	m := NewWorkspaceDashboardModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	m.SetData(workspaceDashboardTestData(time.Now().UTC()))
+	m.SetSize(120, 40)
+
+	out := m.View()
+	for _, want := range []string{"Workspace Health", "2 repos", "1 cross-repo deps", "blocks →", "Cross-repo blockers", "api-1", "Auth endpoint"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+
+	if h := m.SelectedRepo(); h == nil || h.Repo != "api" {
+		t.Fatalf("expected api selected first, got %+v", h)
+	}
+	if id := m.FocusIssueID(); id != "api-1" {
+		t.Errorf("expected api-1 as focus item, got %q", id)
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if h := m.SelectedRepo(); h.Repo != "web" {
+		t.Errorf("expected cursor to stop at web, got %q", h.Repo)
+	}
+	if id := m.FocusIssueID(); id != "api-1" {
+		t.Errorf("expected web to focus the blocker holding it up, got %q", id)
+	}
+}
+
+func TestWorkspaceDashboardRequiresWorkspaceMode(t *testing.T) {
+	issues, _ := workspaceDashboardTestData(time.Now().UTC())
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if m.FocusState() == "workspace_dashboard" {
+		t.Fatalf("expected R to be ignored outside workspace mode")
+	}
+
+	m.EnableWorkspaceMode(WorkspaceInfo{Enabled: true, RepoCount: 2, RepoPrefixes: []string{"api-", "web-"}})
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if m.FocusState() != "workspace_dashboard" {
+		t.Fatalf("expected workspace_dashboard focus after R, got %q", m.FocusState())
+	}
+	if m.CurrentContext() != ContextWorkspaceDashboard {
+		t.Errorf("expected workspace dashboard context, got %q", m.CurrentContext())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.FocusState() != "detail" {
+		t.Errorf("expected enter to open the focus item, got %q", m.FocusState())
+	}
+}
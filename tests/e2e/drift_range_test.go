@@ -0,0 +1,139 @@
+package main_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDriftRangeNamedBaselinesAndRevisions(t *testing.T) {
+	binPath := buildBvBinary(t)
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commitBeads := func(msg, content string) string {
+		if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".beads")
+		git("commit", "-m", msg)
+		return git("rev-parse", "HEAD")
+	}
+	bv := func(args ...string) ([]byte, int) {
+		cmd := exec.Command(binPath, args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "BV_NO_BROWSER=1", "BV_TEST_MODE=1", "TERM=dumb")
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return out, exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("bv %v failed: %v", args, err)
+		}
+		return out, 0
+	}
+
+	acyclic := `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"B","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}
+`
+	cyclic := acyclic + `{"id":"D","title":"D","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"E","type":"blocks"}]}
+{"id":"E","title":"E","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"D","type":"blocks"}]}
+`
+	git("init", "-b", "main")
+	commitBeads("seed", acyclic)
+	if out, code := bv("--save-baseline", "Release 1", "--baseline-name", "v1"); code != 0 {
+		t.Fatalf("save named baseline failed (%d): %s", code, out)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".bv", "baselines", "v1.json")); err != nil {
+		t.Fatalf("named baseline not written: %v", err)
+	}
+	cycleSHA := commitBeads("introduce D-E cycle", cyclic)
+	commitBeads("retitle A", strings.Replace(cyclic, `"title":"A"`, `"title":"A2"`, 1))
+
+	type timelineEntry struct {
+		Type         string `json:"type"`
+		Detail       string `json:"detail"`
+		FirstSeen    string `json:"first_seen"`
+		FirstSeenSHA string `json:"first_seen_sha"`
+		Active       bool   `json:"active"`
+	}
+	type driftOutput struct {
+		ExitCode int `json:"exit_code"`
+		From     struct {
+			Label string `json:"label"`
+			Kind  string `json:"kind"`
+		} `json:"from"`
+		To struct {
+			Label     string `json:"label"`
+			Kind      string `json:"kind"`
+			CommitSHA string `json:"commit_sha"`
+		} `json:"to"`
+		Timeline []timelineEntry `json:"timeline"`
+	}
+
+	// Named baseline to the working tree: the cycle appears in the middle commit.
+	out, code := bv("--check-drift", "--from=v1", "--robot-drift")
+	var named driftOutput
+	if err := json.Unmarshal(out, &named); err != nil {
+		t.Fatalf("parse drift JSON: %v\n%s", err, out)
+	}
+	if code != 1 || named.ExitCode != 1 || named.From.Kind != "baseline" || named.To.Kind != "working_tree" {
+		t.Fatalf("expected a critical baseline→working tree drift, got exit %d %+v", code, named)
+	}
+	cycleEntry := func(entries []timelineEntry) *timelineEntry {
+		for i := range entries {
+			if entries[i].Type == "new_cycle" {
+				return &entries[i]
+			}
+		}
+		return nil
+	}
+	if e := cycleEntry(named.Timeline); e == nil || e.Detail != "D → E → D" || e.FirstSeenSHA != cycleSHA || !e.Active {
+		t.Errorf("expected the cycle first seen in %s, got %+v", cycleSHA, named.Timeline)
+	}
+
+	// Two git revisions, both snapshotted on the fly.
+	out, code = bv("--check-drift", "--from=HEAD~2", "--to=HEAD~1", "--robot-drift")
+	var revs driftOutput
+	if err := json.Unmarshal(out, &revs); err != nil {
+		t.Fatalf("parse drift JSON: %v\n%s", err, out)
+	}
+	if code != 1 || revs.From.Kind != "revision" || revs.To.Kind != "revision" || revs.To.CommitSHA != cycleSHA {
+		t.Fatalf("expected revision→revision drift ending at %s, got exit %d %+v", cycleSHA, code, revs)
+	}
+	if e := cycleEntry(revs.Timeline); e == nil || e.FirstSeen != "HEAD~1" {
+		t.Errorf("expected the cycle first seen at HEAD~1, got %+v", revs.Timeline)
+	}
+
+	// After the cycle, nothing changes structurally.
+	if out, code := bv("--check-drift", "--from=HEAD~1", "--to=HEAD"); code != 0 || !strings.Contains(string(out), "Comparing HEAD~1 → HEAD") {
+		t.Errorf("expected no drift between HEAD~1 and HEAD, got exit %d:\n%s", code, out)
+	}
+
+	if out, code := bv("--check-drift", "--from=no-such-thing"); code != 1 {
+		t.Errorf("expected an unknown point to fail, got exit %d:\n%s", code, out)
+	}
+
+	out, _ = bv("--baseline-info")
+	if !strings.Contains(string(out), "Named baselines:") || !strings.Contains(string(out), "v1") {
+		t.Errorf("expected v1 in the baseline listing:\n%s", out)
+	}
+}
@@ -0,0 +1,112 @@
+package main_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriftCustomRules(t *testing.T) {
+	binPath := buildBvBinary(t)
+	repoDir := t.TempDir()
+	for _, dir := range []string{".beads", ".bv"} {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeBeads := func(content string) {
+		if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bv := func(args ...string) ([]byte, int) {
+		cmd := exec.Command(binPath, args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "BV_NO_BROWSER=1", "BV_TEST_MODE=1", "TERM=dumb")
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return out, exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("bv %v failed: %v", args, err)
+		}
+		return out, 0
+	}
+
+	// Built-in graph-size alerts are noise for such a small project.
+	rules := `disabled_alerts: [node_count_change, edge_count_change, density_growth, actionable_change, pagerank_change]
+rules:
+  - name: payments-growth
+    filter: "label:payments -status:closed"
+    compare: percent
+    op: ">="
+    threshold: 30
+    message: "Open payments work grew {percent}% ({baseline} → {value})"
+  - name: p0-bugs-blocked
+    filter: "type:bug priority:0 status:blocked"
+    where: "blocked_days > 2"
+    severity: critical
+    message: "{count} P0 bug(s) blocked for more than 2 days: {ids}"
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".bv", "drift.yaml"), []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	stamp := func(daysAgo int) string { return now.AddDate(0, 0, -daysAgo).Format(time.RFC3339) }
+	issue := func(id, status, typ string, priority, updatedDaysAgo int, labels string) string {
+		return `{"id":"` + id + `","title":"` + id + `","status":"` + status + `","priority":` + string(rune('0'+priority)) +
+			`,"issue_type":"` + typ + `","labels":[` + labels + `],"created_at":"` + stamp(20) + `","updated_at":"` + stamp(updatedDaysAgo) + `"}` + "\n"
+	}
+	pay := `"payments"`
+	seed := issue("PAY-1", "open", "task", 2, 1, pay) + issue("PAY-2", "open", "task", 2, 1, pay) + issue("BUG-1", "open", "bug", 0, 1, "")
+	writeBeads(seed)
+	if out, code := bv("--save-baseline", "before rules fire"); code != 0 {
+		t.Fatalf("save baseline failed (%d): %s", code, out)
+	}
+
+	type driftOutput struct {
+		ExitCode int `json:"exit_code"`
+		Alerts   []struct {
+			Type     string   `json:"type"`
+			Severity string   `json:"severity"`
+			Message  string   `json:"message"`
+			Rule     string   `json:"rule"`
+			Details  []string `json:"details"`
+		} `json:"alerts"`
+	}
+	check := func() (driftOutput, int) {
+		out, code := bv("--check-drift", "--robot-drift")
+		var res driftOutput
+		if err := json.Unmarshal(out, &res); err != nil {
+			t.Fatalf("parse drift JSON: %v\n%s", err, out)
+		}
+		return res, code
+	}
+
+	if res, code := check(); code != 0 {
+		t.Fatalf("expected no drift against the baseline, got exit %d %+v", code, res)
+	}
+
+	// One more open payments issue: +50% is a warning.
+	writeBeads(seed + issue("PAY-3", "in_progress", "task", 2, 1, pay))
+	res, code := check()
+	if code != 2 || res.ExitCode != 2 || len(res.Alerts) != 1 || res.Alerts[0].Rule != "payments-growth" {
+		t.Fatalf("expected a payments-growth warning, got exit %d %+v", code, res)
+	}
+	if res.Alerts[0].Message != "Open payments work grew +50% (2 → 3)" {
+		t.Errorf("unexpected message %q", res.Alerts[0].Message)
+	}
+
+	// A P0 bug blocked for three days is critical.
+	writeBeads(issue("PAY-1", "open", "task", 2, 1, pay) + issue("PAY-2", "open", "task", 2, 1, pay) + issue("BUG-1", "blocked", "bug", 0, 3, ""))
+	res, code = check()
+	if code != 1 || len(res.Alerts) != 1 || res.Alerts[0].Severity != "critical" || !strings.HasSuffix(res.Alerts[0].Message, ": BUG-1") {
+		t.Fatalf("expected a critical p0-bugs-blocked alert, got exit %d %+v", code, res)
+	}
+}
@@ -0,0 +1,47 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportGantt_MermaidSVGAndPNG(t *testing.T) {
+	f := NewTestFixture(t)
+	root := f.AddIssueWithLabels("Design schema", "open", 1, "task", "db")
+	f.AddIssueWithDeps("Build API", "open", 1, "task", root)
+	f.AddIssueWithLabels("Write docs", "in_progress", 3, "chore", "docs")
+	if err := f.Write(); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	for _, name := range []string{"plan.mmd", "plan.svg", "plan.png"} {
+		out := filepath.Join(f.Dir, name)
+		if _, err := runBVCommand(t, f.Dir, "--export-gantt", out, "--gantt-group", "label"); err != nil {
+			t.Fatalf("--export-gantt %s failed: %v", name, err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil || len(data) == 0 {
+			t.Fatalf("%s missing or empty: %v", name, err)
+		}
+		if name == "plan.mmd" {
+			text := string(data)
+			if !strings.HasPrefix(text, "gantt\n") || !strings.Contains(text, "section db") {
+				t.Errorf("unexpected mermaid output:\n%s", text)
+			}
+		}
+	}
+}
+
+func TestExportGantt_RejectsUnknownGrouping(t *testing.T) {
+	f := NewTestFixture(t)
+	f.AddIssue("Only task", "open", 2, "task")
+	if err := f.Write(); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if _, err := runBVCommand(t, f.Dir, "--export-gantt", filepath.Join(f.Dir, "x.svg"), "--gantt-group", "assignee"); err == nil {
+		t.Fatal("expected failure for unknown --gantt-group")
+	}
+}
@@ -0,0 +1,91 @@
+package main_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckDriftNotify(t *testing.T) {
+	binPath := buildBvBinary(t)
+	repoDir := t.TempDir()
+	for _, dir := range []string{".beads", ".bv"} {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bv := func(args ...string) (string, string, int) {
+		cmd := exec.Command(binPath, args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "BV_NO_BROWSER=1", "BV_TEST_MODE=1", "TERM=dumb")
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return string(out), stderr.String(), exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("bv %v failed: %v", args, err)
+		}
+		return string(out), stderr.String(), 0
+	}
+	writeBeads := func(content string) {
+		if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	acyclic := `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"B","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}
+`
+	writeBeads(acyclic)
+	if out, _, code := bv("--save-baseline", "clean"); code != 0 {
+		t.Fatalf("save baseline failed (%d): %s", code, out)
+	}
+	writeBeads(acyclic + `{"id":"C","title":"C","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"D","type":"blocks"}]}
+{"id":"D","title":"D","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"C","type":"blocks"}]}
+`)
+
+	// Without sinks --notify only warns and the drift exit code is kept.
+	if _, stderr, code := bv("--check-drift", "--notify"); code != 1 || !strings.Contains(stderr, "no sinks configured") {
+		t.Fatalf("expected a warning and exit 1, got %d: %s", code, stderr)
+	}
+
+	config := `min_severity: critical
+sinks:
+  - type: file
+    path: .bv/alerts.jsonl
+  - type: command
+    name: capture
+    command: cat > .bv/last-alert.json
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".bv", "notify.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, stderr, code := bv("--check-drift", "--robot-drift", "--notify")
+	if code != 1 || !strings.Contains(stderr, "Notified 1 alert(s) via 2 sink(s)") {
+		t.Fatalf("expected one critical alert delivered, got exit %d: %s", code, stderr)
+	}
+	if strings.Contains(out, "Notified") {
+		t.Errorf("the report must not pollute stdout JSON:\n%s", out)
+	}
+	lines, err := os.ReadFile(filepath.Join(repoDir, ".bv", "alerts.jsonl"))
+	if err != nil || strings.Count(string(lines), "\n") != 1 || !strings.Contains(string(lines), `"type":"new_cycle"`) {
+		t.Fatalf("expected one new_cycle notification line, got %s (%v)", lines, err)
+	}
+	if payload, err := os.ReadFile(filepath.Join(repoDir, ".bv", "last-alert.json")); err != nil || !strings.Contains(string(payload), `"source":"check-drift"`) {
+		t.Errorf("expected the command to receive the JSON payload, got %s (%v)", payload, err)
+	}
+
+	// A second CI run inside the cooldown sends nothing.
+	if _, stderr, _ := bv("--check-drift", "--notify"); !strings.Contains(stderr, "Notified 0 alert(s)") || !strings.Contains(stderr, "1 suppressed") {
+		t.Errorf("expected the repeat to be suppressed, got: %s", stderr)
+	}
+	if lines, _ := os.ReadFile(filepath.Join(repoDir, ".bv", "alerts.jsonl")); strings.Count(string(lines), "\n") != 1 {
+		t.Errorf("expected no second notification line, got %s", lines)
+	}
+}
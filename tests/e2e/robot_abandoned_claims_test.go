@@ -0,0 +1,96 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRobotAlertsAbandonedClaimsUseGitActivity(t *testing.T) {
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+
+	daysAgo := func(n int) string { return time.Now().UTC().AddDate(0, 0, -n).Format(time.RFC3339) }
+	git := func(date string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Alice",
+			"GIT_AUTHOR_EMAIL=alice@example.com",
+			"GIT_COMMITTER_NAME=Alice",
+			"GIT_COMMITTER_EMAIL=alice@example.com",
+			"GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_DATE="+date,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	writeBeadsAt := func(status, updated string) {
+		content := ""
+		for _, id := range []string{"IDLE-1", "BUSY-1"} {
+			content += fmt.Sprintf(`{"id":%q,"title":%q,"status":%q,"priority":1,"issue_type":"task","assignee":"alice","created_at":%q,"updated_at":%q}`+"\n",
+				id, id, status, daysAgo(30), updated)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write beads.jsonl: %v", err)
+		}
+	}
+
+	git(daysAgo(30), "init", "-b", "main")
+	writeBeadsAt("open", daysAgo(30))
+	git(daysAgo(30), "add", ".")
+	git(daysAgo(30), "commit", "-m", "seed beads")
+
+	writeBeadsAt("in_progress", daysAgo(20))
+	git(daysAgo(20), "add", ".")
+	git(daysAgo(20), "commit", "-m", "claim IDLE-1 and BUSY-1")
+
+	// BUSY-1 keeps moving on an unmerged branch; IDLE-1 sees nothing.
+	git(daysAgo(1), "checkout", "-b", "feature/BUSY-1")
+	if err := os.WriteFile(filepath.Join(repoDir, "busy.go"), []byte("package busy\n"), 0o644); err != nil {
+		t.Fatalf("write busy.go: %v", err)
+	}
+	git(daysAgo(1), "add", "busy.go")
+	git(daysAgo(1), "commit", "-m", "wip")
+	git(daysAgo(1), "checkout", "main")
+
+	var payload struct {
+		Alerts []struct {
+			Type     string   `json:"type"`
+			Severity string   `json:"severity"`
+			IssueID  string   `json:"issue_id"`
+			Message  string   `json:"message"`
+			Details  []string `json:"details"`
+		} `json:"alerts"`
+	}
+	if err := runBVCommandJSON(t, repoDir, &payload, "--robot-alerts", "--alert-type=abandoned_claim"); err != nil {
+		t.Fatalf("--robot-alerts failed: %v", err)
+	}
+	got := map[string]int{}
+	for i, a := range payload.Alerts {
+		got[a.IssueID] = i
+	}
+	if len(payload.Alerts) != 2 {
+		t.Fatalf("expected alerts for IDLE-1 and BUSY-1, got %+v", payload.Alerts)
+	}
+
+	idle := payload.Alerts[got["IDLE-1"]]
+	if idle.Severity != "critical" || !strings.Contains(idle.Message, "no code activity") ||
+		!strings.Contains(strings.Join(idle.Details, "\n"), "last_code_activity=none") {
+		t.Errorf("unexpected IDLE-1 alert %+v", idle)
+	}
+	busy := payload.Alerts[got["BUSY-1"]]
+	details := strings.Join(busy.Details, "\n")
+	if busy.Severity != "info" || !strings.Contains(details, "classification=active_commits") ||
+		!strings.Contains(details, "evidence=branch feature/BUSY-1") {
+		t.Errorf("unexpected BUSY-1 alert %+v", busy)
+	}
+}
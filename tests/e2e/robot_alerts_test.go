@@ -179,3 +179,73 @@ func TestRobotAlerts_UsesBaselineWhenPresent(t *testing.T) {
 		t.Fatalf("expected node_count_change in alerts, got %+v", p.Alerts)
 	}
 }
+
+func TestRobotAlerts_DueDates(t *testing.T) {
+	env := t.TempDir()
+
+	now := time.Now().UTC()
+	ts := now.Add(-1 * time.Hour).Format(time.RFC3339)
+	due := func(days int) string { return now.AddDate(0, 0, days).Format(time.RFC3339) }
+
+	// One-hour estimates and no closures: each issue needs ~5 days (2.6..7.4).
+	writeBeads(t, env, fmt.Sprintf(
+		`{"id":"OVERDUE","title":"Overdue","status":"open","priority":1,"issue_type":"task","estimated_minutes":60,"created_at":"%[1]s","updated_at":"%[1]s","due_date":"%[2]s"}
+{"id":"RISKY","title":"Risky","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"created_at":"%[1]s","updated_at":"%[1]s","due_date":"%[3]s"}
+{"id":"SAFE","title":"Safe","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"created_at":"%[1]s","updated_at":"%[1]s","due_date":"%[4]s"}
+{"id":"CHAINED","title":"Chained","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"created_at":"%[1]s","updated_at":"%[1]s","due_date":"%[5]s","dependencies":[{"issue_id":"CHAINED","depends_on_id":"BLK","type":"blocks"}]}
+{"id":"BLK","title":"Blocker","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"created_at":"%[1]s","updated_at":"%[1]s"}`,
+		ts, due(-3), due(6), due(60), due(5),
+	))
+
+	type alert struct {
+		Type     string   `json:"type"`
+		Severity string   `json:"severity"`
+		IssueID  string   `json:"issue_id"`
+		Details  []string `json:"details"`
+	}
+	var p struct {
+		Alerts []alert `json:"alerts"`
+	}
+	if err := runBVCommandJSON(t, env, &p, "--robot-alerts"); err != nil {
+		t.Fatalf("robot-alerts failed: %v", err)
+	}
+
+	got := map[string]alert{}
+	for _, a := range p.Alerts {
+		if a.Type == "due_date_late" || a.Type == "due_date_at_risk" {
+			got[a.IssueID] = a
+		}
+	}
+	if a := got["OVERDUE"]; a.Type != "due_date_late" || a.Severity != "critical" {
+		t.Errorf("expected critical due_date_late for OVERDUE, got %+v", a)
+	}
+	if a := got["RISKY"]; a.Type != "due_date_at_risk" || a.Severity != "warning" {
+		t.Errorf("expected due_date_at_risk warning for RISKY, got %+v", a)
+	}
+	chained := got["CHAINED"]
+	if chained.Type != "due_date_late" {
+		t.Errorf("expected CHAINED to be late once its blocker is counted, got %+v", chained)
+	}
+	foundChain := false
+	for _, d := range chained.Details {
+		if d == "blocked_by=BLK" {
+			foundChain = true
+		}
+	}
+	if !foundChain {
+		t.Errorf("expected blocked_by=BLK detail, got %v", chained.Details)
+	}
+	if _, ok := got["SAFE"]; ok {
+		t.Errorf("did not expect a due-date alert for SAFE")
+	}
+
+	var filtered struct {
+		Alerts []alert `json:"alerts"`
+	}
+	if err := runBVCommandJSON(t, env, &filtered, "--robot-alerts", "--alert-type=due_date_at_risk"); err != nil {
+		t.Fatalf("filtered robot-alerts failed: %v", err)
+	}
+	if len(filtered.Alerts) != 1 || filtered.Alerts[0].IssueID != "RISKY" {
+		t.Errorf("expected only RISKY in at-risk filter, got %+v", filtered.Alerts)
+	}
+}
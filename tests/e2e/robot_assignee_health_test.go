@@ -0,0 +1,57 @@
+package main_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRobotAssigneeHealth(t *testing.T) {
+	env := t.TempDir()
+	stale := time.Now().UTC().AddDate(0, 0, -12).Format(time.RFC3339)
+	fresh := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	writeBeads(t, env, fmt.Sprintf(`{"id":"A1","title":"Old claim","status":"in_progress","priority":1,"issue_type":"task","assignee":"alice","created_at":%[1]q,"updated_at":%[1]q}
+{"id":"A2","title":"Waiting on API","status":"open","priority":1,"issue_type":"task","assignee":"alice","created_at":%[2]q,"updated_at":%[2]q,"dependencies":[{"issue_id":"A2","depends_on_id":"B1","type":"blocks"}]}
+{"id":"B1","title":"API","status":"in_progress","priority":1,"issue_type":"task","assignee":"bob","created_at":%[2]q,"updated_at":%[2]q}
+{"id":"U1","title":"Nobody's","status":"open","priority":2,"issue_type":"task","created_at":%[2]q}`, stale, fresh))
+
+	var payload struct {
+		DataHash       string `json:"data_hash"`
+		AssigneeHealth struct {
+			UnassignedOpen int `json:"unassigned_open"`
+			Assignees      []struct {
+				Assignee        string   `json:"assignee"`
+				WIP             int      `json:"wip"`
+				BlockedByOthers int      `json:"blocked_by_others"`
+				StaleClaims     []string `json:"stale_claims"`
+				AttentionScore  int      `json:"attention_score"`
+				Blocked         []struct {
+					IssueID  string `json:"issue_id"`
+					Blockers []struct {
+						IssueID  string `json:"issue_id"`
+						Assignee string `json:"assignee"`
+						External bool   `json:"external"`
+					} `json:"blockers"`
+				} `json:"blocked"`
+			} `json:"assignees"`
+		} `json:"assignee_health"`
+	}
+	if err := runBVCommandJSON(t, env, &payload, "--robot-assignee-health"); err != nil {
+		t.Fatalf("--robot-assignee-health failed: %v", err)
+	}
+
+	ah := payload.AssigneeHealth
+	if payload.DataHash == "" || ah.UnassignedOpen != 1 || len(ah.Assignees) != 2 {
+		t.Fatalf("unexpected header: hash=%q unassigned=%d assignees=%d", payload.DataHash, ah.UnassignedOpen, len(ah.Assignees))
+	}
+	alice := ah.Assignees[0]
+	if alice.Assignee != "alice" || alice.AttentionScore <= ah.Assignees[1].AttentionScore {
+		t.Fatalf("expected alice ranked first by attention, got %+v", ah.Assignees)
+	}
+	if alice.WIP != 1 || len(alice.StaleClaims) != 1 || alice.StaleClaims[0] != "A1" {
+		t.Errorf("expected A1 as alice's stale claim, got %+v", alice)
+	}
+	if alice.BlockedByOthers != 1 || len(alice.Blocked) != 1 || alice.Blocked[0].Blockers[0].Assignee != "bob" || !alice.Blocked[0].Blockers[0].External {
+		t.Errorf("expected A2 blocked by bob's B1, got %+v", alice.Blocked)
+	}
+}
@@ -0,0 +1,127 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRobotBurndownReplaysGitHistory(t *testing.T) {
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	daysAgo := func(n int) string { return today.AddDate(0, 0, -n).Add(12 * time.Hour).Format(time.RFC3339) }
+	git := func(date string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(date, msg string, closed []string, scope ...string) string {
+		var beads strings.Builder
+		for _, b := range []struct {
+			id      string
+			minutes int
+		}{{"A", 60}, {"B", 120}, {"C", 30}} {
+			status := "open"
+			for _, c := range closed {
+				if c == b.id {
+					status = "closed"
+				}
+			}
+			fmt.Fprintf(&beads, `{"id":%q,"title":"Issue %s","status":%q,"priority":1,"issue_type":"task","estimated_minutes":%d}`+"\n",
+				b.id, b.id, status, b.minutes)
+		}
+		sprint := fmt.Sprintf(`{"id":"sprint-1","name":"Sprint 1","start_date":%q,"end_date":%q,"bead_ids":["%s"]}`+"\n",
+			today.AddDate(0, 0, -10).Format(time.RFC3339), today.AddDate(0, 0, -3).Format(time.RFC3339), strings.Join(scope, `","`))
+		if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads.String()), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "sprints.jsonl"), []byte(sprint), 0o644); err != nil {
+			t.Fatalf("write sprints: %v", err)
+		}
+		git(date, "add", ".beads")
+		git(date, "commit", "-m", msg)
+		return git(date, "rev-parse", "HEAD")
+	}
+
+	git(daysAgo(12), "init", "-b", "main")
+	commit(daysAgo(12), "plan sprint", nil, "A", "B")
+	commit(daysAgo(8), "close A", []string{"A"}, "A", "B")
+	addC := commit(daysAgo(6), "pull C into sprint", []string{"A"}, "A", "B", "C")
+	commit(daysAgo(4), "close B", []string{"A", "B"}, "A", "B", "C")
+
+	var payload struct {
+		Source       string `json:"source"`
+		ScopeChanges []struct {
+			IssueID   string `json:"issue_id"`
+			Action    string `json:"action"`
+			CommitSHA string `json:"commit_sha"`
+		} `json:"scope_changes"`
+		History *struct {
+			Final          bool `json:"final"`
+			InitialScope   int  `json:"initial_scope"`
+			InitialMinutes int  `json:"initial_minutes"`
+			Days           []struct {
+				Remaining        int `json:"remaining"`
+				RemainingMinutes int `json:"remaining_minutes"`
+			} `json:"days"`
+			Carryover []struct {
+				IssueID        string `json:"issue_id"`
+				AddedMidSprint bool   `json:"added_mid_sprint"`
+			} `json:"carryover"`
+			CarryoverMinutes int `json:"carryover_minutes"`
+		} `json:"history"`
+	}
+	csvPath := filepath.Join(t.TempDir(), "burndown.csv")
+	if err := runBVCommandJSON(t, repoDir, &payload, "--robot-burndown", "sprint-1", "--burndown-csv", csvPath); err != nil {
+		t.Fatalf("--robot-burndown failed: %v", err)
+	}
+
+	if payload.Source != "git" || payload.History == nil {
+		t.Fatalf("expected a git-replayed burndown, got source=%q history=%v", payload.Source, payload.History)
+	}
+	h := payload.History
+	if !h.Final || h.InitialScope != 2 || h.InitialMinutes != 180 || len(h.Days) != 8 {
+		t.Fatalf("unexpected history %+v", h)
+	}
+	// Day 1 (10 days ago) has both planned beads open; by the last day only C remains.
+	if first, last := h.Days[0], h.Days[len(h.Days)-1]; first.Remaining != 2 || last.Remaining != 1 || last.RemainingMinutes != 30 {
+		t.Errorf("unexpected remaining work: first=%+v last=%+v", first, last)
+	}
+	if len(payload.ScopeChanges) != 1 || payload.ScopeChanges[0].IssueID != "C" ||
+		payload.ScopeChanges[0].Action != "added" || payload.ScopeChanges[0].CommitSHA != addC {
+		t.Errorf("expected C added in %s, got %+v", addC, payload.ScopeChanges)
+	}
+	if len(h.Carryover) != 1 || h.Carryover[0].IssueID != "C" || !h.Carryover[0].AddedMidSprint || h.CarryoverMinutes != 30 {
+		t.Errorf("expected C to carry over, got %+v", h.Carryover)
+	}
+
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 9 || !strings.HasPrefix(lines[0], "date,scope,completed,remaining") {
+		t.Errorf("expected a header and 8 day rows, got:\n%s", data)
+	}
+}
@@ -0,0 +1,74 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotClustersSuggestsUnlabeledEpic(t *testing.T) {
+	tempDir := t.TempDir()
+	beadsDir := filepath.Join(tempDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+
+	// Two independent, densely related groups of open work with no epic, plus one loner.
+	var lines []string
+	group := func(prefix string, titles ...string) {
+		for i, title := range titles {
+			id := fmt.Sprintf("%s-%d", prefix, i)
+			var deps []string
+			for j := 0; j < i; j++ {
+				deps = append(deps, fmt.Sprintf(`{"issue_id":%q,"depends_on_id":"%s-%d","type":"related"}`, id, prefix, j))
+			}
+			lines = append(lines, fmt.Sprintf(`{"id":%q,"title":%q,"status":"open","priority":2,"issue_type":"task","dependencies":[%s]}`, id, title, strings.Join(deps, ",")))
+		}
+	}
+	group("bill", "Billing invoice export", "Billing invoice email", "Billing invoice retry", "Billing invoice audit", "Billing invoice pdf")
+	group("search", "Search index build", "Search index query", "Search index ranking")
+	lines = append(lines, `{"id":"lonely","title":"Lonely task","status":"open","priority":3,"issue_type":"task"}`)
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	var payload struct {
+		DataHash string `json:"data_hash"`
+		Clusters struct {
+			IssueCount int `json:"issue_count"`
+			Clusters   []struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+				Size int    `json:"size"`
+			} `json:"clusters"`
+			Suggestions []struct {
+				Type      string   `json:"type"`
+				ClusterID int      `json:"cluster_id"`
+				IssueIDs  []string `json:"issue_ids"`
+			} `json:"suggestions"`
+			Unclustered []string `json:"unclustered"`
+		} `json:"clusters"`
+	}
+	if err := runBVCommandJSON(t, tempDir, &payload, "--robot-clusters"); err != nil {
+		t.Fatalf("--robot-clusters failed: %v", err)
+	}
+
+	c := payload.Clusters
+	if payload.DataHash == "" || c.IssueCount != 9 {
+		t.Fatalf("unexpected header: hash=%q issues=%d", payload.DataHash, c.IssueCount)
+	}
+	if len(c.Clusters) != 2 || c.Clusters[0].Size != 5 || c.Clusters[1].Size != 3 {
+		t.Fatalf("expected clusters of 5 and 3, got %+v", c.Clusters)
+	}
+	if c.Clusters[0].Name != "billing invoice" {
+		t.Errorf("expected name from shared title words, got %q", c.Clusters[0].Name)
+	}
+	if len(c.Unclustered) != 1 || c.Unclustered[0] != "lonely" {
+		t.Errorf("expected the lonely task to be unclustered, got %v", c.Unclustered)
+	}
+	if len(c.Suggestions) != 1 || c.Suggestions[0].Type != "unlabeled_epic" || c.Suggestions[0].ClusterID != 1 || len(c.Suggestions[0].IssueIDs) != 5 {
+		t.Errorf("expected one unlabeled_epic suggestion for the billing chain, got %+v", c.Suggestions)
+	}
+}
@@ -0,0 +1,79 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRobotEpicsRollsUpChildEpics(t *testing.T) {
+	bv := buildBvBinary(t)
+	tempDir := t.TempDir()
+	beadsDir := filepath.Join(tempDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+
+	beads := `{"id":"epic-1","title":"Platform","status":"open","priority":1,"issue_type":"epic"}
+{"id":"epic-2","title":"Storage","status":"open","priority":2,"issue_type":"epic","dependencies":[{"issue_id":"epic-2","depends_on_id":"epic-1","type":"parent-child"}]}
+{"id":"task-1","title":"Schema","status":"closed","priority":2,"issue_type":"task","estimated_minutes":120,"dependencies":[{"issue_id":"task-1","depends_on_id":"epic-1","type":"parent-child"}]}
+{"id":"task-2","title":"Disk layout","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"dependencies":[{"issue_id":"task-2","depends_on_id":"epic-2","type":"parent-child"},{"issue_id":"task-2","depends_on_id":"ext-1","type":"blocks"}]}
+{"id":"ext-1","title":"Auth service","status":"in_progress","priority":1,"issue_type":"task"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	cmd := exec.Command(bv, "--robot-epics")
+	cmd.Dir = tempDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-epics failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash  string `json:"data_hash"`
+		EpicCount int    `json:"epic_count"`
+		Epics     []struct {
+			EpicID           string  `json:"epic_id"`
+			ParentEpicID     string  `json:"parent_epic_id"`
+			Depth            int     `json:"depth"`
+			TotalIssues      int     `json:"total_issues"`
+			ClosedIssues     int     `json:"closed_issues"`
+			PercentByMinutes float64 `json:"percent_by_minutes"`
+			ExternalBlockers []struct {
+				IssueID string `json:"issue_id"`
+			} `json:"external_blockers"`
+			Forecast struct {
+				Status string `json:"status"`
+			} `json:"forecast"`
+		} `json:"epics"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" {
+		t.Error("missing data_hash")
+	}
+	if payload.EpicCount != 2 || len(payload.Epics) != 2 {
+		t.Fatalf("expected 2 epics, got %d", len(payload.Epics))
+	}
+
+	top, child := payload.Epics[0], payload.Epics[1]
+	if top.EpicID != "epic-1" || child.EpicID != "epic-2" || child.ParentEpicID != "epic-1" || child.Depth != 1 {
+		t.Fatalf("unexpected epic tree: %+v", payload.Epics)
+	}
+	if top.TotalIssues != 2 || top.ClosedIssues != 1 {
+		t.Errorf("expected epic-1 to include child epic work (2 items, 1 closed), got %d/%d", top.ClosedIssues, top.TotalIssues)
+	}
+	if top.PercentByMinutes < 66 || top.PercentByMinutes > 67 {
+		t.Errorf("expected ~66.7%% by minutes, got %v", top.PercentByMinutes)
+	}
+	if len(top.ExternalBlockers) != 1 || top.ExternalBlockers[0].IssueID != "ext-1" {
+		t.Errorf("expected ext-1 as external blocker, got %+v", top.ExternalBlockers)
+	}
+	if top.Forecast.Status == "" {
+		t.Error("missing forecast status")
+	}
+}
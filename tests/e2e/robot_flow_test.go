@@ -0,0 +1,100 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"testing"
+)
+
+type robotFlowPayload struct {
+	DataHash string `json:"data_hash"`
+	Flow     struct {
+		Days    int    `json:"days"`
+		Source  string `json:"source"`
+		Project struct {
+			IssueCount int `json:"issue_count"`
+			CFD        []struct {
+				Open       int `json:"open"`
+				InProgress int `json:"in_progress"`
+				Closed     int `json:"closed"`
+			} `json:"cfd"`
+			LeadTime struct {
+				Count int `json:"count"`
+			} `json:"lead_time"`
+			CycleTime struct {
+				Count int `json:"count"`
+			} `json:"cycle_time"`
+			Throughput struct {
+				Total int `json:"total"`
+			} `json:"throughput"`
+			WIPAging []struct {
+				IssueID string `json:"issue_id"`
+			} `json:"wip_aging"`
+		} `json:"project"`
+		Labels []struct {
+			Label string `json:"label"`
+		} `json:"labels"`
+	} `json:"flow"`
+}
+
+func TestRobotFlowUsesGitTransitions(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, _ := createHistoryRepo(t)
+
+	cmd := exec.Command(bv, "--robot-flow", "--flow-days", "7")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-flow failed: %v\n%s", err, out)
+	}
+
+	var payload robotFlowPayload
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" {
+		t.Error("missing data_hash")
+	}
+	if payload.Flow.Source != "git" {
+		t.Errorf("expected git source, got %q", payload.Flow.Source)
+	}
+	if payload.Flow.Days != 7 || len(payload.Flow.Project.CFD) != 7 {
+		t.Fatalf("expected 7-day window, got days=%d points=%d", payload.Flow.Days, len(payload.Flow.Project.CFD))
+	}
+	if last := payload.Flow.Project.CFD[6]; last.Closed != 1 {
+		t.Errorf("expected HIST-1 closed today, got %+v", last)
+	}
+	if payload.Flow.Project.CycleTime.Count != 1 {
+		t.Errorf("expected cycle time from the in_progress commit, got %d samples", payload.Flow.Project.CycleTime.Count)
+	}
+	if payload.Flow.Project.Throughput.Total != 1 {
+		t.Errorf("expected throughput 1, got %d", payload.Flow.Project.Throughput.Total)
+	}
+}
+
+func TestRobotFlowSnapshotFallbackPerLabel(t *testing.T) {
+	f := NewTestFixture(t)
+	f.AddIssueWithLabels("API work", "in_progress", 1, "task", "api")
+	f.AddIssueWithLabels("UI polish", "open", 2, "task", "ui")
+	f.AddIssue("Done already", "closed", 2, "task")
+	if err := f.Write(); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var payload robotFlowPayload
+	if err := runBVCommandJSON(t, f.Dir, &payload, "--robot-flow"); err != nil {
+		t.Fatalf("--robot-flow failed: %v", err)
+	}
+	if payload.Flow.Source != "snapshot" {
+		t.Errorf("expected snapshot source outside git, got %q", payload.Flow.Source)
+	}
+	if payload.Flow.Project.IssueCount != 3 {
+		t.Errorf("expected 3 issues, got %d", payload.Flow.Project.IssueCount)
+	}
+	if len(payload.Flow.Project.WIPAging) != 1 {
+		t.Errorf("expected one WIP item, got %+v", payload.Flow.Project.WIPAging)
+	}
+	if len(payload.Flow.Labels) != 2 || payload.Flow.Labels[0].Label != "api" || payload.Flow.Labels[1].Label != "ui" {
+		t.Errorf("unexpected label summaries: %+v", payload.Flow.Labels)
+	}
+}
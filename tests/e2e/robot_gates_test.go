@@ -0,0 +1,66 @@
+package main_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRobotGatesReportsChainsAndEpics(t *testing.T) {
+	tempDir := t.TempDir()
+	writeBeads(t, tempDir, `{"id":"epic","title":"Launch","status":"open","priority":1,"issue_type":"epic"}
+{"id":"schema","title":"Schema","status":"open","priority":2,"issue_type":"task"}
+{"id":"model","title":"Model","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"model","depends_on_id":"schema","type":"blocks"}]}
+{"id":"api","title":"API","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"api","depends_on_id":"model","type":"blocks"},{"issue_id":"api","depends_on_id":"epic","type":"parent-child"}]}
+{"id":"docs","title":"Docs","status":"open","priority":3,"issue_type":"task","dependencies":[{"issue_id":"docs","depends_on_id":"epic","type":"parent-child"}]}
+{"id":"old","title":"Old","status":"closed","priority":3,"issue_type":"task"}`)
+
+	type gate struct {
+		IssueID   string `json:"issue_id"`
+		Dominates int    `json:"dominates"`
+	}
+	var payload struct {
+		DataHash string `json:"data_hash"`
+		Report   struct {
+			IssueID     string   `json:"issue_id"`
+			GatingChain []gate   `json:"gating_chain"`
+			Dominates   int      `json:"dominates"`
+			Dominated   []string `json:"dominated"`
+			Epics       []struct {
+				EpicID string `json:"epic_id"`
+				Chain  []gate `json:"chain"`
+			} `json:"epics"`
+		} `json:"report"`
+		UsageHints []string `json:"usage_hints"`
+	}
+	if err := runBVCommandJSON(t, tempDir, &payload, "--robot-gates", "model"); err != nil {
+		t.Fatalf("--robot-gates failed: %v", err)
+	}
+	r := payload.Report
+	if payload.DataHash == "" || r.IssueID != "model" || len(payload.UsageHints) == 0 {
+		t.Fatalf("unexpected header: %+v", payload)
+	}
+	if len(r.GatingChain) != 1 || r.GatingChain[0].IssueID != "schema" || r.GatingChain[0].Dominates != 2 {
+		t.Errorf("expected schema to gate model, got %+v", r.GatingChain)
+	}
+	if r.Dominates != 1 || strings.Join(r.Dominated, ",") != "api" {
+		t.Errorf("expected model to dominate api only, got %d %v", r.Dominates, r.Dominated)
+	}
+	if len(r.Epics) != 1 || r.Epics[0].EpicID != "epic" || len(r.Epics[0].Chain) != 1 || r.Epics[0].Chain[0].IssueID != "api" {
+		t.Errorf("expected model to feed the epic through api, got %+v", r.Epics)
+	}
+
+	var all struct {
+		TopGates []gate `json:"top_gates"`
+	}
+	if err := runBVCommandJSON(t, tempDir, &all, "--robot-gates=all"); err != nil {
+		t.Fatalf("--robot-gates=all failed: %v", err)
+	}
+	if len(all.TopGates) != 2 || all.TopGates[0].IssueID != "schema" || all.TopGates[1].IssueID != "model" {
+		t.Errorf("unexpected top gates: %+v", all.TopGates)
+	}
+
+	out, err := runBVCommand(t, tempDir, "--robot-gates", "old")
+	if err == nil || !strings.Contains(err.Error(), "not open") {
+		t.Errorf("expected an error for a closed issue, got %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,82 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotSuggestLabelModel(t *testing.T) {
+	tempDir := t.TempDir()
+	topics := map[string][]string{
+		"auth": {"login", "password", "token", "session"},
+		"ui":   {"button", "layout", "css", "modal"},
+	}
+	var lines []string
+	for label, words := range topics {
+		for i := 0; i < 10; i++ {
+			lines = append(lines, fmt.Sprintf(`{"id":"%s-%d","title":"%s %s broken","status":"closed","priority":2,"issue_type":"task","labels":["%s"]}`,
+				label, i, words[i%4], words[(i+1)%4], label))
+		}
+	}
+	lines = append(lines, `{"id":"new-1","title":"Session token lost after login","status":"open","priority":1,"issue_type":"bug"}`)
+	writeBeads(t, tempDir, strings.Join(lines, "\n"))
+
+	type output struct {
+		Suggestions struct {
+			Suggestions []struct {
+				TargetBead string         `json:"target_bead"`
+				Confidence float64        `json:"confidence"`
+				Metadata   map[string]any `json:"metadata"`
+			} `json:"suggestions"`
+		} `json:"suggestions"`
+		LabelModel *struct {
+			Documents int      `json:"documents"`
+			Labels    []string `json:"labels"`
+			Update    struct {
+				Loaded    bool `json:"loaded"`
+				Retrained bool `json:"retrained"`
+			} `json:"update"`
+			Evaluation struct {
+				HeldOut        int     `json:"held_out"`
+				MacroPrecision float64 `json:"macro_precision"`
+				MacroRecall    float64 `json:"macro_recall"`
+				Labels         []struct {
+					Label string `json:"label"`
+				} `json:"labels"`
+			} `json:"evaluation"`
+		} `json:"label_model"`
+	}
+
+	var first output
+	if err := runBVCommandJSON(t, tempDir, &first, "--robot-suggest", "--suggest-type=label"); err != nil {
+		t.Fatalf("--robot-suggest failed: %v", err)
+	}
+	lm := first.LabelModel
+	if lm == nil || lm.Documents != 20 || strings.Join(lm.Labels, ",") != "auth,ui" || !lm.Update.Retrained {
+		t.Fatalf("unexpected label model %+v", lm)
+	}
+	if lm.Evaluation.HeldOut == 0 || len(lm.Evaluation.Labels) != 2 {
+		t.Errorf("expected held-out evaluation for both labels, got %+v", lm.Evaluation)
+	}
+	if len(first.Suggestions.Suggestions) == 0 {
+		t.Fatal("expected a label suggestion")
+	}
+	s := first.Suggestions.Suggestions[0]
+	if s.TargetBead != "new-1" || s.Metadata["suggested_label"] != "auth" || s.Metadata["source"] != "classifier" || s.Confidence < 0.5 {
+		t.Errorf("unexpected suggestion %+v", s)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".bv", "label_model.json")); err != nil {
+		t.Errorf("expected persisted label model: %v", err)
+	}
+
+	var second output
+	if err := runBVCommandJSON(t, tempDir, &second, "--robot-suggest", "--suggest-type=label"); err != nil {
+		t.Fatalf("second --robot-suggest failed: %v", err)
+	}
+	if second.LabelModel == nil || !second.LabelModel.Update.Loaded || second.LabelModel.Update.Retrained {
+		t.Errorf("expected unchanged data to reuse the saved model, got %+v", second.LabelModel)
+	}
+}
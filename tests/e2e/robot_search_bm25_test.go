@@ -0,0 +1,87 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRobotSearchBM25(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	issues := `{"id":"A","title":"Login timeout on mobile","description":"Session expires early","status":"open","priority":1,"issue_type":"bug"}
+{"id":"B","title":"Timeout handling for login retries","status":"open","priority":2,"issue_type":"task"}
+{"id":"C","title":"Crash report","status":"open","priority":2,"issue_type":"bug","comments":[{"id":1,"issue_id":"C","author":"sam","text":"Reproduced near the zeppelin hangar","created_at":"2025-01-01T00:00:00Z"}]}
+{"id":"D","title":"Update changelog","status":"open","priority":3,"issue_type":"task"}`
+	writeBeads(t, env, issues)
+
+	type result struct {
+		IssueID string  `json:"issue_id"`
+		Score   float64 `json:"score"`
+	}
+	search := func(query string, extra ...string) (lexicalLoaded bool, lexicalPath string, results []result) {
+		t.Helper()
+		args := append([]string{"--search", query, "--robot-search"}, extra...)
+		cmd := exec.Command(bv, args...)
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("robot-search %q failed: %v\n%s", query, err, out)
+		}
+		var payload struct {
+			LexicalLoaded bool     `json:"lexical_loaded"`
+			LexicalPath   string   `json:"lexical_index_path"`
+			Results       []result `json:"results"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil || len(payload.Results) == 0 {
+			t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+		}
+		return payload.LexicalLoaded, payload.LexicalPath, payload.Results
+	}
+
+	// Comments are indexed for BM25 even though the embedding document leaves them out.
+	loaded, path, results := search("zeppelin")
+	if loaded || results[0].IssueID != "C" {
+		t.Fatalf("expected a fresh lexical index with C first, got loaded=%v %+v", loaded, results)
+	}
+	if path != filepath.Join(env, ".bv", "semantic", "lexical.bvlx") {
+		t.Errorf("unexpected lexical index path %q", path)
+	}
+
+	// The phrase only matches A in order; the persisted index is reused.
+	loaded, _, results = search(`"login timeout"`)
+	if !loaded || results[0].IssueID != "A" {
+		t.Fatalf("expected the saved index and A first, got loaded=%v %+v", loaded, results)
+	}
+
+	// Hybrid mode fuses the same BM25 ranking and reports it per result.
+	cmd := exec.Command(bv, "--search", "zeppelin", "--search-mode", "hybrid", "--robot-search")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("hybrid robot-search failed: %v\n%s", err, out)
+	}
+	var hybrid struct {
+		Results []struct {
+			IssueID         string             `json:"issue_id"`
+			ComponentScores map[string]float64 `json:"component_scores"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(out, &hybrid); err != nil || len(hybrid.Results) == 0 {
+		t.Fatalf("hybrid json decode: %v\nout=%s", err, out)
+	}
+	if hybrid.Results[0].IssueID != "C" || hybrid.Results[0].ComponentScores["bm25"] <= 0 {
+		t.Fatalf("expected C first with a bm25 component, got %+v", hybrid.Results[0])
+	}
+
+	// Changing the data invalidates the saved index.
+	writeBeads(t, env, issues+"\n"+`{"id":"E","title":"Zeppelin docking","status":"open","priority":2,"issue_type":"task"}`)
+	loaded, _, results = search("zeppelin")
+	if loaded || results[0].IssueID != "E" {
+		t.Fatalf("expected a rebuild with the title match E first, got loaded=%v %+v", loaded, results)
+	}
+}
@@ -0,0 +1,56 @@
+package main_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRobotSearchHNSW(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, fmt.Sprintf(`{"id":"T-%03d","title":"Routine task %d","description":"chore%d housekeeping","status":"open","priority":2,"issue_type":"task"}`, i, i, i%17))
+	}
+	lines = append(lines, `{"id":"K","title":"Kraken sighting","description":"interstellarkraken interstellarkraken interstellarkraken","status":"open","priority":1,"issue_type":"task"}`)
+	writeBeads(t, env, strings.Join(lines, "\n"))
+
+	search := func() (loaded, approximate bool, top string) {
+		t.Helper()
+		cmd := exec.Command(bv, "--search", "interstellarkraken", "--robot-search")
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(),
+			"BV_SEMANTIC_EMBEDDER=hash",
+			"BV_SEMANTIC_DIM=256",
+			"BV_SEMANTIC_EXACT_THRESHOLD=100",
+		)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("robot-search failed: %v\n%s", err, out)
+		}
+		var payload struct {
+			Loaded      bool `json:"loaded"`
+			Approximate bool `json:"approximate"`
+			Results     []struct {
+				IssueID string `json:"issue_id"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil || len(payload.Results) == 0 {
+			t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+		}
+		return payload.Loaded, payload.Approximate, payload.Results[0].IssueID
+	}
+
+	loaded, approximate, top := search()
+	if loaded || !approximate || top != "K" {
+		t.Fatalf("expected a fresh approximate search with K first, got loaded=%v approximate=%v top=%s", loaded, approximate, top)
+	}
+	// The persisted graph is reused on the next run.
+	if loaded, approximate, top := search(); !loaded || !approximate || top != "K" {
+		t.Fatalf("expected the saved graph to be reused, got loaded=%v approximate=%v top=%s", loaded, approximate, top)
+	}
+}
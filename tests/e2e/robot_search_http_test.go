@@ -0,0 +1,101 @@
+package main_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRobotSearchHTTPEmbedder(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Kraken attacks the harbour","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Update changelog","status":"open","priority":2,"issue_type":"task"}`)
+
+	// A "model" that knows a kraken is a sea monster, which the hash embedder cannot.
+	var requests, inputs atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "sea-model" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		requests.Add(1)
+		inputs.Add(int32(len(req.Input)))
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var data []item
+		for i, text := range req.Input {
+			vec := []float32{0, 1, 0}
+			if lower := strings.ToLower(text); strings.Contains(lower, "kraken") || strings.Contains(lower, "sea monster") {
+				vec = []float32{1, 0.1, 0}
+			}
+			data = append(data, item{i, vec})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	search := func() (provider string, dim int, indexPath string, top string) {
+		t.Helper()
+		cmd := exec.Command(bv, "--search", "sea monster", "--robot-search")
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(),
+			"BV_SEMANTIC_EMBEDDER=openai",
+			"BV_SEMANTIC_URL="+srv.URL+"/v1",
+			"BV_SEMANTIC_MODEL=sea-model",
+			"BV_SEMANTIC_DIM=",
+		)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("robot-search failed: %v\n%s", err, out)
+		}
+		var payload struct {
+			Provider  string `json:"provider"`
+			Dim       int    `json:"dim"`
+			IndexPath string `json:"index_path"`
+			Results   []struct {
+				IssueID string `json:"issue_id"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil || len(payload.Results) == 0 {
+			t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+		}
+		return payload.Provider, payload.Dim, payload.IndexPath, payload.Results[0].IssueID
+	}
+
+	provider, dim, indexPath, top := search()
+	if provider != "openai" || dim != 3 {
+		t.Fatalf("expected the openai provider with a detected dim of 3, got %q %d", provider, dim)
+	}
+	if top != "A" {
+		t.Fatalf("expected the kraken issue first, got %s", top)
+	}
+	if ok, _ := filepath.Match("index-openai-sea-model-????????-3.bvvi", filepath.Base(indexPath)); !ok {
+		t.Errorf("unexpected index path %q", indexPath)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(env, ".bv", "semantic", "cache", "openai-sea-model-*-3.jsonl")); len(matches) != 1 {
+		t.Errorf("expected one embedding cache file, got %v", matches)
+	}
+
+	// The second run only probes the dimension; documents and query come from disk.
+	requests.Store(0)
+	inputs.Store(0)
+	if _, _, _, top := search(); top != "A" {
+		t.Fatalf("expected the kraken issue first on the cached run, got %s", top)
+	}
+	if requests.Load() != 1 || inputs.Load() != 1 {
+		t.Errorf("expected only the dimension probe, got %d request(s) with %d input(s)", requests.Load(), inputs.Load())
+	}
+}
@@ -0,0 +1,184 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+type searchSourceMatch struct {
+	Source  string `json:"source"`
+	Ref     string `json:"ref"`
+	Snippet string `json:"snippet"`
+}
+
+type searchSourcesResult struct {
+	IssueID   string              `json:"issue_id"`
+	MatchedIn []searchSourceMatch `json:"matched_in"`
+}
+
+func runSearchSources(t *testing.T, bv, dir, query string, extra ...string) []searchSourcesResult {
+	t.Helper()
+	args := append([]string{"--search", query, "--robot-search"}, extra...)
+	cmd := exec.Command(bv, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("robot-search %q failed: %v\n%s", query, err, out)
+	}
+	var payload struct {
+		Results []searchSourcesResult `json:"results"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil || len(payload.Results) == 0 {
+		t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+	}
+	return payload.Results
+}
+
+func TestRobotSearchReportsCommentMatches(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Sync stalls","status":"open","priority":1,"issue_type":"bug","comments":[{"id":1,"issue_id":"A","author":"sam","text":"Pretty sure this is the retry bug again","created_at":"2025-01-01T00:00:00Z"}]}
+{"id":"B","title":"Update changelog","status":"open","priority":2,"issue_type":"task"}`)
+
+	results := runSearchSources(t, bv, env, "retry bug")
+	if results[0].IssueID != "A" || len(results[0].MatchedIn) == 0 {
+		t.Fatalf("expected A first with match details, got %+v", results)
+	}
+	m := results[0].MatchedIn[0]
+	if m.Source != "comment" || m.Ref != "sam" || !strings.Contains(m.Snippet, "retry bug") {
+		t.Errorf("unexpected match %+v", m)
+	}
+	if len(results) > 1 && len(results[1].MatchedIn) != 0 {
+		t.Errorf("semantic-only result should carry no keyword matches, got %+v", results[1])
+	}
+
+	// Leaving comments out of the sources drops the match.
+	results = runSearchSources(t, bv, env, "retry bug", "--search-sources", "issue")
+	for _, r := range results {
+		if len(r.MatchedIn) != 0 {
+			t.Fatalf("expected no keyword matches with issue-only sources, got %+v", r)
+		}
+	}
+}
+
+func TestRobotSearchWarnsWhenHistoryUnavailable(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Sync stalls","status":"open","priority":1,"issue_type":"bug"}`)
+
+	cmd := exec.Command(bv, "--search", "sync", "--robot-search", "--search-sources", "all")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("robot-search failed: %v\n%s", err, out)
+	}
+	var payload struct {
+		Results  []searchSourcesResult `json:"results"`
+		Warnings []string              `json:"warnings"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+	}
+	if len(payload.Results) == 0 || payload.Results[0].IssueID != "A" {
+		t.Errorf("expected issue results despite missing history, got %+v", payload.Results)
+	}
+	if len(payload.Warnings) != 1 || !strings.Contains(payload.Warnings[0], "commit and file sources were not searched") {
+		t.Errorf("expected a history warning outside a git repo, got %v", payload.Warnings)
+	}
+
+	// Issue-only sources never need history, so nothing is reported.
+	cmd = exec.Command(bv, "--search", "sync", "--robot-search")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	if out, err = cmd.Output(); err != nil || strings.Contains(string(out), `"warnings"`) {
+		t.Errorf("expected no warnings for default sources, got %s (%v)", out, err)
+	}
+}
+
+func TestRobotSearchIndexPerSourceSet(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Kraken attacks the harbour","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Update changelog","status":"open","priority":2,"issue_type":"task","comments":[{"id":1,"issue_id":"B","author":"sam","text":"Mention the release date","created_at":"2025-01-01T00:00:00Z"}]}`)
+
+	search := func(extra ...string) (bool, string, []searchSourcesResult) {
+		t.Helper()
+		cmd := exec.Command(bv, append([]string{"--search", "kraken", "--robot-search"}, extra...)...)
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("robot-search failed: %v\n%s", err, out)
+		}
+		var payload struct {
+			LexicalPath   string                `json:"lexical_index_path"`
+			LexicalLoaded bool                  `json:"lexical_loaded"`
+			Results       []searchSourcesResult `json:"results"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("robot-search json decode: %v\nout=%s", err, out)
+		}
+		return payload.LexicalLoaded, payload.LexicalPath, payload.Results
+	}
+
+	_, defaultPath, _ := search()
+	_, commentPath, results := search("--search-sources", "comment")
+	if defaultPath == commentPath {
+		t.Fatalf("expected separate indexes per source set, both at %q", defaultPath)
+	}
+	// The query only matches A's title, which comment-only searches leave out,
+	// including through the issue embeddings.
+	if len(results) != 0 {
+		t.Errorf("expected no results from comments, got %+v", results)
+	}
+
+	// Alternating source sets reuses each saved index.
+	if loaded, _, _ := search(); !loaded {
+		t.Error("expected the default index to be reused")
+	}
+	if loaded, _, _ := search("--search-sources", "comment"); !loaded {
+		t.Error("expected the comment index to be reused")
+	}
+}
+
+func TestRobotSearchCommitAndFileSources(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, _ := createHistoryRepo(t)
+
+	// Commit messages are only searched when asked for.
+	for _, r := range runSearchSources(t, bv, repoDir, "claim") {
+		if len(r.MatchedIn) != 0 {
+			t.Fatalf("default sources should not search commits, got %+v", r)
+		}
+	}
+
+	results := runSearchSources(t, bv, repoDir, "claim", "--search-sources", "all")
+	if results[0].IssueID != "HIST-1" || len(results[0].MatchedIn) == 0 {
+		t.Fatalf("expected HIST-1 with a commit match, got %+v", results)
+	}
+	if m := results[0].MatchedIn[0]; m.Source != "commit" || m.Ref == "" || !strings.Contains(m.Snippet, "claim HIST-1") {
+		t.Errorf("unexpected commit match %+v", m)
+	}
+
+	results = runSearchSources(t, bv, repoDir, "work", "--search-sources", "file")
+	if len(results[0].MatchedIn) == 0 || results[0].MatchedIn[0].Ref != "pkg/work.go" {
+		t.Fatalf("expected a match on the touched path, got %+v", results)
+	}
+
+	// Human output says where the match was found.
+	cmd := exec.Command(bv, "--search", "claim", "--search-sources", "commit")
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("search failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "matched in commit ") {
+		t.Errorf("expected a 'matched in commit' line, got:\n%s", out)
+	}
+}
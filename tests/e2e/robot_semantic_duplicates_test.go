@@ -0,0 +1,71 @@
+package main_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotSuggestSemanticDuplicates(t *testing.T) {
+	tempDir := t.TempDir()
+	writeBeads(t, tempDir, `{"id":"bv-1","title":"Login page crashes on submit","status":"open","priority":1,"issue_type":"bug","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"bv-2","title":"Login page crashes on submit","description":"seen on staging","status":"open","priority":2,"issue_type":"bug","created_at":"2026-02-01T00:00:00Z","updated_at":"2026-02-01T00:00:00Z"}
+{"id":"bv-3","title":"Refresh dashboard palette","status":"open","priority":3,"issue_type":"task"}`)
+
+	type suggestOutput struct {
+		Suggestions struct {
+			Suggestions []struct {
+				Type        string         `json:"type"`
+				TargetBead  string         `json:"target_bead"`
+				RelatedBead string         `json:"related_bead"`
+				Metadata    map[string]any `json:"metadata"`
+			} `json:"suggestions"`
+		} `json:"suggestions"`
+	}
+
+	var lexical suggestOutput
+	if err := runBVCommandJSON(t, tempDir, &lexical, "--robot-suggest", "--suggest-type=duplicate"); err != nil {
+		t.Fatalf("--robot-suggest failed: %v", err)
+	}
+	if n := len(lexical.Suggestions.Suggestions); n != 0 {
+		t.Fatalf("expected keyword-only detection to miss the pair, got %d suggestions", n)
+	}
+
+	var semantic suggestOutput
+	if err := runBVCommandJSON(t, tempDir, &semantic, "--robot-suggest", "--suggest-type=duplicate", "--semantic-duplicates"); err != nil {
+		t.Fatalf("--robot-suggest --semantic-duplicates failed: %v", err)
+	}
+	if len(semantic.Suggestions.Suggestions) != 1 {
+		t.Fatalf("expected one semantic duplicate, got %+v", semantic.Suggestions.Suggestions)
+	}
+	s := semantic.Suggestions.Suggestions[0]
+	if s.TargetBead != "bv-1" || s.RelatedBead != "bv-2" || s.Metadata["method"] != "semantic" || s.Metadata["merge_into"] != "bv-1" {
+		t.Errorf("unexpected suggestion %+v", s)
+	}
+
+	var alerts struct {
+		Alerts []struct {
+			Type    string   `json:"type"`
+			IssueID string   `json:"issue_id"`
+			Details []string `json:"details"`
+		} `json:"alerts"`
+	}
+	if err := runBVCommandJSON(t, tempDir, &alerts, "--robot-alerts", "--alert-type=potential_duplicate", "--semantic-duplicates"); err != nil {
+		t.Fatalf("--robot-alerts failed: %v", err)
+	}
+	if len(alerts.Alerts) != 1 || alerts.Alerts[0].IssueID != "bv-2" || alerts.Alerts[0].Details[0] != "duplicate_of=bv-1" {
+		t.Errorf("expected bv-2 flagged as a duplicate of bv-1, got %+v", alerts.Alerts)
+	}
+
+	// The embeddings persist so later runs only embed new or edited issues.
+	if matches, _ := filepath.Glob(filepath.Join(tempDir, ".bv", "semantic", "duplicates-index-*.bvvi")); len(matches) != 1 {
+		t.Errorf("expected a persisted duplicate index, got %v", matches)
+	}
+
+	cmd := exec.Command(buildBvBinary(t), "--robot-suggest", "--semantic-duplicates", "--duplicate-cosine=1.5")
+	cmd.Dir = tempDir
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "--duplicate-cosine") {
+		t.Errorf("expected --duplicate-cosine=1.5 to be rejected, got err=%v\n%s", err, out)
+	}
+}
@@ -0,0 +1,76 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRobotSimilarExplainsSuggestions(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Login retry loop never backs off","status":"open","priority":1,"issue_type":"bug","labels":["auth"]}
+{"id":"B","title":"Retry loop on login hammers the server","status":"open","priority":2,"issue_type":"bug","labels":["auth"]}
+{"id":"C","title":"Session store migration","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"A","type":"blocks"}]}
+{"id":"D","title":"Dashboard colours","status":"open","priority":3,"issue_type":"task","dependencies":[{"issue_id":"D","depends_on_id":"C","type":"blocks"}]}`)
+
+	cmd := exec.Command(bv, "--robot-similar", "A")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-similar failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		IssueID string `json:"issue_id"`
+		Similar []struct {
+			IssueID    string             `json:"issue_id"`
+			Score      float64            `json:"score"`
+			Components map[string]float64 `json:"components"`
+			Reasons    []string           `json:"reasons"`
+			Path       []string           `json:"dependency_path"`
+			AddCommand string             `json:"add_command"`
+		} `json:"similar"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.IssueID != "A" || len(payload.Similar) == 0 || payload.Similar[0].IssueID != "B" {
+		t.Fatalf("expected B as the top suggestion for A, got %s", out)
+	}
+	best := payload.Similar[0]
+	reasons := strings.Join(best.Reasons, "; ")
+	if !strings.Contains(reasons, "similar text") || !strings.Contains(reasons, "shares label auth") {
+		t.Errorf("unexpected reasons %q", reasons)
+	}
+	if best.AddCommand != "bd dep add A B --type related" {
+		t.Errorf("unexpected add command %q", best.AddCommand)
+	}
+
+	var sawD bool
+	for _, s := range payload.Similar {
+		if s.IssueID == "C" {
+			t.Errorf("C already depends on A and should not be suggested")
+		}
+		if s.IssueID == "D" {
+			sawD = true
+			if strings.Join(s.Path, ",") != "A,C,D" || s.Components["graph"] == 0 {
+				t.Errorf("expected D via C, got %+v", s)
+			}
+		}
+	}
+	if !sawD {
+		t.Errorf("expected D two hops away, got %s", out)
+	}
+
+	// Unknown issues fail loudly.
+	cmd = exec.Command(bv, "--robot-similar", "ZZZ")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash")
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "issue not found") {
+		t.Errorf("expected an error for an unknown issue, got err=%v\n%s", err, out)
+	}
+}
@@ -0,0 +1,75 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRobotSprintPlanFillsCapacityAndSaves(t *testing.T) {
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	issues := `{"id":"A","title":"Schema","status":"open","priority":1,"issue_type":"task","estimated_minutes":120}
+{"id":"B","title":"API","status":"open","priority":1,"issue_type":"task","estimated_minutes":120,"dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}
+{"id":"C","title":"Docs","status":"open","priority":3,"issue_type":"task","estimated_minutes":60}
+{"id":"D","title":"Rewrite","status":"open","priority":2,"issue_type":"task","estimated_minutes":3000}
+{"id":"E","title":"Done","status":"closed","priority":1,"issue_type":"task","estimated_minutes":60}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(issues), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	type planPayload struct {
+		CapacityMinutes int `json:"capacity_minutes"`
+		PlannedMinutes  int `json:"planned_minutes"`
+		Items           []struct {
+			IssueID     string   `json:"issue_id"`
+			PulledInFor []string `json:"pulled_in_for"`
+		} `json:"items"`
+		Excluded []struct {
+			IssueID string `json:"issue_id"`
+			Reason  string `json:"reason"`
+		} `json:"excluded"`
+		SavedSprint *struct {
+			ID      string   `json:"id"`
+			BeadIDs []string `json:"bead_ids"`
+		} `json:"saved_sprint"`
+	}
+
+	var plan planPayload
+	if err := runBVCommandJSON(t, repoDir, &plan, "--robot-sprint-plan", "--plan-capacity", "5"); err != nil {
+		t.Fatalf("--robot-sprint-plan failed: %v", err)
+	}
+	if plan.CapacityMinutes != 300 || plan.PlannedMinutes != 300 || len(plan.Items) != 3 {
+		t.Fatalf("expected A, B and C to fill 5h, got %+v", plan)
+	}
+	if plan.Items[0].IssueID != "A" || len(plan.Items[0].PulledInFor) != 1 || plan.Items[0].PulledInFor[0] != "B" {
+		t.Errorf("expected blocker A first, pulled in for B, got %+v", plan.Items)
+	}
+	if len(plan.Excluded) != 1 || plan.Excluded[0].IssueID != "D" || plan.Excluded[0].Reason != "too_large" {
+		t.Errorf("expected D excluded as too large, got %+v", plan.Excluded)
+	}
+
+	var saved planPayload
+	if err := runBVCommandJSON(t, repoDir, &saved, "--robot-sprint-plan", "--plan-capacity", "5", "--plan-save", "sprint-next"); err != nil {
+		t.Fatalf("--plan-save failed: %v", err)
+	}
+	if saved.SavedSprint == nil || saved.SavedSprint.ID != "sprint-next" || len(saved.SavedSprint.BeadIDs) != 3 {
+		t.Fatalf("expected the saved sprint in the output, got %+v", saved.SavedSprint)
+	}
+
+	// The saved beads are now scheduled, so a second plan leaves them out.
+	var next planPayload
+	if err := runBVCommandJSON(t, repoDir, &next, "--robot-sprint-plan"); err != nil {
+		t.Fatalf("second --robot-sprint-plan failed: %v", err)
+	}
+	if next.CapacityMinutes != 300 || len(next.Items) != 0 {
+		t.Errorf("expected the saved capacity and nothing left to plan, got %+v", next)
+	}
+	if _, err := runBVCommand(t, repoDir, "--robot-sprint-plan", "--plan-save", "sprint-next"); err == nil {
+		t.Error("expected saving over an existing sprint ID to fail")
+	}
+}
@@ -0,0 +1,131 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRobotSprintRetro(t *testing.T) {
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	daysAgo := func(n int) string { return today.AddDate(0, 0, -n).Add(12 * time.Hour).Format(time.RFC3339) }
+	git := func(date string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_DATE="+date,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	// commit writes the beads with the given statuses (default open), touches
+	// any code files and commits everything together.
+	commit := func(date, msg string, statuses map[string]string, files ...string) {
+		var beads strings.Builder
+		for _, id := range []string{"RET-1", "RET-2", "RET-3"} {
+			status := statuses[id]
+			if status == "" {
+				status = "open"
+			}
+			fmt.Fprintf(&beads, `{"id":%q,"title":"Retro %s","status":%q,"priority":1,"issue_type":"task","estimated_minutes":60,"created_at":%q}`+"\n",
+				id, id, status, daysAgo(12))
+		}
+		sprint := fmt.Sprintf(`{"id":"sprint-1","name":"Sprint 1","start_date":%q,"end_date":%q,"bead_ids":["RET-1","RET-2","RET-3"]}`+"\n",
+			today.AddDate(0, 0, -10).Format(time.RFC3339), today.AddDate(0, 0, -3).Format(time.RFC3339))
+		if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads.String()), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "sprints.jsonl"), []byte(sprint), 0o644); err != nil {
+			t.Fatalf("write sprints: %v", err)
+		}
+		for _, f := range files {
+			if err := os.WriteFile(filepath.Join(repoDir, f), []byte("package main // "+msg+"\n"), 0o644); err != nil {
+				t.Fatalf("write %s: %v", f, err)
+			}
+		}
+		git(date, "add", ".")
+		git(date, "commit", "-m", msg)
+	}
+
+	git(daysAgo(12), "init", "-b", "main")
+	commit(daysAgo(12), "plan sprint", nil)
+	commit(daysAgo(9), "RET-2 waits on infra", map[string]string{"RET-2": "blocked"})
+	commit(daysAgo(8), "start RET-1", map[string]string{"RET-1": "in_progress", "RET-2": "blocked"}, "shared.go")
+	commit(daysAgo(7), "finish RET-1", map[string]string{"RET-1": "closed", "RET-2": "blocked"}, "shared.go", "one.go")
+	commit(daysAgo(5), "unblock RET-2", map[string]string{"RET-1": "closed", "RET-2": "in_progress"}, "shared.go")
+	commit(daysAgo(4), "finish RET-2", map[string]string{"RET-1": "closed", "RET-2": "closed"})
+
+	var payload struct {
+		SprintID        string  `json:"sprint_id"`
+		Source          string  `json:"source"`
+		Final           bool    `json:"final"`
+		Planned         int     `json:"planned"`
+		Delivered       int     `json:"delivered"`
+		CompletionRatio float64 `json:"completion_ratio"`
+		Carryover       []struct {
+			IssueID string `json:"issue_id"`
+		} `json:"carryover"`
+		MostBlocked []struct {
+			IssueID      string  `json:"issue_id"`
+			BlockedHours float64 `json:"blocked_hours"`
+		} `json:"most_blocked"`
+		ChurnedFiles []struct {
+			FilePath   string `json:"file_path"`
+			TotalBeads int    `json:"total_beads"`
+		} `json:"churned_files"`
+		Velocity []struct {
+			SprintID string `json:"sprint_id"`
+			Current  bool   `json:"current"`
+		} `json:"velocity"`
+		VelocityTrend string `json:"velocity_trend"`
+	}
+	mdPath := filepath.Join(t.TempDir(), "retro.md")
+	if err := runBVCommandJSON(t, repoDir, &payload, "--robot-sprint-retro", "last", "--retro-md", mdPath); err != nil {
+		t.Fatalf("--robot-sprint-retro failed: %v", err)
+	}
+
+	if payload.SprintID != "sprint-1" || payload.Source != "git" || !payload.Final {
+		t.Fatalf("expected a finished git-replayed retro of sprint-1, got %+v", payload)
+	}
+	if payload.Planned != 3 || payload.Delivered != 2 {
+		t.Errorf("expected 2 of 3 delivered, got planned=%d delivered=%d", payload.Planned, payload.Delivered)
+	}
+	if len(payload.Carryover) != 1 || payload.Carryover[0].IssueID != "RET-3" {
+		t.Errorf("expected RET-3 to carry over, got %+v", payload.Carryover)
+	}
+	if len(payload.MostBlocked) != 1 || payload.MostBlocked[0].IssueID != "RET-2" || payload.MostBlocked[0].BlockedHours != 96 {
+		t.Errorf("expected RET-2 blocked for 96h, got %+v", payload.MostBlocked)
+	}
+	if len(payload.ChurnedFiles) == 0 || payload.ChurnedFiles[0].FilePath != "shared.go" || payload.ChurnedFiles[0].TotalBeads != 2 {
+		t.Errorf("expected shared.go as the top churned file, got %+v", payload.ChurnedFiles)
+	}
+	if len(payload.Velocity) != 1 || payload.VelocityTrend != "insufficient_data" {
+		t.Errorf("expected no previous sprints, got %+v %s", payload.Velocity, payload.VelocityTrend)
+	}
+
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("read Markdown: %v", err)
+	}
+	for _, want := range []string{"# Sprint Retrospective: Sprint 1", "## Carried Over", "## Most Blocked", "`shared.go`"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("expected %q in Markdown:\n%s", want, md)
+		}
+	}
+}
@@ -48,3 +48,40 @@ func TestRobotSuggestContract(t *testing.T) {
 		t.Fatalf("suggest data_hash changed between calls: %v vs %v", first.DataHash, second.DataHash)
 	}
 }
+
+func TestRobotSuggestRedundantDependency(t *testing.T) {
+	env := t.TempDir()
+	// C is blocked by B, B by A, and C also directly by A: C→A is redundant.
+	writeBeads(t, env, `{"id":"A","title":"Schema","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Migration","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}
+{"id":"C","title":"Backfill","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"B","type":"blocks"},{"issue_id":"C","depends_on_id":"A","type":"blocks"}]}`)
+
+	var payload struct {
+		Suggestions struct {
+			Suggestions []struct {
+				Type          string `json:"type"`
+				TargetBead    string `json:"target_bead"`
+				RelatedBead   string `json:"related_bead"`
+				ActionCommand string `json:"action_command"`
+				Metadata      struct {
+					Path []string `json:"path"`
+				} `json:"metadata"`
+			} `json:"suggestions"`
+		} `json:"suggestions"`
+	}
+	if err := runBVCommandJSON(t, env, &payload, "--robot-suggest", "--suggest-type=redundant"); err != nil {
+		t.Fatalf("--robot-suggest failed: %v", err)
+	}
+
+	sugs := payload.Suggestions.Suggestions
+	if len(sugs) != 1 {
+		t.Fatalf("expected one redundant edge, got %+v", sugs)
+	}
+	s := sugs[0]
+	if s.Type != "redundant_dependency" || s.TargetBead != "C" || s.RelatedBead != "A" || s.ActionCommand != "bd dep remove C A" {
+		t.Errorf("unexpected suggestion: %+v", s)
+	}
+	if len(s.Metadata.Path) != 3 || s.Metadata.Path[1] != "B" {
+		t.Errorf("expected path C → B → A, got %v", s.Metadata.Path)
+	}
+}
@@ -0,0 +1,87 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTriageConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatalf("mkdir .bv: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "triage.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write triage.yaml: %v", err)
+	}
+}
+
+func TestRobotTriage_CustomFormula(t *testing.T) {
+	env := t.TempDir()
+	ts := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	writeBeads(t, env, fmt.Sprintf(
+		`{"id":"P0","title":"Urgent","status":"open","priority":0,"issue_type":"task","created_at":"%[1]s","updated_at":"%[1]s"}
+{"id":"P2","title":"Normal","status":"open","priority":2,"issue_type":"task","created_at":"%[1]s","updated_at":"%[1]s"}
+{"id":"SEC","title":"Harden auth","status":"open","priority":4,"issue_type":"task","labels":["security"],"created_at":"%[1]s","updated_at":"%[1]s"}
+{"id":"CHORE","title":"Tidy","status":"open","priority":1,"issue_type":"chore","labels":["chore"],"created_at":"%[1]s","updated_at":"%[1]s"}`,
+		ts,
+	))
+	writeTriageConfig(t, env, `
+formula: "10 - priority"
+rules:
+  - name: security first
+    label: security
+    top: 1
+  - name: no chores
+    when: "has_label('chore')"
+    exclude: true
+`)
+
+	var payload struct {
+		Triage struct {
+			Meta struct {
+				Formula string `json:"formula"`
+			} `json:"meta"`
+			Recommendations []struct {
+				ID      string   `json:"id"`
+				Score   float64  `json:"score"`
+				Reasons []string `json:"reasons"`
+			} `json:"recommendations"`
+		} `json:"triage"`
+	}
+	if err := runBVCommandJSON(t, env, &payload, "--robot-triage"); err != nil {
+		t.Fatalf("robot-triage failed: %v", err)
+	}
+
+	recs := payload.Triage.Recommendations
+	var ids []string
+	for _, r := range recs {
+		ids = append(ids, r.ID)
+	}
+	if strings.Join(ids, ",") != "SEC,P0,P2" {
+		t.Fatalf("expected SEC pinned first and CHORE excluded, got %v", ids)
+	}
+	if payload.Triage.Meta.Formula != "10 - priority" {
+		t.Errorf("expected meta.formula, got %q", payload.Triage.Meta.Formula)
+	}
+	if recs[1].Score != 10 || len(recs[1].Reasons) == 0 || !strings.HasPrefix(recs[1].Reasons[0], "🧮 Custom formula score") {
+		t.Errorf("expected formula-scored P0 with explanation, got %+v", recs[1])
+	}
+}
+
+func TestRobotTriage_InvalidFormulaFails(t *testing.T) {
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task"}`)
+	writeTriageConfig(t, env, "formula: \"pagerank + velocity\"\n")
+
+	out, err := runBVCommand(t, env, "--robot-triage")
+	if err == nil {
+		t.Fatalf("expected failure for invalid formula, got output %s", out)
+	}
+	if !strings.Contains(err.Error(), `unknown variable "velocity"`) {
+		t.Errorf("expected validation error on stderr, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotWorkspaceHealthReportsCrossRepoBlockers(t *testing.T) {
+	bv := buildBvBinary(t)
+
+	workspaceRoot := t.TempDir()
+	configPath := filepath.Join(workspaceRoot, ".bv", "workspace.yaml")
+	write := func(rel, content string) {
+		path := filepath.Join(workspaceRoot, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	write("services/api/.beads/issues.jsonl", `{"id":"AUTH-1","title":"API auth","status":"open","priority":1,"issue_type":"task"}
+{"id":"AUTH-2","title":"Tokens","status":"closed","priority":2,"issue_type":"task"}
+`)
+	// Cross-repo dependency references must already be namespaced.
+	write("apps/web/.beads/issues.jsonl", `{"id":"UI-1","title":"Login","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"UI-1","depends_on_id":"api-AUTH-1","type":"blocks"}]}
+{"id":"UI-2","title":"Logout","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"UI-2","depends_on_id":"web-UI-1","type":"blocks"}]}
+`)
+	write(".bv/workspace.yaml", `
+name: test-workspace
+repos:
+  - name: api
+    path: services/api
+    prefix: api-
+  - name: web
+    path: apps/web
+    prefix: web-
+discovery:
+  enabled: false
+`)
+
+	cmd := exec.Command(bv, "--robot-workspace-health", "--workspace", configPath)
+	cmd.Dir = workspaceRoot
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("--robot-workspace-health failed: %v\nstderr=%s", err, stderr.String())
+	}
+
+	var payload struct {
+		DataHash string `json:"data_hash"`
+		Health   struct {
+			Repos []struct {
+				Repo                string `json:"repo"`
+				OpenCount           int    `json:"open_count"`
+				BlockedByOtherRepos int    `json:"blocked_by_other_repos"`
+				BlockingOtherRepos  int    `json:"blocking_other_repos"`
+				Health              int    `json:"health"`
+			} `json:"repos"`
+			Flow struct {
+				Repos              []string `json:"repos"`
+				FlowMatrix         [][]int  `json:"flow_matrix"`
+				TotalCrossRepoDeps int      `json:"total_cross_repo_deps"`
+			} `json:"flow"`
+			CrossRepoBlockers []struct {
+				IssueID          string   `json:"issue_id"`
+				Repo             string   `json:"repo"`
+				BlockedRepos     []string `json:"blocked_repos"`
+				DownstreamImpact int      `json:"downstream_impact"`
+			} `json:"cross_repo_blockers"`
+		} `json:"workspace_health"`
+		UsageHints []string `json:"usage_hints"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	h := payload.Health
+	if payload.DataHash == "" || len(payload.UsageHints) == 0 || len(h.Repos) != 2 {
+		t.Fatalf("unexpected payload: %s", stdout.String())
+	}
+	if api := h.Repos[0]; api.Repo != "api" || api.OpenCount != 1 || api.BlockingOtherRepos != 1 {
+		t.Errorf("unexpected api health %+v", api)
+	}
+	if web := h.Repos[1]; web.Repo != "web" || web.BlockedByOtherRepos != 1 {
+		t.Errorf("unexpected web health %+v", web)
+	}
+	if h.Flow.TotalCrossRepoDeps != 1 || len(h.Flow.FlowMatrix) != 2 || h.Flow.FlowMatrix[0][1] != 1 {
+		t.Errorf("unexpected flow %+v", h.Flow)
+	}
+	if len(h.CrossRepoBlockers) != 1 {
+		t.Fatalf("expected one cross-repo blocker, got %+v", h.CrossRepoBlockers)
+	}
+	if b := h.CrossRepoBlockers[0]; b.IssueID != "api-AUTH-1" || b.DownstreamImpact != 2 || strings.Join(b.BlockedRepos, ",") != "web" {
+		t.Errorf("unexpected blocker %+v", b)
+	}
+}
+
+func TestRobotWorkspaceHealthRequiresWorkspace(t *testing.T) {
+	tempDir := t.TempDir()
+	writeBeads(t, tempDir, `{"id":"A","title":"Alone","status":"open","priority":1,"issue_type":"task"}`)
+
+	out, err := runBVCommand(t, tempDir, "--robot-workspace-health")
+	if err == nil || !strings.Contains(err.Error(), "requires --workspace") {
+		t.Errorf("expected an error without --workspace, got %v\n%s", err, out)
+	}
+}